@@ -17,22 +17,26 @@ package connector
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 )
 
 type Controller struct {
 	connectorStore store.ConnectorStore
 	authorizer     authz.Authorizer
 	spaceStore     store.SpaceStore
+	auditService   audit.Service
 }
 
 func NewController(
 	authorizer authz.Authorizer,
 	connectorStore store.ConnectorStore,
 	spaceStore store.SpaceStore,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
 		connectorStore: connectorStore,
 		authorizer:     authorizer,
 		spaceStore:     spaceStore,
+		auditService:   auditService,
 	}
 }
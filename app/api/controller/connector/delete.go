@@ -20,7 +20,10 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 func (c *Controller) Delete(
@@ -38,9 +41,27 @@ func (c *Controller) Delete(
 	if err != nil {
 		return fmt.Errorf("failed to authorize: %w", err)
 	}
-	err = c.connectorStore.DeleteByIdentifier(ctx, space.ID, identifier)
+
+	connector, err := c.connectorStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find connector: %w", err)
+	}
+
+	err = c.connectorStore.Delete(ctx, connector.ID)
 	if err != nil {
 		return fmt.Errorf("could not delete connector: %w", err)
 	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeConnector, connector.Identifier),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(connector),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete connector operation: %s", err)
+	}
+
 	return nil
 }
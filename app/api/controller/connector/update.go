@@ -21,9 +21,12 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // UpdateInput is used for updating a connector.
@@ -61,7 +64,9 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to find connector: %w", err)
 	}
 
-	return c.connectorStore.UpdateOptLock(ctx, connector, func(original *types.Connector) error {
+	oldConnector := *connector
+
+	connector, err = c.connectorStore.UpdateOptLock(ctx, connector, func(original *types.Connector) error {
 		if in.Identifier != nil {
 			original.Identifier = *in.Identifier
 		}
@@ -74,6 +79,23 @@ func (c *Controller) Update(
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeConnector, connector.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(oldConnector),
+		audit.WithNewObject(connector),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update connector operation: %s", err)
+	}
+
+	return connector, nil
 }
 
 func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
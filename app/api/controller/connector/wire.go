@@ -17,6 +17,7 @@ package connector
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 
 	"github.com/google/wire"
 )
@@ -30,6 +31,7 @@ func ProvideController(
 	connectorStore store.ConnectorStore,
 	authorizer authz.Authorizer,
 	spaceStore store.SpaceStore,
+	auditService audit.Service,
 ) *Controller {
-	return NewController(authorizer, connectorStore, spaceStore)
+	return NewController(authorizer, connectorStore, spaceStore, auditService)
 }
@@ -34,6 +34,7 @@ type Controller struct {
 	repoStore      store.RepoStore
 	stageStore     store.StageStore
 	pipelineStore  store.PipelineStore
+	secretStore    store.SecretStore
 }
 
 func NewController(
@@ -47,6 +48,7 @@ func NewController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	secretStore store.SecretStore,
 ) *Controller {
 	return &Controller{
 		tx:             tx,
@@ -59,5 +61,6 @@ func NewController(
 		repoStore:      repoStore,
 		stageStore:     stageStore,
 		pipelineStore:  pipelineStore,
+		secretStore:    secretStore,
 	}
 }
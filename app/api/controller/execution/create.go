@@ -27,12 +27,19 @@ import (
 	"github.com/drone/go-scm/scm"
 )
 
+// CreateInput is used for starting an execution manually.
+type CreateInput struct {
+	// Inputs are the values of the input parameters declared by the pipeline.
+	Inputs map[string]string `json:"inputs"`
+}
+
 func (c *Controller) Create(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	pipelineIdentifier string,
 	branch string,
+	in *CreateInput,
 ) (*types.Execution, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -49,6 +56,11 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
+	inputs, err := c.sanitizeInputs(ctx, session, repo, pipeline, in.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
 	// If the branch is empty, use the default branch specified in the pipeline.
 	// It that is also empty, use the repo default branch.
 	if branch == "" {
@@ -82,6 +94,7 @@ func (c *Controller) Create(
 		Source:      branch,
 		Target:      branch,
 		Params:      map[string]string{},
+		Inputs:      inputs,
 		Timestamp:   commit.Author.When.UnixMilli(),
 	}
 
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// sanitizeInputs validates the provided values against the inputs declared by the pipeline
+// and returns the values of all inputs, with defaults applied for inputs that weren't provided.
+func (c *Controller) sanitizeInputs(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	values map[string]string,
+) (map[string]string, error) {
+	declared := make(map[string]struct{}, len(pipeline.Inputs))
+	for _, input := range pipeline.Inputs {
+		declared[input.Name] = struct{}{}
+	}
+
+	for name := range values {
+		if _, ok := declared[name]; !ok {
+			return nil, usererror.BadRequestf("Pipeline doesn't have an input '%s'.", name)
+		}
+	}
+
+	inputs := make(map[string]string, len(pipeline.Inputs))
+	for i := range pipeline.Inputs {
+		input := &pipeline.Inputs[i]
+
+		value := values[input.Name]
+		if value == "" {
+			value = input.Default
+		}
+		if value == "" {
+			if input.Required {
+				return nil, usererror.BadRequestf("Input '%s' is required.", input.Name)
+			}
+			continue
+		}
+
+		value, err := input.SanitizeValue(value)
+		if err != nil {
+			return nil, usererror.BadRequestf("Invalid value for input '%s': %s", input.Name, err)
+		}
+
+		if input.Type == enum.PipelineInputTypeSecret {
+			if err = c.checkSecretInput(ctx, session, repo, value); err != nil {
+				return nil, err
+			}
+		}
+
+		inputs[input.Name] = value
+	}
+
+	return inputs, nil
+}
+
+// checkSecretInput verifies that the referenced secret is available to executions of the repo
+// and that the user starting the execution is allowed to access it.
+func (c *Controller) checkSecretInput(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	identifier string,
+) error {
+	// executions have access to the secrets of the parent space of the repo.
+	if _, err := c.secretStore.FindByIdentifier(ctx, repo.ParentID, identifier); err != nil {
+		return fmt.Errorf("failed to find secret '%s': %w", identifier, err)
+	}
+
+	err := apiauth.CheckSecret(ctx, c.authorizer, session, paths.Parent(repo.Path), identifier,
+		enum.PermissionSecretView)
+	if err != nil {
+		return fmt.Errorf("failed to authorize secret '%s': %w", identifier, err)
+	}
+
+	return nil
+}
@@ -24,10 +24,13 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/api"
@@ -35,6 +38,8 @@ import (
 	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type Controller struct {
@@ -52,6 +57,8 @@ type Controller struct {
 	preReceiveExtender  PreReceiveExtender
 	updateExtender      UpdateExtender
 	postReceiveExtender PostReceiveExtender
+	auditService        audit.Service
+	publicKey           publickey.Service
 }
 
 func NewController(
@@ -69,6 +76,8 @@ func NewController(
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
+	auditService audit.Service,
+	publicKey publickey.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
@@ -85,6 +94,31 @@ func NewController(
 		preReceiveExtender:  preReceiveExtender,
 		updateExtender:      updateExtender,
 		postReceiveExtender: postReceiveExtender,
+		auditService:        auditService,
+		publicKey:           publicKey,
+	}
+}
+
+// logRefAudit records an audit event for a reference that was changed by a git push.
+func (c *Controller) logRefAudit(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	action audit.Action,
+	ref string,
+	options ...audit.Option,
+) {
+	options = append(options, audit.WithData(audit.DataKeyRepoName, repo.Identifier, audit.DataKeyRef, ref))
+
+	err := c.auditService.Log(ctx,
+		*principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		action,
+		paths.Parent(repo.Path),
+		options...,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for git push %s operation: %s", action, err)
 	}
 }
 
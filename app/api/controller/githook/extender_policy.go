@@ -0,0 +1,528 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pushpolicy"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+const maxPolicyResponseSize = 1 << 20 // 1MiB
+
+// PolicyConfig is the configuration of the push policy extender.
+type PolicyConfig struct {
+	Executable string
+	URL        string
+	// OPAURL is the base URL of the OPA server that evaluates the global and the per-space Rego policies.
+	OPAURL     string
+	Timeout    time.Duration
+	FailOpen   bool
+	MaxCommits int
+}
+
+// Enabled returns true if a push policy backend is configured.
+func (c PolicyConfig) Enabled() bool {
+	return c.Executable != "" || c.URL != "" || c.OPAURL != ""
+}
+
+func (c PolicyConfig) Validate() error {
+	backends := 0
+	for _, v := range []string{c.Executable, c.URL, c.OPAURL} {
+		if v != "" {
+			backends++
+		}
+	}
+	if backends > 1 {
+		return errors.New("only one of push policy executable, URL and OPA URL can be configured")
+	}
+	if c.URL != "" {
+		if _, err := url.ParseRequestURI(c.URL); err != nil {
+			return fmt.Errorf("invalid push policy URL: %w", err)
+		}
+	}
+	if c.OPAURL != "" {
+		if _, err := url.ParseRequestURI(c.OPAURL); err != nil {
+			return fmt.Errorf("invalid push policy OPA URL: %w", err)
+		}
+	}
+	if c.Timeout <= 0 {
+		return errors.New("push policy timeout must be positive")
+	}
+	if c.MaxCommits <= 0 {
+		return errors.New("push policy max commits must be positive")
+	}
+	return nil
+}
+
+// PolicyInput is the document that is provided to the push policy.
+type PolicyInput struct {
+	Principal   PolicyPrincipal   `json:"principal"`
+	Repository  PolicyRepository  `json:"repository"`
+	RefUpdates  []PolicyRefUpdate `json:"ref_updates"`
+	PushOptions []string          `json:"push_options"`
+	// Internal is true if the push originates from Gitness itself (e.g. a merge).
+	Internal bool `json:"internal"`
+}
+
+type PolicyPrincipal struct {
+	ID          int64  `json:"id"`
+	UID         string `json:"uid"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+	Admin       bool   `json:"admin"`
+}
+
+type PolicyRepository struct {
+	ID            int64  `json:"id"`
+	Identifier    string `json:"identifier"`
+	Path          string `json:"path"`
+	DefaultBranch string `json:"default_branch"`
+	// SpacePath is the path of the space the repository belongs to.
+	SpacePath string `json:"space_path"`
+	// Spaces contains the paths of the space and all its ancestors, starting with the root space.
+	Spaces []string `json:"spaces"`
+}
+
+type PolicyRefUpdate struct {
+	Ref    string `json:"ref"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+	Action string `json:"action"`
+	// Forced is true if the update isn't a fast-forward of a branch.
+	Forced bool `json:"forced"`
+	// Commits contains the commits that are introduced by the update.
+	Commits []PolicyCommit `json:"commits"`
+	// CommitsTruncated is true if the update introduces more commits than provided.
+	CommitsTruncated bool `json:"commits_truncated"`
+	// Files contains the paths of all files that are changed by the introduced commits.
+	Files []string `json:"files"`
+}
+
+type PolicyCommit struct {
+	SHA       string          `json:"sha"`
+	Parents   []string        `json:"parents"`
+	Title     string          `json:"title"`
+	Message   string          `json:"message"`
+	Author    PolicySignature `json:"author"`
+	Committer PolicySignature `json:"committer"`
+	// Signed is true if the commit carries a signature. The signature isn't necessarily valid.
+	Signed bool `json:"signed"`
+	// Verified is true if the signature of the commit was verified with a signing key registered in Gitness.
+	Verified bool     `json:"verified"`
+	Files    []string `json:"files"`
+}
+
+type PolicySignature struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	When  int64  `json:"when"`
+}
+
+// PolicyResult is the result of the push policy evaluation.
+// The push is rejected if it contains any deny messages.
+type PolicyResult struct {
+	Deny []string `json:"deny"`
+	Warn []string `json:"warn"`
+}
+
+// PolicyPreReceiveExtender evaluates an external push policy for every push.
+// The policy is either a local executable, an HTTP endpoint or the Rego policies evaluated by an OPA server:
+// the global decision and the push policies of the space of the repository and all its ancestors.
+type PolicyPreReceiveExtender struct {
+	config     PolicyConfig
+	httpClient *http.Client
+	pushPolicy *pushpolicy.Service
+	verifier   protection.CommitVerifier
+}
+
+func NewPolicyPreReceiveExtender(
+	config PolicyConfig,
+	pushPolicy *pushpolicy.Service,
+	verifier protection.CommitVerifier,
+) (*PolicyPreReceiveExtender, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &PolicyPreReceiveExtender{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		pushPolicy: pushPolicy,
+		verifier:   verifier,
+	}, nil
+}
+
+func (e *PolicyPreReceiveExtender) Extend(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	session *auth.Session,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	if len(in.RefUpdates) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.config.Timeout)
+	defer cancel()
+
+	result, err := e.evaluate(ctx, rgit, session, repo, in)
+	if err != nil {
+		if e.config.FailOpen {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to evaluate push policy, allowing the push")
+			return nil
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to evaluate push policy")
+		output.Error = ptr.String("Push policy could not be evaluated.")
+		return nil
+	}
+
+	printPolicyResult(output, result)
+
+	if len(result.Deny) > 0 {
+		output.Error = ptr.String("Push rejected by push policy.")
+	}
+
+	return nil
+}
+
+func (e *PolicyPreReceiveExtender) evaluate(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	session *auth.Session,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) (PolicyResult, error) {
+	input, err := e.buildInput(ctx, rgit, session, repo, in)
+	if err != nil {
+		return PolicyResult{}, fmt.Errorf("failed to build policy input: %w", err)
+	}
+
+	switch {
+	case e.config.Executable != "":
+		return e.evaluateExecutable(ctx, input)
+	case e.config.URL != "":
+		return e.evaluateHTTP(ctx, input)
+	default:
+		result, err := e.pushPolicy.Evaluate(ctx, repo.ParentID, input)
+		return PolicyResult(result), err
+	}
+}
+
+func (e *PolicyPreReceiveExtender) buildInput(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	session *auth.Session,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) (*PolicyInput, error) {
+	spacePath := path.Dir(repo.Path)
+	segments := strings.Split(spacePath, "/")
+	spaces := make([]string, len(segments))
+	for i := range segments {
+		spaces[i] = strings.Join(segments[:i+1], "/")
+	}
+
+	input := &PolicyInput{
+		Principal: PolicyPrincipal{
+			ID:          session.Principal.ID,
+			UID:         session.Principal.UID,
+			Email:       session.Principal.Email,
+			DisplayName: session.Principal.DisplayName,
+			Type:        string(session.Principal.Type),
+			Admin:       session.Principal.Admin,
+		},
+		Repository: PolicyRepository{
+			ID:            repo.ID,
+			Identifier:    repo.Identifier,
+			Path:          repo.Path,
+			DefaultBranch: repo.DefaultBranch,
+			SpacePath:     spacePath,
+			Spaces:        spaces,
+		},
+		RefUpdates:  make([]PolicyRefUpdate, len(in.RefUpdates)),
+		PushOptions: in.PushOptions,
+		Internal:    in.Internal,
+	}
+	if input.PushOptions == nil {
+		input.PushOptions = []string{}
+	}
+
+	for i, refUpdate := range in.RefUpdates {
+		update := PolicyRefUpdate{
+			Ref:     refUpdate.Ref,
+			Old:     refUpdate.Old.String(),
+			New:     refUpdate.New.String(),
+			Action:  policyRefAction(refUpdate),
+			Commits: []PolicyCommit{},
+			Files:   []string{},
+		}
+
+		if !refUpdate.New.IsNil() {
+			if err := e.fillCommits(ctx, rgit, repo, in, refUpdate, &update); err != nil {
+				return nil, err
+			}
+		}
+
+		input.RefUpdates[i] = update
+	}
+
+	return input, nil
+}
+
+func (e *PolicyPreReceiveExtender) fillCommits(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	refUpdate hook.ReferenceUpdate,
+	update *PolicyRefUpdate,
+) error {
+	params, err := pushedCommitsParams(ctx, rgit, repo, in, refUpdate)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) && !refUpdate.Old.IsNil() {
+		result, err := rgit.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          params.ReadParams,
+			AncestorCommitSHA:   refUpdate.Old,
+			DescendantCommitSHA: refUpdate.New,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check ancestor of %q: %w", refUpdate.Ref, err)
+		}
+		update.Forced = !result.Ancestor
+	}
+
+	// request one commit more than the limit to find out if the list is truncated.
+	params.Page = 1
+	params.Limit = int32(e.config.MaxCommits + 1)
+	params.IncludeStats = true
+	params.IncludeSignatures = true
+
+	out, err := rgit.ListCommits(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to list commits of %q: %w", refUpdate.Ref, err)
+	}
+
+	commits := out.Commits
+	if len(commits) > e.config.MaxCommits {
+		commits = commits[:e.config.MaxCommits]
+		update.CommitsTruncated = true
+	}
+
+	verifications, err := e.verifier.VerifyCommits(ctx, commits)
+	if err != nil {
+		return fmt.Errorf("failed to verify commit signatures of %q: %w", refUpdate.Ref, err)
+	}
+
+	fileMap := make(map[string]struct{})
+	update.Commits = make([]PolicyCommit, len(commits))
+	for i := range commits {
+		commit := &commits[i]
+
+		parents := make([]string, len(commit.ParentSHAs))
+		for j := range commit.ParentSHAs {
+			parents[j] = commit.ParentSHAs[j].String()
+		}
+
+		files := make([]string, 0, len(commit.FileStats))
+		for _, stat := range commit.FileStats {
+			files = append(files, stat.Path)
+			if _, ok := fileMap[stat.Path]; !ok {
+				fileMap[stat.Path] = struct{}{}
+				update.Files = append(update.Files, stat.Path)
+			}
+			if stat.OldPath != "" {
+				if _, ok := fileMap[stat.OldPath]; !ok {
+					fileMap[stat.OldPath] = struct{}{}
+					update.Files = append(update.Files, stat.OldPath)
+				}
+			}
+		}
+
+		update.Commits[i] = PolicyCommit{
+			SHA:       commit.SHA.String(),
+			Parents:   parents,
+			Title:     commit.Title,
+			Message:   commit.Message,
+			Author:    policySignature(commit.Author),
+			Committer: policySignature(commit.Committer),
+			Signed:    commit.Signature != nil,
+			Verified:  verifications[i] != nil && verifications[i].Verified,
+			Files:     files,
+		}
+	}
+
+	return nil
+}
+
+// evaluateExecutable runs the configured executable with the policy input on stdin.
+// On success the executable is expected to write the result to stdout.
+// A non-zero exit code without a result rejects the push with the output of the executable.
+func (e *PolicyPreReceiveExtender) evaluateExecutable(ctx context.Context, input *PolicyInput) (PolicyResult, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return PolicyResult{}, fmt.Errorf("failed to marshal policy input: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, e.config.Executable)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if runErr != nil && (!errors.As(runErr, &exitErr) || ctx.Err() != nil) {
+		return PolicyResult{}, fmt.Errorf("failed to run push policy executable: %w", runErr)
+	}
+
+	var result PolicyResult
+	if stdout.Len() > 0 {
+		err = json.Unmarshal(stdout.Bytes(), &result)
+		if err != nil && runErr == nil {
+			return PolicyResult{}, fmt.Errorf("failed to parse push policy executable output: %w", err)
+		}
+	}
+
+	if runErr != nil && len(result.Deny) == 0 {
+		result.Deny = outputLines(stderr.String(), stdout.String())
+		if len(result.Deny) == 0 {
+			result.Deny = []string{fmt.Sprintf("push policy exited with code %d", exitErr.ExitCode())}
+		}
+	}
+
+	return result, nil
+}
+
+// evaluateHTTP posts the policy input to the configured endpoint which is expected to respond with the result.
+func (e *PolicyPreReceiveExtender) evaluateHTTP(ctx context.Context, input *PolicyInput) (PolicyResult, error) {
+	var result PolicyResult
+	if err := e.post(ctx, e.config.URL, input, &result); err != nil {
+		return PolicyResult{}, err
+	}
+
+	return result, nil
+}
+
+func (e *PolicyPreReceiveExtender) post(ctx context.Context, endpoint string, in any, out any) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create push policy request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send push policy request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPolicyResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read push policy response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("push policy endpoint responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	if err = json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse push policy response: %w", err)
+	}
+
+	return nil
+}
+
+func printPolicyResult(output *hook.Output, result PolicyResult) {
+	if len(result.Deny) == 0 && len(result.Warn) == 0 {
+		return
+	}
+
+	for _, msg := range result.Warn {
+		output.Messages = append(output.Messages, colorScanHeader.Sprintf("Warning:")+" "+msg)
+	}
+
+	for _, msg := range result.Deny {
+		output.Messages = append(output.Messages, colorScanSummary.Sprintf("Denied:")+" "+msg)
+	}
+
+	output.Messages = append(output.Messages, "") // add empty line for making it visually more consumable
+}
+
+func policyRefAction(refUpdate hook.ReferenceUpdate) string {
+	switch {
+	case refUpdate.Old.IsNil():
+		return "create"
+	case refUpdate.New.IsNil():
+		return "delete"
+	default:
+		return "update"
+	}
+}
+
+func policySignature(sig git.Signature) PolicySignature {
+	return PolicySignature{
+		Name:  sig.Identity.Name,
+		Email: sig.Identity.Email,
+		When:  sig.When.UnixMilli(),
+	}
+}
+
+func outputLines(outputs ...string) []string {
+	var lines []string
+	for _, out := range outputs {
+		for _, line := range strings.Split(out, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+func (g *commitGraph) IsAncestor(_ context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error) {
+	ancestors := map[sha.SHA]struct{}{}
+	g.reachable(params.DescendantCommitSHA, ancestors)
+	_, ok := ancestors[params.AncestorCommitSHA]
+	return git.IsAncestorOutput{Ancestor: ok}, nil
+}
+
+func TestPolicyPreReceiveExtender_BuildInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		refUpdate     hook.ReferenceUpdate
+		maxCommits    int
+		wantForced    bool
+		wantCommits   []string
+		wantTruncated bool
+	}{
+		{
+			name:        "fast-forward",
+			refUpdate:   hook.ReferenceUpdate{Ref: "refs/heads/main", Old: commitRoot, New: commitMerged},
+			maxCommits:  10,
+			wantCommits: []string{commitMerged.String(), commitPlain.String()},
+		},
+		{
+			name:        "forced",
+			refUpdate:   hook.ReferenceUpdate{Ref: "refs/heads/feature", Old: commitMerged, New: commitPlain},
+			maxCommits:  10,
+			wantForced:  true,
+			wantCommits: []string{},
+		},
+		{
+			name:          "truncated",
+			refUpdate:     hook.ReferenceUpdate{Ref: "refs/heads/release", Old: sha.Nil, New: commitMerged},
+			maxCommits:    1,
+			wantCommits:   []string{commitMerged.String()},
+			wantTruncated: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := &PolicyPreReceiveExtender{
+				config:   PolicyConfig{MaxCommits: test.maxCommits},
+				verifier: unsignedCommits{},
+			}
+			session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+			repo := &types.Repository{GitUID: "repo", Path: "root/child/repo", DefaultBranch: "main"}
+			in := types.GithookPreReceiveInput{
+				PreReceiveInput: hook.PreReceiveInput{RefUpdates: []hook.ReferenceUpdate{test.refUpdate}},
+			}
+
+			input, err := e.buildInput(context.Background(), newCommitGraph(), session, repo, in)
+			if err != nil {
+				t.Fatalf("failed to build policy input: %s", err.Error())
+			}
+
+			if want := []string{"root", "root/child"}; !reflect.DeepEqual(input.Repository.Spaces, want) {
+				t.Errorf("spaces: want=%v got=%v", want, input.Repository.Spaces)
+			}
+
+			update := input.RefUpdates[0]
+			if update.Forced != test.wantForced {
+				t.Errorf("forced: want=%t got=%t", test.wantForced, update.Forced)
+			}
+			if update.CommitsTruncated != test.wantTruncated {
+				t.Errorf("truncated: want=%t got=%t", test.wantTruncated, update.CommitsTruncated)
+			}
+
+			commits := make([]string, len(update.Commits))
+			for i, commit := range update.Commits {
+				commits[i] = commit.SHA
+				if commit.Verified {
+					t.Errorf("commit %s isn't expected to be verified", commit.SHA)
+				}
+				if len(commit.Files) != 1 || commit.Files[0] != commit.SHA+".txt" {
+					t.Errorf("unexpected files of commit %s: %v", commit.SHA, commit.Files)
+				}
+			}
+			if !reflect.DeepEqual(commits, test.wantCommits) {
+				t.Errorf("commits: want=%v got=%v", test.wantCommits, commits)
+			}
+			if len(update.Files) != len(test.wantCommits) {
+				t.Errorf("expected a file per commit, got: %v", update.Files)
+			}
+		})
+	}
+}
+
+func TestPolicyPreReceiveExtender_EvaluateExecutable(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   PolicyResult
+	}{
+		{
+			name:   "result",
+			script: `echo '{"deny":["denied"],"warn":["warned"]}'`,
+			want:   PolicyResult{Deny: []string{"denied"}, Warn: []string{"warned"}},
+		},
+		{
+			name:   "exit-code",
+			script: "echo 'not allowed' >&2; exit 3",
+			want:   PolicyResult{Deny: []string{"not allowed"}},
+		},
+		{
+			name:   "exit-code-without-output",
+			script: "exit 3",
+			want:   PolicyResult{Deny: []string{"push policy exited with code 3"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executable := filepath.Join(t.TempDir(), "policy.sh")
+			err := os.WriteFile(executable, []byte("#!/bin/sh\ncat > /dev/null\n"+test.script+"\n"), 0o700)
+			if err != nil {
+				t.Fatalf("failed to write policy executable: %s", err.Error())
+			}
+
+			e := &PolicyPreReceiveExtender{config: PolicyConfig{Executable: executable}}
+
+			result, err := e.evaluateExecutable(context.Background(), &PolicyInput{})
+			if err != nil {
+				t.Fatalf("failed to evaluate policy: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(result, test.want) {
+				t.Errorf("want=%+v got=%+v", test.want, result)
+			}
+		})
+	}
+}
+
+func TestPolicyPreReceiveExtender_EvaluateHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		_, _ = w.Write([]byte(`{"deny":["denied"]}`))
+	}))
+	defer server.Close()
+
+	e := &PolicyPreReceiveExtender{
+		config:     PolicyConfig{URL: server.URL},
+		httpClient: &http.Client{Timeout: time.Second},
+	}
+
+	result, err := e.evaluateHTTP(context.Background(), &PolicyInput{})
+	if err != nil {
+		t.Fatalf("failed to evaluate policy: %s", err.Error())
+	}
+
+	if want := (PolicyResult{Deny: []string{"denied"}}); !reflect.DeepEqual(result, want) {
+		t.Errorf("want=%+v got=%+v", want, result)
+	}
+}
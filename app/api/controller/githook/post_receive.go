@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/bootstrap"
 	events "github.com/harness/gitness/app/events/git"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
@@ -147,9 +148,29 @@ func (c *Controller) reportBranchEvent(
 			NewSHA:      branchUpdate.New.String(),
 			Forced:      forced,
 		})
+
+		if err == nil && forced {
+			c.logForcePushAudit(ctx, repo, principalID, branchUpdate)
+		}
 	}
 }
 
+func (c *Controller) logForcePushAudit(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	branchUpdate hook.ReferenceUpdate,
+) {
+	principal, err := c.principalStore.Find(ctx, principalID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find principal for force push audit log")
+		return
+	}
+
+	c.logRefAudit(ctx, principal, repo, audit.ActionForcePushed, branchUpdate.Ref,
+		audit.WithData("old_sha", branchUpdate.Old.String(), "new_sha", branchUpdate.New.String()))
+}
+
 func (c *Controller) reportTagEvent(
 	ctx context.Context,
 	repo *types.Repository,
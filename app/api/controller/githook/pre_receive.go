@@ -24,6 +24,8 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -33,6 +35,9 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+// pushOptionBypassJustification is the push option used to provide the justification for bypassing protection rules.
+const pushOptionBypassJustification = "bypass-justification"
+
 // PreReceive executes the pre-receive hook for a git repository.
 func (c *Controller) PreReceive(
 	ctx context.Context,
@@ -67,17 +72,20 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	var principal *types.Principal
+	var bypassedViolations []types.RuleViolations
+
 	// For internal calls - through the application interface (API) - no need to verify protection rules.
 	if !in.Internal {
 		// TODO: use store.PrincipalInfoCache once we abstracted principals.
-		principal, err := c.principalStore.Find(ctx, in.PrincipalID)
+		principal, err = c.principalStore.Find(ctx, in.PrincipalID)
 		if err != nil {
 			return hook.Output{}, fmt.Errorf("failed to find inner principal with id %d: %w", in.PrincipalID, err)
 		}
 
 		dummySession := &auth.Session{Principal: *principal, Metadata: nil}
 
-		err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+		bypassedViolations, err = c.checkProtectionRules(ctx, rgit, dummySession, repo, in, refUpdates, &output)
 		if err != nil {
 			return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 		}
@@ -101,6 +109,13 @@ func (c *Controller) PreReceive(
 		return hook.Output{}, err
 	}
 
+	if output.Error == nil && len(bypassedViolations) > 0 {
+		refNames := append(refUpdates.branches.all(), refUpdates.tags.all()...)
+		c.logRefAudit(ctx, principal, repo, audit.ActionBypassed, strings.Join(refNames, ","),
+			audit.WithNewObject(bypassedViolations),
+			audit.WithData(audit.DataKeyBypassJustification, bypassJustification(in.PushOptions)))
+	}
+
 	return output, nil
 }
 
@@ -116,19 +131,31 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
+	rgit RestrictedGIT,
 	session *auth.Session,
 	repo *types.Repository,
+	in types.GithookPreReceiveInput,
 	refUpdates changedRefs,
 	output *hook.Output,
-) error {
+) ([]types.RuleViolations, error) {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
 	if err != nil {
-		return fmt.Errorf("failed to determine if user is repo owner: %w", err)
+		return nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
 	}
 
 	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+		return nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	updated, forced, err := c.splitForcedUpdates(ctx, rgit, repo, in)
+	if err != nil {
+		return nil, err
+	}
+
+	refCommits, refFiles, pushFiles, err := c.pushedCommits(ctx, rgit, repo, in)
+	if err != nil {
+		return nil, err
 	}
 
 	var ruleViolations []types.RuleViolations
@@ -147,6 +174,11 @@ func (c *Controller) checkProtectionRules(
 			RefAction:   refAction,
 			RefType:     refType,
 			RefNames:    names,
+			RefCommits:  refCommits,
+			RefFiles:    refFiles,
+			PushFiles:   pushFiles,
+
+			BypassJustification: bypassJustification(in.PushOptions),
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -158,16 +190,30 @@ func (c *Controller) checkProtectionRules(
 
 	checkAction(protection.RefActionCreate, protection.RefTypeBranch, refUpdates.branches.created)
 	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
-	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
+	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, updated)
+	checkAction(protection.RefActionUpdateForce, protection.RefTypeBranch, forced)
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, refUpdates.tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, refUpdates.tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, refUpdates.tags.updated)
 
 	if errCheckAction != nil {
-		return errCheckAction
+		return nil, errCheckAction
 	}
 
 	var criticalViolation bool
+	var justificationRequired bool
+	var bypassed []types.RuleViolations
+
+	// The same rule can report the same violation for each of the ref actions (e.g. per push limits).
+	messages := make(map[string]struct{})
 
 	for _, ruleViolation := range ruleViolations {
 		criticalViolation = criticalViolation || ruleViolation.IsCritical()
+		justificationRequired = justificationRequired ||
+			ruleViolation.IsCritical() && ruleViolation.BypassJustificationRequired
+		if ruleViolation.Bypassed && len(ruleViolation.Violations) > 0 {
+			bypassed = append(bypassed, ruleViolation)
+		}
 		for _, violation := range ruleViolation.Violations {
 			var message string
 			if ruleViolation.Bypassed {
@@ -175,15 +221,140 @@ func (c *Controller) checkProtectionRules(
 			} else {
 				message = fmt.Sprintf("Rule %q violation: %s", ruleViolation.Rule.Identifier, violation.Message)
 			}
+			if _, ok := messages[message]; ok {
+				continue
+			}
+			messages[message] = struct{}{}
 			output.Messages = append(output.Messages, message)
 		}
 	}
 
+	if justificationRequired {
+		output.Messages = append(output.Messages, fmt.Sprintf(
+			"To bypass the rules provide a justification: git push -o %s=\"<reason>\"",
+			pushOptionBypassJustification))
+	}
+
 	if criticalViolation {
 		output.Error = ptr.String("Blocked by protection rules.")
 	}
 
-	return nil
+	return bypassed, nil
+}
+
+// bypassJustification returns the justification for bypassing protection rules provided as a push option.
+func bypassJustification(pushOptions []string) string {
+	for _, pushOption := range pushOptions {
+		if justification, ok := strings.CutPrefix(pushOption, pushOptionBypassJustification+"="); ok {
+			return strings.TrimSpace(justification)
+		}
+	}
+
+	return ""
+}
+
+// splitForcedUpdates splits the updated branches to the fast-forward updates and the forced updates.
+func (c *Controller) splitForcedUpdates(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) ([]string, []string, error) {
+	var updated, forced []string
+
+	for _, refUpdate := range in.RefUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) ||
+			refUpdate.Old.IsNil() || refUpdate.New.IsNil() {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+
+		result, err := rgit.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			AncestorCommitSHA:   refUpdate.Old,
+			DescendantCommitSHA: refUpdate.New,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check ancestry of branch %q: %w", branchName, err)
+		}
+
+		if result.Ancestor {
+			updated = append(updated, branchName)
+		} else {
+			forced = append(forced, branchName)
+		}
+	}
+
+	return updated, forced, nil
+}
+
+// pushedCommits returns, for every created or updated branch, functions that list
+// the commits introduced by the push and the files they change, and a function that lists
+// the files changed by the whole push. The commits and the files are listed only if a protection rule needs them.
+func (c *Controller) pushedCommits(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) (map[string]protection.CommitsFunc, map[string]protection.FilesFunc, protection.FilesFunc, error) {
+	refCommits := make(map[string]protection.CommitsFunc)
+	refFiles := make(map[string]protection.FilesFunc)
+	allFiles := make([]protection.FilesFunc, 0, len(in.RefUpdates))
+
+	for _, refUpdate := range in.RefUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+
+		params, err := pushedCommitsParams(ctx, rgit, repo, in, refUpdate)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		refCommits[branchName] = protection.ListCommitsFunc(rgit, c.publicKey, params)
+		refFiles[branchName] = protection.ListFilesFunc(rgit, params)
+		allFiles = append(allFiles, refFiles[branchName])
+	}
+
+	return refCommits, refFiles, protection.UnionFilesFunc(allFiles...), nil
+}
+
+// pushedCommitsParams returns the parameters for listing the commits a push introduces to a branch.
+// For an updated branch those are the commits that aren't reachable from its old value,
+// for a created branch the commits that aren't reachable from the default branch.
+// NOTE: Commits reachable from other references are intentionally not excluded,
+// because they might have been pushed to a branch that isn't protected by the same rules.
+func pushedCommitsParams(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	refUpdate hook.ReferenceUpdate,
+) (*git.ListCommitsParams, error) {
+	params := &git.ListCommitsParams{
+		ReadParams: git.ReadParams{
+			RepoUID:             repo.GitUID,
+			AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+		},
+		GitREF: refUpdate.New.String(),
+	}
+
+	baseSHA, ok, err := GetBaseSHAForScanningChanges(ctx, rgit, repo, in.Environment, in.RefUpdates, refUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base commit of %q: %w", refUpdate.Ref, err)
+	}
+
+	if ok {
+		params.After = baseSHA.String()
+	}
+
+	return params, nil
 }
 
 type changes struct {
@@ -203,6 +374,14 @@ func (c *changes) groupByAction(refUpdate hook.ReferenceUpdate, name string) {
 	}
 }
 
+func (c *changes) all() []string {
+	all := make([]string, 0, len(c.created)+len(c.deleted)+len(c.updated))
+	all = append(all, c.created...)
+	all = append(all, c.deleted...)
+	all = append(all, c.updated...)
+	return all
+}
+
 type changedRefs struct {
 	branches changes
 	tags     changes
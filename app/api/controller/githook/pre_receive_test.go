@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gliderlabs/ssh"
+)
+
+var (
+	commitRoot   = sha.Must("1111111111111111111111111111111111111111")
+	commitPlain  = sha.Must("2222222222222222222222222222222222222222")
+	commitMerged = sha.Must("3333333333333333333333333333333333333333")
+)
+
+// commitGraph is a fake git that lists commits of the graph like "git rev-list <GitREF> ^<After>".
+type commitGraph struct {
+	RestrictedGIT
+	parents  map[sha.SHA][]sha.SHA
+	branches map[string]sha.SHA
+}
+
+func newCommitGraph() *commitGraph {
+	// main:    root
+	// feature: root <- plain <- merged (merge commit of plain and root)
+	return &commitGraph{
+		parents: map[sha.SHA][]sha.SHA{
+			commitRoot:   nil,
+			commitPlain:  {commitRoot},
+			commitMerged: {commitPlain, commitRoot},
+		},
+		branches: map[string]sha.SHA{
+			"main":    commitRoot,
+			"feature": commitMerged,
+		},
+	}
+}
+
+func (g *commitGraph) reachable(from sha.SHA, set map[sha.SHA]struct{}) {
+	if _, ok := set[from]; ok {
+		return
+	}
+	set[from] = struct{}{}
+	for _, parent := range g.parents[from] {
+		g.reachable(parent, set)
+	}
+}
+
+func (g *commitGraph) GetBranch(_ context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	commitSHA, ok := g.branches[params.BranchName]
+	if !ok {
+		return nil, errors.NotFound("branch not found")
+	}
+	return &git.GetBranchOutput{Branch: git.Branch{Name: params.BranchName, SHA: commitSHA}}, nil
+}
+
+func (g *commitGraph) ListCommits(_ context.Context, params *git.ListCommitsParams) (*git.ListCommitsOutput, error) {
+	included := map[sha.SHA]struct{}{}
+	g.reachable(sha.Must(params.GitREF), included)
+
+	excluded := map[sha.SHA]struct{}{}
+	if params.After != "" {
+		g.reachable(sha.Must(params.After), excluded)
+	}
+
+	var commits []git.Commit
+	for _, commitSHA := range []sha.SHA{commitMerged, commitPlain, commitRoot} {
+		_, in := included[commitSHA]
+		_, ex := excluded[commitSHA]
+		if in && !ex {
+			commits = append(commits, git.Commit{
+				SHA:        commitSHA,
+				ParentSHAs: g.parents[commitSHA],
+				Message:    "commit " + commitSHA.String(),
+				FileStats:  []git.CommitFileStats{{Path: commitSHA.String() + ".txt"}},
+			})
+		}
+	}
+
+	return &git.ListCommitsOutput{Commits: commits}, nil
+}
+
+// unsignedCommits is a signature verifier that doesn't verify any commit.
+type unsignedCommits struct{}
+
+func (unsignedCommits) ValidateKey(context.Context, ssh.PublicKey, enum.PublicKeyUsage) (*types.PrincipalInfo, error) {
+	return nil, nil
+}
+
+func (unsignedCommits) VerifyCommits(_ context.Context, commits []git.Commit) ([]*types.SignatureVerification, error) {
+	return make([]*types.SignatureVerification, len(commits)), nil
+}
+
+// TestPushedCommits_ExistingCommits ensures that the commits a push introduces to a branch are verified
+// even if they are already reachable from another branch. Otherwise, commits pushed to an unprotected branch
+// could be used to fast-forward a protected branch without being verified.
+func TestPushedCommits_ExistingCommits(t *testing.T) {
+	tests := []struct {
+		name      string
+		refUpdate hook.ReferenceUpdate
+		refAction protection.RefAction
+		branch    string
+	}{
+		{
+			name:      "fast-forward",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/heads/main", Old: commitRoot, New: commitMerged},
+			refAction: protection.RefActionUpdate,
+			branch:    "main",
+		},
+		{
+			name:      "create",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/heads/release", Old: sha.Nil, New: commitMerged},
+			refAction: protection.RefActionCreate,
+			branch:    "release",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := &Controller{publicKey: unsignedCommits{}}
+			repo := &types.Repository{GitUID: "repo", DefaultBranch: "main"}
+			in := types.GithookPreReceiveInput{
+				PreReceiveInput: hook.PreReceiveInput{RefUpdates: []hook.ReferenceUpdate{test.refUpdate}},
+			}
+
+			refCommits, _, pushFiles, err := c.pushedCommits(ctx, newCommitGraph(), repo, in)
+			if err != nil {
+				t.Fatalf("failed to get pushed commits: %s", err.Error())
+			}
+
+			commits, err := refCommits[test.branch](ctx)
+			if err != nil {
+				t.Fatalf("failed to list pushed commits: %s", err.Error())
+			}
+
+			if len(commits) != 2 || commits[0].SHA != commitMerged.String() || commits[1].SHA != commitPlain.String() {
+				t.Fatalf("expected the merged and the plain commit, got: %+v", commits)
+			}
+
+			def := protection.DefCommits{RequireSignatures: true, RequireLinearHistory: true}
+			violations, err := def.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+				RefAction:  test.refAction,
+				RefType:    protection.RefTypeBranch,
+				RefNames:   []string{test.branch},
+				RefCommits: refCommits,
+			})
+			if err != nil {
+				t.Fatalf("failed to verify commits: %s", err.Error())
+			}
+
+			if len(violations) != 1 || len(violations[0].Violations) != 2 {
+				t.Errorf("expected linear history and signature violations, got: %+v", violations)
+			}
+
+			defPush := protection.DefPush{CommitMessageRegex: "^JIRA-[0-9]+: ", MaxFiles: 1}
+			violations, err = defPush.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+				RefAction:  test.refAction,
+				RefType:    protection.RefTypeBranch,
+				RefNames:   []string{test.branch},
+				RefCommits: refCommits,
+				PushFiles:  pushFiles,
+			})
+			if err != nil {
+				t.Fatalf("failed to verify push: %s", err.Error())
+			}
+
+			if len(violations) != 1 || len(violations[0].Violations) != 2 {
+				t.Errorf("expected commit message and max files violations, got: %+v", violations)
+			}
+		})
+	}
+}
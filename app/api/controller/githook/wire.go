@@ -20,11 +20,15 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pushpolicy"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -56,6 +60,8 @@ func ProvideController(
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
+	auditService audit.Service,
+	publicKey publickey.Service,
 ) *Controller {
 	ctrl := NewController(
 		authorizer,
@@ -72,6 +78,8 @@ func ProvideController(
 		preReceiveExtender,
 		updateExtender,
 		postReceiveExtender,
+		auditService,
+		publicKey,
 	)
 
 	// TODO: improve wiring if possible
@@ -89,8 +97,24 @@ var ExtenderWireSet = wire.NewSet(
 	ProvidePostReceiveExtender,
 )
 
-func ProvidePreReceiveExtender() (PreReceiveExtender, error) {
-	return NewPreReceiveExtender(), nil
+func ProvidePreReceiveExtender(
+	config *types.Config,
+	pushPolicy *pushpolicy.Service,
+	publicKey publickey.Service,
+) (PreReceiveExtender, error) {
+	policyConfig := PolicyConfig{
+		Executable: config.PushPolicy.Executable,
+		URL:        config.PushPolicy.URL,
+		OPAURL:     config.PushPolicy.OPAURL,
+		Timeout:    config.PushPolicy.Timeout,
+		FailOpen:   config.PushPolicy.FailOpen,
+		MaxCommits: config.PushPolicy.MaxCommits,
+	}
+	if !policyConfig.Enabled() {
+		return NewPreReceiveExtender(), nil
+	}
+
+	return NewPolicyPreReceiveExtender(policyConfig, pushPolicy, publicKey)
 }
 
 func ProvideUpdateExtender() (UpdateExtender, error) {
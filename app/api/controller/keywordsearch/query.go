@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	filterRepo    = "repo"
+	filterBranch  = "branch"
+	filterPath    = "path"
+	filterLang    = "lang"
+	filterCase    = "case"
+	filterSymbol  = "symbol"
+	operatorOr    = "OR"
+	negatedPrefix = "-"
+)
+
+// parsedQuery is the result of parsing the query string of a search request.
+type parsedQuery struct {
+	types.SearchQuery
+
+	// repoPatterns are regular expressions of which at least one has to match the path of a searched repository.
+	repoPatterns []*regexp.Regexp
+	// branches are branches of which at least one has to be the default branch of a searched repository.
+	// Only default branches are indexed, other branches are rejected by checkBranches.
+	branches []string
+}
+
+// queryToken is a single whitespace separated part of the query.
+type queryToken struct {
+	value string
+	// quoted is true if any part of the token was quoted, which disables the interpretation as operator.
+	quoted bool
+	// quoteOffset is the offset of the first quoted character in the value.
+	// A filter name is only recognized if it isn't quoted, which allows searching for terms like "path:".
+	quoteOffset int
+}
+
+// parseQuery parses the search query. The query consists of search terms and the following filters:
+//
+//	repo:<regex>     search only repositories with a matching path
+//	branch:<name>    search only repositories with the branch as default branch (only default branches
+//	                 are indexed, a branch that isn't the default branch of any searched repository is rejected)
+//	path:<regex>     search only files with a matching path, -path:<regex> excludes matching files
+//	lang:<language>  search only files written in the language
+//	case:yes|no      enables or disables case-sensitive matching
+//	symbol:<name>    search for definitions of the symbol
+//
+// Terms containing whitespace can be quoted using double quotes. The query can be split
+// into alternatives using OR, a file matches if it contains all terms of any alternative.
+func parseQuery(query string, enableRegex bool) (*parsedQuery, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &parsedQuery{}
+	q.EnableRegex = enableRegex
+
+	var alternative []types.SearchTerm
+	for i, token := range tokens {
+		if !token.quoted && token.value == operatorOr {
+			if len(alternative) == 0 || i == len(tokens)-1 {
+				return nil, usererror.BadRequest("OR has to be placed between search terms.")
+			}
+
+			q.Alternatives = append(q.Alternatives, alternative)
+			alternative = nil
+			continue
+		}
+
+		name, value, isFilter := splitFilter(token)
+		if !isFilter {
+			if token.value == "" {
+				// ignore empty quotes
+				continue
+			}
+			alternative = append(alternative, types.SearchTerm{Value: token.value})
+			continue
+		}
+
+		if value == "" {
+			return nil, usererror.BadRequestf("Filter %q requires a value.", name)
+		}
+
+		switch name {
+		case filterRepo:
+			re, err := regexp.Compile("(?i)" + value)
+			if err != nil {
+				return nil, usererror.BadRequestf("Invalid repo filter %q: %s", value, err)
+			}
+			q.repoPatterns = append(q.repoPatterns, re)
+		case filterBranch:
+			q.branches = append(q.branches, value)
+		case filterPath:
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, usererror.BadRequestf("Invalid path filter %q: %s", value, err)
+			}
+			q.PathIncludes = append(q.PathIncludes, value)
+		case negatedPrefix + filterPath:
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, usererror.BadRequestf("Invalid path filter %q: %s", value, err)
+			}
+			q.PathExcludes = append(q.PathExcludes, value)
+		case filterLang:
+			q.Languages = append(q.Languages, value)
+		case filterCase:
+			switch strings.ToLower(value) {
+			case "yes":
+				q.CaseSensitive = true
+			case "no":
+				q.CaseSensitive = false
+			default:
+				return nil, usererror.BadRequestf("Invalid case filter %q, expected yes or no.", value)
+			}
+		case filterSymbol:
+			alternative = append(alternative, types.SearchTerm{Value: value, Symbol: true})
+		}
+	}
+
+	if len(alternative) > 0 {
+		q.Alternatives = append(q.Alternatives, alternative)
+	}
+
+	if len(q.Alternatives) == 0 {
+		return nil, usererror.BadRequest("Query has to contain at least one search term.")
+	}
+
+	return q, nil
+}
+
+// splitFilter splits the token into the filter name and its value.
+// Tokens with an unknown filter name are treated as regular search terms.
+func splitFilter(token queryToken) (string, string, bool) {
+	name, value, found := strings.Cut(token.value, ":")
+	if !found || token.quoted && len(name) >= token.quoteOffset {
+		return "", "", false
+	}
+
+	switch strings.ToLower(name) {
+	case filterRepo, filterBranch, filterPath, negatedPrefix + filterPath, filterLang, filterCase, filterSymbol:
+		return strings.ToLower(name), value, true
+	default:
+		return "", "", false
+	}
+}
+
+// tokenizeQuery splits the query at whitespace outside of double quotes.
+// Within quotes, a backslash escapes the following double quote or backslash.
+func tokenizeQuery(query string) ([]queryToken, error) {
+	var (
+		tokens  []queryToken
+		current strings.Builder
+		token   queryToken
+		inToken bool
+		inQuote bool
+	)
+
+	flush := func() {
+		if inToken {
+			token.value = current.String()
+			tokens = append(tokens, token)
+		}
+		current.Reset()
+		token = queryToken{}
+		inToken = false
+	}
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case inQuote && r == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\'):
+			i++
+			current.WriteRune(runes[i])
+		case r == '"':
+			if !token.quoted {
+				token.quoted = true
+				token.quoteOffset = current.Len()
+			}
+			inQuote = !inQuote
+			inToken = true
+		case !inQuote && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			flush()
+		default:
+			inToken = true
+			current.WriteRune(r)
+		}
+	}
+
+	if inQuote {
+		return nil, usererror.BadRequest("Query contains an unterminated quote.")
+	}
+
+	flush()
+
+	return tokens, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  types.SearchQuery
+	}{
+		{
+			name:  "terms",
+			query: "foo  bar",
+			want: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "foo"}, {Value: "bar"}}},
+			},
+		},
+		{
+			name:  "quoted-phrase",
+			query: `"foo bar" "say \"hi\""`,
+			want: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "foo bar"}, {Value: `say "hi"`}}},
+			},
+		},
+		{
+			name:  "alternatives",
+			query: `foo OR bar baz "OR"`,
+			want: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "foo"}}, {{Value: "bar"}, {Value: "baz"}, {Value: "OR"}}},
+			},
+		},
+		{
+			name:  "filters",
+			query: `path:^app/ -path:_test\.go$ lang:go case:yes symbol:Search "path:" path:"a b"`,
+			want: types.SearchQuery{
+				Alternatives:  [][]types.SearchTerm{{{Value: "Search", Symbol: true}, {Value: "path:"}}},
+				PathIncludes:  []string{"^app/", "a b"},
+				PathExcludes:  []string{`_test\.go$`},
+				Languages:     []string{"go"},
+				CaseSensitive: true,
+			},
+		},
+		{
+			name:  "unknown-filter",
+			query: "http://example.com",
+			want: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "http://example.com"}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseQuery(test.query, false)
+			if err != nil {
+				t.Fatalf("failed to parse query: %s", err)
+			}
+
+			if !reflect.DeepEqual(got.SearchQuery, test.want) {
+				t.Errorf("want=%+v got=%+v", test.want, got.SearchQuery)
+			}
+		})
+	}
+}
+
+func TestParseQuery_RepoFilters(t *testing.T) {
+	q, err := parseQuery("foo repo:^space/backend branch:main branch:develop", false)
+	if err != nil {
+		t.Fatalf("failed to parse query: %s", err)
+	}
+
+	tests := []struct {
+		repo types.Repository
+		want bool
+	}{
+		{repo: types.Repository{Path: "space/backend-api", DefaultBranch: "main"}, want: true},
+		{repo: types.Repository{Path: "Space/Backend", DefaultBranch: "develop"}, want: true},
+		{repo: types.Repository{Path: "space/frontend", DefaultBranch: "main"}, want: false},
+		{repo: types.Repository{Path: "space/backend", DefaultBranch: "master"}, want: false},
+	}
+
+	for _, test := range tests {
+		if got := q.matchesRepo(&test.repo); got != test.want {
+			t.Errorf("repo %s@%s: want=%t got=%t", test.repo.Path, test.repo.DefaultBranch, test.want, got)
+		}
+	}
+}
+
+func TestParseQuery_CheckBranches(t *testing.T) {
+	repos := map[int64]*types.Repository{
+		1: {Path: "space/backend", DefaultBranch: "main"},
+		2: {Path: "space/frontend", DefaultBranch: "develop"},
+	}
+
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{query: "foo", wantErr: false},
+		{query: "foo branch:main", wantErr: false},
+		{query: "foo branch:main branch:develop", wantErr: false},
+		{query: "foo branch:feature", wantErr: true},
+		{query: "foo branch:main branch:feature", wantErr: true},
+	}
+
+	for _, test := range tests {
+		q, err := parseQuery(test.query, false)
+		if err != nil {
+			t.Fatalf("failed to parse query %q: %s", test.query, err)
+		}
+
+		err = q.checkBranches(repos)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("query %q: want error=%t got=%v", test.query, test.wantErr, err)
+		}
+	}
+}
+
+func TestParseQuery_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"OR foo",
+		"foo OR",
+		"foo OR OR bar",
+		`"foo`,
+		"path:foo",
+		"foo path:",
+		"foo path:a(b",
+		"foo repo:a(b",
+		"foo case:maybe",
+	}
+
+	for _, query := range tests {
+		if _, err := parseQuery(query, false); err == nil {
+			t.Errorf("expected an error for query %q", query)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -41,55 +42,162 @@ func (c *Controller) Search(
 			"either repo paths or space paths need to be set.")
 	}
 
-	repoIDToPathMap, err := c.getReposByPath(ctx, session, in.RepoPaths)
+	if in.Page < 0 || in.Size < 0 {
+		return types.SearchResult{}, usererror.BadRequest("page and size can't be negative.")
+	}
+
+	query, err := parseQuery(in.Query, in.EnableRegex)
+	if err != nil {
+		return types.SearchResult{}, err
+	}
+
+	repoIDToRepoMap, err := c.getReposByPath(ctx, session, in.RepoPaths)
 	if err != nil {
 		return types.SearchResult{}, fmt.Errorf("failed to search repos by path: %w", err)
 	}
 
-	spaceRepoIDToPathMap, err := c.getReposBySpacePaths(ctx, session, in.SpacePaths, in.Recursive)
+	spaceRepoIDToRepoMap, err := c.getReposBySpacePaths(ctx, session, in.SpacePaths, in.Recursive)
 	if err != nil {
 		return types.SearchResult{}, fmt.Errorf("failed to search repos by space path: %w", err)
 	}
 
-	for repoID, repoPath := range spaceRepoIDToPathMap {
-		repoIDToPathMap[repoID] = repoPath
+	for repoID, repo := range spaceRepoIDToRepoMap {
+		repoIDToRepoMap[repoID] = repo
+	}
+
+	for repoID, repo := range repoIDToRepoMap {
+		if !query.matchesRepoPath(repo) {
+			delete(repoIDToRepoMap, repoID)
+		}
+	}
+
+	if err = query.checkBranches(repoIDToRepoMap); err != nil {
+		return types.SearchResult{}, err
 	}
 
-	if len(repoIDToPathMap) == 0 {
+	for repoID, repo := range repoIDToRepoMap {
+		if !query.matchesRepo(repo) {
+			delete(repoIDToRepoMap, repoID)
+		}
+	}
+
+	if len(repoIDToRepoMap) == 0 {
 		return types.SearchResult{}, usererror.NotFound("no repositories found")
 	}
 
-	repoIDs := make([]int64, 0, len(repoIDToPathMap))
-	for repoID := range repoIDToPathMap {
+	repoIDs := make([]int64, 0, len(repoIDToRepoMap))
+	for repoID := range repoIDToRepoMap {
 		repoIDs = append(repoIDs, repoID)
 	}
 
-	result, err := c.searcher.Search(ctx, repoIDs, in.Query, in.EnableRegex, in.MaxResultCount)
+	opts := keywordsearch.SearchOptions{
+		MaxResultCount: in.MaxResultCount,
+	}
+	if in.Size > 0 {
+		page := in.Page
+		if page < 1 {
+			page = 1
+		}
+
+		opts.Offset = (page - 1) * in.Size
+		opts.Limit = in.Size
+	}
+
+	result, err := c.searcher.Search(ctx, repoIDs, &query.SearchQuery, opts)
 	if err != nil {
 		return types.SearchResult{}, fmt.Errorf("failed to search: %w", err)
 	}
 
 	for idx, fileMatch := range result.FileMatches {
-		repoPath, ok := repoIDToPathMap[fileMatch.RepoID]
+		repo, ok := repoIDToRepoMap[fileMatch.RepoID]
 		if !ok {
-			log.Ctx(ctx).Warn().Msgf("repo path not found for repo ID %d, repo mapping: %v",
-				fileMatch.RepoID, repoIDToPathMap)
+			log.Ctx(ctx).Warn().Msgf("repo path not found for repo ID %d", fileMatch.RepoID)
 			continue
 		}
-		result.FileMatches[idx].RepoPath = repoPath
+		result.FileMatches[idx].RepoPath = repo.Path
 	}
+
+	for idx, repoStats := range result.RepoStats {
+		if repo, ok := repoIDToRepoMap[repoStats.RepoID]; ok {
+			result.RepoStats[idx].RepoPath = repo.Path
+		}
+	}
+
 	return result, nil
 }
 
-// getReposByPath returns a list of repo IDs that the user has access to for input repo paths.
+// matchesRepoPath returns true if the repository passes the repo filters of the query.
+func (q *parsedQuery) matchesRepoPath(repo *types.Repository) bool {
+	if len(q.repoPatterns) == 0 {
+		return true
+	}
+
+	for _, re := range q.repoPatterns {
+		if re.MatchString(repo.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkBranches returns an error if a branch filter of the query isn't the default branch of any of the
+// repositories. Only default branches are indexed, so other branches can't be searched.
+func (q *parsedQuery) checkBranches(repos map[int64]*types.Repository) error {
+	if len(repos) == 0 {
+		// reported as not found by the caller.
+		return nil
+	}
+
+	for _, branch := range q.branches {
+		found := false
+		for _, repo := range repos {
+			if repo.DefaultBranch == branch {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return usererror.BadRequestf(
+				"Branch %q isn't the default branch of any searched repository, only default branches are searchable.",
+				branch)
+		}
+	}
+
+	return nil
+}
+
+// matchesRepo returns true if the repository passes the repo and branch filters of the query.
+func (q *parsedQuery) matchesRepo(repo *types.Repository) bool {
+	if !q.matchesRepoPath(repo) {
+		return false
+	}
+
+	if len(q.branches) > 0 {
+		matched := false
+		for _, branch := range q.branches {
+			if branch == repo.DefaultBranch {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getReposByPath returns the repositories that the user has access to for input repo paths.
 func (c *Controller) getReposByPath(
 	ctx context.Context,
 	session *auth.Session,
 	repoPaths []string,
-) (map[int64]string, error) {
-	repoIDToPathMap := make(map[int64]string)
+) (map[int64]*types.Repository, error) {
+	repoIDToRepoMap := make(map[int64]*types.Repository)
 	if len(repoPaths) == 0 {
-		return repoIDToPathMap, nil
+		return repoIDToRepoMap, nil
 	}
 
 	for _, repoPath := range repoPaths {
@@ -101,9 +209,9 @@ func (c *Controller) getReposByPath(
 		if err != nil {
 			return nil, fmt.Errorf("failed to find repository: %w", err)
 		}
-		repoIDToPathMap[repo.ID] = repoPath
+		repoIDToRepoMap[repo.ID] = &repo.Repository
 	}
-	return repoIDToPathMap, nil
+	return repoIDToRepoMap, nil
 }
 
 func (c *Controller) getReposBySpacePaths(
@@ -111,19 +219,19 @@ func (c *Controller) getReposBySpacePaths(
 	session *auth.Session,
 	spacePaths []string,
 	recursive bool,
-) (map[int64]string, error) {
-	repoIDToPathMap := make(map[int64]string)
+) (map[int64]*types.Repository, error) {
+	repoIDToRepoMap := make(map[int64]*types.Repository)
 	for _, spacePath := range spacePaths {
 		m, err := c.getReposBySpacePath(ctx, session, spacePath, recursive)
 		if err != nil {
 			return nil, fmt.Errorf("failed to search repos by space path: %w", err)
 		}
 
-		for repoID, repoPath := range m {
-			repoIDToPathMap[repoID] = repoPath
+		for repoID, repo := range m {
+			repoIDToRepoMap[repoID] = repo
 		}
 	}
-	return repoIDToPathMap, nil
+	return repoIDToRepoMap, nil
 }
 
 func (c *Controller) getReposBySpacePath(
@@ -131,10 +239,10 @@ func (c *Controller) getReposBySpacePath(
 	session *auth.Session,
 	spacePath string,
 	recursive bool,
-) (map[int64]string, error) {
-	repoIDToPathMap := make(map[int64]string)
+) (map[int64]*types.Repository, error) {
+	repoIDToRepoMap := make(map[int64]*types.Repository)
 	if spacePath == "" {
-		return repoIDToPathMap, nil
+		return repoIDToRepoMap, nil
 	}
 
 	filter := &types.RepoFilter{
@@ -151,7 +259,7 @@ func (c *Controller) getReposBySpacePath(
 	}
 
 	for _, repo := range repos {
-		repoIDToPathMap[repo.ID] = repo.Path
+		repoIDToRepoMap[repo.ID] = &repo.Repository
 	}
-	return repoIDToPathMap, nil
+	return repoIDToRepoMap, nil
 }
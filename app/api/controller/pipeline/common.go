@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"regexp"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxPipelineInputs defines the max number of inputs a pipeline can declare.
+	maxPipelineInputs = 50
+	// maxPipelineInputOptions defines the max number of options of a choice input.
+	maxPipelineInputOptions = 100
+)
+
+// pipelineInputNameRegex restricts input names to valid environment variable names.
+var pipelineInputNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,63}$`)
+
+// sanitizeInputs validates the declared inputs of a pipeline.
+func sanitizeInputs(inputs []types.PipelineInput) error {
+	if len(inputs) > maxPipelineInputs {
+		return check.NewValidationErrorf("A pipeline can have at most %d inputs.", maxPipelineInputs)
+	}
+
+	names := make(map[string]struct{}, len(inputs))
+	for i := range inputs {
+		input := &inputs[i]
+
+		if !pipelineInputNameRegex.MatchString(input.Name) {
+			return check.NewValidationErrorf("Input name '%s' is invalid. It has to start with a letter "+
+				"or underscore, can only contain letters, digits and underscores and be at most 64 characters long.",
+				input.Name)
+		}
+		if _, ok := names[input.Name]; ok {
+			return check.NewValidationErrorf("Input name '%s' is used more than once.", input.Name)
+		}
+		names[input.Name] = struct{}{}
+
+		inputType, ok := input.Type.Sanitize()
+		if !ok {
+			return check.NewValidationErrorf("Input '%s' has an invalid type '%s'.", input.Name, input.Type)
+		}
+		input.Type = inputType
+
+		if err := check.Description(input.Description); err != nil {
+			return err
+		}
+
+		if input.Type == enum.PipelineInputTypeChoice {
+			if len(input.Options) == 0 || len(input.Options) > maxPipelineInputOptions {
+				return check.NewValidationErrorf("Choice input '%s' requires between 1 and %d options.",
+					input.Name, maxPipelineInputOptions)
+			}
+		} else if len(input.Options) > 0 {
+			return check.NewValidationErrorf("Only choice inputs can have options, input '%s' is of type '%s'.",
+				input.Name, input.Type)
+		}
+
+		if input.Default == "" {
+			continue
+		}
+
+		value, err := input.SanitizeValue(input.Default)
+		if err != nil {
+			return check.NewValidationErrorf("Default value of input '%s' is invalid: %s", input.Name, err)
+		}
+		input.Default = value
+	}
+
+	return nil
+}
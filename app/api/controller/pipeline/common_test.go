@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestSanitizeInputs(t *testing.T) {
+	inputs := []types.PipelineInput{
+		{Name: "version", Type: enum.PipelineInputTypeString, Required: true},
+		{Name: "environment", Type: enum.PipelineInputTypeChoice, Options: []string{"qa", "prod"}, Default: "qa"},
+		{Name: "dry_run", Type: enum.PipelineInputTypeBool, Default: "1"},
+		{Name: "token", Type: enum.PipelineInputTypeSecret},
+	}
+
+	if err := sanitizeInputs(inputs); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if inputs[2].Default != "true" {
+		t.Errorf("expected bool default to be normalized, got %q", inputs[2].Default)
+	}
+}
+
+func TestSanitizeInputs_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs []types.PipelineInput
+	}{
+		{
+			name:   "invalid-name",
+			inputs: []types.PipelineInput{{Name: "1version", Type: enum.PipelineInputTypeString}},
+		},
+		{
+			name: "duplicate-name",
+			inputs: []types.PipelineInput{
+				{Name: "version", Type: enum.PipelineInputTypeString},
+				{Name: "version", Type: enum.PipelineInputTypeBool},
+			},
+		},
+		{
+			name:   "invalid-type",
+			inputs: []types.PipelineInput{{Name: "version", Type: "number"}},
+		},
+		{
+			name:   "choice-without-options",
+			inputs: []types.PipelineInput{{Name: "environment", Type: enum.PipelineInputTypeChoice}},
+		},
+		{
+			name: "options-on-string",
+			inputs: []types.PipelineInput{
+				{Name: "version", Type: enum.PipelineInputTypeString, Options: []string{"1"}},
+			},
+		},
+		{
+			name: "default-not-an-option",
+			inputs: []types.PipelineInput{
+				{Name: "environment", Type: enum.PipelineInputTypeChoice, Options: []string{"qa"}, Default: "prod"},
+			},
+		},
+		{
+			name:   "invalid-bool-default",
+			inputs: []types.PipelineInput{{Name: "dry_run", Type: enum.PipelineInputTypeBool, Default: "maybe"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := sanitizeInputs(test.inputs); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
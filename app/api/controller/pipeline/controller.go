@@ -17,6 +17,7 @@ package pipeline
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 )
 
 type Controller struct {
@@ -25,6 +26,7 @@ type Controller struct {
 	triggerStore  store.TriggerStore
 	authorizer    authz.Authorizer
 	pipelineStore store.PipelineStore
+	auditService  audit.Service
 }
 
 func NewController(
@@ -32,11 +34,13 @@ func NewController(
 	repoStore store.RepoStore,
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
 		repoStore:     repoStore,
 		triggerStore:  triggerStore,
 		authorizer:    authorizer,
 		pipelineStore: pipelineStore,
+		auditService:  auditService,
 	}
 }
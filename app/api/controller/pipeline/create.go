@@ -23,6 +23,8 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -44,6 +46,9 @@ type CreateInput struct {
 	Disabled      bool   `json:"disabled"`
 	DefaultBranch string `json:"default_branch"`
 	ConfigPath    string `json:"config_path"`
+
+	// Inputs are the input parameters that have to be provided when an execution is started manually.
+	Inputs []types.PipelineInput `json:"inputs"`
 }
 
 func (c *Controller) Create(
@@ -77,6 +82,7 @@ func (c *Controller) Create(
 		Seq:           0,
 		DefaultBranch: in.DefaultBranch,
 		ConfigPath:    in.ConfigPath,
+		Inputs:        in.Inputs,
 		Created:       now,
 		Updated:       now,
 		Version:       0,
@@ -86,6 +92,18 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("pipeline creation failed: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePipeline, pipeline.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(pipeline),
+		audit.WithData(audit.DataKeyRepoName, repo.Identifier),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create pipeline operation: %s", err)
+	}
+
 	// Try to create a default trigger on pipeline creation.
 	// Default trigger operations are set on pull request created, reopened or updated.
 	// We log an error on failure but don't fail the op.
@@ -97,8 +115,10 @@ func (c *Controller) Create(
 		RepoID:      pipeline.RepoID,
 		CreatedBy:   session.Principal.ID,
 		Identifier:  "default",
+		Type:        enum.TriggerHook,
 		Actions: []enum.TriggerAction{enum.TriggerActionPullReqCreated,
-			enum.TriggerActionPullReqReopened, enum.TriggerActionPullReqBranchUpdated},
+			enum.TriggerActionPullReqReopened, enum.TriggerActionPullReqBranchUpdated,
+			enum.TriggerActionPullReqMergeQueued},
 		Disabled: false,
 		Version:  0,
 	}
@@ -133,5 +153,9 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return errPipelineRequiresConfigPath
 	}
 
+	if err := sanitizeInputs(in.Inputs); err != nil {
+		return err
+	}
+
 	return nil
 }
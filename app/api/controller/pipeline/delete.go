@@ -20,7 +20,11 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 func (c *Controller) Delete(
@@ -39,9 +43,27 @@ func (c *Controller) Delete(
 		return fmt.Errorf("failed to authorize pipeline: %w", err)
 	}
 
-	err = c.pipelineStore.DeleteByIdentifier(ctx, repo.ID, identifier)
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	err = c.pipelineStore.Delete(ctx, pipeline.ID)
 	if err != nil {
 		return fmt.Errorf("could not delete pipeline: %w", err)
 	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePipeline, pipeline.Identifier),
+		audit.ActionDeleted,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(pipeline),
+		audit.WithData(audit.DataKeyRepoName, repo.Identifier),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete pipeline operation: %s", err)
+	}
+
 	return nil
 }
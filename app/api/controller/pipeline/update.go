@@ -21,9 +21,13 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type UpdateInput struct {
@@ -33,6 +37,9 @@ type UpdateInput struct {
 	Description *string `json:"description"`
 	Disabled    *bool   `json:"disabled"`
 	ConfigPath  *string `json:"config_path"`
+
+	// Inputs replace the input parameters of the pipeline if provided.
+	Inputs []types.PipelineInput `json:"inputs"`
 }
 
 func (c *Controller) Update(
@@ -60,7 +67,9 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
-	return c.pipelineStore.UpdateOptLock(ctx, pipeline, func(pipeline *types.Pipeline) error {
+	oldPipeline := *pipeline
+
+	pipeline, err = c.pipelineStore.UpdateOptLock(ctx, pipeline, func(pipeline *types.Pipeline) error {
 		if in.Identifier != nil {
 			pipeline.Identifier = *in.Identifier
 		}
@@ -73,9 +82,30 @@ func (c *Controller) Update(
 		if in.Disabled != nil {
 			pipeline.Disabled = *in.Disabled
 		}
+		if in.Inputs != nil {
+			pipeline.Inputs = in.Inputs
+		}
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePipeline, pipeline.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(oldPipeline),
+		audit.WithNewObject(pipeline),
+		audit.WithData(audit.DataKeyRepoName, repo.Identifier),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update pipeline operation: %s", err)
+	}
+
+	return pipeline, nil
 }
 
 func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
@@ -103,5 +133,11 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Inputs != nil {
+		if err := sanitizeInputs(in.Inputs); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -17,6 +17,7 @@ package pipeline
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 
 	"github.com/google/wire"
 )
@@ -31,11 +32,13 @@ func ProvideController(
 	triggerStore store.TriggerStore,
 	authorizer authz.Authorizer,
 	pipelineStore store.PipelineStore,
+	auditService audit.Service,
 ) *Controller {
 	return NewController(
 		authorizer,
 		repoStore,
 		triggerStore,
 		pipelineStore,
+		auditService,
 	)
 }
@@ -0,0 +1,251 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AutoMergeInput struct {
+	Method             enum.MergeMethod `json:"method"`
+	Title              string           `json:"title"`
+	Message            string           `json:"message"`
+	DeleteSourceBranch bool             `json:"delete_source_branch"`
+}
+
+func (in *AutoMergeInput) sanitize() error {
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Method == enum.MergeMethodRebase && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequest("rebase doesn't support customizing commit title and message")
+	}
+
+	return nil
+}
+
+// AutoMergeEnable enables auto-merge for a pull request. The pull request gets merged with the provided
+// merge options as soon as all requirements for merging it are satisfied. If the requirements are satisfied
+// already, the pull request is merged right away.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeInput,
+) (*types.AutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.IsDraft {
+		return nil, usererror.BadRequest(
+			"Draft pull requests can't be merged. Clear the draft flag first.",
+		)
+	}
+
+	now := time.Now().UnixMilli()
+	autoMerge := &types.AutoMerge{
+		PullReqID:          pr.ID,
+		RepoID:             targetRepo.ID,
+		Method:             in.Method,
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		CreatedBy:          session.Principal.ID,
+		Created:            now,
+		Updated:            now,
+	}
+
+	if err = c.autoMergeStore.Upsert(ctx, autoMerge); err != nil {
+		return nil, fmt.Errorf("failed to enable auto-merge: %w", err)
+	}
+
+	c.writeAutoMergeActivity(ctx, targetRepo, pr, session.Principal.ID, &types.PullRequestActivityPayloadAutoMerge{
+		Action: enum.AutoMergeActionEnabled,
+		Method: in.Method,
+	})
+
+	if _, err = c.AutoMerge(ctx, pr, autoMerge); err != nil {
+		// non-critical error, merging is reattempted when the pull request changes.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to auto-merge pull request")
+	}
+
+	return autoMerge, nil
+}
+
+// AutoMergeDisable disables auto-merge for a pull request.
+func (c *Controller) AutoMergeDisable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if err = c.checkPullReqWriteAccess(ctx, session, targetRepo, pr); err != nil {
+		return err
+	}
+
+	err = c.autoMergeStore.Delete(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.BadRequest("Auto-merge isn't enabled for the pull request.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to disable auto-merge: %w", err)
+	}
+
+	c.writeAutoMergeActivity(ctx, targetRepo, pr, session.Principal.ID, &types.PullRequestActivityPayloadAutoMerge{
+		Action: enum.AutoMergeActionDisabled,
+	})
+
+	return nil
+}
+
+// AutoMergeFind returns the auto-merge options of a pull request.
+func (c *Controller) AutoMergeFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.AutoMerge, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Auto-merge isn't enabled for the pull request.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find auto-merge: %w", err)
+	}
+
+	return autoMerge, nil
+}
+
+// AutoMerge merges the pull request on behalf of the user who enabled auto-merge.
+// The merge goes through the same flow, including the protection rules verification, as when the user
+// merges the pull request. It returns false if the requirements for merging aren't satisfied yet.
+func (c *Controller) AutoMerge(
+	ctx context.Context,
+	pr *types.PullReq,
+	autoMerge *types.AutoMerge,
+) (bool, error) {
+	if pr.State != enum.PullReqStateOpen || pr.IsDraft {
+		return false, nil
+	}
+
+	principal, err := c.principalStore.Find(ctx, autoMerge.CreatedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to find principal who enabled auto-merge: %w", err)
+	}
+
+	targetRepo, err := c.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	_, violations, err := c.Merge(ctx, session, targetRepo.Path, pr.Number, &MergeInput{
+		Method:             autoMerge.Method,
+		SourceSHA:          pr.SourceSHA,
+		Title:              autoMerge.Title,
+		Message:            autoMerge.Message,
+		DeleteSourceBranch: autoMerge.DeleteSourceBranch,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to merge pull request: %w", err)
+	}
+
+	if violations != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (c *Controller) writeAutoMergeActivity(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadAutoMerge,
+) {
+	pr, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to get pull request activity number for auto-merge activity")
+		return
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, pr, principalID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request auto-merge activity")
+	}
+
+	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/sha"
@@ -62,6 +63,9 @@ type CommentApplySuggestionsInput struct {
 
 	DryRunRules bool `json:"dry_run_rules"`
 	BypassRules bool `json:"bypass_rules"`
+
+	// BypassJustification is the reason for bypassing the protection rules.
+	BypassJustification string `json:"bypass_justification"`
 }
 
 func (i *CommentApplySuggestionsInput) sanitize() error {
@@ -98,52 +102,31 @@ func (c *Controller) CommentApplySuggestions(
 	prNum int64,
 	in *CommentApplySuggestionsInput,
 ) (CommentApplySuggestionsOutput, []types.RuleViolations, error) {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
 
-	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, prNum)
 	if err != nil {
 		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
-	if err := in.sanitize(); err != nil {
-		return CommentApplySuggestionsOutput{}, nil, err
-	}
-
-	// verify branch rules
-	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
-	if err != nil {
-		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
-	}
-	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
-	if err != nil {
-		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf(
-			"failed to fetch protection rules for the repository: %w", err)
-	}
-	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-		Actor:       &session.Principal,
-		AllowBypass: in.BypassRules,
-		IsRepoOwner: isRepoOwner,
-		Repo:        repo,
-		RefAction:   protection.RefActionUpdate,
-		RefType:     protection.RefTypeBranch,
-		RefNames:    []string{pr.SourceBranch},
-	})
-	if err != nil {
-		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	// suggestions are committed to the source branch, so the user must be able to push to the source repository.
+	repo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		repo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to find source repo: %w", err)
+		}
 	}
 
-	if in.DryRunRules {
-		return CommentApplySuggestionsOutput{
-			DryRunRules:    true,
-			RuleViolations: violations,
-		}, nil, nil
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush); err != nil {
+		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
 	}
 
-	if protection.IsCritical(violations) {
-		return CommentApplySuggestionsOutput{}, violations, nil
+	if err := in.sanitize(); err != nil {
+		return CommentApplySuggestionsOutput{}, nil, err
 	}
 
 	actions := []git.CommitFileAction{}
@@ -282,6 +265,70 @@ func (c *Controller) CommentApplySuggestions(
 		}
 	}
 
+	// verify branch rules
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf(
+			"failed to fetch protection rules for the repository: %w", err)
+	}
+
+	// backfill title if not provided (keeping it basic for now, user can provide more detailed title)
+	if in.Title == "" {
+		in.Title = "Apply code review suggestions"
+	}
+
+	commitMessage := in.Title
+	if in.Message != "" {
+		commitMessage += "\n\n" + in.Message
+	}
+
+	// the commit is created by the server, on behalf of the user and without a signature.
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+	refCommits := protection.StaticCommitsFunc(protection.Commit{
+		SHA:            protection.PendingCommitSHA,
+		Message:        commitMessage,
+		AuthorEmail:    session.Principal.Email,
+		CommitterEmail: systemPrincipal.Email,
+	})
+
+	paths := make([]string, len(actions))
+	for i, action := range actions {
+		paths[i] = action.Path
+	}
+	refFiles := protection.StaticFilesFunc(paths...)
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:               &session.Principal,
+		AllowBypass:         in.BypassRules,
+		BypassJustification: in.BypassJustification,
+		IsRepoOwner:         isRepoOwner,
+		Repo:                repo,
+		RefAction:           protection.RefActionUpdate,
+		RefType:             protection.RefTypeBranch,
+		RefNames:            []string{pr.SourceBranch},
+		RefCommits:          map[string]protection.CommitsFunc{pr.SourceBranch: refCommits},
+		RefFiles:            map[string]protection.FilesFunc{pr.SourceBranch: refFiles},
+		PushFiles:           refFiles,
+	})
+	if err != nil {
+		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return CommentApplySuggestionsOutput{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return CommentApplySuggestionsOutput{}, violations, nil
+	}
+
 	// we want to complete the operation independent of request cancel - start with new, time restricted context.
 	// TODO: This is a small change to reduce likelihood of dirty state (e.g. git work done but db canceled).
 	// We still require a proper solution to handle an application crash or very slow execution times
@@ -298,20 +345,15 @@ func (c *Controller) CommentApplySuggestions(
 		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
-	// backfill title if not provided (keeping it basic for now, user can provide more detailed title)
-	if in.Title == "" {
-		in.Title = "Apply code review suggestions"
-	}
-
 	now := time.Now()
 	commitOut, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
 		WriteParams:   writeParams,
 		Title:         in.Title,
 		Message:       in.Message,
 		Branch:        pr.SourceBranch,
-		Committer:     identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
+		Committer:     pullreq.IdentityFromPrincipalInfo(*systemPrincipal.ToPrincipalInfo()),
 		CommitterDate: &now,
-		Author:        identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo()),
+		Author:        pullreq.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo()),
 		AuthorDate:    &now,
 		Actions:       actions,
 	})
@@ -319,6 +361,9 @@ func (c *Controller) CommentApplySuggestions(
 		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to commit changes: %w", err)
 	}
 
+	protection.LogBypassAudit(ctx, c.auditService, session.Principal, repo,
+		[]string{pr.SourceBranch}, violations, in.BypassJustification)
+
 	// update activities (use UpdateOptLock as it can have racing condition with comment migration)
 	resolved := ptr.Of(now.UnixMilli())
 	resolvedBy := &session.Principal.ID
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -113,5 +114,12 @@ func (c *Controller) CommentUpdate(
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
 
+	c.eventReporter.CommentUpdated(ctx, &events.CommentUpdatedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		ActivityID: act.ID,
+		SourceSHA:  pr.SourceSHA,
+		IsReply:    act.IsReply(),
+	})
+
 	return act, nil
 }
@@ -19,21 +19,28 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -62,6 +69,14 @@ type Controller struct {
 	sseStreamer         sse.Streamer
 	codeOwners          *codeowners.Service
 	locker              *locker.Locker
+	auditService        audit.Service
+	mergeQueueStore     store.MergeQueueStore
+	mergeQueue          *mergequeue.Service
+	publicKey           publickey.Service
+	autoMergeStore      store.AutoMergeStore
+	labelSvc            *label.Service
+	pullReqLabelStore   store.PullReqLabelStore
+	templates           *pullreqtemplate.Service
 }
 
 func NewController(
@@ -87,6 +102,14 @@ func NewController(
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
 	locker *locker.Locker,
+	auditService audit.Service,
+	mergeQueueStore store.MergeQueueStore,
+	mergeQueue *mergequeue.Service,
+	publicKey publickey.Service,
+	autoMergeStore store.AutoMergeStore,
+	labelSvc *label.Service,
+	pullReqLabelStore store.PullReqLabelStore,
+	templates *pullreqtemplate.Service,
 ) *Controller {
 	return &Controller{
 		tx:                  tx,
@@ -111,6 +134,14 @@ func NewController(
 		sseStreamer:         sseStreamer,
 		codeOwners:          codeowners,
 		locker:              locker,
+		auditService:        auditService,
+		mergeQueueStore:     mergeQueueStore,
+		mergeQueue:          mergeQueue,
+		publicKey:           publicKey,
+		autoMergeStore:      autoMergeStore,
+		labelSvc:            labelSvc,
+		pullReqLabelStore:   pullReqLabelStore,
+		templates:           templates,
 	}
 }
 
@@ -163,6 +194,76 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 	return repo, nil
 }
 
+// checkPullReqWriteAccess checks if the user is allowed to modify the pull request.
+// The author of a pull request from a fork doesn't need push access to the target repository.
+func (c *Controller) checkPullReqWriteAccess(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+) error {
+	if pr.SourceRepoID != pr.TargetRepoID && pr.CreatedBy == session.Principal.ID {
+		return nil
+	}
+
+	if err := apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush); err != nil {
+		return fmt.Errorf("access check failed: %w", err)
+	}
+
+	return nil
+}
+
+// verifyForkRelation checks that pull requests between the two repositories are allowed.
+// Pull requests across repositories are only supported between a fork and its upstream repository.
+func verifyForkRelation(sourceRepo, targetRepo *types.Repository) error {
+	if sourceRepo.ID == targetRepo.ID {
+		return nil
+	}
+
+	if sourceRepo.ForkID != targetRepo.ID && targetRepo.ForkID != sourceRepo.ID {
+		return usererror.BadRequest(
+			"Pull requests between different repositories are only supported between a fork and its upstream.")
+	}
+
+	return nil
+}
+
+// fetchSourceCommits makes the source commit of a cross repository pull request
+// available in the target repository, the commits must pass the pre-receive checks of the target repository.
+// It's a no-op if both repositories are the same.
+func (c *Controller) fetchSourceCommits(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+	sourceSHA string,
+) error {
+	if sourceRepo.ID == targetRepo.ID {
+		return nil
+	}
+
+	objectSHA, err := sha.New(sourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to parse source commit SHA: %w", err)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHA:     objectSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commits into the target repository: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) getCommentForPR(
 	ctx context.Context,
 	pr *types.PullReq,
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Export returns the complete history of the pull request: its details, reviewers,
+// status checks of the latest source commit and the timeline of all activities.
+func (c *Controller) Export(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) (*types.PullReqExport, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.Find(ctx, session, repoRef, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	reviewers, err := c.ReviewerList(ctx, session, repoRef, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	checks, err := c.ListChecks(ctx, session, repoRef, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request status checks: %w", err)
+	}
+
+	timeline, err := c.ActivityList(ctx, session, repoRef, prNum, &types.PullReqActivityFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request activities: %w", err)
+	}
+
+	return &types.PullReqExport{
+		Exported:   time.Now().UnixMilli(),
+		ExportedBy: *session.Principal.ToPrincipalInfo(),
+		RepoPath:   repo.Path,
+		PullReq:    pr,
+		Reviewers:  reviewers,
+		Checks:     checks,
+		Timeline:   timeline,
+	}, nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// WriteExportMarkdown writes the Markdown rendition of the pull request export.
+func WriteExportMarkdown(w io.Writer, export *types.PullReqExport) error {
+	bw := bufio.NewWriter(w)
+	md := &markdownWriter{w: bw}
+
+	pr := export.PullReq
+
+	md.printf("# Pull request #%d: %s\n\n", pr.Number, pr.Title)
+
+	md.printf("| | |\n|---|---|\n")
+	md.printf("| Repository | %s |\n", escapeMarkdownTable(export.RepoPath))
+	md.printf("| State | %s |\n", pullReqStateText(pr))
+	md.printf("| Author | %s |\n", escapeMarkdownTable(principalText(&pr.Author)))
+	md.printf("| Created | %s |\n", formatExportTime(pr.Created))
+	md.printf("| Source branch | `%s` at `%s` |\n", pr.SourceBranch, pr.SourceSHA)
+	md.printf("| Target branch | `%s` |\n", pr.TargetBranch)
+	md.printf("| Merge base | `%s` |\n", pr.MergeBaseSHA)
+	if pr.Merged != nil {
+		md.printf("| Merged | %s |\n", formatExportTime(*pr.Merged))
+		if pr.Merger != nil {
+			md.printf("| Merged by | %s |\n", escapeMarkdownTable(principalText(pr.Merger)))
+		}
+		if pr.MergeMethod != nil {
+			md.printf("| Merge method | %s |\n", *pr.MergeMethod)
+		}
+	}
+	if pr.Closed != nil && pr.Merged == nil {
+		md.printf("| Closed | %s |\n", formatExportTime(*pr.Closed))
+	}
+	md.printf("| Exported | %s by %s |\n\n",
+		formatExportTime(export.Exported), escapeMarkdownTable(principalText(&export.ExportedBy)))
+
+	md.printf("## Description\n\n")
+	if pr.Description != "" {
+		md.printf("%s\n\n", pr.Description)
+	} else {
+		md.printf("_No description provided._\n\n")
+	}
+
+	md.printf("## Reviewers\n\n")
+	if len(export.Reviewers) > 0 {
+		md.printf("| Reviewer | Type | Decision | Reviewed commit |\n|---|---|---|---|\n")
+		for _, reviewer := range export.Reviewers {
+			md.printf("| %s | %s | %s | %s |\n",
+				escapeMarkdownTable(principalText(&reviewer.Reviewer)),
+				reviewer.Type,
+				reviewer.ReviewDecision,
+				codeOrEmpty(reviewer.SHA))
+		}
+		md.printf("\n")
+	} else {
+		md.printf("_No reviewers._\n\n")
+	}
+
+	md.printf("## Status checks\n\n")
+	if len(export.Checks.Checks) > 0 {
+		md.printf("Status checks of commit `%s`.\n\n", export.Checks.CommitSHA)
+		md.printf("| Check | Status | Required | Summary |\n|---|---|---|---|\n")
+		for _, check := range export.Checks.Checks {
+			required := "no"
+			if check.Required {
+				required = "yes"
+				if check.Bypassable {
+					required = "yes (bypassable)"
+				}
+			}
+			md.printf("| %s | %s | %s | %s |\n",
+				escapeMarkdownTable(check.Check.Identifier),
+				check.Check.Status,
+				required,
+				escapeMarkdownTable(check.Check.Summary))
+		}
+		md.printf("\n")
+	} else {
+		md.printf("_No status checks._\n\n")
+	}
+
+	md.printf("## Timeline\n\n")
+	for _, act := range export.Timeline {
+		writeMarkdownActivity(md, act)
+	}
+
+	if md.err != nil {
+		return md.err
+	}
+
+	return bw.Flush()
+}
+
+// markdownWriter remembers the first write error, to avoid checking the error of every single write.
+type markdownWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (m *markdownWriter) printf(format string, args ...any) {
+	if m.err != nil {
+		return
+	}
+	_, m.err = fmt.Fprintf(m.w, format, args...)
+}
+
+func writeMarkdownActivity(md *markdownWriter, act *types.PullReqActivity) {
+	indent := ""
+	if act.IsReply() {
+		indent = "  "
+	}
+
+	md.printf("%s- **%s** %s %s\n",
+		indent, formatExportTime(act.Created), principalText(&act.Author), activityDescription(act))
+
+	if act.Deleted != nil {
+		md.printf("%s  _Deleted at %s._\n", indent, formatExportTime(*act.Deleted))
+		return
+	}
+
+	if act.Text != "" {
+		md.printf("\n")
+		for _, line := range strings.Split(act.Text, "\n") {
+			md.printf("%s  > %s\n", indent, line)
+		}
+		md.printf("\n")
+	}
+
+	if act.Resolved != nil && act.Resolver != nil {
+		md.printf("%s  _Resolved at %s by %s._\n", indent, formatExportTime(*act.Resolved), principalText(act.Resolver))
+	}
+}
+
+// activityDescription returns a short description of the activity based on its type and payload.
+func activityDescription(act *types.PullReqActivity) string {
+	switch {
+	case act.IsReply():
+		return "replied"
+	case act.Type == enum.PullReqActivityTypeComment:
+		return "commented"
+	case act.Type == enum.PullReqActivityTypeCodeComment:
+		return codeCommentDescription(act.CodeComment)
+	}
+
+	payload, err := act.GetPayload()
+	if err != nil {
+		return string(act.Type)
+	}
+
+	switch p := payload.(type) {
+	case *types.PullRequestActivityPayloadMerge:
+		s := fmt.Sprintf("merged commit `%s` into `%s` using %s, producing `%s`",
+			p.SourceSHA, p.TargetSHA, p.MergeMethod, p.MergeSHA)
+		if p.RulesBypassed {
+			s += " (protection rules bypassed)"
+		}
+		return s
+	case *types.PullRequestActivityPayloadStateChange:
+		return fmt.Sprintf("changed the state from %s to %s",
+			stateText(p.Old, p.OldDraft), stateText(p.New, p.NewDraft))
+	case *types.PullRequestActivityPayloadTitleChange:
+		return fmt.Sprintf("changed the title from %q to %q", p.Old, p.New)
+	case *types.PullRequestActivityPayloadReviewSubmit:
+		return fmt.Sprintf("submitted a review with decision %s for commit `%s`", p.Decision, p.CommitSHA)
+	case *types.PullRequestActivityPayloadBranchUpdate:
+		return fmt.Sprintf("updated the source branch from `%s` to `%s`", p.Old, p.New)
+	case *types.PullRequestActivityPayloadBranchDelete:
+		return fmt.Sprintf("deleted the source branch at `%s`", p.SHA)
+	case *types.PullRequestActivityPayloadMergeQueue:
+		return mergeQueueDescription(p)
+	case *types.PullRequestActivityPayloadAutoMerge:
+		return autoMergeDescription(p)
+	case *types.PullRequestActivityPayloadLabel:
+		return labelDescription(p)
+	case *types.PullRequestActivityPayloadTargetBranchChange:
+		return targetBranchChangeDescription(p)
+	default:
+		return string(act.Type)
+	}
+}
+
+func codeCommentDescription(cc *types.CodeCommentFields) string {
+	if cc == nil {
+		return "commented on code"
+	}
+
+	s := fmt.Sprintf("commented on `%s` line %d", cc.Path, cc.LineNew)
+	if cc.SpanNew > 1 {
+		s = fmt.Sprintf("commented on `%s` lines %d-%d", cc.Path, cc.LineNew, cc.LineNew+cc.SpanNew-1)
+	}
+
+	s += fmt.Sprintf(" at commit `%s`", cc.SourceSHA)
+	if cc.Outdated {
+		s += " (outdated)"
+	}
+
+	return s
+}
+
+func pullReqStateText(pr *types.PullReq) string {
+	return stateText(pr.State, pr.IsDraft)
+}
+
+func mergeQueueDescription(p *types.PullRequestActivityPayloadMergeQueue) string {
+	var s string
+	switch p.Action {
+	case enum.MergeQueueActionAdded:
+		s = fmt.Sprintf("added the pull request to the merge queue of `%s`", p.TargetBranch)
+	case enum.MergeQueueActionRemoved:
+		s = fmt.Sprintf("removed the pull request from the merge queue of `%s`", p.TargetBranch)
+	case enum.MergeQueueActionEjected:
+		s = fmt.Sprintf("ejected the pull request from the merge queue of `%s`", p.TargetBranch)
+	default:
+		s = fmt.Sprintf("changed the merge queue of `%s`", p.TargetBranch)
+	}
+	if p.Reason != "" {
+		s += ": " + p.Reason
+	}
+	return s
+}
+
+func autoMergeDescription(p *types.PullRequestActivityPayloadAutoMerge) string {
+	var s string
+	switch p.Action {
+	case enum.AutoMergeActionEnabled:
+		s = fmt.Sprintf("enabled auto-merge using the %s method", p.Method)
+	case enum.AutoMergeActionDisabled:
+		s = "disabled auto-merge"
+	case enum.AutoMergeActionCanceled:
+		s = "canceled auto-merge"
+	default:
+		s = "changed auto-merge"
+	}
+	if p.Reason != "" {
+		s += ": " + p.Reason
+	}
+	return s
+}
+
+func labelDescription(p *types.PullRequestActivityPayloadLabel) string {
+	switch p.Type {
+	case enum.PullReqLabelActivityTypeAssign:
+		return fmt.Sprintf("added label `%s`", p.Label)
+	case enum.PullReqLabelActivityTypeReassign:
+		return fmt.Sprintf("replaced label `%s` with `%s`", p.OldLabel, p.Label)
+	case enum.PullReqLabelActivityTypeUnassign:
+		return fmt.Sprintf("removed label `%s`", p.Label)
+	default:
+		return "changed labels"
+	}
+}
+
+func targetBranchChangeDescription(p *types.PullRequestActivityPayloadTargetBranchChange) string {
+	s := fmt.Sprintf("changed the target branch from `%s` to `%s`", p.Old, p.New)
+	if p.MergedPullReqNumber != 0 {
+		s += fmt.Sprintf(" after #%d was merged", p.MergedPullReqNumber)
+	}
+	return s
+}
+
+func stateText(state enum.PullReqState, isDraft bool) string {
+	if isDraft {
+		return string(state) + " (draft)"
+	}
+	return string(state)
+}
+
+func principalText(p *types.PrincipalInfo) string {
+	if p.Email == "" {
+		return p.DisplayName
+	}
+	return fmt.Sprintf("%s <%s>", p.DisplayName, p.Email)
+}
+
+func formatExportTime(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+func codeOrEmpty(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + s + "`"
+}
+
+func escapeMarkdownTable(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestWriteExportMarkdown(t *testing.T) {
+	author := types.PrincipalInfo{DisplayName: "Jane", Email: "jane@example.com"}
+	merged := int64(1700000600000)
+	mergeMethod := enum.MergeMethodSquash
+
+	newActivity := func(
+		order, subOrder int64,
+		kind enum.PullReqActivityKind,
+		payload types.PullReqActivityPayload,
+		text string,
+	) *types.PullReqActivity {
+		act := &types.PullReqActivity{
+			Created:  1700000000000,
+			Order:    order,
+			SubOrder: subOrder,
+			Type:     payload.ActivityType(),
+			Kind:     kind,
+			Text:     text,
+			Author:   author,
+		}
+		if subOrder > 0 {
+			parentID := order
+			act.ParentID = &parentID
+		}
+		if err := act.SetPayload(payload); err != nil {
+			t.Fatalf("failed to set payload: %s", err)
+		}
+		return act
+	}
+
+	codeComment := newActivity(2, 0, enum.PullReqActivityKindChangeComment,
+		&types.PullRequestActivityPayloadCodeComment{}, "Please rename this.")
+	codeComment.CodeComment = &types.CodeCommentFields{Path: "main.go", LineNew: 10, SpanNew: 3, SourceSHA: "abc"}
+
+	export := &types.PullReqExport{
+		Exported:   1700000900000,
+		ExportedBy: author,
+		RepoPath:   "space/repo",
+		PullReq: &types.PullReq{
+			Number:       7,
+			Title:        "Add feature",
+			State:        enum.PullReqStateMerged,
+			SourceBranch: "feature",
+			TargetBranch: "main",
+			Merged:       &merged,
+			MergeMethod:  &mergeMethod,
+			Author:       author,
+		},
+		Reviewers: []*types.PullReqReviewer{
+			{
+				Reviewer:       author,
+				Type:           enum.PullReqReviewerTypeRequested,
+				ReviewDecision: enum.PullReqReviewDecisionApproved,
+			},
+		},
+		Checks: types.PullReqChecks{
+			CommitSHA: "abc",
+			Checks: []types.PullReqCheck{
+				{Required: true, Check: types.Check{Identifier: "build", Status: enum.CheckStatusSuccess}},
+			},
+		},
+		Timeline: []*types.PullReqActivity{
+			newActivity(1, 0, enum.PullReqActivityKindComment, types.PullRequestActivityPayloadComment{}, "LGTM"),
+			codeComment,
+			newActivity(2, 1, enum.PullReqActivityKindChangeComment,
+				&types.PullRequestActivityPayloadCodeComment{}, "Done"),
+			newActivity(3, 0, enum.PullReqActivityKindSystem, &types.PullRequestActivityPayloadMerge{
+				MergeMethod: enum.MergeMethodSquash, SourceSHA: "abc", TargetSHA: "def", MergeSHA: "123",
+			}, ""),
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteExportMarkdown(buf, export); err != nil {
+		t.Fatalf("failed to write markdown: %s", err)
+	}
+
+	got := buf.String()
+
+	for _, want := range []string{
+		"# Pull request #7: Add feature\n",
+		"| Repository | space/repo |\n",
+		"| Merge method | squash |\n",
+		"| Jane <jane@example.com> | requested | approved |  |\n",
+		"| build | success | yes |  |\n",
+		"- **2023-11-14T22:13:20Z** Jane <jane@example.com> commented\n\n  > LGTM\n",
+		"commented on `main.go` lines 10-12 at commit `abc`\n",
+		"  - **2023-11-14T22:13:20Z** Jane <jane@example.com> replied\n\n    > Done\n",
+		"merged commit `abc` into `def` using squash, producing `123`\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected export to contain %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LabelAssignInput struct {
+	LabelID int64 `json:"label_id"`
+}
+
+func (in *LabelAssignInput) sanitize() error {
+	if in.LabelID <= 0 {
+		return usererror.BadRequest("A valid label ID must be provided.")
+	}
+
+	return nil
+}
+
+// LabelAssign assigns a label to a pull request. The label must be defined either in the repository
+// or in any of the repository's ancestor spaces. Assigning a scoped label ("key:value")
+// replaces the label with the same key that is already assigned to the pull request.
+func (c *Controller) LabelAssign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *LabelAssignInput,
+) ([]types.LabelInfo, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	lbl, err := c.labelSvc.FindAvailable(ctx, repo, in.LabelID)
+	if err != nil {
+		return nil, err
+	}
+
+	var assigned bool
+	var replaced []types.LabelInfo
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var current []types.LabelInfo
+		current, err = c.pullReqLabelStore.ListAssigned(ctx, pr.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list pull request labels: %w", err)
+		}
+
+		replaced = replaced[:0]
+		for _, l := range current {
+			if l.ID == lbl.ID {
+				assigned = true
+				return nil
+			}
+
+			if key := lbl.Key(); key != "" && types.LabelKey(l.Name) == key {
+				replaced = append(replaced, l)
+			}
+		}
+
+		for _, l := range replaced {
+			if err = c.pullReqLabelStore.Unassign(ctx, pr.ID, l.ID); err != nil {
+				return fmt.Errorf("failed to unassign scoped label %q: %w", l.Name, err)
+			}
+		}
+
+		err = c.pullReqLabelStore.Assign(ctx, &types.PullReqLabel{
+			PullReqID: pr.ID,
+			LabelID:   lbl.ID,
+			CreatedBy: session.Principal.ID,
+			Created:   time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to assign label: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !assigned {
+		payload := &types.PullRequestActivityPayloadLabel{
+			Type:  enum.PullReqLabelActivityTypeAssign,
+			Label: lbl.Name,
+			Color: lbl.Color,
+		}
+		if len(replaced) > 0 {
+			payload.Type = enum.PullReqLabelActivityTypeReassign
+			payload.OldLabel = replaced[0].Name
+			payload.OldColor = replaced[0].Color
+		}
+
+		c.writeLabelActivity(ctx, repo, pr, session.Principal.ID, payload)
+	}
+
+	labels, err := c.pullReqLabelStore.ListAssigned(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// LabelUnassign removes a label from a pull request.
+func (c *Controller) LabelUnassign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	labelID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	current, err := c.pullReqLabelStore.ListAssigned(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
+	var lbl *types.LabelInfo
+	for i := range current {
+		if current[i].ID == labelID {
+			lbl = &current[i]
+			break
+		}
+	}
+
+	if lbl == nil {
+		return usererror.NotFound("Label isn't assigned to the pull request.")
+	}
+
+	if err = c.pullReqLabelStore.Unassign(ctx, pr.ID, lbl.ID); err != nil {
+		return fmt.Errorf("failed to unassign label: %w", err)
+	}
+
+	c.writeLabelActivity(ctx, repo, pr, session.Principal.ID, &types.PullRequestActivityPayloadLabel{
+		Type:  enum.PullReqLabelActivityTypeUnassign,
+		Label: lbl.Name,
+		Color: lbl.Color,
+	})
+
+	return nil
+}
+
+// LabelList returns labels assigned to a pull request.
+func (c *Controller) LabelList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) ([]types.LabelInfo, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	labels, err := c.pullReqLabelStore.ListAssigned(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// fillLabels sets the labels of all pull requests in the list.
+func (c *Controller) fillLabels(ctx context.Context, list []*types.PullReq) error {
+	ids := make([]int64, len(list))
+	for i, pr := range list {
+		ids[i] = pr.ID
+	}
+
+	labelMap, err := c.pullReqLabelStore.ListAssignedByPullReqIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
+	for _, pr := range list {
+		pr.Labels = labelMap[pr.ID]
+	}
+
+	return nil
+}
+
+func (c *Controller) writeLabelActivity(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadLabel,
+) {
+	pr, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to get pull request activity number for label activity")
+		return
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, pr, principalID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request label activity")
+	}
+
+	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+}
@@ -28,6 +28,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
@@ -47,6 +48,14 @@ type MergeInput struct {
 	Message     string           `json:"message"`
 	BypassRules bool             `json:"bypass_rules"`
 	DryRun      bool             `json:"dry_run"`
+
+	// DeleteSourceBranch requests deletion of the source branch after the merge,
+	// even if the protection rules don't require it.
+	DeleteSourceBranch bool `json:"delete_source_branch"`
+
+	// BypassJustification is the reason for bypassing the protection rules.
+	// It's required only by the rules that opted in to require a justification for bypassing.
+	BypassJustification string `json:"bypass_justification"`
 }
 
 func (in *MergeInput) sanitize() error {
@@ -169,15 +178,24 @@ func (c *Controller) Merge(
 
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
+	canDeleteSourceBranch := true
 	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+
 		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 
-		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		// the source branch in a fork is deleted only if the user is allowed to push to the fork.
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			canDeleteSourceBranch = false
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("failed to check access to the source repository: %w", err)
 		}
 	}
 
@@ -213,11 +231,16 @@ func (c *Controller) Merge(
 		Method:       in.Method,
 		CheckResults: checkResults,
 		CodeOwners:   codeOwnerWithApproval,
+		Commits:      protection.PullReqCommitsFunc(c.git, c.publicKey, targetRepo, pr),
+
+		BypassJustification: in.BypassJustification,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
+	deleteSourceBranch := (ruleOut.DeleteSourceBranch || in.DeleteSourceBranch) && canDeleteSourceBranch
+
 	// we want to complete the merge independent of request cancel - start with new, time restricted context.
 	// TODO: This is a small change to reduce likelihood of dirty state.
 	// We still require a proper solution to handle an application crash or very slow execution times
@@ -285,7 +308,7 @@ func (c *Controller) Merge(
 
 		// With in.DryRun=true this function never returns types.MergeViolations
 		out := &types.MergeResponse{
-			BranchDeleted:  ruleOut.DeleteSourceBranch,
+			BranchDeleted:  deleteSourceBranch,
 			RuleViolations: violations,
 
 			// values only retured by dry run
@@ -298,6 +321,7 @@ func (c *Controller) Merge(
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
 		}
 
 		return out, nil, nil
@@ -313,9 +337,9 @@ func (c *Controller) Merge(
 
 	switch in.Method {
 	case enum.MergeMethodMerge:
-		author = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+		author = pullreq.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
 	case enum.MergeMethodSquash:
-		author = identityFromPrincipalInfo(pr.Author)
+		author = pullreq.IdentityFromPrincipalInfo(pr.Author)
 	case enum.MergeMethodRebase:
 		author = nil // Not important for the rebase merge: the author info in the commits will be preserved.
 	}
@@ -324,21 +348,14 @@ func (c *Controller) Merge(
 
 	switch in.Method {
 	case enum.MergeMethodMerge, enum.MergeMethodSquash:
-		committer = identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+		committer = pullreq.IdentityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
 	case enum.MergeMethodRebase:
-		committer = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+		committer = pullreq.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
 	}
 
 	// backfill commit title if none provided
 	if in.Title == "" {
-		switch in.Method {
-		case enum.MergeMethodMerge:
-			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
-		case enum.MergeMethodSquash:
-			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase:
-			// Not used.
-		}
+		in.Title = defaultMergeTitle(in.Method, pr, sourceRepo)
 	}
 
 	// create merge commit(s)
@@ -421,7 +438,7 @@ func (c *Controller) Merge(
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
 
-		if ruleOut.DeleteSourceBranch {
+		if deleteSourceBranch {
 			pr.ActivitySeq++
 			activitySeqBranchDeleted = pr.ActivitySeq
 		}
@@ -433,18 +450,24 @@ func (c *Controller) Merge(
 	}
 
 	pr.ActivitySeq = activitySeqMerge
+	rulesBypassed := protection.IsBypassed(violations)
 	activityPayload := &types.PullRequestActivityPayloadMerge{
 		MergeMethod:   in.Method,
 		MergeSHA:      mergeOutput.MergeSHA.String(),
 		TargetSHA:     mergeOutput.BaseSHA.String(),
 		SourceSHA:     mergeOutput.HeadSHA.String(),
-		RulesBypassed: protection.IsBypassed(violations),
+		RulesBypassed: rulesBypassed,
+	}
+	if rulesBypassed {
+		activityPayload.BypassJustification = in.BypassJustification
 	}
 	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, activityPayload); errAct != nil {
 		// non-critical error
 		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
 	}
 
+	pullreq.LogMergeAudit(ctx, c.auditService, session.Principal, targetRepo, pr, violations, in.BypassJustification)
+
 	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
 		Base:        eventBase(pr, &session.Principal),
 		MergeMethod: in.Method,
@@ -454,27 +477,9 @@ func (c *Controller) Merge(
 	})
 
 	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
-		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
-			WriteParams: sourceWriteParams,
-			BranchName:  pr.SourceBranch,
-		})
-		if errDelete != nil {
-			// non-critical error
-			log.Ctx(ctx).Err(errDelete).Msgf("failed to delete source branch after merging")
-		} else {
-			branchDeleted = true
-
-			// NOTE: there is a chance someone pushed on the branch between merge and delete.
-			// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
-			pr.ActivitySeq = activitySeqBranchDeleted
-			if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID,
-				&types.PullRequestActivityPayloadBranchDelete{SHA: in.SourceSHA}); errAct != nil {
-				// non-critical error
-				log.Ctx(ctx).Err(errAct).
-					Msgf("failed to write pull request activity for successful automatic branch delete")
-			}
-		}
+	if deleteSourceBranch {
+		branchDeleted = pullreq.DeleteSourceBranch(ctx, c.git, c.activityStore, sourceWriteParams,
+			pr, session.Principal.ID, in.SourceSHA, activitySeqBranchDeleted)
 	}
 
 	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
@@ -487,3 +492,17 @@ func (c *Controller) Merge(
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// defaultMergeTitle returns the title of the commit created by merging the pull request with the merge method.
+func defaultMergeTitle(method enum.MergeMethod, pr *types.PullReq, sourceRepo *types.Repository) string {
+	switch method {
+	case enum.MergeMethodMerge:
+		return fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase:
+		// Not used.
+	}
+
+	return ""
+}
@@ -0,0 +1,300 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MergeQueueAddInput struct {
+	Method      enum.MergeMethod `json:"method"`
+	SourceSHA   string           `json:"source_sha"`
+	Title       string           `json:"title"`
+	Message     string           `json:"message"`
+	BypassRules bool             `json:"bypass_rules"`
+
+	// BypassJustification is the reason for bypassing the protection rules.
+	// It's required only by the rules that opted in to require a justification for bypassing.
+	BypassJustification string `json:"bypass_justification"`
+}
+
+func (in *MergeQueueAddInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("source SHA must be provided")
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Method == enum.MergeMethodRebase && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequest("rebase doesn't support customizing commit title and message")
+	}
+
+	return nil
+}
+
+// MergeQueueAdd adds a pull request to the end of the merge queue of its target branch.
+//
+// The pull request must satisfy all protection rules, same as for merging it directly.
+// Once added, it gets merged after the status checks required by the rules succeeded
+// for the speculative merge commit of the pull request and all pull requests ahead of it in the queue.
+//
+//nolint:gocognit
+func (c *Controller) MergeQueueAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *MergeQueueAddInput,
+) (*types.MergeQueueEntry, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	unlock, err := c.locker.LockPR(ctx, targetRepo.ID, pullreqNum, 30*time.Second)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.IsDraft {
+		return nil, nil, usererror.BadRequest(
+			"Draft pull requests can't be merged. Clear the draft flag first.",
+		)
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil,
+			usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	if pr.MergeCheckStatus == enum.MergeCheckStatusConflict {
+		return nil, nil, usererror.BadRequest("Pull request has merge conflicts.")
+	}
+
+	_, err = c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err == nil {
+		return nil, nil, usererror.BadRequest("Pull request is already in the merge queue.")
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, targetRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	checkResults, err := c.checkStore.ListResults(ctx, targetRepo.ID, pr.SourceSHA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	// check for error and ignore if it is codeowners file not found else throw error
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	_, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:        &session.Principal,
+		AllowBypass:  in.BypassRules,
+		IsRepoOwner:  isRepoOwner,
+		TargetRepo:   targetRepo,
+		SourceRepo:   sourceRepo,
+		PullReq:      pr,
+		Reviewers:    reviewers,
+		Method:       in.Method,
+		CheckResults: checkResults,
+		CodeOwners:   codeOwnerWithApproval,
+		MergeQueue:   true,
+		Commits:      protection.PullReqCommitsFunc(c.git, c.publicKey, targetRepo, pr),
+
+		BypassJustification: in.BypassJustification,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	}
+
+	// backfill commit title if none provided
+	if in.Title == "" {
+		in.Title = defaultMergeTitle(in.Method, pr, sourceRepo)
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		RepoID:              targetRepo.ID,
+		PullReqID:           pr.ID,
+		PullReqNumber:       pr.Number,
+		TargetBranch:        pr.TargetBranch,
+		State:               enum.MergeQueueEntryStateQueued,
+		Method:              in.Method,
+		Title:               in.Title,
+		Message:             in.Message,
+		BypassRules:         in.BypassRules,
+		SourceSHA:           pr.SourceSHA,
+		BypassJustification: in.BypassJustification,
+		CreatedBy:           session.Principal.ID,
+		Created:             now,
+		Updated:             now,
+	}
+
+	if err = c.mergeQueueStore.Create(ctx, entry); err != nil {
+		return nil, nil, fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+	}
+
+	c.mergeQueue.WriteActivity(ctx, targetRepo, pr, session.Principal.ID, &types.PullRequestActivityPayloadMergeQueue{
+		Action:       enum.MergeQueueActionAdded,
+		TargetBranch: pr.TargetBranch,
+	})
+
+	entries, err := c.mergeQueueStore.List(ctx, targetRepo.ID, pr.TargetBranch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list merge queue: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.ID == entry.ID {
+			entry.Position = e.Position
+		}
+	}
+
+	// create the speculative merge commit right away in the background, so the status checks can start.
+	if err = c.mergeQueue.ScheduleProcess(ctx, targetRepo.ID, pr.TargetBranch); err != nil {
+		// non-critical error, the queue is processed periodically
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to schedule processing of the merge queue")
+	}
+
+	return entry, nil, nil
+}
+
+// MergeQueueRemove removes a pull request from the merge queue of its target branch.
+func (c *Controller) MergeQueueRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	// pull requests are merged from the queue under the repo level lock, so the pull request can't be merged meanwhile.
+	unlock, err := c.locker.LockPR(ctx, targetRepo.ID, 0, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entry, err := c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.BadRequest("Pull request isn't in the merge queue.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	return c.mergeQueue.Remove(ctx, targetRepo, pr, entry, session.Principal.ID)
+}
+
+// MergeQueueList returns the merge queue of the branch. If the branch isn't provided,
+// the merge queue of the default branch of the repository is returned.
+func (c *Controller) MergeQueueList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	entries, err := c.mergeQueueStore.List(ctx, repo.ID, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue: %w", err)
+	}
+
+	return entries, nil
+}
@@ -47,16 +47,22 @@ func (c *Controller) Commits(
 	afterRef := pr.MergeBaseSHA
 
 	output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
-		ReadParams: git.CreateReadParams(repo),
-		GitREF:     gitRef,
-		After:      afterRef,
-		Page:       int32(filter.Page),
-		Limit:      int32(filter.Limit),
+		ReadParams:        git.CreateReadParams(repo),
+		GitREF:            gitRef,
+		After:             afterRef,
+		Page:              int32(filter.Page),
+		Limit:             int32(filter.Limit),
+		IncludeSignatures: true,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	verifications, err := c.publicKey.VerifyCommits(ctx, output.Commits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
 	commits := make([]types.Commit, len(output.Commits))
 	for i := range output.Commits {
 		var commit *types.Commit
@@ -64,6 +70,7 @@ func (c *Controller) Commits(
 		if err != nil {
 			return nil, fmt.Errorf("failed to map commit: %w", err)
 		}
+		commit.Verification = verifications[i]
 		commits[i] = *commit
 	}
 
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
@@ -36,6 +37,10 @@ type CreateInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 
+	// Template is the name of the pull request template used as the description if the description is empty.
+	// If not provided, the default template of the target branch is used.
+	Template string `json:"template"`
+
 	SourceRepoRef string `json:"source_repo_ref"`
 	SourceBranch  string `json:"source_branch"`
 	TargetBranch  string `json:"target_branch"`
@@ -53,7 +58,7 @@ func (c *Controller) Create(
 		return nil, usererror.BadRequest("pull request title can't be empty")
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
@@ -66,6 +71,18 @@ func (c *Controller) Create(
 		}
 	}
 
+	// Pull requests from a fork only require read access to the target repository,
+	// otherwise the user must be able to push to the repository.
+	if sourceRepo.ID == targetRepo.ID {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush); err != nil {
+			return nil, fmt.Errorf("access check failed: %w", err)
+		}
+	}
+
+	if err = verifyForkRelation(sourceRepo, targetRepo); err != nil {
+		return nil, err
+	}
+
 	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
@@ -76,7 +93,8 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	if _, err = c.verifyBranchExistence(ctx, targetRepo, in.TargetBranch); err != nil {
+	targetSHA, err := c.verifyBranchExistence(ctx, targetRepo, in.TargetBranch)
+	if err != nil {
 		return nil, err
 	}
 
@@ -84,9 +102,20 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if err = c.fetchSourceCommits(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(in.Description) == "" {
+		in.Description, err = c.descriptionFromTemplate(ctx, targetRepo, targetSHA, in.Template)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA,
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
@@ -46,6 +46,11 @@ func (c *Controller) Find(
 		return nil, err
 	}
 
+	pr.Labels, err = c.pullReqLabelStore.ListAssigned(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
 	headRef := pr.SourceSHA
 	baseRef := pr.MergeBaseSHA
 
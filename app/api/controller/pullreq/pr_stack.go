@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxStackSize is the maximum number of pull requests returned as a part of a stack.
+const maxStackSize = 100
+
+// Stack returns the stack of pull requests the pull request is a part of: The open pull requests
+// the pull request is stacked on (those whose source branch is targeted by it, recursively),
+// the pull request itself and the open pull requests that are stacked on top of it.
+// The pull requests are ordered from the bottom of the stack to the top.
+func (c *Controller) Stack(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) ([]types.PullReqStackEntry, error) {
+	if pullreqNum <= 0 {
+		return nil, usererror.BadRequest("A valid pull request number must be provided.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.stack(ctx, repo.ID, pr)
+}
+
+// stack walks the stack of the pull request within the repository.
+// Pull requests from forks can be a part of a stack, but nothing can be stacked on top of them.
+func (c *Controller) stack(
+	ctx context.Context,
+	repoID int64,
+	pr *types.PullReq,
+) ([]types.PullReqStackEntry, error) {
+	visited := map[int64]struct{}{pr.ID: {}}
+
+	// walk down the stack, following the target branches, leaving room for the pull request itself
+	below := make([]*types.PullReq, 0)
+	for cur := pr; len(below) < maxStackSize-1; {
+		prs, err := c.listStacked(ctx, repoID, cur.TargetBranch, "", 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(prs) == 0 {
+			break
+		}
+		if _, ok := visited[prs[0].ID]; ok {
+			break
+		}
+
+		cur = prs[0]
+		visited[cur.ID] = struct{}{}
+		below = append(below, cur)
+	}
+
+	stack := make([]types.PullReqStackEntry, 0, len(below)+1)
+	for i := len(below) - 1; i >= 0; i-- {
+		stack = append(stack, stackEntry(below[i], stack))
+	}
+	stack = append(stack, stackEntry(pr, stack))
+
+	// walk up the stack, collecting all pull requests stacked on top of the pull request
+	for i := len(stack) - 1; i < len(stack) && len(stack) < maxStackSize; i++ {
+		parent := stack[i]
+		if parent.PullReq.SourceRepoID != repoID {
+			continue
+		}
+
+		prs, err := c.listStacked(ctx, repoID, "", parent.PullReq.SourceBranch, maxStackSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range prs {
+			if _, ok := visited[child.ID]; ok || len(stack) >= maxStackSize {
+				continue
+			}
+
+			visited[child.ID] = struct{}{}
+			stack = append(stack, types.PullReqStackEntry{
+				Depth:        parent.Depth + 1,
+				ParentNumber: &parent.PullReq.Number,
+				PullReq:      child,
+			})
+		}
+	}
+
+	return stack, nil
+}
+
+// listStacked lists open pull requests within the repository with the provided source or target branch.
+func (c *Controller) listStacked(
+	ctx context.Context,
+	repoID int64,
+	sourceBranch string,
+	targetBranch string,
+	size int,
+) ([]*types.PullReq, error) {
+	prs, err := c.pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         size,
+		SourceRepoID: repoID,
+		SourceBranch: sourceBranch,
+		TargetRepoID: repoID,
+		TargetBranch: targetBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacked pull requests: %w", err)
+	}
+
+	return prs, nil
+}
+
+// stackEntry creates a new stack entry for the pull request placed on top of the last entry of the stack.
+func stackEntry(pr *types.PullReq, stack []types.PullReqStackEntry) types.PullReqStackEntry {
+	if len(stack) == 0 {
+		return types.PullReqStackEntry{PullReq: pr}
+	}
+
+	parent := stack[len(stack)-1].PullReq
+
+	return types.PullReqStackEntry{
+		Depth:        len(stack),
+		ParentNumber: &parent.Number,
+		PullReq:      pr,
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const stackRepoID = 1
+
+// stackedPullReqs is a fake pull request store that lists the pull requests ordered by number.
+type stackedPullReqs struct {
+	store.PullReqStore
+	prs []*types.PullReq
+}
+
+func (s *stackedPullReqs) List(_ context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
+	var result []*types.PullReq
+	for _, pr := range s.prs {
+		if len(result) >= opts.Size ||
+			pr.State != enum.PullReqStateOpen ||
+			opts.SourceRepoID != 0 && pr.SourceRepoID != opts.SourceRepoID ||
+			opts.SourceBranch != "" && pr.SourceBranch != opts.SourceBranch ||
+			opts.TargetRepoID != 0 && pr.TargetRepoID != opts.TargetRepoID ||
+			opts.TargetBranch != "" && pr.TargetBranch != opts.TargetBranch {
+			continue
+		}
+		result = append(result, pr)
+	}
+	return result, nil
+}
+
+func stackedPullReq(number int64, sourceBranch, targetBranch string) *types.PullReq {
+	return &types.PullReq{
+		ID:           number,
+		Number:       number,
+		State:        enum.PullReqStateOpen,
+		SourceRepoID: stackRepoID,
+		SourceBranch: sourceBranch,
+		TargetRepoID: stackRepoID,
+		TargetBranch: targetBranch,
+	}
+}
+
+func TestController_Stack(t *testing.T) {
+	forkPR := stackedPullReq(1, "feature", "main")
+	forkPR.SourceRepoID = stackRepoID + 1
+
+	long := make([]*types.PullReq, 2*maxStackSize)
+	for i := range long {
+		long[i] = stackedPullReq(int64(i+1), fmt.Sprintf("b%d", i+1), fmt.Sprintf("b%d", i))
+	}
+
+	tests := []struct {
+		name       string
+		prs        []*types.PullReq
+		number     int64
+		wantNumber []int64
+		wantDepth  []int
+	}{
+		{
+			name: "linear",
+			prs: []*types.PullReq{
+				stackedPullReq(1, "a", "main"),
+				stackedPullReq(2, "b", "a"),
+				stackedPullReq(3, "c", "b"),
+				stackedPullReq(4, "d", "b"),
+				stackedPullReq(5, "e", "main"),
+			},
+			number:     2,
+			wantNumber: []int64{1, 2, 3, 4},
+			wantDepth:  []int{0, 1, 2, 2},
+		},
+		{
+			name: "cycle",
+			prs: []*types.PullReq{
+				stackedPullReq(1, "x", "y"),
+				stackedPullReq(2, "y", "x"),
+			},
+			number:     1,
+			wantNumber: []int64{2, 1},
+			wantDepth:  []int{0, 1},
+		},
+		{
+			name: "fork",
+			prs: []*types.PullReq{
+				forkPR,
+				stackedPullReq(2, "other", "feature"),
+			},
+			number:     1,
+			wantNumber: []int64{1},
+			wantDepth:  []int{0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{pullreqStore: &stackedPullReqs{prs: test.prs}}
+
+			stack, err := c.stack(context.Background(), stackRepoID, test.prs[test.number-1])
+			if err != nil {
+				t.Fatalf("failed to get stack: %s", err.Error())
+			}
+
+			numbers := make([]int64, len(stack))
+			depths := make([]int, len(stack))
+			for i, entry := range stack {
+				numbers[i] = entry.PullReq.Number
+				depths[i] = entry.Depth
+			}
+
+			if !reflect.DeepEqual(numbers, test.wantNumber) {
+				t.Errorf("numbers: want=%v got=%v", test.wantNumber, numbers)
+			}
+			if !reflect.DeepEqual(depths, test.wantDepth) {
+				t.Errorf("depths: want=%v got=%v", test.wantDepth, depths)
+			}
+		})
+	}
+
+	t.Run("max-size", func(t *testing.T) {
+		c := &Controller{pullreqStore: &stackedPullReqs{prs: long}}
+
+		for _, pr := range []*types.PullReq{long[0], long[maxStackSize], long[len(long)-1]} {
+			stack, err := c.stack(context.Background(), stackRepoID, pr)
+			if err != nil {
+				t.Fatalf("failed to get stack: %s", err.Error())
+			}
+
+			if len(stack) != maxStackSize {
+				t.Errorf("expected the stack of pull request %d to be capped at %d, got %d",
+					pr.Number, maxStackSize, len(stack))
+			}
+		}
+	})
+}
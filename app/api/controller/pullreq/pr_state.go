@@ -61,7 +61,7 @@ func (c *Controller) State(ctx context.Context,
 		return nil, err
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
@@ -71,6 +71,10 @@ func (c *Controller) State(ctx context.Context,
 		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
 	}
 
+	if err = c.checkPullReqWriteAccess(ctx, session, targetRepo, pr); err != nil {
+		return nil, err
+	}
+
 	sourceRepo := targetRepo
 	if pr.SourceRepoID != pr.TargetRepoID {
 		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
@@ -120,9 +124,13 @@ func (c *Controller) State(ctx context.Context,
 			return nil, err
 		}
 
+		if err = c.fetchSourceCommits(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+			return nil, err
+		}
+
 		mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-			ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-			Ref1:       pr.SourceBranch,
+			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+			Ref1:       sourceSHA,
 			Ref2:       pr.TargetBranch,
 		})
 		if err != nil {
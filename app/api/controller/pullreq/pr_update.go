@@ -23,6 +23,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -55,7 +56,7 @@ func (c *Controller) Update(ctx context.Context,
 		return nil, err
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
@@ -65,6 +66,10 @@ func (c *Controller) Update(ctx context.Context,
 		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
 	}
 
+	if err = c.checkPullReqWriteAccess(ctx, session, targetRepo, pr); err != nil {
+		return nil, err
+	}
+
 	if pr.SourceRepoID != pr.TargetRepoID {
 		var sourceRepo *types.Repository
 
@@ -85,6 +90,7 @@ func (c *Controller) Update(ctx context.Context,
 
 	needToWriteActivity := in.Title != pr.Title
 	oldTitle := pr.Title
+	oldDescription := pr.Description
 
 	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.Title = in.Title
@@ -114,5 +120,13 @@ func (c *Controller) Update(ctx context.Context,
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
 
+	c.eventReporter.Updated(ctx, &pullreqevents.UpdatedPayload{
+		Base:           eventBase(pr, &session.Principal),
+		OldTitle:       oldTitle,
+		NewTitle:       pr.Title,
+		OldDescription: oldDescription,
+		NewDescription: pr.Description,
+	})
+
 	return pr, nil
 }
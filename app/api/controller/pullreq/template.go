@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TemplateList returns the pull request templates available on the target branch.
+// If the target branch isn't provided, the default branch of the repository is used.
+func (c *Controller) TemplateList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	targetBranch string,
+) ([]types.PullReqTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if targetBranch == "" {
+		targetBranch = repo.DefaultBranch
+	}
+
+	targetSHA, err := c.verifyBranchExistence(ctx, repo, targetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := c.templates.List(ctx, repo, targetSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// descriptionFromTemplate returns the content of the named pull request template on the target branch.
+// If the name is empty, the content of the default template is returned, or an empty string
+// if the target branch doesn't have the default template.
+func (c *Controller) descriptionFromTemplate(
+	ctx context.Context,
+	repo *types.Repository,
+	targetSHA string,
+	name string,
+) (string, error) {
+	templateName := name
+	if templateName == "" {
+		templateName = pullreqtemplate.DefaultName
+	}
+
+	template, err := c.templates.Find(ctx, repo, targetSHA, templateName)
+	if errors.IsNotFound(err) {
+		if name == "" {
+			return "", nil
+		}
+		return "", usererror.BadRequestf("Pull request template '%s' doesn't exist on the target branch.", name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find pull request template: %w", err)
+	}
+
+	return template.Content, nil
+}
@@ -19,12 +19,17 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -34,6 +39,7 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideController,
+	wire.Bind(new(pullreq.Merger), new(*Controller)),
 )
 
 func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer authz.Authorizer,
@@ -45,7 +51,13 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 	checkStore store.CheckStore,
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, ruleManager *protection.Manager, sseStreamer sse.Streamer,
-	codeOwners *codeowners.Service, locker *locker.Locker,
+	codeOwners *codeowners.Service, locker *locker.Locker, auditService audit.Service,
+	mergeQueueStore store.MergeQueueStore, mergeQueue *mergequeue.Service,
+	publicKey publickey.Service,
+	autoMergeStore store.AutoMergeStore,
+	labelSvc *label.Service,
+	pullReqLabelStore store.PullReqLabelStore,
+	templates *pullreqtemplate.Service,
 ) *Controller {
 	return NewController(tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
@@ -56,5 +68,7 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 		checkStore,
 		rpcClient, eventReporter,
 		codeCommentMigrator,
-		pullreqService, ruleManager, sseStreamer, codeOwners, locker)
+		pullreqService, ruleManager, sseStreamer, codeOwners, locker, auditService,
+		mergeQueueStore, mergeQueue, publicKey, autoMergeStore,
+		labelSvc, pullReqLabelStore, templates)
 }
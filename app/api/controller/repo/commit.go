@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/controller"
@@ -55,6 +56,18 @@ type CommitFilesOptions struct {
 
 	DryRunRules bool `json:"dry_run_rules"`
 	BypassRules bool `json:"bypass_rules"`
+
+	// BypassJustification is the reason for bypassing the protection rules.
+	BypassJustification string `json:"bypass_justification"`
+}
+
+// commitMessage returns the message of a commit created from the provided title and message.
+func commitMessage(title, message string) string {
+	commitMsg := strings.TrimSpace(title)
+	if message != "" {
+		commitMsg += "\n\n" + strings.TrimSpace(message)
+	}
+	return commitMsg
 }
 
 func (c *Controller) CommitFiles(ctx context.Context,
@@ -72,24 +85,79 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, nil, err
 	}
 
+	actions := make([]git.CommitFileAction, len(in.Actions))
+	paths := make([]string, 0, len(in.Actions))
+	for i, action := range in.Actions {
+		var rawPayload []byte
+		switch action.Encoding {
+		case enum.ContentEncodingTypeBase64:
+			rawPayload, err = base64.StdEncoding.DecodeString(action.Payload)
+			if err != nil {
+				return types.CommitFilesResponse{}, nil, errors.Internal(err, "failed to decode base64 payload")
+			}
+		case enum.ContentEncodingTypeUTF8:
+			fallthrough
+		default:
+			// by default we treat content as is
+			rawPayload = []byte(action.Payload)
+		}
+
+		actions[i] = git.CommitFileAction{
+			Action:  action.Action,
+			Path:    action.Path,
+			Payload: rawPayload,
+			SHA:     action.SHA,
+		}
+
+		paths = append(paths, action.Path)
+		if action.Action == git.MoveAction {
+			// the payload of a move action is the new path of the file.
+			paths = append(paths, string(rawPayload))
+		}
+	}
+
+	// the commit is created from the default branch if no branch is provided.
+	baseBranch := in.Branch
+	if baseBranch == "" {
+		baseBranch = repo.DefaultBranch
+	}
+
+	// the commit is created by the server, on behalf of the user and without a signature.
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+	refCommits := protection.StaticCommitsFunc(protection.Commit{
+		SHA:            protection.PendingCommitSHA,
+		Message:        commitMessage(in.Title, in.Message),
+		AuthorEmail:    session.Principal.Email,
+		CommitterEmail: systemPrincipal.Email,
+	})
+
+	refFiles := protection.StaticFilesFunc(paths...)
+
 	var refAction protection.RefAction
 	var branchName string
 	if in.NewBranch != "" {
 		refAction = protection.RefActionCreate
 		branchName = in.NewBranch
+		branchCommits, branchFiles := c.branchChanges(repo, baseBranch, repo.DefaultBranch)
+		refCommits = protection.JoinCommitsFunc(branchCommits, refCommits)
+		refFiles = protection.UnionFilesFunc(branchFiles, refFiles)
 	} else {
 		refAction = protection.RefActionUpdate
-		branchName = in.Branch
+		branchName = baseBranch
 	}
 
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-		Actor:       &session.Principal,
-		AllowBypass: in.BypassRules,
-		IsRepoOwner: isRepoOwner,
-		Repo:        repo,
-		RefAction:   refAction,
-		RefType:     protection.RefTypeBranch,
-		RefNames:    []string{branchName},
+		Actor:               &session.Principal,
+		AllowBypass:         in.BypassRules,
+		BypassJustification: in.BypassJustification,
+		IsRepoOwner:         isRepoOwner,
+		Repo:                repo,
+		RefAction:           refAction,
+		RefType:             protection.RefTypeBranch,
+		RefNames:            []string{branchName},
+		RefCommits:          map[string]protection.CommitsFunc{branchName: refCommits},
+		RefFiles:            map[string]protection.FilesFunc{branchName: refFiles},
+		PushFiles:           refFiles,
 	})
 	if err != nil {
 		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -106,30 +174,6 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, violations, nil
 	}
 
-	actions := make([]git.CommitFileAction, len(in.Actions))
-	for i, action := range in.Actions {
-		var rawPayload []byte
-		switch action.Encoding {
-		case enum.ContentEncodingTypeBase64:
-			rawPayload, err = base64.StdEncoding.DecodeString(action.Payload)
-			if err != nil {
-				return types.CommitFilesResponse{}, nil, errors.Internal(err, "failed to decode base64 payload")
-			}
-		case enum.ContentEncodingTypeUTF8:
-			fallthrough
-		default:
-			// by default we treat content as is
-			rawPayload = []byte(action.Payload)
-		}
-
-		actions[i] = git.CommitFileAction{
-			Action:  action.Action,
-			Path:    action.Path,
-			Payload: rawPayload,
-			SHA:     action.SHA,
-		}
-	}
-
 	// Create internal write params. Note: This will skip the pre-commit protection rules check.
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
@@ -144,7 +188,7 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		Branch:        in.Branch,
 		NewBranch:     in.NewBranch,
 		Actions:       actions,
-		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		Committer:     identityFromPrincipal(systemPrincipal),
 		CommitterDate: &now,
 		Author:        identityFromPrincipal(session.Principal),
 		AuthorDate:    &now,
@@ -153,6 +197,9 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, nil, err
 	}
 
+	protection.LogBypassAudit(ctx, c.auditService, session.Principal, repo,
+		[]string{branchName}, violations, in.BypassJustification)
+
 	return types.CommitFilesResponse{
 		CommitID:       commit.CommitID.String(),
 		RuleViolations: violations,
@@ -0,0 +1,219 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type pushRuleStore struct {
+	store.RuleStore
+	definition protection.DefPush
+}
+
+func (s pushRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	definition, _ := json.Marshal(protection.Push{Push: s.definition})
+	return []types.RuleInfoInternal{{
+		RuleInfo: types.RuleInfo{
+			ID:         1,
+			Identifier: "push-rule",
+			Type:       protection.TypePush,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    json.RawMessage("{}"),
+		Definition: definition,
+	}}, nil
+}
+
+// commitGit lists the commits of the existing branches and records the committed file actions.
+type commitGit struct {
+	git.Interface
+	branchCommits []git.Commit
+	committed     []git.CommitFileAction
+}
+
+func (g *commitGit) ListCommits(context.Context, *git.ListCommitsParams) (*git.ListCommitsOutput, error) {
+	return &git.ListCommitsOutput{Commits: g.branchCommits}, nil
+}
+
+func (g *commitGit) CommitFiles(_ context.Context, params *git.CommitFilesParams) (git.CommitFilesResponse, error) {
+	g.committed = params.Actions
+	return git.CommitFilesResponse{CommitID: sha.EmptyTree}, nil
+}
+
+type unverifiedCommits struct {
+	publickey.Service
+}
+
+func (unverifiedCommits) VerifyCommits(
+	_ context.Context,
+	commits []git.Commit,
+) ([]*types.SignatureVerification, error) {
+	return make([]*types.SignatureVerification, len(commits)), nil
+}
+
+type systemServiceStore struct {
+	store.PrincipalStore
+}
+
+func (systemServiceStore) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: 2, UID: uid, Email: "system@example.com", Admin: true}, nil
+}
+
+type internalURLs struct {
+	cloneURLs
+}
+
+func (internalURLs) GetInternalAPIURL() string { return "http://localhost/api" }
+
+func TestController_CommitFiles_PushRules(t *testing.T) {
+	branchCommits := []git.Commit{{
+		SHA:       sha.EmptyTree,
+		Message:   "JIRA-1 update config",
+		FileStats: []git.CommitFileStats{{Path: "config/app.yaml"}},
+	}}
+
+	tests := []struct {
+		name       string
+		definition protection.DefPush
+		in         CommitFilesOptions
+		expCodes   []string
+	}{
+		{
+			name:       "forbidden-path",
+			definition: protection.DefPush{ForbiddenPaths: []string{"secrets/**"}},
+			in: CommitFilesOptions{
+				Title:   "add key",
+				Actions: []CommitFileAction{{Action: git.CreateAction, Path: "/secrets/./key.pem"}},
+			},
+			expCodes: []string{"push.forbidden_path"},
+		},
+		{
+			name:       "forbidden-path-moved-into",
+			definition: protection.DefPush{ForbiddenPaths: []string{"secrets/**"}},
+			in: CommitFilesOptions{
+				Title:   "move key",
+				Actions: []CommitFileAction{{Action: git.MoveAction, Path: "key.pem", Payload: "secrets/key.pem"}},
+			},
+			expCodes: []string{"push.forbidden_path"},
+		},
+		{
+			name:       "forbidden-path-in-base-branch",
+			definition: protection.DefPush{ForbiddenPaths: []string{"config/**"}},
+			in: CommitFilesOptions{
+				Title:     "update readme",
+				Branch:    "feature",
+				NewBranch: "feature-copy",
+				Actions:   []CommitFileAction{{Action: git.UpdateAction, Path: "README.md"}},
+			},
+			expCodes: []string{"push.forbidden_path"},
+		},
+		{
+			name:       "commit-message",
+			definition: protection.DefPush{CommitMessageRegex: "^JIRA-[0-9]+ "},
+			in: CommitFilesOptions{
+				Title:   "update readme",
+				Actions: []CommitFileAction{{Action: git.UpdateAction, Path: "README.md"}},
+			},
+			expCodes: []string{"push.commit_message"},
+		},
+		{
+			name:       "max-files",
+			definition: protection.DefPush{MaxFiles: 1},
+			in: CommitFilesOptions{
+				Title: "update docs",
+				Actions: []CommitFileAction{
+					{Action: git.UpdateAction, Path: "README.md"},
+					{Action: git.UpdateAction, Path: "docs/index.md"},
+				},
+			},
+			expCodes: []string{"push.max_files"},
+		},
+		{
+			name: "success",
+			definition: protection.DefPush{
+				CommitMessageRegex: "^JIRA-[0-9]+ ",
+				ForbiddenPaths:     []string{"secrets/**"},
+				MaxFiles:           2,
+			},
+			in: CommitFilesOptions{
+				Title:   "JIRA-2 update readme",
+				Actions: []CommitFileAction{{Action: git.UpdateAction, Path: "README.md"}},
+			},
+		},
+	}
+
+	// the commits are created by the system service principal.
+	err := bootstrap.SystemService(context.Background(), &types.Config{},
+		service.NewController(nil, nil, systemServiceStore{}))
+	if err != nil {
+		t.Fatalf("failed to set up the system service: %s", err.Error())
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager, err := protection.ProvideManager(pushRuleStore{definition: test.definition}, nil)
+			if err != nil {
+				t.Fatalf("failed to create protection manager: %s", err.Error())
+			}
+
+			gitFake := &commitGit{branchCommits: branchCommits}
+			c := &Controller{
+				repoStore: &forkRepoStore{
+					upstream: &types.Repository{ID: 1, Path: "public/repo", DefaultBranch: "main"},
+				},
+				authorizer:        spaceAuthorizer{},
+				urlProvider:       internalURLs{},
+				protectionManager: manager,
+				git:               gitFake,
+				publicKey:         unverifiedCommits{},
+			}
+
+			session := &auth.Session{Principal: types.Principal{ID: 1, Email: "dev@example.com"}}
+			_, violations, err := c.CommitFiles(context.Background(), session, "public/repo", &test.in)
+			if err != nil {
+				t.Fatalf("failed to commit files: %s", err.Error())
+			}
+
+			var codes []string
+			for _, ruleViolations := range violations {
+				for _, violation := range ruleViolations.Violations {
+					codes = append(codes, violation.Code)
+				}
+			}
+			if !reflect.DeepEqual(codes, test.expCodes) {
+				t.Errorf("violation codes: want=%v got=%v", test.expCodes, codes)
+			}
+
+			if committed := gitFake.committed != nil; committed != (len(test.expCodes) == 0) {
+				t.Errorf("expected the files to be committed only without violations, committed=%t", committed)
+			}
+		})
+	}
+}
@@ -30,9 +30,12 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -68,29 +71,30 @@ func (r RepositoryOutput) MarshalJSON() ([]byte, error) {
 type Controller struct {
 	defaultBranch string
 
-	tx                 dbtx.Transactor
-	urlProvider        url.Provider
-	authorizer         authz.Authorizer
-	repoStore          store.RepoStore
-	spaceStore         store.SpaceStore
-	pipelineStore      store.PipelineStore
-	principalStore     store.PrincipalStore
-	ruleStore          store.RuleStore
-	settings           *settings.Service
-	principalInfoCache store.PrincipalInfoCache
-	protectionManager  *protection.Manager
-	git                git.Interface
-	importer           *importer.Repository
-	codeOwners         *codeowners.Service
-	eventReporter      *repoevents.Reporter
-	indexer            keywordsearch.Indexer
-	resourceLimiter    limiter.ResourceLimiter
-	locker             *locker.Locker
-	auditService       audit.Service
-	mtxManager         lock.MutexManager
-	identifierCheck    check.RepoIdentifier
-	repoCheck          Check
-	publicAccess       publicaccess.Service
+	tx                dbtx.Transactor
+	urlProvider       url.Provider
+	authorizer        authz.Authorizer
+	repoStore         store.RepoStore
+	spaceStore        store.SpaceStore
+	pipelineStore     store.PipelineStore
+	principalStore    store.PrincipalStore
+	rulesSvc          *rules.Service
+	labelSvc          *label.Service
+	settings          *settings.Service
+	protectionManager *protection.Manager
+	git               git.Interface
+	importer          *importer.Repository
+	codeOwners        *codeowners.Service
+	eventReporter     *repoevents.Reporter
+	indexer           keywordsearch.Indexer
+	resourceLimiter   limiter.ResourceLimiter
+	locker            *locker.Locker
+	auditService      audit.Service
+	mtxManager        lock.MutexManager
+	identifierCheck   check.RepoIdentifier
+	repoCheck         Check
+	publicAccess      publicaccess.Service
+	publicKey         publickey.Service
 }
 
 func NewController(
@@ -102,9 +106,9 @@ func NewController(
 	spaceStore store.SpaceStore,
 	pipelineStore store.PipelineStore,
 	principalStore store.PrincipalStore,
-	ruleStore store.RuleStore,
+	rulesSvc *rules.Service,
+	labelSvc *label.Service,
 	settings *settings.Service,
-	principalInfoCache store.PrincipalInfoCache,
 	protectionManager *protection.Manager,
 	git git.Interface,
 	importer *importer.Repository,
@@ -118,32 +122,34 @@ func NewController(
 	identifierCheck check.RepoIdentifier,
 	repoCheck Check,
 	publicAccess publicaccess.Service,
+	publicKey publickey.Service,
 ) *Controller {
 	return &Controller{
-		defaultBranch:      config.Git.DefaultBranch,
-		tx:                 tx,
-		urlProvider:        urlProvider,
-		authorizer:         authorizer,
-		repoStore:          repoStore,
-		spaceStore:         spaceStore,
-		pipelineStore:      pipelineStore,
-		principalStore:     principalStore,
-		ruleStore:          ruleStore,
-		settings:           settings,
-		principalInfoCache: principalInfoCache,
-		protectionManager:  protectionManager,
-		git:                git,
-		importer:           importer,
-		codeOwners:         codeOwners,
-		eventReporter:      eventReporter,
-		indexer:            indexer,
-		resourceLimiter:    limiter,
-		locker:             locker,
-		auditService:       auditService,
-		mtxManager:         mtxManager,
-		identifierCheck:    identifierCheck,
-		repoCheck:          repoCheck,
-		publicAccess:       publicAccess,
+		defaultBranch:     config.Git.DefaultBranch,
+		tx:                tx,
+		urlProvider:       urlProvider,
+		authorizer:        authorizer,
+		repoStore:         repoStore,
+		spaceStore:        spaceStore,
+		pipelineStore:     pipelineStore,
+		principalStore:    principalStore,
+		rulesSvc:          rulesSvc,
+		labelSvc:          labelSvc,
+		settings:          settings,
+		protectionManager: protectionManager,
+		git:               git,
+		importer:          importer,
+		codeOwners:        codeOwners,
+		eventReporter:     eventReporter,
+		indexer:           indexer,
+		resourceLimiter:   limiter,
+		locker:            locker,
+		auditService:      auditService,
+		mtxManager:        mtxManager,
+		identifierCheck:   identifierCheck,
+		repoCheck:         repoCheck,
+		publicAccess:      publicAccess,
+		publicKey:         publicKey,
 	}
 }
 
@@ -204,21 +210,18 @@ func (c *Controller) fetchRules(
 	return protectionRules, isRepoOwner, nil
 }
 
-func (c *Controller) getRuleUsers(ctx context.Context, r *types.Rule) (map[int64]*types.PrincipalInfo, error) {
-	rule, err := c.protectionManager.FromJSON(r.Type, r.Definition, false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse json rule definition: %w", err)
-	}
-
-	userIDs, err := rule.UserIDs()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user ID from rule: %w", err)
-	}
-
-	userMap, err := c.principalInfoCache.Map(ctx, userIDs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get principal infos: %w", err)
+// branchChanges returns the functions that list the commits reachable from the target that aren't reachable
+// from the base, and the files changed by them. Those are the changes a created or updated branch introduces.
+func (c *Controller) branchChanges(
+	repo *types.Repository,
+	target string,
+	base string,
+) (protection.CommitsFunc, protection.FilesFunc) {
+	params := &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     target,
+		After:      base,
 	}
 
-	return userMap, nil
+	return protection.ListCommitsFunc(c.git, c.publicKey, params), protection.ListFilesFunc(c.git, params)
 }
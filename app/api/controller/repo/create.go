@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
@@ -176,6 +177,11 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		}
 	}
 
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+	})
+
 	return repoOutput, nil
 }
 
@@ -219,6 +225,10 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
+	if in.ForkID != 0 {
+		return usererror.BadRequest("Forks have to be created using the fork operation of the upstream repository.")
+	}
+
 	in.Description = strings.TrimSpace(in.Description)
 	if err := check.Description(in.Description); err != nil {
 		return err
@@ -35,6 +35,9 @@ type CreateBranchInput struct {
 	Target string `json:"target"`
 
 	BypassRules bool `json:"bypass_rules"`
+
+	// BypassJustification is the reason for bypassing the protection rules.
+	BypassJustification string `json:"bypass_justification"`
 }
 
 // CreateBranch creates a new branch for a repo.
@@ -58,14 +61,20 @@ func (c *Controller) CreateBranch(ctx context.Context,
 		return nil, nil, err
 	}
 
+	refCommits, refFiles := c.branchChanges(repo, in.Target, repo.DefaultBranch)
+
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-		Actor:       &session.Principal,
-		AllowBypass: in.BypassRules,
-		IsRepoOwner: isRepoOwner,
-		Repo:        repo,
-		RefAction:   protection.RefActionCreate,
-		RefType:     protection.RefTypeBranch,
-		RefNames:    []string{in.Name},
+		Actor:               &session.Principal,
+		AllowBypass:         in.BypassRules,
+		BypassJustification: in.BypassJustification,
+		IsRepoOwner:         isRepoOwner,
+		Repo:                repo,
+		RefAction:           protection.RefActionCreate,
+		RefType:             protection.RefTypeBranch,
+		RefNames:            []string{in.Name},
+		RefCommits:          map[string]protection.CommitsFunc{in.Name: refCommits},
+		RefFiles:            map[string]protection.FilesFunc{in.Name: refFiles},
+		PushFiles:           refFiles,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -88,6 +97,9 @@ func (c *Controller) CreateBranch(ctx context.Context,
 		return nil, nil, err
 	}
 
+	protection.LogBypassAudit(ctx, c.auditService, session.Principal, repo,
+		[]string{in.Name}, violations, in.BypassJustification)
+
 	branch, err := mapBranch(rpcOut.Branch)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to map branch: %w", err)
@@ -39,6 +39,9 @@ type CreateCommitTagInput struct {
 	Message string `json:"message"`
 
 	BypassRules bool `json:"bypass_rules"`
+
+	// BypassJustification is the reason for bypassing the protection rules.
+	BypassJustification string `json:"bypass_justification"`
 }
 
 // CreateCommitTag creates a new tag for a repo.
@@ -63,13 +66,14 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 	}
 
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-		Actor:       &session.Principal,
-		AllowBypass: in.BypassRules,
-		IsRepoOwner: isRepoOwner,
-		Repo:        repo,
-		RefAction:   protection.RefActionCreate,
-		RefType:     protection.RefTypeTag,
-		RefNames:    []string{in.Name},
+		Actor:               &session.Principal,
+		AllowBypass:         in.BypassRules,
+		BypassJustification: in.BypassJustification,
+		IsRepoOwner:         isRepoOwner,
+		Repo:                repo,
+		RefAction:           protection.RefActionCreate,
+		RefType:             protection.RefTypeTag,
+		RefNames:            []string{in.Name},
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -96,6 +100,9 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		return nil, nil, err
 	}
 
+	protection.LogBypassAudit(ctx, c.auditService, session.Principal, repo,
+		[]string{in.Name}, violations, in.BypassJustification)
+
 	commitTag, err := mapCommitTag(rpcOut.CommitTag)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to map tag received from service output: %w", err)
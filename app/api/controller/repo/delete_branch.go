@@ -33,6 +33,7 @@ func (c *Controller) DeleteBranch(ctx context.Context,
 	repoRef string,
 	branchName string,
 	bypassRules bool,
+	bypassJustification string,
 ) ([]types.RuleViolations, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
 	if err != nil {
@@ -53,13 +54,14 @@ func (c *Controller) DeleteBranch(ctx context.Context,
 	}
 
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-		Actor:       &session.Principal,
-		AllowBypass: bypassRules,
-		IsRepoOwner: isRepoOwner,
-		Repo:        repo,
-		RefAction:   protection.RefActionDelete,
-		RefType:     protection.RefTypeBranch,
-		RefNames:    []string{branchName},
+		Actor:               &session.Principal,
+		AllowBypass:         bypassRules,
+		BypassJustification: bypassJustification,
+		IsRepoOwner:         isRepoOwner,
+		Repo:                repo,
+		RefAction:           protection.RefActionDelete,
+		RefType:             protection.RefTypeBranch,
+		RefNames:            []string{branchName},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -81,5 +83,8 @@ func (c *Controller) DeleteBranch(ctx context.Context,
 		return nil, err
 	}
 
+	protection.LogBypassAudit(ctx, c.auditService, session.Principal, repo,
+		[]string{branchName}, violations, bypassJustification)
+
 	return nil, nil
 }
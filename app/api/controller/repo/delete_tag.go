@@ -32,6 +32,7 @@ func (c *Controller) DeleteTag(ctx context.Context,
 	repoRef,
 	tagName string,
 	bypassRules bool,
+	bypassJustification string,
 ) ([]types.RuleViolations, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
 	if err != nil {
@@ -44,13 +45,14 @@ func (c *Controller) DeleteTag(ctx context.Context,
 	}
 
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-		Actor:       &session.Principal,
-		AllowBypass: bypassRules,
-		IsRepoOwner: isRepoOwner,
-		Repo:        repo,
-		RefAction:   protection.RefActionDelete,
-		RefType:     protection.RefTypeTag,
-		RefNames:    []string{tagName},
+		Actor:               &session.Principal,
+		AllowBypass:         bypassRules,
+		BypassJustification: bypassJustification,
+		IsRepoOwner:         isRepoOwner,
+		Repo:                repo,
+		RefAction:           protection.RefActionDelete,
+		RefType:             protection.RefTypeTag,
+		RefNames:            []string{tagName},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -72,5 +74,8 @@ func (c *Controller) DeleteTag(ctx context.Context,
 		return nil, err
 	}
 
+	protection.LogBypassAudit(ctx, c.auditService, session.Principal, repo,
+		[]string{tagName}, violations, bypassJustification)
+
 	return nil, nil
 }
@@ -0,0 +1,398 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ForkInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+
+	// DefaultBranchOnly restricts the fork to the default branch of the upstream repository.
+	DefaultBranchOnly bool `json:"default_branch_only"`
+}
+
+// Fork creates a new repository in the target space that is a fork of the provided repository.
+// The fork shares the git objects of the upstream repository.
+//
+//nolint:gocognit
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*RepositoryOutput, error) {
+	upstream, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if upstream.Importing {
+		return nil, usererror.BadRequest("Repository can't be forked while it's being imported.")
+	}
+
+	if err := c.sanitizeForkInput(in, upstream); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	isPublicAccessSupported, err := c.publicAccess.IsPublicAccessSupported(ctx, parentSpace.Path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to check if public access is supported for parent space %q: %w",
+			parentSpace.Path,
+			err,
+		)
+	}
+	if in.IsPublic && !isPublicAccessSupported {
+		return nil, errPublicRepoCreationDisabled
+	}
+
+	err = c.repoCheck.Create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: upstream.DefaultBranch,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gitResp, err := c.forkGitRepository(ctx, session, upstream, in.DefaultBranchOnly)
+	if err != nil {
+		return nil, fmt.Errorf("error forking repository on git: %w", err)
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		parentSpace, err = c.spaceStore.FindForUpdate(ctx, parentSpace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the parent space: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitResp.UID,
+			Description:   in.Description,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			ForkID:        upstream.ID,
+			DefaultBranch: upstream.DefaultBranch,
+			IsEmpty:       upstream.IsEmpty,
+		}
+
+		if err = c.repoStore.Create(ctx, repo); err != nil {
+			return err
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(r *types.Repository) error {
+			r.NumForks++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update number of forks of the upstream repository: %w", err)
+		}
+
+		return nil
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		// best effort cleanup
+		if dErr := c.DeleteGitRepository(ctx, session, gitResp.UID); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete forked repo for cleanup")
+		}
+		return nil, err
+	}
+
+	err = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, in.IsPublic)
+	if err != nil {
+		if dErr := c.publicAccess.Delete(ctx, enum.PublicResourceTypeRepo, repo.Path); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and public access cleanup: %w): %w", dErr, err)
+		}
+
+		// only cleanup repo itself if cleanup of public access succeeded (to avoid leaking public access)
+		if dErr := c.PurgeNoAuth(ctx, session, repo); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and repo purge: %w): %w", dErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to set repo public access (succesfull cleanup): %w", err)
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
+
+	repoOutput := &RepositoryOutput{
+		Repository: *repo,
+		IsPublic:   in.IsPublic,
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(audit.RepositoryObject{
+			Repository: repoOutput.Repository,
+			IsPublic:   repoOutput.IsPublic,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for fork repository operation: %s", err)
+	}
+
+	if !repo.IsEmpty {
+		err = c.indexer.Index(ctx, repo)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
+		}
+	}
+
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+	})
+
+	return repoOutput, nil
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, upstream *types.Repository) error {
+	if in.Identifier == "" {
+		in.Identifier = upstream.Identifier
+	}
+
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if err := c.identifierCheck(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Description == "" {
+		in.Description = upstream.Description
+	}
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) forkGitRepository(
+	ctx context.Context,
+	session *auth.Session,
+	upstream *types.Repository,
+	defaultBranchOnly bool,
+) (*git.ForkRepositoryOutput, error) {
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	var branches []string
+	if defaultBranchOnly && !upstream.IsEmpty {
+		branches = []string{upstream.DefaultBranch}
+	}
+
+	resp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+		Actor:           *identityFromPrincipal(session.Principal),
+		EnvVars:         envVars,
+		UpstreamRepoUID: upstream.GitUID,
+		DefaultBranch:   upstream.DefaultBranch,
+		Branches:        branches,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fork repo: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListForks lists the forks of a repository. Forks the user doesn't have access to are omitted.
+// Access is checked per fork, so all forks are loaded and the filtered list is paginated afterwards.
+func (c *Controller) ListForks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.RepoFilter,
+) ([]*RepositoryOutput, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	allFilter := *filter
+	allFilter.Page = 1
+	allFilter.Size = int(math.MaxInt)
+
+	forks, err := c.repoStore.ListForks(ctx, repo.ID, &allFilter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	accessible := make([]*types.Repository, 0, len(forks))
+	for _, fork := range forks {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, fork, enum.PermissionRepoView); err != nil {
+			if errors.Is(err, apiauth.ErrNotAuthorized) {
+				continue
+			}
+			return nil, 0, fmt.Errorf("failed to check access to fork %q: %w", fork.Path, err)
+		}
+
+		accessible = append(accessible, fork)
+	}
+
+	count := int64(len(accessible))
+	accessible = paginateForks(accessible, filter.Page, filter.Size)
+
+	forksOut := make([]*RepositoryOutput, 0, len(accessible))
+	for _, fork := range accessible {
+		// backfill URLs
+		fork.GitURL = c.urlProvider.GenerateGITCloneURL(fork.Path)
+		fork.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(fork.Path)
+
+		forkOut, err := GetRepoOutput(ctx, c.publicAccess, fork)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get fork %q output: %w", fork.Path, err)
+		}
+
+		forksOut = append(forksOut, forkOut)
+	}
+
+	return forksOut, count, nil
+}
+
+// paginateForks returns the requested page of the forks. All forks are returned if size isn't set.
+func paginateForks(forks []*types.Repository, page int, size int) []*types.Repository {
+	if size <= 0 {
+		return forks
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * size
+	if start >= len(forks) {
+		return []*types.Repository{}
+	}
+
+	end := start + size
+	if end > len(forks) {
+		end = len(forks)
+	}
+
+	return forks[start:end]
+}
+
+// DissociateForks makes all forks of the repository independent of it.
+// It must be called before the git repository is deleted, as forks borrow git objects from it.
+func (c *Controller) DissociateForks(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) error {
+	if repo.NumForks == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	for _, deletedBeforeOrAt := range []*int64{nil, &now} {
+		forks, err := c.repoStore.ListForks(ctx, repo.ID, &types.RepoFilter{
+			Page:              1,
+			Size:              int(math.MaxInt),
+			Sort:              enum.RepoAttrCreated,
+			Order:             enum.OrderAsc,
+			DeletedBeforeOrAt: deletedBeforeOrAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list forks: %w", err)
+		}
+
+		for _, fork := range forks {
+			writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork)
+			if err != nil {
+				return fmt.Errorf("failed to create RPC write params: %w", err)
+			}
+
+			err = c.git.DissociateRepository(ctx, &git.DissociateRepositoryParams{WriteParams: writeParams})
+			if err != nil {
+				return fmt.Errorf("failed to dissociate fork %q: %w", fork.Path, err)
+			}
+
+			if fork.Deleted != nil {
+				continue
+			}
+
+			_, err = c.repoStore.UpdateOptLock(ctx, fork, func(r *types.Repository) error {
+				r.ForkID = 0
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update fork %q: %w", fork.Path, err)
+			}
+		}
+	}
+
+	return nil
+}
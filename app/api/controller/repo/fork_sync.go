@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SyncForkInput struct {
+	// Branch is the branch of the fork that is synced (default: default branch of the fork).
+	Branch string `json:"branch"`
+	// UpstreamBranch is the branch of the upstream repository the fork is synced with (default: same as Branch).
+	UpstreamBranch string `json:"upstream_branch"`
+
+	BypassRules bool `json:"bypass_rules"`
+
+	// BypassJustification is the reason for bypassing the protection rules.
+	BypassJustification string `json:"bypass_justification"`
+}
+
+type SyncForkOutput struct {
+	Branch         string `json:"branch"`
+	UpstreamBranch string `json:"upstream_branch"`
+	OldSHA         string `json:"old_sha,omitempty"`
+	NewSHA         string `json:"new_sha"`
+	// AlreadyUpToDate is true if the branch of the fork already pointed to the upstream commit.
+	AlreadyUpToDate bool `json:"already_up_to_date"`
+}
+
+// SyncFork fast-forwards a branch of a fork to the latest commit of the upstream branch.
+// The branch is created if it doesn't exist in the fork yet.
+//
+//nolint:gocognit
+func (c *Controller) SyncFork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SyncForkInput,
+) (*SyncForkOutput, []types.RuleViolations, error) {
+	fork, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if fork.ForkID == 0 {
+		return nil, nil, usererror.BadRequest("Repository is not a fork.")
+	}
+
+	upstream, err := c.repoStore.Find(ctx, fork.ForkID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil, usererror.BadRequest("The upstream repository of the fork doesn't exist anymore.")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, upstream, enum.PermissionRepoView); err != nil {
+		return nil, nil, fmt.Errorf("access check for upstream repository failed: %w", err)
+	}
+
+	if in.Branch == "" {
+		in.Branch = fork.DefaultBranch
+	}
+	if in.UpstreamBranch == "" {
+		in.UpstreamBranch = in.Branch
+	}
+
+	upstreamBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(upstream),
+		BranchName: in.UpstreamBranch,
+	})
+	if errors.IsNotFound(err) {
+		return nil, nil, usererror.NotFoundf("Upstream branch %q doesn't exist.", in.UpstreamBranch)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get upstream branch: %w", err)
+	}
+
+	newSHA := upstreamBranch.Branch.SHA
+	oldSHA := sha.Nil
+
+	forkBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(fork),
+		BranchName: in.Branch,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("failed to get branch of the fork: %w", err)
+	}
+	if err == nil {
+		oldSHA = forkBranch.Branch.SHA
+	}
+
+	out := &SyncForkOutput{
+		Branch:         in.Branch,
+		UpstreamBranch: in.UpstreamBranch,
+		NewSHA:         newSHA.String(),
+	}
+	if !oldSHA.IsNil() {
+		out.OldSHA = oldSHA.String()
+	}
+
+	if oldSHA.Equal(newSHA) {
+		out.AlreadyUpToDate = true
+		return out, nil, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// the objects are usually borrowed from the upstream repository already, in that case nothing is fetched.
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: upstream.GitUID,
+		ObjectSHA:     newSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch upstream commits: %w", err)
+	}
+
+	refAction := protection.RefActionCreate
+	if !oldSHA.IsNil() {
+		refAction = protection.RefActionUpdate
+
+		ancestor, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.CreateReadParams(fork),
+			AncestorCommitSHA:   oldSHA,
+			DescendantCommitSHA: newSHA,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check if the fork branch is behind the upstream: %w", err)
+		}
+
+		if !ancestor.Ancestor {
+			return nil, nil, usererror.Newf(http.StatusConflict,
+				"Branch %q of the fork contains commits that are not in upstream branch %q.",
+				in.Branch, in.UpstreamBranch)
+		}
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, fork)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the commits the sync introduces to the branch, for a created branch those not on the default branch.
+	baseRef := fork.DefaultBranch
+	if !oldSHA.IsNil() {
+		baseRef = oldSHA.String()
+	}
+	refCommits, refFiles := c.branchChanges(fork, newSHA.String(), baseRef)
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:               &session.Principal,
+		AllowBypass:         in.BypassRules,
+		BypassJustification: in.BypassJustification,
+		IsRepoOwner:         isRepoOwner,
+		Repo:                fork,
+		RefAction:           refAction,
+		RefType:             protection.RefTypeBranch,
+		RefNames:            []string{in.Branch},
+		RefCommits:          map[string]protection.CommitsFunc{in.Branch: refCommits},
+		RefFiles:            map[string]protection.FilesFunc{in.Branch: refFiles},
+		PushFiles:           refFiles,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        in.Branch,
+		Type:        gitenum.RefTypeBranch,
+		NewValue:    newSHA,
+		OldValue:    oldSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update branch of the fork: %w", err)
+	}
+
+	protection.LogBypassAudit(ctx, c.auditService, session.Principal, fork,
+		[]string{in.Branch}, violations, in.BypassJustification)
+
+	return out, nil, nil
+}
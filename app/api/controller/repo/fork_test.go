@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type forkRepoStore struct {
+	store.RepoStore
+	upstream *types.Repository
+	forks    []*types.Repository
+}
+
+func (s *forkRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	return s.upstream, nil
+}
+
+func (s *forkRepoStore) ListForks(_ context.Context, _ int64, filter *types.RepoFilter) ([]*types.Repository, error) {
+	start := (filter.Page - 1) * filter.Size
+	if start >= len(s.forks) {
+		return nil, nil
+	}
+	end := len(s.forks)
+	if filter.Size < end-start {
+		end = start + filter.Size
+	}
+	return s.forks[start:end], nil
+}
+
+// spaceAuthorizer permits access to all resources except the ones in the "private" space.
+type spaceAuthorizer struct {
+	authz.Authorizer
+}
+
+func (spaceAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	scope *types.Scope,
+	_ *types.Resource,
+	_ enum.Permission,
+) (bool, error) {
+	return scope.SpacePath != "private", nil
+}
+
+type privateRepos struct {
+	publicaccess.Service
+}
+
+func (privateRepos) Get(context.Context, enum.PublicResourceType, string) (bool, error) {
+	return false, nil
+}
+
+type cloneURLs struct {
+	url.Provider
+}
+
+func (cloneURLs) GenerateGITCloneURL(repoPath string) string    { return "https://git/" + repoPath }
+func (cloneURLs) GenerateGITCloneSSHURL(repoPath string) string { return "ssh://git/" + repoPath }
+
+func TestController_ListForks(t *testing.T) {
+	upstream := &types.Repository{ID: 1, Path: "public/upstream"}
+
+	// every other fork is inaccessible
+	var forks []*types.Repository
+	for i := 1; i <= 10; i++ {
+		space := "public"
+		if i%2 == 0 {
+			space = "private"
+		}
+		forks = append(forks, &types.Repository{ID: int64(i + 1), ForkID: 1, Path: fmt.Sprintf("%s/fork%d", space, i)})
+	}
+
+	c := &Controller{
+		repoStore:    &forkRepoStore{upstream: upstream, forks: forks},
+		authorizer:   spaceAuthorizer{},
+		publicAccess: privateRepos{},
+		urlProvider:  cloneURLs{},
+	}
+
+	tests := []struct {
+		page int
+		want []string
+	}{
+		{page: 1, want: []string{"public/fork1", "public/fork3"}},
+		{page: 2, want: []string{"public/fork5", "public/fork7"}},
+		{page: 3, want: []string{"public/fork9"}},
+		{page: 4, want: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("page-%d", test.page), func(t *testing.T) {
+			out, count, err := c.ListForks(context.Background(), &auth.Session{}, "public/upstream",
+				&types.RepoFilter{Page: test.page, Size: 2})
+			if err != nil {
+				t.Fatalf("failed to list forks: %s", err.Error())
+			}
+
+			if count != 5 {
+				t.Errorf("expected only the accessible forks to be counted, got %d", count)
+			}
+
+			paths := make([]string, len(out))
+			for i, fork := range out {
+				paths[i] = fork.Path
+			}
+			if !reflect.DeepEqual(paths, test.want) {
+				t.Errorf("want=%v got=%v", test.want, paths)
+			}
+		})
+	}
+}
@@ -37,8 +37,9 @@ func (c *Controller) GetCommit(ctx context.Context,
 	}
 
 	rpcOut, err := c.git.GetCommit(ctx, &git.GetCommitParams{
-		ReadParams: git.CreateReadParams(repo),
-		Revision:   sha,
+		ReadParams:       git.CreateReadParams(repo),
+		Revision:         sha,
+		IncludeSignature: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commit: %w", err)
@@ -50,5 +51,12 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
+	verifications, err := c.publicKey.VerifyCommits(ctx, []git.Commit{rpcCommit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signature: %w", err)
+	}
+
+	commit.Verification = verifications[0]
+
 	return commit, nil
 }
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// LabelList returns pull request labels of a repo. If inherited is true, the result also includes
+// labels of all ancestor spaces, i.e. all labels that can be assigned to pull requests of the repo.
+func (c *Controller) LabelList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	inherited bool,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if inherited {
+		return c.labelSvc.ListAvailable(ctx, repo, filter)
+	}
+
+	return c.labelSvc.List(ctx, enum.ParentResourceTypeRepo, repo.ID, filter)
+}
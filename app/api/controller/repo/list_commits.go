@@ -43,21 +43,27 @@ func (c *Controller) ListCommits(ctx context.Context,
 	}
 
 	rpcOut, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
-		ReadParams:   git.CreateReadParams(repo),
-		GitREF:       gitRef,
-		After:        filter.After,
-		Page:         int32(filter.Page),
-		Limit:        int32(filter.Limit),
-		Path:         filter.Path,
-		Since:        filter.Since,
-		Until:        filter.Until,
-		Committer:    filter.Committer,
-		IncludeStats: filter.IncludeStats,
+		ReadParams:        git.CreateReadParams(repo),
+		GitREF:            gitRef,
+		After:             filter.After,
+		Page:              int32(filter.Page),
+		Limit:             int32(filter.Limit),
+		Path:              filter.Path,
+		Since:             filter.Since,
+		Until:             filter.Until,
+		Committer:         filter.Committer,
+		IncludeStats:      filter.IncludeStats,
+		IncludeSignatures: true,
 	})
 	if err != nil {
 		return types.ListCommitResponse{}, err
 	}
 
+	verifications, err := c.publicKey.VerifyCommits(ctx, rpcOut.Commits)
+	if err != nil {
+		return types.ListCommitResponse{}, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
 	commits := make([]types.Commit, len(rpcOut.Commits))
 	for i := range rpcOut.Commits {
 		var commit *types.Commit
@@ -65,6 +71,7 @@ func (c *Controller) ListCommits(ctx context.Context,
 		if err != nil {
 			return types.ListCommitResponse{}, fmt.Errorf("failed to map commit: %w", err)
 		}
+		commit.Verification = verifications[i]
 		commits[i] = *commit
 	}
 
@@ -21,6 +21,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -133,6 +134,15 @@ func (c *Controller) Move(ctx context.Context,
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
 
+	if repo.Identifier != oldIdentifier {
+		c.eventReporter.Renamed(ctx, &repoevents.RenamedPayload{
+			RepoID:        repo.ID,
+			PrincipalID:   session.Principal.ID,
+			OldIdentifier: oldIdentifier,
+			NewIdentifier: repo.Identifier,
+		})
+	}
+
 	return GetRepoOutput(ctx, c.publicAccess, repo)
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -72,10 +73,18 @@ func (c *Controller) PurgeNoAuth(
 		}
 	}
 
+	if err := c.DissociateForks(ctx, session, repo); err != nil {
+		return fmt.Errorf("failed to dissociate forks of the repo: %w", err)
+	}
+
 	if err := c.repoStore.Purge(ctx, repo.ID, repo.Deleted); err != nil {
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		c.decrementNumForks(ctx, repo.ForkID)
+	}
+
 	if err := c.DeleteGitRepository(ctx, session, repo.GitUID); err != nil {
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
@@ -89,6 +98,28 @@ func (c *Controller) PurgeNoAuth(
 	return nil
 }
 
+// decrementNumForks decrements the number of forks of the upstream repository (best effort).
+func (c *Controller) decrementNumForks(ctx context.Context, upstreamID int64) {
+	upstream, err := c.repoStore.Find(ctx, upstreamID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find upstream repository of the purged fork")
+		return
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(r *types.Repository) error {
+		if r.NumForks > 0 {
+			r.NumForks--
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update number of forks of the upstream repository")
+	}
+}
+
 func (c *Controller) DeleteGitRepository(
 	ctx context.Context,
 	session *auth.Session,
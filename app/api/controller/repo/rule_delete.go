@@ -16,14 +16,10 @@ package repo
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
-	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
-
-	"github.com/rs/zerolog/log"
 )
 
 // RuleDelete deletes a protection rule by identifier.
@@ -37,26 +33,6 @@ func (c *Controller) RuleDelete(ctx context.Context,
 		return err
 	}
 
-	r, err := c.ruleStore.FindByIdentifier(ctx, nil, &repo.ID, identifier)
-	if err != nil {
-		return fmt.Errorf("failed to find repository-level protection rule by identifier: %w", err)
-	}
-
-	err = c.ruleStore.Delete(ctx, r.ID)
-	if err != nil {
-		return fmt.Errorf("failed to delete repository-level protection rule: %w", err)
-	}
-
-	err = c.auditService.Log(ctx,
-		session.Principal,
-		audit.NewResource(audit.ResourceTypeBranchRule, r.Identifier),
-		audit.ActionDeleted,
-		paths.Parent(repo.Path),
-		audit.WithOldObject(r),
-	)
-	if err != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete branch rule operation: %s", err)
-	}
-
-	return nil
+	return c.rulesSvc.Delete(ctx, &session.Principal,
+		enum.ParentResourceTypeRepo, repo.ID, paths.Parent(repo.Path), identifier)
 }
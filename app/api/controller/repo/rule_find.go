@@ -16,7 +16,6 @@ package repo
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
@@ -34,15 +33,5 @@ func (c *Controller) RuleFind(ctx context.Context,
 		return nil, err
 	}
 
-	r, err := c.ruleStore.FindByIdentifier(ctx, nil, &repo.ID, identifier)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find repository-level protection rule by identifier: %w", err)
-	}
-
-	r.Users, err = c.getRuleUsers(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-
-	return r, nil
+	return c.rulesSvc.Find(ctx, enum.ParentResourceTypeRepo, repo.ID, identifier)
 }
@@ -16,10 +16,8 @@ package repo
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/harness/gitness/app/auth"
-	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -35,37 +33,5 @@ func (c *Controller) RuleList(ctx context.Context,
 		return nil, 0, err
 	}
 
-	var list []types.Rule
-	var count int64
-
-	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
-		list, err = c.ruleStore.List(ctx, nil, &repo.ID, filter)
-		if err != nil {
-			return fmt.Errorf("failed to list repository-level protection rules: %w", err)
-		}
-
-		if filter.Page == 1 && len(list) < filter.Size {
-			count = int64(len(list))
-			return nil
-		}
-
-		count, err = c.ruleStore.Count(ctx, nil, &repo.ID, filter)
-		if err != nil {
-			return fmt.Errorf("failed to count repository-level protection rules: %w", err)
-		}
-
-		return nil
-	}, dbtx.TxDefaultReadOnly)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	for i := range list {
-		list[i].Users, err = c.getRuleUsers(ctx, &list[i])
-		if err != nil {
-			return nil, 0, err
-		}
-	}
-
-	return list, count, nil
+	return c.rulesSvc.List(ctx, enum.ParentResourceTypeRepo, repo.ID, filter)
 }
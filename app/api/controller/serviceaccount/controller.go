@@ -16,11 +16,18 @@ package serviceaccount
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type Controller struct {
@@ -30,11 +37,12 @@ type Controller struct {
 	spaceStore        store.SpaceStore
 	repoStore         store.RepoStore
 	tokenStore        store.TokenStore
+	auditService      audit.Service
 }
 
 func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
+	tokenStore store.TokenStore, auditService audit.Service) *Controller {
 	return &Controller{
 		principalUIDCheck: principalUIDCheck,
 		authorizer:        authorizer,
@@ -42,6 +50,7 @@ func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Author
 		spaceStore:        spaceStore,
 		repoStore:         repoStore,
 		tokenStore:        tokenStore,
+		auditService:      auditService,
 	}
 }
 
@@ -49,3 +58,41 @@ func findServiceAccountFromUID(ctx context.Context,
 	principalStore store.PrincipalStore, saUID string) (*types.ServiceAccount, error) {
 	return principalStore.FindServiceAccountByUID(ctx, saUID)
 }
+
+// getParentSpacePath returns the path of the space the service account parent belongs to.
+func (c *Controller) getParentSpacePath(ctx context.Context,
+	parentType enum.ParentResourceType, parentID int64) (string, error) {
+	switch parentType {
+	case enum.ParentResourceTypeSpace:
+		space, err := c.spaceStore.Find(ctx, parentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent space: %w", err)
+		}
+		return space.Path, nil
+	case enum.ParentResourceTypeRepo:
+		repo, err := c.repoStore.Find(ctx, parentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent repo: %w", err)
+		}
+		return paths.Parent(repo.Path), nil
+	default:
+		return "", fmt.Errorf("unknown parent type %q", parentType)
+	}
+}
+
+func (c *Controller) logAudit(
+	ctx context.Context,
+	session *auth.Session,
+	sa *types.ServiceAccount,
+	resource audit.Resource,
+	action audit.Action,
+	options ...audit.Option,
+) {
+	spacePath, err := c.getParentSpacePath(ctx, sa.ParentType, sa.ParentID)
+	if err == nil {
+		err = c.auditService.Log(ctx, session.Principal, resource, action, spacePath, options...)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for service account %s operation: %s", action, err)
+	}
+}
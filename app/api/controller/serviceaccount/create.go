@@ -22,6 +22,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -58,7 +59,18 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session,
 	}
 
 	// TODO: There's a chance of duplicate error - we should retry?
-	return c.CreateNoAuth(ctx, in, uid)
+	sa, err := c.CreateNoAuth(ctx, in, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logAudit(ctx, session, sa,
+		audit.NewResource(audit.ResourceTypeServiceAccount, sa.UID),
+		audit.ActionCreated,
+		audit.WithNewObject(sa),
+	)
+
+	return sa, nil
 }
 
 /*
@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -68,6 +69,13 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
+	c.logAudit(ctx, session, sa,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionTokenIssued,
+		audit.WithNewObject(token),
+		audit.WithData(audit.DataKeyOwner, sa.UID),
+	)
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -23,7 +23,10 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/pushpolicy"
+	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -81,6 +84,10 @@ type Controller struct {
 	resourceLimiter limiter.ResourceLimiter
 	publicAccess    publicaccess.Service
 	auditService    audit.Service
+	auditStore      audit.Store
+	rulesSvc        *rules.Service
+	labelSvc        *label.Service
+	pushPolicy      *pushpolicy.Service
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -90,6 +97,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
 	limiter limiter.ResourceLimiter, publicAccess publicaccess.Service, auditService audit.Service,
+	auditStore audit.Store, rulesSvc *rules.Service, labelSvc *label.Service, pushPolicy *pushpolicy.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -113,5 +121,9 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		resourceLimiter:     limiter,
 		publicAccess:        publicAccess,
 		auditService:        auditService,
+		auditStore:          auditStore,
+		rulesSvc:            rulesSvc,
+		labelSvc:            labelSvc,
+		pushPolicy:          pushPolicy,
 	}
 }
@@ -26,9 +26,12 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 var (
@@ -97,6 +100,20 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to set space public access (succesfull cleanup): %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpace, space.Identifier),
+		audit.ActionCreated,
+		space.Path,
+		audit.WithNewObject(audit.SpaceObject{
+			Space:    *space,
+			IsPublic: in.IsPublic,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create space operation: %s", err)
+	}
+
 	return GetSpaceOutput(ctx, c.publicAccess, space)
 }
 
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListAuditEvents lists the audit events recorded in a space (and optionally in all of its sub-spaces).
+func (c *Controller) ListAuditEvents(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *audit.ListFilter,
+) ([]*audit.Event, int64, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	// audit trail is only available to those that are allowed to change the space.
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
+		return nil, 0, err
+	}
+
+	filter.SpaceID = space.ID
+
+	var events []*audit.Event
+	var count int64
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		events, err = c.auditStore.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		if filter.Page == 1 && len(events) < filter.Size {
+			count = int64(len(events))
+			return nil
+		}
+
+		count, err = c.auditStore.Count(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count audit events: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
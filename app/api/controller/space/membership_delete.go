@@ -20,8 +20,11 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // MembershipDelete removes an existing membership from a space.
@@ -44,13 +47,29 @@ func (c *Controller) MembershipDelete(ctx context.Context,
 		return fmt.Errorf("failed to find user by uid: %w", err)
 	}
 
-	err = c.membershipStore.Delete(ctx, types.MembershipKey{
+	membership, err := c.membershipStore.FindUser(ctx, types.MembershipKey{
 		SpaceID:     space.ID,
 		PrincipalID: user.ID,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to find user membership: %w", err)
+	}
+
+	err = c.membershipStore.Delete(ctx, membership.MembershipKey)
 	if err != nil {
 		return fmt.Errorf("failed to delete user membership: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, user.UID),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(*membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete membership operation: %s", err)
+	}
+
 	return nil
 }
@@ -21,8 +21,11 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // MoveInput is used for moving a space.
@@ -68,6 +71,8 @@ func (c *Controller) Move(
 		return GetSpaceOutput(ctx, c.publicAccess, space)
 	}
 
+	spaceClone := *space
+
 	if err = c.moveInner(
 		ctx,
 		session,
@@ -77,6 +82,18 @@ func (c *Controller) Move(
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpace, space.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(spaceClone),
+		audit.WithNewObject(*space),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for move space operation: %s", err)
+	}
+
 	return GetSpaceOutput(ctx, c.publicAccess, space)
 }
 
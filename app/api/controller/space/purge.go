@@ -80,6 +80,14 @@ func (c *Controller) PurgeNoAuth(
 	// permanently purge all repositories in the space and its subspaces after successful space purge tnx.
 	// cleanup will handle failed repository deletions.
 	for _, repo := range toBeDeletedRepos {
+		// forks outside the purged space still borrow git objects from the repository - keep it if that fails.
+		if err := c.repoCtrl.DissociateForks(ctx, session, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repo.ID).
+				Msg("failed to dissociate forks of repository, skipping deletion of the git repository")
+			continue
+		}
+
 		err := c.repoCtrl.DeleteGitRepository(ctx, session, repo.GitUID)
 		if err != nil {
 			log.Ctx(ctx).Warn().Err(err).
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PushPolicy is the push policy of a space.
+type PushPolicy struct {
+	// Module is the Rego module that defines the "deny" and "warn" sets of messages for a push.
+	// It's evaluated for all pushes to the repositories of the space and its subspaces.
+	Module string `json:"module"`
+}
+
+// PushPolicyFind returns the push policy of the space.
+func (c *Controller) PushPolicyFind(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*PushPolicy, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+		return nil, err
+	}
+
+	module, err := c.pushPolicy.Find(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PushPolicy{Module: module}, nil
+}
+
+// PushPolicyUpdate replaces the push policy of the space. An empty module removes the push policy.
+func (c *Controller) PushPolicyUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *PushPolicy,
+) (*PushPolicy, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
+		return nil, err
+	}
+
+	oldModule, err := c.pushPolicy.Find(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.pushPolicy.Update(ctx, space.ID, in.Module); err != nil {
+		return nil, fmt.Errorf("failed to update push policy: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpace, space.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(PushPolicy{Module: oldModule}),
+		audit.WithNewObject(*in),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space push policy operation: %s", err)
+	}
+
+	return c.PushPolicyFind(ctx, session, spaceRef)
+}
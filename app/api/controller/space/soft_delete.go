@@ -22,8 +22,11 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type SoftDeleteResponse struct {
@@ -75,6 +78,17 @@ func (c *Controller) SoftDeleteNoAuth(
 		return nil, fmt.Errorf("failed to soft delete the space: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpace, space.Identifier),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(*space),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete space operation: %s", err)
+	}
+
 	return softDelRes, nil
 }
 
@@ -21,9 +21,12 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // UpdateInput is used for updating a space.
@@ -59,6 +62,8 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
+	spaceClone := *space
+
 	space, err = c.spaceStore.UpdateOptLock(ctx, space, func(space *types.Space) error {
 		// update values only if provided
 		if in.Description != nil {
@@ -71,6 +76,18 @@ func (c *Controller) Update(
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpace, space.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(spaceClone),
+		audit.WithNewObject(*space),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space operation: %s", err)
+	}
+
 	return GetSpaceOutput(ctx, c.publicAccess, space)
 }
 
@@ -21,7 +21,10 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type UpdatePublicAccessInput struct {
@@ -75,6 +78,24 @@ func (c *Controller) UpdatePublicAccess(ctx context.Context,
 		return nil, fmt.Errorf("failed to update space public access: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpace, space.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(audit.SpaceObject{
+			Space:    *space,
+			IsPublic: isPublic,
+		}),
+		audit.WithNewObject(audit.SpaceObject{
+			Space:    *space,
+			IsPublic: in.IsPublic,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space public access operation: %s", err)
+	}
+
 	return &SpaceOutput{
 		Space:    *space,
 		IsPublic: in.IsPublic,
@@ -15,6 +15,11 @@
 package trigger
 
 import (
+	"time"
+
+	triggerservice "github.com/harness/gitness/app/services/trigger"
+	gitcheck "github.com/harness/gitness/git/check"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
@@ -36,6 +41,55 @@ func checkSecret(secret string) error {
 	return nil
 }
 
+// sanitizeType validates the trigger type and defaults it to a hook trigger.
+func sanitizeType(triggerType string) (string, error) {
+	switch triggerType {
+	case "":
+		return enum.TriggerHook, nil
+	case enum.TriggerHook, enum.TriggerCron:
+		return triggerType, nil
+	default:
+		return "", check.NewValidationErrorf("The trigger type has to be either '%s' or '%s'.",
+			enum.TriggerHook, enum.TriggerCron)
+	}
+}
+
+// checkCron validates the schedule of a scheduled trigger.
+func checkCron(cron string, branch string, timezone string) error {
+	if cron == "" {
+		return check.NewValidationError("A scheduled trigger requires a cron expression.")
+	}
+
+	if _, err := triggerservice.NextCronExecution(cron, timezone, time.Now()); err != nil {
+		return check.NewValidationErrorf("The schedule of the trigger is invalid: %s", err)
+	}
+
+	if branch == "" {
+		return nil
+	}
+
+	if err := gitcheck.BranchName(branch); err != nil {
+		return check.NewValidationErrorf("The branch of the trigger is invalid: %s", err)
+	}
+
+	return nil
+}
+
+// nextCronExecution returns the time (in milliseconds) at which an enabled scheduled trigger fires next.
+func nextCronExecution(t *types.Trigger) int64 {
+	if t.Type != enum.TriggerCron || t.Disabled {
+		return 0
+	}
+
+	next, err := triggerservice.NextCronExecution(t.Cron, t.CronTimezone, time.Now())
+	if err != nil {
+		// the schedule was validated already
+		return 0
+	}
+
+	return next.UnixMilli()
+}
+
 // checkActions validates the trigger actions.
 func checkActions(actions []enum.TriggerAction) error {
 	// ignore duplicates here, should be deduplicated later
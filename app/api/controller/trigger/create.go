@@ -35,6 +35,13 @@ type CreateInput struct {
 	Secret     string               `json:"secret"`
 	Disabled   bool                 `json:"disabled"`
 	Actions    []enum.TriggerAction `json:"actions"`
+
+	// Type is the type of the trigger, either @hook (default) or @cron.
+	Type string `json:"trigger_type"`
+	// Cron, CronBranch and CronTimezone define the schedule of a @cron trigger.
+	Cron         string `json:"cron"`
+	CronBranch   string `json:"cron_branch"`
+	CronTimezone string `json:"cron_timezone"`
 }
 
 func (c *Controller) Create(
@@ -77,7 +84,14 @@ func (c *Controller) Create(
 		Created:     now,
 		Updated:     now,
 		Version:     0,
+
+		Type:         in.Type,
+		Cron:         in.Cron,
+		CronBranch:   in.CronBranch,
+		CronTimezone: in.CronTimezone,
 	}
+	trigger.CronNext = nextCronExecution(trigger)
+
 	err = c.triggerStore.Create(ctx, trigger)
 	if err != nil {
 		return nil, fmt.Errorf("trigger creation failed: %w", err)
@@ -101,6 +115,23 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 	if err := checkActions(in.Actions); err != nil {
 		return err
 	}
+
+	var err error
+	if in.Type, err = sanitizeType(in.Type); err != nil {
+		return err
+	}
+
+	if in.Type == enum.TriggerCron {
+		if len(in.Actions) > 0 {
+			return check.NewValidationError("A scheduled trigger can't have actions.")
+		}
+		if err = checkCron(in.Cron, in.CronBranch, in.CronTimezone); err != nil {
+			return err
+		}
+	} else if in.Cron != "" || in.CronBranch != "" || in.CronTimezone != "" {
+		return check.NewValidationErrorf("Only triggers of type '%s' can have a schedule.", enum.TriggerCron)
+	}
+
 	if err := check.Identifier(in.Identifier); err != nil { //nolint:revive
 		return err
 	}
@@ -35,6 +35,11 @@ type UpdateInput struct {
 	Actions    []enum.TriggerAction `json:"actions"`
 	Secret     *string              `json:"secret"`
 	Disabled   *bool                `json:"disabled"` // can be nil, so keeping it a pointer
+
+	// Cron, CronBranch and CronTimezone update the schedule of a @cron trigger.
+	Cron         *string `json:"cron"`
+	CronBranch   *string `json:"cron_branch"`
+	CronTimezone *string `json:"cron_timezone"`
 }
 
 func (c *Controller) Update(
@@ -70,6 +75,10 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to find trigger: %w", err)
 	}
 
+	if err = checkUpdateSchedule(trigger, in); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
 	return c.triggerStore.UpdateOptLock(ctx,
 		trigger, func(original *types.Trigger) error {
 			if in.Identifier != nil {
@@ -87,11 +96,51 @@ func (c *Controller) Update(
 			if in.Disabled != nil {
 				original.Disabled = *in.Disabled
 			}
+			if in.Cron != nil {
+				original.Cron = *in.Cron
+			}
+			if in.CronBranch != nil {
+				original.CronBranch = *in.CronBranch
+			}
+			if in.CronTimezone != nil {
+				original.CronTimezone = *in.CronTimezone
+			}
+
+			if in.Cron != nil || in.CronTimezone != nil || in.Disabled != nil {
+				original.CronNext = nextCronExecution(original)
+			}
 
 			return nil
 		})
 }
 
+// checkUpdateSchedule validates the updated schedule against the type of the trigger.
+func checkUpdateSchedule(trigger *types.Trigger, in *UpdateInput) error {
+	if trigger.Type != enum.TriggerCron {
+		if in.Cron != nil || in.CronBranch != nil || in.CronTimezone != nil {
+			return check.NewValidationErrorf("Only triggers of type '%s' can have a schedule.", enum.TriggerCron)
+		}
+		return nil
+	}
+
+	if len(in.Actions) > 0 {
+		return check.NewValidationError("A scheduled trigger can't have actions.")
+	}
+
+	cron, branch, timezone := trigger.Cron, trigger.CronBranch, trigger.CronTimezone
+	if in.Cron != nil {
+		cron = *in.Cron
+	}
+	if in.CronBranch != nil {
+		branch = *in.CronBranch
+	}
+	if in.CronTimezone != nil {
+		timezone = *in.CronTimezone
+	}
+
+	return checkCron(cron, branch, timezone)
+}
+
 func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == nil {
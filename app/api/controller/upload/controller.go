@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
@@ -36,8 +37,11 @@ import (
 
 const (
 	MaxFileSize       = 10 << 20 // 10 MB file limit set in Handler
-	fileBucketPathFmt = "uploads/%d/%s"
+	fileBucketPathFmt = FileBucketPathPrefix + "%d/%s"
 	peekBytes         = 512
+
+	// FileBucketPathPrefix is the prefix of the blob store paths of all uploaded files.
+	FileBucketPathPrefix = "uploads/"
 )
 
 var supportedFileTypes = map[string]struct{}{
@@ -105,3 +109,18 @@ func (c *Controller) getFileExtension(file *bufio.Reader) (string, error) {
 func getFileBucketPath(repoID int64, fileName string) string {
 	return fmt.Sprintf(fileBucketPathFmt, repoID, fileName)
 }
+
+// ParseFileBucketPath returns the repo ID and the file name of an uploaded file from its blob store path.
+func ParseFileBucketPath(fileBucketPath string) (int64, string, bool) {
+	repoIDStr, fileName, found := strings.Cut(strings.TrimPrefix(fileBucketPath, FileBucketPathPrefix), "/")
+	if !found || fileName == "" || strings.Contains(fileName, "/") {
+		return 0, "", false
+	}
+
+	repoID, err := strconv.ParseInt(repoIDStr, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return repoID, fileName, true
+}
@@ -19,11 +19,13 @@ import (
 
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -35,6 +37,8 @@ type Controller struct {
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
 	publicKeyStore    store.PublicKeyStore
+	auditService      audit.Service
+	auditStore        audit.Store
 }
 
 func NewController(
@@ -45,6 +49,8 @@ func NewController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	auditService audit.Service,
+	auditStore audit.Store,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -54,6 +60,8 @@ func NewController(
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
 		publicKeyStore:    publicKeyStore,
+		auditService:      auditService,
+		auditStore:        auditStore,
 	}
 }
 
@@ -71,6 +79,20 @@ func findUserFromEmail(ctx context.Context,
 	return principalStore.FindUserByEmail(ctx, email)
 }
 
+// logAudit records an account level audit event, which isn't bound to any space.
+func (c *Controller) logAudit(
+	ctx context.Context,
+	principal types.Principal,
+	resource audit.Resource,
+	action audit.Action,
+	options ...audit.Option,
+) {
+	err := c.auditService.Log(ctx, principal, resource, action, "", options...)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for user %s operation: %s", action, err)
+	}
+}
+
 func isUserTokenType(tokenType enum.TokenType) bool {
 	return tokenType == enum.TokenTypePAT || tokenType == enum.TokenTypeSession
 }
@@ -22,6 +22,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -50,7 +51,18 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
-	return c.CreateNoAuth(ctx, in, false)
+	user, err := c.CreateNoAuth(ctx, in, false)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logAudit(ctx, session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionCreated,
+		audit.WithNewObject(user),
+	)
+
+	return user, nil
 }
 
 /*
@@ -21,6 +21,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -50,5 +51,15 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session,
 		return err
 	}
 
-	return c.principalStore.DeleteUser(ctx, user.ID)
+	if err = c.principalStore.DeleteUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	c.logAudit(ctx, session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionDeleted,
+		audit.WithOldObject(user),
+	)
+
+	return nil
 }
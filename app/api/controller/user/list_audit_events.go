@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListAuditEvents lists the account level audit events, which aren't recorded in any space
+// (changes of user accounts, their tokens and public keys).
+func (c *Controller) ListAuditEvents(
+	ctx context.Context,
+	session *auth.Session,
+	filter *audit.ListFilter,
+) ([]*audit.Event, int64, error) {
+	// audit trail of the accounts is only available to those that are allowed to change users.
+	scope := &types.Scope{}
+	resource := &types.Resource{
+		Type: enum.ResourceTypeUser,
+	}
+	if err := apiauth.Check(ctx, c.authorizer, session, scope, resource, enum.PermissionUserEdit); err != nil {
+		return nil, 0, err
+	}
+
+	filter.SpaceID = 0
+	filter.Recursive = false
+	filter.AccountScoped = true
+
+	var events []*audit.Event
+	var count int64
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		events, err = c.auditStore.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		if filter.Page == 1 && len(events) < filter.Size {
+			count = int64(len(events))
+			return nil
+		}
+
+		count, err = c.auditStore.Count(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count audit events: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 
@@ -66,6 +67,11 @@ func (c *Controller) Login(
 			Str("user_uid", user.UID).
 			Msg("invalid password")
 
+		c.logAudit(ctx, *user.ToPrincipal(),
+			audit.NewResource(audit.ResourceTypeUser, user.UID),
+			audit.ActionLoginFailed,
+		)
+
 		return nil, usererror.ErrNotFound
 	}
 
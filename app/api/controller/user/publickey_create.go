@@ -23,6 +23,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -54,7 +55,7 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
-	key, comment, err := publickey.ParseString(in.Content)
+	key, comment, err := publickey.ParseForUsage(in.Content, in.Usage)
 	if err != nil {
 		return nil, errors.InvalidArgument("could not parse public key")
 	}
@@ -80,7 +81,12 @@ func (c *Controller) CreatePublicKey(
 		}
 
 		for _, existingKey := range existingKeys {
-			if key.Matches(existingKey.Content) {
+			if !key.Matches(existingKey.Content) {
+				continue
+			}
+
+			// the same key can be used for authentication and signing, but only by a single user.
+			if existingKey.PrincipalID != k.PrincipalID || existingKey.Usage == k.Usage {
 				return errors.InvalidArgument("Key is already in use")
 			}
 		}
@@ -96,6 +102,13 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
+	c.logAudit(ctx, session.Principal,
+		audit.NewResource(audit.ResourceTypePublicKey, k.Identifier),
+		audit.ActionCreated,
+		audit.WithNewObject(k),
+		audit.WithData(audit.DataKeyOwner, user.UID),
+	)
+
 	return k, nil
 }
 
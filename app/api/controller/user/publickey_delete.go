@@ -20,6 +20,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -38,10 +39,22 @@ func (c *Controller) DeletePublicKey(
 		return err
 	}
 
+	key, err := c.publicKeyStore.FindByIdentifier(ctx, user.ID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find public key by identifier: %w", err)
+	}
+
 	err = c.publicKeyStore.DeleteByIdentifier(ctx, user.ID, identifier)
 	if err != nil {
 		return fmt.Errorf("failed to delete public key by id: %w", err)
 	}
 
+	c.logAudit(ctx, session.Principal,
+		audit.NewResource(audit.ResourceTypePublicKey, key.Identifier),
+		audit.ActionDeleted,
+		audit.WithOldObject(key),
+		audit.WithData(audit.DataKeyOwner, user.UID),
+	)
+
 	return nil
 }
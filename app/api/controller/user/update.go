@@ -17,11 +17,13 @@ package user
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -53,6 +55,8 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
+	oldUser := *user
+
 	if in.DisplayName != nil {
 		user.DisplayName = *in.DisplayName
 	}
@@ -74,6 +78,14 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	c.logAudit(ctx, session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionUpdated,
+		audit.WithOldObject(oldUser),
+		audit.WithNewObject(user),
+		audit.WithData("password_updated", strconv.FormatBool(in.Password != nil)),
+	)
+
 	return user, nil
 }
 
@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -55,6 +56,8 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		}
 	}
 
+	oldUser := *user
+
 	user.Admin = request.Admin
 	user.Updated = time.Now().UnixMilli()
 
@@ -63,5 +66,12 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	c.logAudit(ctx, session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionUpdated,
+		audit.WithOldObject(oldUser),
+		audit.WithNewObject(user),
+	)
+
 	return user, nil
 }
@@ -17,6 +17,7 @@ package user
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
 
@@ -36,6 +37,8 @@ func ProvideController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	auditService audit.Service,
+	auditStore audit.Store,
 ) *Controller {
 	return NewController(
 		tx,
@@ -44,5 +47,7 @@ func ProvideController(
 		principalStore,
 		tokenStore,
 		membershipStore,
-		publicKeyStore)
+		publicKeyStore,
+		auditService,
+		auditStore)
 }
@@ -16,9 +16,13 @@ package webhook
 
 import (
 	"net"
+	"net/http"
 	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
@@ -28,8 +32,17 @@ const (
 	webhookMaxURLLength = 2048
 	// webhookMaxSecretLength defines the max allowed length of a webhook secret.
 	webhookMaxSecretLength = 4096
+	// webhookMaxPayloadTemplateLength defines the max allowed length of a webhook payload template.
+	webhookMaxPayloadTemplateLength = 65536
+	// webhookMaxHeaders defines the max allowed number of custom headers of a webhook.
+	webhookMaxHeaders = 32
+	// webhookMaxHeaderValueLength defines the max allowed length of a custom header value of a webhook.
+	webhookMaxHeaderValueLength = 4096
 )
 
+// headerNameRegex matches valid HTTP header names.
+var headerNameRegex = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+\-.^_|~]+$`)
+
 var ErrInternalWebhookOperationNotAllowed = usererror.Forbidden("changes to internal webhooks are not allowed")
 
 // checkURL validates the url of a webhook.
@@ -96,6 +109,60 @@ func checkTriggers(triggers []enum.WebhookTrigger) error {
 	return nil
 }
 
+// checkPayloadFormat validates the payload format and the payload template of a webhook.
+func checkPayloadFormat(format enum.WebhookPayloadFormat, payloadTemplate string) error {
+	sanitizedFormat, ok := format.Sanitize()
+	if !ok {
+		return check.NewValidationErrorf("The provided webhook payload format '%s' is invalid.", format)
+	}
+
+	if len(payloadTemplate) > webhookMaxPayloadTemplateLength {
+		return check.NewValidationErrorf("The payload template of a webhook can be at most %d characters long.",
+			webhookMaxPayloadTemplateLength)
+	}
+
+	if sanitizedFormat == enum.WebhookPayloadFormatTemplate && strings.TrimSpace(payloadTemplate) == "" {
+		return check.NewValidationError("A payload template is required for the template payload format.")
+	}
+
+	if payloadTemplate != "" {
+		if _, err := webhook.ParsePayloadTemplate(payloadTemplate); err != nil {
+			return check.NewValidationErrorf("The provided payload template is invalid: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// checkHeaders validates the custom headers of a webhook.
+func checkHeaders(headers map[string]string) error {
+	if len(headers) > webhookMaxHeaders {
+		return check.NewValidationErrorf("A webhook can have at most %d custom headers.", webhookMaxHeaders)
+	}
+
+	for name, value := range headers {
+		if !headerNameRegex.MatchString(name) {
+			return check.NewValidationErrorf("The provided header name '%s' is invalid.", name)
+		}
+
+		switch http.CanonicalHeaderKey(name) {
+		case "Host", "Content-Length", "Transfer-Encoding", "User-Agent":
+			return check.NewValidationErrorf("The header '%s' can't be set for a webhook.", name)
+		}
+
+		if len(value) > webhookMaxHeaderValueLength {
+			return check.NewValidationErrorf("The value of a webhook header can be at most %d characters long.",
+				webhookMaxHeaderValueLength)
+		}
+
+		if strings.ContainsAny(value, "\r\n") {
+			return check.NewValidationErrorf("The value of header '%s' must not contain line breaks.", name)
+		}
+	}
+
+	return nil
+}
+
 // deduplicateTriggers de-duplicates the triggers provided by the user.
 func deduplicateTriggers(in []enum.WebhookTrigger) []enum.WebhookTrigger {
 	if len(in) == 0 {
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -37,8 +38,10 @@ type Controller struct {
 	webhookStore          store.WebhookStore
 	webhookExecutionStore store.WebhookExecutionStore
 	repoStore             store.RepoStore
+	spaceStore            store.SpaceStore
 	webhookService        *webhook.Service
 	encrypter             encrypt.Encrypter
+	auditService          audit.Service
 }
 
 func NewController(
@@ -48,8 +51,10 @@ func NewController(
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	webhookService *webhook.Service,
 	encrypter encrypt.Encrypter,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
 		allowLoopback:         allowLoopback,
@@ -58,8 +63,10 @@ func NewController(
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
 		repoStore:             repoStore,
+		spaceStore:            spaceStore,
 		webhookService:        webhookService,
 		encrypter:             encrypter,
+		auditService:          auditService,
 	}
 }
 
@@ -80,3 +87,21 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 
 	return repo, nil
 }
+
+func (c *Controller) getSpaceCheckAccess(ctx context.Context,
+	session *auth.Session, spaceRef string, reqPermission enum.Permission) (*types.Space, error) {
+	if spaceRef == "" {
+		return nil, usererror.BadRequest("A valid space reference must be provided.")
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, reqPermission); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return space, nil
+}
@@ -22,7 +22,10 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -43,6 +46,10 @@ type CreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	PayloadFormat   enum.WebhookPayloadFormat `json:"payload_format"`
+	PayloadTemplate string                    `json:"payload_template"`
+	Headers         map[string]string         `json:"headers"`
 }
 
 // Create creates a new webhook.
@@ -73,6 +80,11 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
 	}
 
+	encryptedHeaders, err := webhook.EncryptHeaders(c.encrypter, in.Headers, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt webhook headers: %w", err)
+	}
+
 	// create new webhook object
 	hook := &types.Webhook{
 		ID:         0, // the ID will be populated in the data layer
@@ -94,6 +106,9 @@ func (c *Controller) Create(
 		Insecure:              in.Insecure,
 		Triggers:              deduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
+		PayloadFormat:         in.PayloadFormat,
+		PayloadTemplate:       in.PayloadTemplate,
+		Headers:               encryptedHeaders,
 	}
 
 	err = c.webhookStore.Create(ctx, hook)
@@ -118,6 +133,18 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to store webhook: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, hook.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(hook),
+		audit.WithData(audit.DataKeyRepoName, repo.Identifier),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create webhook operation: %s", err)
+	}
+
 	return hook, nil
 }
 
@@ -155,7 +182,15 @@ func sanitizeCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwor
 	if err := checkSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := checkTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := checkTriggers(in.Triggers); err != nil {
+		return err
+	}
+
+	in.PayloadFormat, _ = in.PayloadFormat.Sanitize()
+	if err := checkPayloadFormat(in.PayloadFormat, in.PayloadTemplate); err != nil {
+		return err
+	}
+	if err := checkHeaders(in.Headers); err != nil { //nolint:revive
 		return err
 	}
 
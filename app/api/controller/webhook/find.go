@@ -45,6 +45,15 @@ func (c *Controller) getWebhookVerifyOwnership(
 	ctx context.Context,
 	repoID int64,
 	webhookIdentifier string,
+) (*types.Webhook, error) {
+	return c.getWebhookVerifyParent(ctx, enum.WebhookParentRepo, repoID, webhookIdentifier)
+}
+
+func (c *Controller) getWebhookVerifyParent(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookIdentifier string,
 ) (*types.Webhook, error) {
 	// TODO: Remove once webhook identifier migration completed
 	webhookID, err := strconv.ParseInt(webhookIdentifier, 10, 64)
@@ -56,15 +65,16 @@ func (c *Controller) getWebhookVerifyOwnership(
 	if err == nil {
 		webhook, err = c.webhookStore.Find(ctx, webhookID)
 	} else {
-		webhook, err = c.webhookStore.FindByIdentifier(ctx, enum.WebhookParentRepo, repoID, webhookIdentifier)
+		webhook, err = c.webhookStore.FindByIdentifier(ctx, parentType, parentID, webhookIdentifier)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to find webhook with identifier %q: %w", webhookIdentifier, err)
 	}
 
-	// ensure the webhook actually belongs to the repo
-	if webhook.ParentType != enum.WebhookParentRepo || webhook.ParentID != repoID {
-		return nil, fmt.Errorf("webhook doesn't belong to requested repo. Returning error %w", usererror.ErrNotFound)
+	// ensure the webhook actually belongs to the parent
+	if webhook.ParentType != parentType || webhook.ParentID != parentID {
+		return nil, fmt.Errorf("webhook doesn't belong to requested %s. Returning error %w",
+			parentType, usererror.ErrNotFound)
 	}
 
 	return webhook, nil
@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
@@ -65,3 +66,85 @@ func (c *Controller) RetriggerExecution(
 
 	return executionResult.Execution, nil
 }
+
+type RetriggerFailedExecutionsInput struct {
+	// Since is the time (unix milli) from which on failed executions are redelivered.
+	Since int64 `json:"since"`
+}
+
+func (in *RetriggerFailedExecutionsInput) sanitize() error {
+	if in.Since <= 0 {
+		return check.NewValidationError("Since has to be a positive unix timestamp in milliseconds.")
+	}
+
+	return nil
+}
+
+// RetriggerFailedExecutions schedules the redelivery of all triggers of the webhook
+// that failed to be delivered since the provided time.
+// It returns the failed executions that got scheduled for redelivery.
+func (c *Controller) RetriggerFailedExecutions(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	webhookIdentifier string,
+	in *RetriggerFailedExecutionsInput,
+) ([]*types.WebhookExecution, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	// get the webhook and ensure it belongs to us
+	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.retriggerFailedExecutions(ctx, webhook, in)
+}
+
+// RetriggerFailedSpaceExecutions schedules the redelivery of all triggers of the space webhook
+// that failed to be delivered since the provided time.
+// It returns the failed executions that got scheduled for redelivery.
+func (c *Controller) RetriggerFailedSpaceExecutions(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	webhookIdentifier string,
+	in *RetriggerFailedExecutionsInput,
+) ([]*types.WebhookExecution, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	// get the webhook and ensure it belongs to us
+	webhook, err := c.getWebhookVerifyParent(ctx, enum.WebhookParentSpace, space.ID, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.retriggerFailedExecutions(ctx, webhook, in)
+}
+
+func (c *Controller) retriggerFailedExecutions(
+	ctx context.Context,
+	webhook *types.Webhook,
+	in *RetriggerFailedExecutionsInput,
+) ([]*types.WebhookExecution, error) {
+	executions, err := c.webhookService.RedeliverFailedWebhookExecutions(ctx, webhook.ID, in.Since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver failed webhook executions: %w", err)
+	}
+
+	return executions, nil
+}
@@ -19,9 +19,14 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type UpdateInput struct {
@@ -36,6 +41,10 @@ type UpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	PayloadFormat   *enum.WebhookPayloadFormat `json:"payload_format"`
+	PayloadTemplate *string                    `json:"payload_template"`
+	Headers         map[string]string          `json:"headers"`
 }
 
 // Update updates an existing webhook.
@@ -66,6 +75,8 @@ func (c *Controller) Update(
 		return nil, ErrInternalWebhookOperationNotAllowed
 	}
 
+	oldHook := *hook
+
 	// update webhook struct (only for values that are provided)
 	if in.Identifier != nil {
 		hook.Identifier = *in.Identifier
@@ -87,6 +98,12 @@ func (c *Controller) Update(
 		hook.Secret = string(encryptedSecret)
 	}
 	if in.Enabled != nil {
+		// reset the failure statistics when (re-)enabling a webhook to close its circuit.
+		if *in.Enabled && !hook.Enabled {
+			hook.ConsecutiveFailures = 0
+			hook.FailingSince = 0
+			hook.DisabledReason = ""
+		}
 		hook.Enabled = *in.Enabled
 	}
 	if in.Insecure != nil {
@@ -95,11 +112,41 @@ func (c *Controller) Update(
 	if in.Triggers != nil {
 		hook.Triggers = deduplicateTriggers(in.Triggers)
 	}
+	if in.PayloadFormat != nil {
+		hook.PayloadFormat = *in.PayloadFormat
+	}
+	if in.PayloadTemplate != nil {
+		hook.PayloadTemplate = *in.PayloadTemplate
+	}
+	if in.Headers != nil {
+		hook.Headers, err = webhook.EncryptHeaders(c.encrypter, in.Headers, oldHook.Headers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt webhook headers: %w", err)
+		}
+	}
+
+	// validate the combination of payload format and template, as they can be updated independently
+	if err = checkPayloadFormat(hook.PayloadFormat, hook.PayloadTemplate); err != nil {
+		return nil, err
+	}
 
 	if err = c.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, hook.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(&oldHook),
+		audit.WithNewObject(hook),
+		audit.WithData(audit.DataKeyRepoName, repo.Identifier),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update webhook operation: %s", err)
+	}
+
 	return hook, nil
 }
 
@@ -139,6 +186,18 @@ func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwor
 			return err
 		}
 	}
+	if in.PayloadFormat != nil {
+		format, ok := in.PayloadFormat.Sanitize()
+		if !ok {
+			return check.NewValidationErrorf("The provided webhook payload format '%s' is invalid.", *in.PayloadFormat)
+		}
+		in.PayloadFormat = &format
+	}
+	if in.Headers != nil {
+		if err := checkHeaders(in.Headers); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
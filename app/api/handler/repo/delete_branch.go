@@ -46,7 +46,9 @@ func HandleDeleteBranch(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		violations, err := repoCtrl.DeleteBranch(ctx, session, repoRef, branchName, bypassRules)
+		bypassJustification := request.ParseBypassJustificationFromQuery(r)
+
+		violations, err := repoCtrl.DeleteBranch(ctx, session, repoRef, branchName, bypassRules, bypassJustification)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 		}
@@ -45,7 +45,9 @@ func HandleDeleteCommitTag(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		violations, err := repoCtrl.DeleteTag(ctx, session, repoRef, tagName, bypassRules)
+		bypassJustification := request.ParseBypassJustificationFromQuery(r)
+
+		violations, err := repoCtrl.DeleteTag(ctx, session, repoRef, tagName, bypassRules, bypassJustification)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
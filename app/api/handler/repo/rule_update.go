@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/services/rules"
 )
 
 // HandleRuleUpdate handles API that updates a protection rule of a repository.
@@ -41,7 +42,7 @@ func HandleRuleUpdate(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		in := new(repo.RuleUpdateInput)
+		in := new(rules.UpdateInput)
 		err = json.NewDecoder(r.Body).Decode(in)
 		if err != nil {
 			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type defineSpaceLabelRequest struct {
+	spaceRequest
+	label.DefineInput
+}
+
+type updateSpaceLabelRequest struct {
+	spaceRequest
+	LabelID int64 `path:"label_id"`
+	label.UpdateInput
+}
+
+type spaceLabelRequest struct {
+	spaceRequest
+	LabelID int64 `path:"label_id"`
+}
+
+type defineRepoLabelRequest struct {
+	repoRequest
+	label.DefineInput
+}
+
+type updateRepoLabelRequest struct {
+	repoRequest
+	LabelID int64 `path:"label_id"`
+	label.UpdateInput
+}
+
+type repoLabelRequest struct {
+	repoRequest
+	LabelID int64 `path:"label_id"`
+}
+
+type assignPullReqLabelRequest struct {
+	pullReqRequest
+	pullreq.LabelAssignInput
+}
+
+type unassignPullReqLabelRequest struct {
+	pullReqRequest
+	LabelID int64 `path:"label_id"`
+}
+
+var queryParameterQueryLabel = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the labels are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterInheritedLabel = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamInherited,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The result should also include labels of all ancestor spaces of the repository."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterLabelIDPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLabelID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of label IDs. Only pull requests that have all the labels are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+		// making it look like label_id=1&label_id=2
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+//nolint:funlen // api spec generation no need for checking func complexity
+func labelOperations(reflector *openapi3.Reflector) {
+	opSpaceLabelDefine := openapi3.Operation{}
+	opSpaceLabelDefine.WithTags("space")
+	opSpaceLabelDefine.WithMapOfAnything(map[string]interface{}{"operationId": "spaceLabelDefine"})
+	_ = reflector.SetRequest(&opSpaceLabelDefine, new(defineSpaceLabelRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDefine, new(types.Label), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDefine, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDefine, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDefine, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDefine, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDefine, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/labels", opSpaceLabelDefine)
+
+	opSpaceLabelUpdate := openapi3.Operation{}
+	opSpaceLabelUpdate.WithTags("space")
+	opSpaceLabelUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "spaceLabelUpdate"})
+	_ = reflector.SetRequest(&opSpaceLabelUpdate, new(updateSpaceLabelRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSpaceLabelUpdate, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceLabelUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpaceLabelUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceLabelUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceLabelUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceLabelUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/labels/{label_id}", opSpaceLabelUpdate)
+
+	opSpaceLabelDelete := openapi3.Operation{}
+	opSpaceLabelDelete.WithTags("space")
+	opSpaceLabelDelete.WithMapOfAnything(map[string]interface{}{"operationId": "spaceLabelDelete"})
+	_ = reflector.SetRequest(&opSpaceLabelDelete, new(spaceLabelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceLabelDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/labels/{label_id}", opSpaceLabelDelete)
+
+	opSpaceLabelList := openapi3.Operation{}
+	opSpaceLabelList.WithTags("space")
+	opSpaceLabelList.WithMapOfAnything(map[string]interface{}{"operationId": "spaceLabelList"})
+	opSpaceLabelList.WithParameters(queryParameterQueryLabel, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opSpaceLabelList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSpaceLabelList, []types.Label{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceLabelList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpaceLabelList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceLabelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceLabelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceLabelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/labels", opSpaceLabelList)
+
+	opRepoLabelDefine := openapi3.Operation{}
+	opRepoLabelDefine.WithTags("repository")
+	opRepoLabelDefine.WithMapOfAnything(map[string]interface{}{"operationId": "repoLabelDefine"})
+	_ = reflector.SetRequest(&opRepoLabelDefine, new(defineRepoLabelRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRepoLabelDefine, new(types.Label), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRepoLabelDefine, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoLabelDefine, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoLabelDefine, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoLabelDefine, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoLabelDefine, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/labels", opRepoLabelDefine)
+
+	opRepoLabelUpdate := openapi3.Operation{}
+	opRepoLabelUpdate.WithTags("repository")
+	opRepoLabelUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "repoLabelUpdate"})
+	_ = reflector.SetRequest(&opRepoLabelUpdate, new(updateRepoLabelRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opRepoLabelUpdate, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoLabelUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoLabelUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoLabelUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoLabelUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoLabelUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/labels/{label_id}", opRepoLabelUpdate)
+
+	opRepoLabelDelete := openapi3.Operation{}
+	opRepoLabelDelete.WithTags("repository")
+	opRepoLabelDelete.WithMapOfAnything(map[string]interface{}{"operationId": "repoLabelDelete"})
+	_ = reflector.SetRequest(&opRepoLabelDelete, new(repoLabelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRepoLabelDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRepoLabelDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoLabelDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoLabelDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoLabelDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoLabelDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/labels/{label_id}", opRepoLabelDelete)
+
+	opRepoLabelList := openapi3.Operation{}
+	opRepoLabelList.WithTags("repository")
+	opRepoLabelList.WithMapOfAnything(map[string]interface{}{"operationId": "repoLabelList"})
+	opRepoLabelList.WithParameters(queryParameterQueryLabel, queryParameterInheritedLabel,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opRepoLabelList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoLabelList, []types.Label{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoLabelList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoLabelList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoLabelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoLabelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoLabelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/labels", opRepoLabelList)
+
+	opPullReqLabelList := openapi3.Operation{}
+	opPullReqLabelList.WithTags("pullreq")
+	opPullReqLabelList.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqLabels"})
+	_ = reflector.SetRequest(&opPullReqLabelList, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPullReqLabelList, []types.LabelInfo{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqLabelList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPullReqLabelList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqLabelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqLabelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqLabelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels", opPullReqLabelList)
+
+	opPullReqLabelAssign := openapi3.Operation{}
+	opPullReqLabelAssign.WithTags("pullreq")
+	opPullReqLabelAssign.WithMapOfAnything(map[string]interface{}{"operationId": "assignPullReqLabel"})
+	_ = reflector.SetRequest(&opPullReqLabelAssign, new(assignPullReqLabelRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opPullReqLabelAssign, []types.LabelInfo{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqLabelAssign, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPullReqLabelAssign, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqLabelAssign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqLabelAssign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqLabelAssign, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels", opPullReqLabelAssign)
+
+	opPullReqLabelUnassign := openapi3.Operation{}
+	opPullReqLabelUnassign.WithTags("pullreq")
+	opPullReqLabelUnassign.WithMapOfAnything(map[string]interface{}{"operationId": "unassignPullReqLabel"})
+	_ = reflector.SetRequest(&opPullReqLabelUnassign, new(unassignPullReqLabelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPullReqLabelUnassign, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opPullReqLabelUnassign, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPullReqLabelUnassign, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqLabelUnassign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqLabelUnassign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqLabelUnassign, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels/{label_id}", opPullReqLabelUnassign)
+}
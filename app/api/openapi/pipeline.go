@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/request"
@@ -51,6 +52,8 @@ type logRequest struct {
 
 type createExecutionRequest struct {
 	pipelineRequest
+	Branch string `query:"branch"`
+	execution.CreateInput
 }
 
 type createTriggerRequest struct {
@@ -66,6 +66,30 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type mergeQueueAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.MergeQueueAddInput
+}
+
+type autoMergeEnablePullReqRequest struct {
+	pullReqRequest
+	pullreq.AutoMergeInput
+}
+
+var queryParameterMergeQueueBranch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamBranch,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Branch of the merge queue. Defaults to the default branch of the repository."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -142,6 +166,10 @@ type getPullReqChecksRequest struct {
 	pullReqRequest
 }
 
+type exportPullReqRequest struct {
+	pullReqRequest
+}
+
 var queryParameterQueryPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -198,6 +226,20 @@ var queryParameterTargetBranchPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterTargetBranchTemplate = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        "target_branch",
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Target branch of the pull request. The default branch is used if not provided."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterCreatedByPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamCreatedBy,
@@ -259,6 +301,22 @@ var queryParameterSortPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterFormatPullRequestExport = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamExportFormat,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The format of the pull request export."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.PullReqExportFormatJSON),
+				Enum:    enum.PullReqExportFormat("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterKindPullRequestActivity = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamKind,
@@ -334,6 +392,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		queryParameterStatePullRequest, queryParameterSourceRepoRefPullRequest,
 		queryParameterSourceBranchPullRequest, queryParameterTargetBranchPullRequest,
 		queryParameterQueryPullRequest, queryParameterCreatedByPullRequest,
+		queryParameterLabelIDPullRequest,
 		queryParameterOrder, queryParameterSortPullRequest,
 		queryParameterCreatedLt, queryParameterCreatedGt,
 		QueryParameterPage, QueryParameterLimit)
@@ -356,6 +415,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&getPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}", getPullReq)
 
+	stackPullReq := openapi3.Operation{}
+	stackPullReq.WithTags("pullreq")
+	stackPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqStack"})
+	_ = reflector.SetRequest(&stackPullReq, new(getPullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&stackPullReq, new([]types.PullReqStackEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stackPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stackPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stackPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stackPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stackPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/stack", stackPullReq)
+
 	putPullReq := openapi3.Operation{}
 	putPullReq.WithTags("pullreq")
 	putPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "updatePullReq"})
@@ -517,6 +588,91 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	opMergeQueueAdd := openapi3.Operation{}
+	opMergeQueueAdd.WithTags("pullreq")
+	opMergeQueueAdd.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueAddPullReq"})
+	_ = reflector.SetRequest(&opMergeQueueAdd, new(mergeQueueAddPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueAdd)
+
+	opMergeQueueRemove := openapi3.Operation{}
+	opMergeQueueRemove.WithTags("pullreq")
+	opMergeQueueRemove.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueRemovePullReq"})
+	_ = reflector.SetRequest(&opMergeQueueRemove, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueRemove)
+
+	opMergeQueueList := openapi3.Operation{}
+	opMergeQueueList.WithTags("pullreq")
+	opMergeQueueList.WithMapOfAnything(map[string]interface{}{"operationId": "listMergeQueue"})
+	opMergeQueueList.WithParameters(queryParameterMergeQueueBranch)
+	_ = reflector.SetRequest(&opMergeQueueList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, []types.MergeQueueEntry{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/merge-queue", opMergeQueueList)
+
+	opTemplateList := openapi3.Operation{}
+	opTemplateList.WithTags("pullreq")
+	opTemplateList.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqTemplates"})
+	opTemplateList.WithParameters(queryParameterTargetBranchTemplate)
+	_ = reflector.SetRequest(&opTemplateList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTemplateList, []types.PullReqTemplate{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/templates", opTemplateList)
+
+	opAutoMergeFind := openapi3.Operation{}
+	opAutoMergeFind.WithTags("pullreq")
+	opAutoMergeFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeFind, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(types.AutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeFind)
+
+	opAutoMergeEnable := openapi3.Operation{}
+	opAutoMergeEnable.WithTags("pullreq")
+	opAutoMergeEnable.WithMapOfAnything(map[string]interface{}{"operationId": "enablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeEnable, new(autoMergeEnablePullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(types.AutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeEnable)
+
+	opAutoMergeDisable := openapi3.Operation{}
+	opAutoMergeDisable.WithTags("pullreq")
+	opAutoMergeDisable.WithMapOfAnything(map[string]interface{}{"operationId": "disablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeDisable, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeDisable)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -624,4 +780,19 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	panicOnErr(reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusForbidden))
 	panicOnErr(reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusNotFound))
 	panicOnErr(reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/checks", opChecks))
+
+	opExport := openapi3.Operation{}
+	opExport.WithTags("pullreq")
+	opExport.WithMapOfAnything(map[string]interface{}{"operationId": "exportPullReq"})
+	opExport.WithParameters(queryParameterFormatPullRequestExport)
+	_ = reflector.SetRequest(&opExport, new(exportPullReqRequest), http.MethodGet)
+	panicOnErr(reflector.SetStringResponse(&opExport, http.StatusOK, "text/markdown"))
+	panicOnErr(reflector.SetJSONResponse(&opExport, new(types.PullReqExport), http.StatusOK))
+	panicOnErr(reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusBadRequest))
+	panicOnErr(reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/export", opExport))
 }
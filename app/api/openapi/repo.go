@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/git"
 	gittypes "github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/types"
@@ -174,14 +175,14 @@ type codeOwnersValidate struct {
 type ruleType string
 
 func (ruleType) Enum() []interface{} {
-	return []interface{}{protection.TypeBranch}
+	return []interface{}{protection.TypeBranch, protection.TypePush, protection.TypeTag}
 }
 
 // ruleDefinition is a plugin for types.Rule Definition to allow using oneof.
 type ruleDefinition struct{}
 
 func (ruleDefinition) JSONSchemaOneOf() []interface{} {
-	return []interface{}{protection.Branch{}}
+	return []interface{}{protection.Branch{}, protection.Push{}, protection.Tag{}}
 }
 
 type rule struct {
@@ -215,6 +216,16 @@ type generalSettingsRequest struct {
 	reposettings.GeneralSettings
 }
 
+type forkRepoRequest struct {
+	repoRequest
+	repo.ForkInput
+}
+
+type syncForkRequest struct {
+	repoRequest
+	repo.SyncForkInput
+}
+
 type archiveRequest struct {
 	repoRequest
 	GitRef string `path:"git_ref" required:"true"`
@@ -510,6 +521,20 @@ var queryParameterBypassRules = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterBypassJustification = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamBypassJustification,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The reason for bypassing the rule violations (required only by rules that opt in)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterDeletedAt = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamDeletedAt,
@@ -688,6 +713,45 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/restore", opRestore)
 
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, new(forkRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
+	opSyncFork := openapi3.Operation{}
+	opSyncFork.WithTags("repository")
+	opSyncFork.WithMapOfAnything(map[string]interface{}{"operationId": "syncFork"})
+	_ = reflector.SetRequest(&opSyncFork, new(syncForkRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(repo.SyncForkOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork/sync", opSyncFork)
+
+	opListForks := openapi3.Operation{}
+	opListForks.WithTags("repository")
+	opListForks.WithMapOfAnything(map[string]interface{}{"operationId": "listForks"})
+	opListForks.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListForks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListForks, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/forks", opListForks)
+
 	opMove := openapi3.Operation{}
 	opMove.WithTags("repository")
 	opMove.WithMapOfAnything(map[string]interface{}{"operationId": "moveRepository"})
@@ -850,7 +914,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opDeleteBranch := openapi3.Operation{}
 	opDeleteBranch.WithTags("repository")
 	opDeleteBranch.WithMapOfAnything(map[string]interface{}{"operationId": "deleteBranch"})
-	opDeleteBranch.WithParameters(queryParameterBypassRules)
+	opDeleteBranch.WithParameters(queryParameterBypassRules, queryParameterBypassJustification)
 	_ = reflector.SetRequest(&opDeleteBranch, new(deleteBranchRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDeleteBranch, nil, http.StatusNoContent)
 	_ = reflector.SetJSONResponse(&opDeleteBranch, new(usererror.Error), http.StatusInternalServerError)
@@ -904,7 +968,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opDeleteTag := openapi3.Operation{}
 	opDeleteTag.WithTags("repository")
 	opDeleteTag.WithMapOfAnything(map[string]interface{}{"operationId": "deleteTag"})
-	opDeleteTag.WithParameters(queryParameterBypassRules)
+	opDeleteTag.WithParameters(queryParameterBypassRules, queryParameterBypassJustification)
 	_ = reflector.SetRequest(&opDeleteTag, new(deleteTagRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDeleteTag, nil, http.StatusNoContent)
 	_ = reflector.SetJSONResponse(&opDeleteTag, new(usererror.Error), http.StatusInternalServerError)
@@ -987,7 +1051,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opRuleAdd.WithMapOfAnything(map[string]interface{}{"operationId": "ruleAdd"})
 	_ = reflector.SetRequest(&opRuleAdd, struct {
 		repoRequest
-		repo.RuleCreateInput
+		rules.CreateInput
 
 		// overshadow "definition"
 		Type       ruleType       `json:"type"`
@@ -1020,7 +1084,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetRequest(&opRuleUpdate, &struct {
 		repoRequest
 		Identifier string `path:"rule_identifier"`
-		repo.RuleUpdateInput
+		rules.UpdateInput
 
 		// overshadow Type and Definition to enable oneof.
 		Type       ruleType       `json:"type"`
@@ -20,6 +20,8 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -95,7 +97,7 @@ var queryParameterQueryRepo = openapi3.ParameterOrRef{
 
 var queryParameterRecursive = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
-		Name:        request.QueryParamQuery,
+		Name:        request.QueryParamRecursive,
 		In:          openapi3.ParameterInQuery,
 		Description: ptr.String("The boolean used to do space recursive op on repos."),
 		Required:    ptr.Bool(false),
@@ -107,6 +109,71 @@ var queryParameterRecursive = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterAuditAction = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditAction,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The actions of the audit events to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: audit.Action("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditResourceType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditResourceType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The resource types of the audit events to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: audit.ResourceType("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditPrincipalID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditPrincipalID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of IDs of the principals that performed the audited actions."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
 var queryParameterSortSpace = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -376,6 +443,22 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opSecrets, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/secrets", opSecrets)
 
+	opAuditEvents := openapi3.Operation{}
+	opAuditEvents.WithTags("space")
+	opAuditEvents.WithMapOfAnything(map[string]interface{}{"operationId": "listAuditEvents"})
+	opAuditEvents.WithParameters(queryParameterAuditAction, queryParameterAuditResourceType,
+		queryParameterAuditPrincipalID, queryParameterRecursive, queryParameterOrder,
+		queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opAuditEvents, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAuditEvents, []audit.Event{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-events", opAuditEvents)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("space")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listServiceAccounts"})
@@ -446,4 +529,113 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members", opMembershipList)
+
+	opSpaceRuleAdd := openapi3.Operation{}
+	opSpaceRuleAdd.WithTags("space")
+	opSpaceRuleAdd.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleAdd"})
+	_ = reflector.SetRequest(&opSpaceRuleAdd, struct {
+		spaceRequest
+		rules.CreateInput
+
+		// overshadow "definition"
+		Type       ruleType       `json:"type"`
+		Definition ruleDefinition `json:"definition"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSpaceRuleAdd, rule{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opSpaceRuleAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceRuleAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceRuleAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceRuleAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/rules", opSpaceRuleAdd)
+
+	opSpaceRuleDelete := openapi3.Operation{}
+	opSpaceRuleDelete.WithTags("space")
+	opSpaceRuleDelete.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleDelete"})
+	_ = reflector.SetRequest(&opSpaceRuleDelete, struct {
+		spaceRequest
+		RuleIdentifier string `path:"rule_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opSpaceRuleDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opSpaceRuleDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceRuleDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceRuleDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceRuleDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/rules/{rule_identifier}", opSpaceRuleDelete)
+
+	opSpaceRuleUpdate := openapi3.Operation{}
+	opSpaceRuleUpdate.WithTags("space")
+	opSpaceRuleUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleUpdate"})
+	_ = reflector.SetRequest(&opSpaceRuleUpdate, &struct {
+		spaceRequest
+		Identifier string `path:"rule_identifier"`
+		rules.UpdateInput
+
+		// overshadow Type and Definition to enable oneof.
+		Type       ruleType       `json:"type"`
+		Definition ruleDefinition `json:"definition"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSpaceRuleUpdate, rule{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceRuleUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceRuleUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceRuleUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceRuleUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/rules/{rule_identifier}", opSpaceRuleUpdate)
+
+	opSpaceRuleList := openapi3.Operation{}
+	opSpaceRuleList.WithTags("space")
+	opSpaceRuleList.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleList"})
+	opSpaceRuleList.WithParameters(
+		queryParameterQueryRuleList,
+		queryParameterOrder, queryParameterSortRuleList,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opSpaceRuleList, &struct {
+		spaceRequest
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSpaceRuleList, []rule{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceRuleList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceRuleList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceRuleList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceRuleList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/rules", opSpaceRuleList)
+
+	opSpaceRuleGet := openapi3.Operation{}
+	opSpaceRuleGet.WithTags("space")
+	opSpaceRuleGet.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleGet"})
+	_ = reflector.SetRequest(&opSpaceRuleGet, &struct {
+		spaceRequest
+		Identifier string `path:"rule_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSpaceRuleGet, rule{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceRuleGet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceRuleGet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceRuleGet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceRuleGet, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/rules/{rule_identifier}", opSpaceRuleGet)
+
+	opSpacePushPolicyGet := openapi3.Operation{}
+	opSpacePushPolicyGet.WithTags("space")
+	opSpacePushPolicyGet.WithMapOfAnything(map[string]interface{}{"operationId": "spacePushPolicyGet"})
+	_ = reflector.SetRequest(&opSpacePushPolicyGet, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyGet, new(space.PushPolicy), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyGet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyGet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyGet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyGet, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/push-policy", opSpacePushPolicyGet)
+
+	opSpacePushPolicyUpdate := openapi3.Operation{}
+	opSpacePushPolicyUpdate.WithTags("space")
+	opSpacePushPolicyUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "spacePushPolicyUpdate"})
+	_ = reflector.SetRequest(&opSpacePushPolicyUpdate, &struct {
+		spaceRequest
+		space.PushPolicy
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyUpdate, new(space.PushPolicy), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSpacePushPolicyUpdate, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/push-policy", opSpacePushPolicyUpdate)
 }
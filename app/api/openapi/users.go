@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 
 	"github.com/swaggest/openapi-go/openapi3"
@@ -117,4 +118,19 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}", opDelete)
+
+	opAuditEvents := openapi3.Operation{}
+	opAuditEvents.WithTags("admin")
+	opAuditEvents.WithMapOfAnything(map[string]interface{}{"operationId": "adminListAuditEvents"})
+	opAuditEvents.WithParameters(queryParameterAuditAction, queryParameterAuditResourceType,
+		queryParameterAuditPrincipalID, queryParameterOrder,
+		queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opAuditEvents, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAuditEvents, []audit.Event{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAuditEvents, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-events", opAuditEvents)
 }
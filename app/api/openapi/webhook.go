@@ -73,6 +73,17 @@ type getWebhookExecutionRequest struct {
 	webhookExecutionRequest
 }
 
+type retriggerFailedWebhookExecutionsRequest struct {
+	webhookRequest
+	webhook.RetriggerFailedExecutionsInput
+}
+
+type retriggerFailedSpaceWebhookExecutionsRequest struct {
+	spaceRequest
+	ID int64 `path:"webhook_identifier"`
+	webhook.RetriggerFailedExecutionsInput
+}
+
 var queryParameterSortWebhook = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -207,4 +218,38 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&retriggerWebhookExecution, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}", retriggerWebhookExecution)
+
+	retriggerFailedWebhookExecutions := openapi3.Operation{}
+	retriggerFailedWebhookExecutions.WithTags("webhook")
+	retriggerFailedWebhookExecutions.WithMapOfAnything(
+		map[string]interface{}{"operationId": "retriggerFailedWebhookExecutions"})
+	_ = reflector.SetRequest(&retriggerFailedWebhookExecutions,
+		new(retriggerFailedWebhookExecutionsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&retriggerFailedWebhookExecutions, new([]types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&retriggerFailedWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&retriggerFailedWebhookExecutions, new(usererror.Error),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&retriggerFailedWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&retriggerFailedWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/retrigger", retriggerFailedWebhookExecutions)
+
+	retriggerFailedSpaceWebhookExecutions := openapi3.Operation{}
+	retriggerFailedSpaceWebhookExecutions.WithTags("webhook")
+	retriggerFailedSpaceWebhookExecutions.WithMapOfAnything(
+		map[string]interface{}{"operationId": "retriggerFailedSpaceWebhookExecutions"})
+	_ = reflector.SetRequest(&retriggerFailedSpaceWebhookExecutions,
+		new(retriggerFailedSpaceWebhookExecutionsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&retriggerFailedSpaceWebhookExecutions, new([]types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&retriggerFailedSpaceWebhookExecutions, new(usererror.Error),
+		http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&retriggerFailedSpaceWebhookExecutions, new(usererror.Error),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&retriggerFailedSpaceWebhookExecutions, new(usererror.Error),
+		http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&retriggerFailedSpaceWebhookExecutions, new(usererror.Error),
+		http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions/retrigger",
+		retriggerFailedSpaceWebhookExecutions)
 }
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/audit"
+)
+
+const (
+	QueryParamAuditAction       = "action"
+	QueryParamAuditResourceType = "resource_type"
+	QueryParamAuditPrincipalID  = "principal_id"
+)
+
+// ParseAuditListFilter extracts the audit event filter from the url.
+func ParseAuditListFilter(r *http.Request) (*audit.ListFilter, error) {
+	recursive, err := ParseRecursiveFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	principalIDs, err := QueryParamListAsPositiveInt64(r, QueryParamAuditPrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing principal filter: %w", err)
+	}
+
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing time range filter: %w", err)
+	}
+
+	return &audit.ListFilter{
+		Pagination:    ParsePaginationFromRequest(r),
+		CreatedFilter: createdFilter,
+		Recursive:     recursive,
+		Actions:       parseAuditActions(r),
+		ResourceTypes: parseAuditResourceTypes(r),
+		PrincipalIDs:  principalIDs,
+		Order:         ParseOrder(r),
+	}, nil
+}
+
+// parseAuditActions extracts the audit actions from the url, unknown values are ignored.
+func parseAuditActions(r *http.Request) []audit.Action {
+	strActions, _ := QueryParamList(r, QueryParamAuditAction)
+	actions := make([]audit.Action, 0, len(strActions))
+	for _, s := range strActions {
+		if action := audit.Action(s); action.Validate() == nil {
+			actions = append(actions, action)
+		}
+	}
+
+	return actions
+}
+
+// parseAuditResourceTypes extracts the audit resource types from the url, unknown values are ignored.
+func parseAuditResourceTypes(r *http.Request) []audit.ResourceType {
+	strTypes, _ := QueryParamList(r, QueryParamAuditResourceType)
+	resourceTypes := make([]audit.ResourceType, 0, len(strTypes))
+	for _, s := range strTypes {
+		if resourceType := audit.ResourceType(s); resourceType.Validate() == nil {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+
+	return resourceTypes
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	PathParamLabelID = "label_id"
+
+	QueryParamLabelID   = "label_id"
+	QueryParamInherited = "inherited"
+)
+
+// GetLabelIDFromPath extracts the label ID from the url path.
+func GetLabelIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamLabelID)
+}
+
+// ParseLabelFilter extracts the label query parameters from the url.
+func ParseLabelFilter(r *http.Request) *types.LabelFilter {
+	return &types.LabelFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+	}
+}
+
+// ParseInheritedFromQuery extracts the inherited option from the url.
+func ParseInheritedFromQuery(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamInherited, false)
+}
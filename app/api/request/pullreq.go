@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -26,6 +27,8 @@ const (
 	PathParamPullReqNumber    = "pullreq_number"
 	PathParamPullReqCommentID = "pullreq_comment_id"
 	PathParamReviewerID       = "pullreq_reviewer_id"
+
+	QueryParamExportFormat = "format"
 )
 
 func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
@@ -40,6 +43,16 @@ func GetPullReqCommentIDPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamPullReqCommentID)
 }
 
+// ParsePullReqExportFormat extracts the pull request export format from the url.
+func ParsePullReqExportFormat(r *http.Request) (enum.PullReqExportFormat, error) {
+	format, ok := enum.PullReqExportFormat(r.URL.Query().Get(QueryParamExportFormat)).Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Invalid export format, supported formats: %v",
+			enum.PullReqExportFormat("").Enum())
+	}
+	return format, nil
+}
+
 // ParseSortPullReq extracts the pull request sort parameter from the url.
 func ParseSortPullReq(r *http.Request) enum.PullReqSort {
 	result, _ := enum.PullReqSort(r.URL.Query().Get(QueryParamSort)).Sanitize()
@@ -71,6 +84,11 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		return nil, fmt.Errorf("encountered error parsing createdby filter: %w", err)
 	}
 
+	labelIDs, err := QueryParamListAsPositiveInt64(r, QueryParamLabelID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing label filter: %w", err)
+	}
+
 	createdAtFilter, err := ParseCreated(r)
 	if err != nil {
 		return nil, fmt.Errorf("encountered error parsing pr created filter: %w", err)
@@ -85,6 +103,7 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		SourceBranch:  r.URL.Query().Get("source_branch"),
 		TargetBranch:  r.URL.Query().Get("target_branch"),
 		States:        parsePullReqStates(r),
+		LabelIDs:      labelIDs,
 		Sort:          ParseSortPullReq(r),
 		Order:         ParseOrder(r),
 		CreatedFilter: createdAtFilter,
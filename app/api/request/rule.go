@@ -24,7 +24,8 @@ import (
 const (
 	PathParamRuleIdentifier = "rule_identifier"
 
-	QueryParamBypassRules = "bypass_rules"
+	QueryParamBypassRules         = "bypass_rules"
+	QueryParamBypassJustification = "bypass_justification"
 )
 
 // ParseRuleFilter extracts the protection rule query parameters from the url.
@@ -71,3 +72,8 @@ func parseRuleSort(r *http.Request) enum.RuleSort {
 func ParseBypassRulesFromQuery(r *http.Request) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamBypassRules, false)
 }
+
+// ParseBypassJustificationFromQuery extracts the bypass justification parameter from the URL query.
+func ParseBypassJustificationFromQuery(r *http.Request) string {
+	return r.URL.Query().Get(QueryParamBypassJustification)
+}
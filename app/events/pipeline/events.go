@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ExecutionBase contains the common fields of all pipeline execution events.
+type ExecutionBase struct {
+	RepoID      int64 `json:"repo_id"`
+	PipelineID  int64 `json:"pipeline_id"`
+	ExecutionID int64 `json:"execution_id"`
+	Number      int64 `json:"number"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+const ExecutionStartedEvent events.EventType = "execution-started"
+
+type ExecutionStartedPayload struct {
+	ExecutionBase
+}
+
+func (r *Reporter) ExecutionStarted(ctx context.Context, payload *ExecutionStartedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ExecutionStartedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pipeline execution started event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pipeline execution started event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterExecutionStarted(fn events.HandlerFunc[*ExecutionStartedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, ExecutionStartedEvent, fn, opts...)
+}
+
+const ExecutionFinishedEvent events.EventType = "execution-finished"
+
+type ExecutionFinishedPayload struct {
+	ExecutionBase
+	Status enum.CIStatus `json:"status"`
+}
+
+func (r *Reporter) ExecutionFinished(ctx context.Context, payload *ExecutionFinishedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ExecutionFinishedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pipeline execution finished event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pipeline execution finished event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterExecutionFinished(fn events.HandlerFunc[*ExecutionFinishedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, ExecutionFinishedEvent, fn, opts...)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeQueueCommitCreatedEvent events.EventType = "merge-queue-commit-created"
+
+// MergeQueueCommitCreatedPayload is reported once the speculative merge commit
+// of a pull request in the merge queue is (re)created, the status checks for the commit have to run.
+type MergeQueueCommitCreatedPayload struct {
+	Base
+	TargetBranch string `json:"target_branch"`
+	SourceSHA    string `json:"source_sha"`
+	BaseSHA      string `json:"base_sha"`
+	MergeSHA     string `json:"merge_sha"`
+	Ref          string `json:"ref"`
+}
+
+func (r *Reporter) MergeQueueCommitCreated(ctx context.Context, payload *MergeQueueCommitCreatedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueCommitCreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue commit created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue commit created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueCommitCreated(fn events.HandlerFunc[*MergeQueueCommitCreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueCommitCreatedEvent, fn, opts...)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -35,6 +36,7 @@ type service struct {
 	scheduler      scheduler.Scheduler
 	stageStore     store.StageStore
 	stepStore      store.StepStore
+	eventReporter  *pipelineevents.Reporter
 }
 
 // Canceler cancels a build.
@@ -52,6 +54,7 @@ func New(
 	scheduler scheduler.Scheduler,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
+	eventReporter *pipelineevents.Reporter,
 ) Canceler {
 	return &service{
 		executionStore: executionStore,
@@ -60,6 +63,7 @@ func New(
 		scheduler:      scheduler,
 		stageStore:     stageStore,
 		stepStore:      stepStore,
+		eventReporter:  eventReporter,
 	}
 }
 
@@ -153,5 +157,16 @@ func (s *service) Cancel(ctx context.Context, repo *types.Repository, execution
 		log.Debug().Err(err).Msg("canceler: failed to publish server-sent event")
 	}
 
+	s.eventReporter.ExecutionFinished(ctx, &pipelineevents.ExecutionFinishedPayload{
+		ExecutionBase: pipelineevents.ExecutionBase{
+			RepoID:      execution.RepoID,
+			PipelineID:  execution.PipelineID,
+			ExecutionID: execution.ID,
+			Number:      execution.Number,
+			PrincipalID: execution.CreatedBy,
+		},
+		Status: execution.Status,
+	})
+
 	return nil
 }
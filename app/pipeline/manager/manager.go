@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -150,6 +151,7 @@ type Manager struct {
 	// System  *store.System
 	Users store.PrincipalStore
 	// Webhook store.WebhookSender
+	EventReporter *pipelineevents.Reporter
 
 	publicAccess publicaccess.Service
 }
@@ -172,6 +174,7 @@ func New(
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
 	publicAccess publicaccess.Service,
+	eventReporter *pipelineevents.Reporter,
 ) *Manager {
 	return &Manager{
 		Config:           config,
@@ -191,6 +194,7 @@ func New(
 		Steps:            stepStore,
 		Users:            userStore,
 		publicAccess:     publicAccess,
+		EventReporter:    eventReporter,
 	}
 }
 
@@ -363,6 +367,12 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 		return nil, err
 	}
 
+	// Secret inputs expose only the identifier of the secret, the runner resolves and masks the value.
+	if err = verifySecretInputs(pipeline, execution, secrets); err != nil {
+		log.Warn().Err(err).Msg("manager: cannot find secret inputs")
+		return nil, err
+	}
+
 	return &ExecutionContext{
 		Repo:         repo,
 		RepoIsPublic: repoIsPublic,
@@ -374,6 +384,42 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 	}, nil
 }
 
+// verifySecretInputs verifies that the secrets referenced by the secret inputs of the execution
+// are among the secrets provided to the runner. Only the identifiers are exposed as input parameters,
+// the pipeline reads the values from the runner's secrets (e.g. with secrets.get), which masks them in the logs.
+func verifySecretInputs(
+	pipeline *types.Pipeline,
+	execution *types.Execution,
+	secrets []*types.Secret,
+) error {
+	for i := range pipeline.Inputs {
+		input := &pipeline.Inputs[i]
+		if input.Type != enum.PipelineInputTypeSecret {
+			continue
+		}
+
+		identifier, ok := execution.Inputs[input.Name]
+		if !ok {
+			continue
+		}
+
+		if _, ok := findSecret(secrets, identifier); !ok {
+			return fmt.Errorf("secret %q of input %q not found", identifier, input.Name)
+		}
+	}
+
+	return nil
+}
+
+func findSecret(secrets []*types.Secret, identifier string) (*types.Secret, bool) {
+	for _, secret := range secrets {
+		if secret.Identifier == identifier {
+			return secret, true
+		}
+	}
+	return nil, false
+}
+
 func (m *Manager) createNetrc(repo *types.Repository) (*Netrc, error) {
 	pipelinePrincipal := bootstrap.NewPipelineServiceSession().Principal
 	jwt, err := jwt.GenerateWithMembership(
@@ -464,6 +510,8 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 		Steps:       m.Steps,
 		Stages:      m.Stages,
 		Users:       m.Users,
+
+		EventReporter: m.EventReporter,
 	}
 
 	return s.do(noContext, stage)
@@ -481,6 +529,8 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 		Scheduler:   m.Scheduler,
 		Steps:       m.Steps,
 		Stages:      m.Stages,
+
+		EventReporter: m.EventReporter,
 	}
 	return t.do(noContext, stage)
 }
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -38,6 +39,8 @@ type setup struct {
 	Steps       store.StepStore
 	Stages      store.StageStore
 	Users       store.PrincipalStore
+
+	EventReporter *pipelineevents.Reporter
 }
 
 func (s *setup) do(ctx context.Context, stage *types.Stage) error {
@@ -87,11 +90,16 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		}
 	}
 
-	_, err = s.updateExecution(noContext, execution)
+	started, err := s.updateExecution(noContext, execution)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: cannot update the execution")
 		return err
 	}
+	if started {
+		s.EventReporter.ExecutionStarted(ctx, &pipelineevents.ExecutionStartedPayload{
+			ExecutionBase: executionEventBase(execution),
+		})
+	}
 	pipeline, err := s.Pipelines.Find(ctx, execution.PipelineID)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: cannot find pipeline")
@@ -116,6 +124,17 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 	return nil
 }
 
+// executionEventBase returns the base of the pipeline execution events.
+func executionEventBase(execution *types.Execution) pipelineevents.ExecutionBase {
+	return pipelineevents.ExecutionBase{
+		RepoID:      execution.RepoID,
+		PipelineID:  execution.PipelineID,
+		ExecutionID: execution.ID,
+		Number:      execution.Number,
+		PrincipalID: execution.CreatedBy,
+	}
+}
+
 // helper function that updates the execution status from pending to running.
 // This accounts for the fact that another agent may have already updated
 // the execution status, which may happen if two stages execute concurrently.
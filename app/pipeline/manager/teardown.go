@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
@@ -43,6 +44,8 @@ type teardown struct {
 	Repos       store.RepoStore
 	Steps       store.StepStore
 	Stages      store.StageStore
+
+	EventReporter *pipelineevents.Reporter
 }
 
 //nolint:gocognit // refactor if needed.
@@ -167,6 +170,11 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
+	t.EventReporter.ExecutionFinished(ctx, &pipelineevents.ExecutionFinishedPayload{
+		ExecutionBase: executionEventBase(execution),
+		Status:        execution.Status,
+	})
+
 	execution.Stages = stages
 	err = t.SSEStreamer.Publish(noContext, repo.ParentID, enum.SSETypeExecutionCompleted, execution)
 	if err != nil {
@@ -31,6 +31,43 @@ func combine(env ...map[string]string) map[string]string {
 	return c
 }
 
+// InputEnvs returns the environment variables of the input parameters of an execution.
+// The value of an input is exposed as INPUT_<NAME>, with the name of the input in upper case.
+// Secret inputs expose the identifier of the secret, pipelines read its value with secrets.get,
+// which makes the runner mask it in the logs.
+func InputEnvs(inputs map[string]string) map[string]string {
+	envs := make(map[string]string, len(inputs))
+	for name, value := range inputs {
+		envs[types.PipelineInputEnvName(name)] = value
+	}
+	return envs
+}
+
+// defaultInputs returns the provided inputs, or the default values of the pipeline inputs
+// if the execution was started without inputs (e.g. by a trigger).
+func defaultInputs(pipeline *types.Pipeline, inputs map[string]string) map[string]string {
+	if inputs != nil {
+		return inputs
+	}
+
+	defaults := make(map[string]string, len(pipeline.Inputs))
+	for _, input := range pipeline.Inputs {
+		if input.Default != "" {
+			defaults[input.Name] = input.Default
+		}
+	}
+	return defaults
+}
+
+// pipelineInputs returns the input parameters of an execution for the evaluation of expressions (e.g. inputs.version).
+func pipelineInputs(inputs map[string]string) map[string]interface{} {
+	params := make(map[string]interface{}, len(inputs))
+	for name, value := range inputs {
+		params[name] = value
+	}
+	return params
+}
+
 func Envs(
 	repo *types.Repository,
 	pipeline *types.Pipeline,
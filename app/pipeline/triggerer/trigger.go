@@ -71,6 +71,7 @@ type Hook struct {
 	Cron         string             `json:"cron"`
 	Sender       string             `json:"sender"`
 	Params       map[string]string  `json:"params"`
+	Inputs       map[string]string  `json:"inputs"`
 }
 
 // Triggerer is responsible for triggering a Execution from an
@@ -151,6 +152,9 @@ func (t *triggerer) Trigger(
 	}()
 
 	event := string(base.Action.GetTriggerEvent())
+	if base.Trigger == enum.TriggerCron {
+		event = enum.TriggerEventCron
+	}
 
 	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
 	if err != nil {
@@ -194,6 +198,7 @@ func (t *triggerer) Trigger(
 		AuthorEmail:  base.AuthorEmail,
 		AuthorAvatar: base.AuthorAvatar,
 		Params:       base.Params,
+		Inputs:       defaultInputs(pipeline, base.Inputs),
 		Debug:        base.Debug,
 		Sender:       base.Sender,
 		Cron:         base.Cron,
@@ -356,7 +361,7 @@ func (t *triggerer) Trigger(
 	// TODO: this can be made better. We are setting this later since otherwise any parsing failure
 	// would lead to an incremented pipeline sequence number.
 	execution.Number = pipeline.Seq
-	execution.Params = combine(execution.Params, Envs(repo, pipeline, t.urlProvider))
+	execution.Params = combine(execution.Params, InputEnvs(execution.Inputs), Envs(repo, pipeline, t.urlProvider))
 
 	err = t.createExecutionWithStages(ctx, execution, stages)
 	if err != nil {
@@ -433,6 +438,7 @@ func parseV1Stages(
 	inputParams := map[string]interface{}{}
 	inputParams["repo"] = inputs.Repo(manager.ConvertToDroneRepo(repo, repoIsPublic))
 	inputParams["build"] = inputs.Build(manager.ConvertToDroneBuild(execution))
+	inputParams["inputs"] = pipelineInputs(execution.Inputs)
 
 	var prevStage string
 
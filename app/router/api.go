@@ -196,7 +196,7 @@ func setupRoutesV1(r chi.Router,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
 ) {
-	setupSpaces(r, appCtx, spaceCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, webhookCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
 		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl)
 	setupConnectors(r, connectorCtrl)
@@ -215,7 +215,12 @@ func setupRoutesV1(r chi.Router,
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
-func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controller) {
+func setupSpaces(
+	r chi.Router,
+	appCtx context.Context,
+	spaceCtrl *space.Controller,
+	webhookCtrl *webhook.Controller,
+) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerspace.HandleCreate(spaceCtrl))
@@ -242,6 +247,28 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Post("/export", handlerspace.HandleExport(spaceCtrl))
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
+			r.Get("/audit-events", handlerspace.HandleListAuditEvents(spaceCtrl))
+			r.Get("/push-policy", handlerspace.HandlePushPolicyFind(spaceCtrl))
+			r.Put("/push-policy", handlerspace.HandlePushPolicyUpdate(spaceCtrl))
+
+			r.Route("/rules", func(r chi.Router) {
+				r.Post("/", handlerspace.HandleRuleCreate(spaceCtrl))
+				r.Get("/", handlerspace.HandleRuleList(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamRuleIdentifier), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleRuleUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleRuleDelete(spaceCtrl))
+					r.Get("/", handlerspace.HandleRuleFind(spaceCtrl))
+				})
+			})
+
+			r.Route("/labels", func(r chi.Router) {
+				r.Post("/", handlerspace.HandleLabelDefine(spaceCtrl))
+				r.Get("/", handlerspace.HandleLabelList(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleLabelUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleLabelDelete(spaceCtrl))
+				})
+			})
 
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
@@ -251,6 +278,11 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
 				})
 			})
+
+			r.Route(fmt.Sprintf("/webhooks/{%s}/executions", request.PathParamWebhookIdentifier),
+				func(r chi.Router) {
+					r.Post("/retrigger", handlerwebhook.HandleRetriggerFailedSpaceExecutions(webhookCtrl))
+				})
 		})
 	})
 }
@@ -296,6 +328,10 @@ func setupRepos(r chi.Router,
 
 			r.Post("/default-branch", handlerrepo.HandleUpdateDefaultBranch(repoCtrl))
 
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
+			r.Post("/fork/sync", handlerrepo.HandleSyncFork(repoCtrl))
+			r.Get("/forks", handlerrepo.HandleListForks(repoCtrl))
+
 			// content operations
 			// NOTE: this allows /content and /content/ to both be valid (without any other tricks.)
 			// We don't expect there to be any other operations in that route (as that could overlap with file names)
@@ -372,6 +408,8 @@ func setupRepos(r chi.Router,
 			SetupUploads(r, uploadCtrl)
 
 			SetupRules(r, repoCtrl)
+
+			SetupLabels(r, repoCtrl)
 		})
 	})
 }
@@ -514,12 +552,15 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
+		r.Get("/templates", handlerpullreq.HandleTemplateList(pullreqCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
 			r.Patch("/", handlerpullreq.HandleUpdate(pullreqCtrl))
 			r.Post("/state", handlerpullreq.HandleState(pullreqCtrl))
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
+			r.Get("/stack", handlerpullreq.HandleStack(pullreqCtrl))
 			r.Route("/comments", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
 				r.Post("/apply-suggestions", handlerpullreq.HandleCommentApplySuggestions(pullreqCtrl))
@@ -540,6 +581,21 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleMergeQueueAdd(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueRemove(pullreqCtrl))
+			})
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleAutoMergeFind(pullreqCtrl))
+				r.Put("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
+			r.Route("/labels", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleLabelList(pullreqCtrl))
+				r.Put("/", handlerpullreq.HandleLabelAssign(pullreqCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamLabelID),
+					handlerpullreq.HandleLabelUnassign(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 
@@ -552,6 +608,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
+			r.Get("/export", handlerpullreq.HandleExport(pullreqCtrl))
 		})
 	})
 }
@@ -568,6 +625,7 @@ func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutions(webhookCtrl))
+				r.Post("/retrigger", handlerwebhook.HandleRetriggerFailedExecutions(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecution(webhookCtrl))
@@ -600,6 +658,17 @@ func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
+func SetupLabels(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/labels", func(r chi.Router) {
+		r.Post("/", handlerrepo.HandleLabelDefine(repoCtrl))
+		r.Get("/", handlerrepo.HandleLabelList(repoCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+			r.Patch("/", handlerrepo.HandleLabelUpdate(repoCtrl))
+			r.Delete("/", handlerrepo.HandleLabelDelete(repoCtrl))
+		})
+	})
+}
+
 func setupUser(r chi.Router, userCtrl *user.Controller) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
@@ -702,6 +771,7 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+		r.Get("/audit-events", users.HandleListAuditEvents(userCtrl))
 	})
 }
 
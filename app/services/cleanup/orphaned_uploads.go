@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/upload"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeOrphanedUploads        = "gitness:cleanup:orphaned-uploads"
+	jobCronOrphanedUploads        = "50 2 * * *" // At minute 50 past 2am every day.
+	jobMaxDurationOrphanedUploads = 30 * time.Minute
+)
+
+type orphanedUploadsCleanupJob struct {
+	retentionTime time.Duration
+
+	blobStore    blob.Store
+	pullReqStore store.PullReqStore
+}
+
+func newOrphanedUploadsCleanupJob(
+	retentionTime time.Duration,
+	blobStore blob.Store,
+	pullReqStore store.PullReqStore,
+) *orphanedUploadsCleanupJob {
+	return &orphanedUploadsCleanupJob{
+		retentionTime: retentionTime,
+
+		blobStore:    blobStore,
+		pullReqStore: pullReqStore,
+	}
+}
+
+// Handle deletes uploaded files that are older than the retention time
+// and aren't referenced by any pull request description or comment of their repository.
+// Multipart uploads that weren't updated within the retention time are discarded as well.
+func (j *orphanedUploadsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging orphaned uploads older than %s (aka uploaded before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	files, err := j.blobStore.List(ctx, upload.FileBucketPathPrefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to list uploaded files: %w", err)
+	}
+
+	type uploadedFile struct {
+		path string
+		name string
+		size int64
+	}
+
+	// group the files by repository, to check the references of all files of a repository at once.
+	repoFiles := make(map[int64][]uploadedFile)
+	for _, file := range files {
+		if file.ModTime.After(olderThan) {
+			// recently uploaded files might not be referenced yet, e.g. the comment is still being written.
+			continue
+		}
+
+		repoID, fileName, ok := upload.ParseFileBucketPath(file.Path)
+		if !ok {
+			log.Ctx(ctx).Warn().Msgf("skipping file with unexpected path %q", file.Path)
+			continue
+		}
+
+		repoFiles[repoID] = append(repoFiles[repoID], uploadedFile{path: file.Path, name: fileName, size: file.Size})
+	}
+
+	var deletedCount int
+	var deletedSize int64
+	for repoID, uploadedFiles := range repoFiles {
+		fileNames := make([]string, len(uploadedFiles))
+		for i, file := range uploadedFiles {
+			fileNames[i] = file.name
+		}
+
+		referenced, err := j.pullReqStore.ContainedTexts(ctx, repoID, fileNames)
+		if err != nil {
+			return "", fmt.Errorf("failed to check references of uploaded files of repo %d: %w", repoID, err)
+		}
+
+		for _, file := range uploadedFiles {
+			if referenced[file.name] {
+				continue
+			}
+
+			if err = j.blobStore.Delete(ctx, file.path); err != nil {
+				return "", fmt.Errorf("failed to delete uploaded file %q: %w", file.path, err)
+			}
+
+			deletedCount++
+			deletedSize += file.size
+		}
+	}
+
+	abortedCount, err := j.blobStore.AbortStaleMultipartUploads(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to abort stale multipart uploads: %w", err)
+	}
+
+	result := "no orphaned uploads found"
+	if deletedCount > 0 || abortedCount > 0 {
+		result = fmt.Sprintf("deleted %d orphaned uploads (%d bytes) and %d stale multipart uploads",
+			deletedCount, deletedSize, abortedCount)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
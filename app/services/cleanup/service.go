@@ -22,12 +22,16 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	AuditEventsRetentionTime         time.Duration
+	OrphanedUploadsRetentionTime     time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +45,14 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.AuditEventsRetentionTime <= 0 {
+		return errors.New("config.AuditEventsRetentionTime has to be provided")
+	}
+
+	if c.OrphanedUploadsRetentionTime <= 0 {
+		return errors.New("config.OrphanedUploadsRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -53,6 +65,9 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+	auditStore            audit.Store
+	blobStore             blob.Store
+	pullReqStore          store.PullReqStore
 }
 
 func NewService(
@@ -63,6 +78,9 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	auditStore audit.Store,
+	blobStore blob.Store,
+	pullReqStore store.PullReqStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +95,9 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		auditStore:            auditStore,
+		blobStore:             blobStore,
+		pullReqStore:          pullReqStore,
 	}, nil
 }
 
@@ -126,6 +147,28 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeAuditEvents,
+		jobTypeAuditEvents,
+		jobCronAuditEvents,
+		jobMaxDurationAuditEvents,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule audit events cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeOrphanedUploads,
+		jobTypeOrphanedUploads,
+		jobCronOrphanedUploads,
+		jobMaxDurationOrphanedUploads,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule orphaned uploads cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +203,26 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeAuditEvents,
+		newAuditEventsCleanupJob(
+			s.config.AuditEventsRetentionTime,
+			s.auditStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for audit events cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeOrphanedUploads,
+		newOrphanedUploadsCleanupJob(
+			s.config.OrphanedUploadsRetentionTime,
+			s.blobStore,
+			s.pullReqStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for orphaned uploads cleanup: %w", err)
+	}
 	return nil
 }
@@ -17,6 +17,8 @@ package cleanup
 import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
@@ -34,6 +36,9 @@ func ProvideService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	auditStore audit.Store,
+	blobStore blob.Store,
+	pullReqStore store.PullReqStore,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -43,5 +48,8 @@ func ProvideService(
 		tokenStore,
 		repoStore,
 		repoCtrl,
+		auditStore,
+		blobStore,
+		pullReqStore,
 	)
 }
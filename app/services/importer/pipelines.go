@@ -122,8 +122,10 @@ func (r *Repository) processPipelines(ctx context.Context,
 				RepoID:      pipeline.RepoID,
 				CreatedBy:   principal.ID,
 				Identifier:  "default",
+				Type:        enum.TriggerHook,
 				Actions: []enum.TriggerAction{enum.TriggerActionPullReqCreated,
-					enum.TriggerActionPullReqReopened, enum.TriggerActionPullReqBranchUpdated},
+					enum.TriggerActionPullReqReopened, enum.TriggerActionPullReqBranchUpdated,
+					enum.TriggerActionPullReqMergeQueued},
 				Disabled: false,
 				Version:  0,
 			}
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+)
+
+// indexFormatVersion has to be increased with every incompatible change of the persisted index.
+const indexFormatVersion = 2
+
+// binaryCheckSize is the number of leading bytes that are checked when determining if a file is binary.
+const binaryCheckSize = 8000
+
+// trigram is a sequence of three (lower-cased) bytes packed into a single integer.
+type trigram uint32
+
+func newTrigram(a, b, c byte) trigram {
+	return trigram(uint32(toLowerASCII(a))<<16 | uint32(toLowerASCII(b))<<8 | uint32(toLowerASCII(c)))
+}
+
+// indexedFile is a single file of the indexed revision.
+// Content of the file isn't part of the index, it's read from git when the file is searched.
+type indexedFile struct {
+	Path string
+	SHA  string
+
+	// Binary is true for binary files, they are never searched.
+	Binary bool
+}
+
+// repoIndex is the search index of the default branch of a single repository.
+// Once built, the index is never modified, any update of the repository results in a new index.
+type repoIndex struct {
+	Version   int
+	RepoID    int64
+	RepoUID   string
+	Branch    string
+	CommitSHA string
+	Files     []indexedFile
+
+	// Postings maps every trigram to the sorted list of indexes of the files containing it.
+	Postings map[trigram][]uint32
+}
+
+// indexBuilder builds a new repoIndex file by file.
+type indexBuilder struct {
+	files    []indexedFile
+	postings map[trigram][]uint32
+}
+
+func newIndexBuilder() *indexBuilder {
+	return &indexBuilder{
+		postings: make(map[trigram][]uint32),
+	}
+}
+
+// add appends the file containing the provided (unique) trigrams to the index.
+func (b *indexBuilder) add(file indexedFile, trigrams []trigram) {
+	fileIdx := uint32(len(b.files))
+	b.files = append(b.files, file)
+	for _, t := range trigrams {
+		b.postings[t] = append(b.postings[t], fileIdx)
+	}
+}
+
+func (b *indexBuilder) build(repoID int64, repoUID, branch, commitSHA string) *repoIndex {
+	return &repoIndex{
+		Version:   indexFormatVersion,
+		RepoID:    repoID,
+		RepoUID:   repoUID,
+		Branch:    branch,
+		CommitSHA: commitSHA,
+		Files:     b.files,
+		Postings:  b.postings,
+	}
+}
+
+// fileTrigrams returns the trigrams of every file of the index.
+func (idx *repoIndex) fileTrigrams() [][]trigram {
+	result := make([][]trigram, len(idx.Files))
+	for t, posting := range idx.Postings {
+		for _, fileIdx := range posting {
+			result[fileIdx] = append(result[fileIdx], t)
+		}
+	}
+
+	return result
+}
+
+// candidates returns indexes of all files that contain every one of the provided trigrams.
+// If no trigrams are provided, indexes of all files are returned.
+func (idx *repoIndex) candidates(trigrams []trigram) []uint32 {
+	if len(trigrams) == 0 {
+		all := make([]uint32, len(idx.Files))
+		for i := range all {
+			all[i] = uint32(i)
+		}
+		return all
+	}
+
+	var result []uint32
+	for i, t := range trigrams {
+		posting := idx.Postings[t]
+		if i == 0 {
+			result = append([]uint32(nil), posting...)
+		} else {
+			result = intersectPostings(result, posting)
+		}
+
+		if len(result) == 0 {
+			return nil
+		}
+	}
+
+	return result
+}
+
+// intersectPostings returns elements present in both sorted lists. The first list is reused for the result.
+func intersectPostings(a, b []uint32) []uint32 {
+	result := a[:0]
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
+
+// contentTrigrams returns all unique trigrams found in the content.
+func contentTrigrams(content []byte) []trigram {
+	seen := make(map[trigram]struct{})
+	var trigrams []trigram
+	for i := 0; i+2 < len(content); i++ {
+		t := newTrigram(content[i], content[i+1], content[i+2])
+		if _, ok := seen[t]; ok {
+			continue
+		}
+
+		seen[t] = struct{}{}
+		trigrams = append(trigrams, t)
+	}
+
+	return trigrams
+}
+
+// queryTrigrams returns trigrams of the literal that every matching file must contain.
+// Trigrams containing non-ASCII bytes are skipped, because the index folds only the case of ASCII letters.
+func queryTrigrams(literal string) []trigram {
+	var trigrams []trigram
+	seen := make(map[trigram]struct{})
+	for i := 0; i+2 < len(literal); i++ {
+		if literal[i] >= 0x80 || literal[i+1] >= 0x80 || literal[i+2] >= 0x80 {
+			continue
+		}
+
+		t := newTrigram(literal[i], literal[i+1], literal[i+2])
+		if _, ok := seen[t]; ok {
+			continue
+		}
+
+		seen[t] = struct{}{}
+		trigrams = append(trigrams, t)
+	}
+
+	return trigrams
+}
+
+func toLowerASCII(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b + ('a' - 'A')
+	}
+	return b
+}
+
+// isBinary uses the same heuristic as git: content with a NUL byte in the leading bytes is binary.
+func isBinary(content []byte) bool {
+	if len(content) > binaryCheckSize {
+		content = content[:binaryCheckSize]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// indexKey identifies an index by the repository and the indexed commit.
+type indexKey struct {
+	repoID    int64
+	commitSHA string
+}
+
+// cachedIndex is an index loaded from the disk, with the modification time of the file it was loaded from.
+type cachedIndex struct {
+	key     indexKey
+	idx     *repoIndex
+	modTime time.Time
+}
+
+// indexCache is a bounded LRU cache of the loaded indexes, it holds at most one index per repository.
+// A cached index is used only while the index file it was loaded from is unchanged,
+// which makes indexes updated by other instances sharing the index directory get reloaded.
+type indexCache struct {
+	mx       sync.Mutex
+	size     int
+	order    *list.List // of *cachedIndex, the most recently used first
+	entries  map[indexKey]*list.Element
+	repoKeys map[int64]indexKey
+}
+
+func newIndexCache(size int) *indexCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &indexCache{
+		size:     size,
+		order:    list.New(),
+		entries:  make(map[indexKey]*list.Element),
+		repoKeys: make(map[int64]indexKey),
+	}
+}
+
+// get returns the cached index of the repository, or nil if it isn't cached
+// or was loaded from an index file with a different modification time.
+func (c *indexCache) get(repoID int64, modTime time.Time) *repoIndex {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	key, ok := c.repoKeys[repoID]
+	if !ok {
+		return nil
+	}
+
+	element := c.entries[key]
+	entry, _ := element.Value.(*cachedIndex)
+	if !entry.modTime.Equal(modTime) {
+		return nil
+	}
+
+	c.order.MoveToFront(element)
+
+	return entry.idx
+}
+
+// add caches the index, replacing any other index of the same repository.
+// The least recently used indexes are evicted once the cache is full.
+func (c *indexCache) add(idx *repoIndex, modTime time.Time) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.removeRepo(idx.RepoID)
+
+	key := indexKey{repoID: idx.RepoID, commitSHA: idx.CommitSHA}
+	c.entries[key] = c.order.PushFront(&cachedIndex{key: key, idx: idx, modTime: modTime})
+	c.repoKeys[idx.RepoID] = key
+
+	for c.order.Len() > c.size {
+		oldest, _ := c.order.Back().Value.(*cachedIndex)
+		c.removeRepo(oldest.key.repoID)
+	}
+}
+
+// remove removes the index of the repository from the cache.
+func (c *indexCache) remove(repoID int64) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.removeRepo(repoID)
+}
+
+// removeRepo has to be called while holding the lock.
+func (c *indexCache) removeRepo(repoID int64) {
+	key, ok := c.repoKeys[repoID]
+	if !ok {
+		return
+	}
+
+	c.order.Remove(c.entries[key])
+	delete(c.entries, key)
+	delete(c.repoKeys, repoID)
+}
@@ -22,9 +22,21 @@ import (
 
 type Indexer interface {
 	Index(ctx context.Context, repo *types.Repository) error
+	Delete(ctx context.Context, repoID int64) error
 }
 
 type Searcher interface {
-	Search(ctx context.Context, repoIDs []int64, query string, enableRegex bool, maxResultCount int) (
+	Search(ctx context.Context, repoIDs []int64, query *types.SearchQuery, opts SearchOptions) (
 		types.SearchResult, error)
 }
+
+// SearchOptions limits the part of the search result that is returned.
+type SearchOptions struct {
+	// MaxResultCount is the maximum number of matching lines returned.
+	MaxResultCount int
+
+	// Offset is the number of matching files that are skipped before the returned ones.
+	Offset int
+	// Limit is the maximum number of matching files returned. The number of files isn't limited if it isn't set.
+	Limit int
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const indexFileExtension = ".idx"
+
+var errIndexNotFound = errors.New("index not found")
+
+// indexStore persists repository indexes as gzip compressed gob files in a local directory.
+type indexStore struct {
+	root string
+}
+
+func (s *indexStore) filePath(repoID int64) string {
+	return filepath.Join(s.root, strconv.FormatInt(repoID, 10)+indexFileExtension)
+}
+
+// load reads the index of the repository from the disk.
+// It returns errIndexNotFound if the index doesn't exist or was written in an outdated format.
+func (s *indexStore) load(repoID int64) (*repoIndex, error) {
+	f, err := os.Open(s.filePath(repoID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errIndexNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader for index file: %w", err)
+	}
+	defer r.Close()
+
+	idx := &repoIndex{}
+	if err = gob.NewDecoder(r).Decode(idx); err != nil {
+		return nil, fmt.Errorf("failed to decode index file: %w", err)
+	}
+
+	if idx.Version != indexFormatVersion {
+		return nil, errIndexNotFound
+	}
+
+	return idx, nil
+}
+
+// modTime returns the modification time of the index file of the repository.
+// It returns errIndexNotFound if the index doesn't exist.
+func (s *indexStore) modTime(repoID int64) (time.Time, error) {
+	info, err := os.Stat(s.filePath(repoID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, errIndexNotFound
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to stat index file: %w", err)
+	}
+
+	return info.ModTime(), nil
+}
+
+// save writes the index to the disk. The existing index file is replaced atomically.
+func (s *indexStore) save(idx *repoIndex) error {
+	if err := os.MkdirAll(s.root, 0o700); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(s.root, "tmp-*"+indexFileExtension)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+
+	tmpPath := f.Name()
+	defer func() {
+		// no-op if the temporary file was successfully renamed
+		_ = os.Remove(tmpPath)
+	}()
+
+	w := gzip.NewWriter(f)
+
+	err = gob.NewEncoder(w).Encode(idx)
+	if err == nil {
+		err = w.Close()
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+
+	if err = os.Rename(tmpPath, s.filePath(idx.RepoID)); err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+
+	return nil
+}
+
+// delete removes the index of the repository from the disk.
+func (s *indexStore) delete(repoID int64) error {
+	err := os.Remove(s.filePath(repoID))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete index file: %w", err)
+	}
+
+	return nil
+}
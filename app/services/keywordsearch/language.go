@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"path"
+	"strings"
+)
+
+var languagesByExtension = map[string]string{
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".css":    "CSS",
+	".dart":   "Dart",
+	".go":     "Go",
+	".groovy": "Groovy",
+	".html":   "HTML",
+	".java":   "Java",
+	".js":     "JavaScript",
+	".jsx":    "JavaScript",
+	".json":   "JSON",
+	".kt":     "Kotlin",
+	".lua":    "Lua",
+	".md":     "Markdown",
+	".php":    "PHP",
+	".pl":     "Perl",
+	".proto":  "Protocol Buffer",
+	".py":     "Python",
+	".rb":     "Ruby",
+	".rs":     "Rust",
+	".scala":  "Scala",
+	".scss":   "SCSS",
+	".sh":     "Shell",
+	".sql":    "SQL",
+	".swift":  "Swift",
+	".tf":     "HCL",
+	".toml":   "TOML",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".xml":    "XML",
+	".yaml":   "YAML",
+	".yml":    "YAML",
+}
+
+var languagesByFileName = map[string]string{
+	"dockerfile": "Dockerfile",
+	"makefile":   "Makefile",
+}
+
+// languageFromPath returns the programming language of the file based on its name,
+// or an empty string if the language isn't known.
+func languageFromPath(filePath string) string {
+	name := strings.ToLower(path.Base(filePath))
+	if language, ok := languagesByFileName[name]; ok {
+		return language
+	}
+
+	return languagesByExtension[path.Ext(name)]
+}
@@ -16,27 +16,430 @@ package keywordsearch
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/contextutil"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
 )
 
+const (
+	// defaultMaxResultCount is the number of matches returned if the caller didn't provide a limit.
+	defaultMaxResultCount = 100
+
+	// backgroundIndexTimeout is the time after which indexing of a repository that was searched before
+	// it got indexed is aborted.
+	backgroundIndexTimeout = 30 * time.Minute
+)
+
+// LocalIndexSearcher maintains a trigram index of the default branch of every repository
+// in a local directory and uses it to serve keyword search requests.
 type LocalIndexSearcher struct {
+	config    Config
+	repoStore store.RepoStore
+	git       git.Interface
+	store     *indexStore
+	cache     *indexCache
+
+	mx        sync.Mutex
+	repoLocks map[int64]*sync.Mutex
+	pending   map[int64]struct{}
+
+	// workers limits the number of repositories indexed in the background.
+	workers    chan struct{}
+	background sync.WaitGroup
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(
+	config Config,
+	repoStore store.RepoStore,
+	git git.Interface,
+) *LocalIndexSearcher {
+	concurrency := config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &LocalIndexSearcher{
+		config:    config,
+		repoStore: repoStore,
+		git:       git,
+		store:     &indexStore{root: config.IndexRoot},
+		cache:     newIndexCache(config.MaxCachedIndexes),
+		repoLocks: make(map[int64]*sync.Mutex),
+		pending:   make(map[int64]struct{}),
+		workers:   make(chan struct{}, concurrency),
+	}
 }
 
+// Search searches the indexes of the provided repositories and returns up to opts.MaxResultCount matching lines.
+// The first opts.Offset matching files are skipped, they are included only in the stats.
+// Repositories that haven't been indexed yet are not searched, they are indexed in the background
+// and reported in the stats as not indexed.
+//
+//nolint:gocognit
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ bool,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query *types.SearchQuery,
+	opts SearchOptions,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+	m, err := newMatcher(query)
+	if err != nil {
+		return types.SearchResult{}, gitnesserrors.InvalidArgument("%s", err)
+	}
+
+	remainingMatches := opts.MaxResultCount
+	if remainingMatches <= 0 {
+		remainingMatches = defaultMaxResultCount
+	}
+
+	skipFiles := opts.Offset
+
+	// search repositories in a stable order to return consistent results for truncated searches.
+	repoIDs = append([]int64(nil), repoIDs...)
+	sort.Slice(repoIDs, func(i, j int) bool { return repoIDs[i] < repoIDs[j] })
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+		RepoStats:   []types.RepoSearchStats{},
+	}
+
+	// done reports if the search can stop, because the requested page of the result is complete.
+	done := func() bool {
+		return remainingMatches <= 0 || opts.Limit > 0 && len(result.FileMatches) >= opts.Limit
+	}
+
+	for _, repoID := range repoIDs {
+		if done() {
+			result.Stats.Truncated = true
+			break
+		}
+
+		idx, err := s.loadIndex(repoID)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to get index for repo %d: %w", repoID, err)
+		}
+		if idx == nil {
+			s.indexInBackground(ctx, repoID)
+			result.RepoStats = append(result.RepoStats, types.RepoSearchStats{RepoID: repoID, NotIndexed: true})
+			continue
+		}
+
+		readParams := git.ReadParams{RepoUID: idx.RepoUID}
+		repoStats := types.RepoSearchStats{RepoID: repoID}
+
+		for _, fileIdx := range m.candidates(idx) {
+			if done() {
+				result.Stats.Truncated = true
+				break
+			}
+
+			file := &idx.Files[fileIdx]
+			if file.Binary || !m.acceptsFile(file.Path) {
+				continue
+			}
+
+			content, err := s.readBlob(ctx, readParams, file.SHA)
+			if gitnesserrors.IsNotFound(err) {
+				// the index is being updated, the file will be searchable again once that's done.
+				continue
+			}
+			if err != nil {
+				return types.SearchResult{}, fmt.Errorf("failed to read file %q of repo %d: %w", file.Path, repoID, err)
+			}
+
+			limit := remainingMatches
+			if skipFiles > 0 {
+				// skipped files aren't returned, but all their matches are included in the stats.
+				limit = math.MaxInt
+			}
+
+			matches := m.match(file.Path, content, limit)
+			if len(matches) == 0 {
+				continue
+			}
+
+			result.Stats.TotalFiles++
+			result.Stats.TotalMatches += len(matches)
+			repoStats.TotalFiles++
+			repoStats.TotalMatches += len(matches)
+
+			if skipFiles > 0 {
+				skipFiles--
+				continue
+			}
+
+			result.FileMatches = append(result.FileMatches, types.FileMatch{
+				FileName:   file.Path,
+				RepoID:     repoID,
+				RepoBranch: idx.Branch,
+				Language:   languageFromPath(file.Path),
+				Matches:    matches,
+			})
+			remainingMatches -= len(matches)
+		}
+
+		if repoStats.TotalFiles > 0 {
+			result.RepoStats = append(result.RepoStats, repoStats)
+		}
+	}
+
+	if remainingMatches <= 0 {
+		// matches of the last returned file might have been cut off.
+		result.Stats.Truncated = true
+	}
+
+	return result, nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
+// Index updates the index of the repository to the latest commit of its default branch.
+//
+// The update is incremental - trigrams of files that didn't change since the last update
+// are taken over from the previous index, only new and modified files are read from git.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	unlock := s.lockRepo(repo.ID)
+	defer unlock()
+
+	_, err := s.index(ctx, repo)
+	return err
+}
+
+// Delete removes the index of the repository.
+func (s *LocalIndexSearcher) Delete(_ context.Context, repoID int64) error {
+	unlock := s.lockRepo(repoID)
+	defer unlock()
+
+	s.cache.remove(repoID)
+
+	return s.store.delete(repoID)
+}
+
+// index has to be called while holding the repository lock.
+func (s *LocalIndexSearcher) index(ctx context.Context, repo *types.Repository) (*repoIndex, error) {
+	readParams := git.ReadParams{RepoUID: repo.GitUID}
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if gitnesserrors.IsNotFound(err) {
+		// the default branch doesn't exist yet (empty repository), the index is saved empty
+		// to distinguish the repository from repositories that weren't indexed yet.
+		idx := newIndexBuilder().build(repo.ID, repo.GitUID, repo.DefaultBranch, "")
+		return idx, s.saveIndex(idx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	commitSHA := branchOut.Branch.SHA.String()
+
+	oldIdx, err := s.loadIndex(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldIdx != nil && oldIdx.Branch == repo.DefaultBranch && oldIdx.CommitSHA == commitSHA {
+		return oldIdx, nil
+	}
+
+	blobsOut, err := s.git.ListBlobs(ctx, &git.ListBlobsParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	// the old index can be reused for all unchanged blobs, regardless of their path.
+	type indexedBlob struct {
+		binary   bool
+		trigrams []trigram
+	}
+	knownBlobs := make(map[string]indexedBlob)
+	if oldIdx != nil {
+		oldTrigrams := oldIdx.fileTrigrams()
+		for i, file := range oldIdx.Files {
+			knownBlobs[file.SHA] = indexedBlob{binary: file.Binary, trigrams: oldTrigrams[i]}
+		}
+	}
+
+	builder := newIndexBuilder()
+	for _, blob := range blobsOut.Blobs {
+		if blob.Mode == git.TreeNodeModeSymlink || blob.Size > s.config.MaxFileSize {
+			continue
+		}
+
+		blobSHA := blob.SHA.String()
+
+		indexed, ok := knownBlobs[blobSHA]
+		if !ok {
+			content, err := s.readBlob(ctx, readParams, blobSHA)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file %q: %w", blob.Path, err)
+			}
+
+			if isBinary(content) {
+				indexed = indexedBlob{binary: true}
+			} else {
+				indexed = indexedBlob{trigrams: contentTrigrams(content)}
+			}
+			knownBlobs[blobSHA] = indexed
+		}
+
+		builder.add(indexedFile{
+			Path:   blob.Path,
+			SHA:    blobSHA,
+			Binary: indexed.binary,
+		}, indexed.trigrams)
+	}
+
+	idx := builder.build(repo.ID, repo.GitUID, repo.DefaultBranch, commitSHA)
+
+	if err = s.saveIndex(idx); err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("commit_sha", commitSHA).
+		Int("files", len(idx.Files)).
+		Msg("keyword search index updated")
+
+	return idx, nil
+}
+
+// readBlob returns the content of the blob.
+func (s *LocalIndexSearcher) readBlob(ctx context.Context, readParams git.ReadParams, blobSHA string) ([]byte, error) {
+	blobOut, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  s.config.MaxFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+	defer func() {
+		if errClose := blobOut.Content.Close(); errClose != nil {
+			log.Ctx(ctx).Warn().Err(errClose).Msgf("failed to close blob content reader")
+		}
+	}()
+
+	content, err := io.ReadAll(blobOut.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	return content, nil
+}
+
+// saveIndex writes the index to the disk and caches it.
+func (s *LocalIndexSearcher) saveIndex(idx *repoIndex) error {
+	if err := s.store.save(idx); err != nil {
+		return fmt.Errorf("failed to save index: %w", err)
+	}
+
+	modTime, err := s.store.modTime(idx.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get index modification time: %w", err)
+	}
+
+	s.cache.add(idx, modTime)
+
 	return nil
 }
+
+// loadIndex returns the current index of the repository, or nil if the repository wasn't indexed yet.
+// The cached index is used unless the index file changed since it was loaded (e.g. by another instance).
+func (s *LocalIndexSearcher) loadIndex(repoID int64) (*repoIndex, error) {
+	modTime, err := s.store.modTime(repoID)
+	if errors.Is(err, errIndexNotFound) {
+		s.cache.remove(repoID)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if idx := s.cache.get(repoID, modTime); idx != nil {
+		return idx, nil
+	}
+
+	idx, err := s.store.load(repoID)
+	if errors.Is(err, errIndexNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load index: %w", err)
+	}
+
+	s.cache.add(idx, modTime)
+
+	return idx, nil
+}
+
+// indexInBackground indexes the repository in the background, unless it's being indexed already.
+func (s *LocalIndexSearcher) indexInBackground(ctx context.Context, repoID int64) {
+	s.mx.Lock()
+	_, pending := s.pending[repoID]
+	s.pending[repoID] = struct{}{}
+	s.mx.Unlock()
+
+	if pending {
+		return
+	}
+
+	// indexing outlives the search request - start with a new, time restricted context.
+	ctx, cancel := context.WithTimeout(contextutil.WithNewValues(context.Background(), ctx), backgroundIndexTimeout)
+
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		defer cancel()
+		defer func() {
+			s.mx.Lock()
+			delete(s.pending, repoID)
+			s.mx.Unlock()
+		}()
+
+		s.workers <- struct{}{}
+		defer func() { <-s.workers }()
+
+		repo, err := s.repoStore.Find(ctx, repoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).
+				Msg("failed to find repository for keyword search indexing")
+			return
+		}
+
+		if err := s.Index(ctx, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).
+				Msg("failed to index repository for keyword search")
+		}
+	}()
+}
+
+func (s *LocalIndexSearcher) lockRepo(repoID int64) func() {
+	s.mx.Lock()
+	l, ok := s.repoLocks[repoID]
+	if !ok {
+		l = &sync.Mutex{}
+		s.repoLocks[repoID] = l
+	}
+	s.mx.Unlock()
+
+	l.Lock()
+
+	return l.Unlock
+}
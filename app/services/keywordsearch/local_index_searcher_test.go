@@ -0,0 +1,402 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+// fakeGit serves the files of a single branch and counts how many times each blob was read.
+type fakeGit struct {
+	git.Interface
+	commitSHA sha.SHA
+	files     map[string]string // path -> content
+	reads     map[string]int    // blob SHA -> number of reads
+}
+
+func blobSHA(content string) sha.SHA {
+	sum := sha1.Sum([]byte(content)) //nolint:gosec // not used for security
+	return sha.Must(hex.EncodeToString(sum[:]))
+}
+
+func (g *fakeGit) GetBranch(context.Context, *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	return &git.GetBranchOutput{Branch: git.Branch{Name: "main", SHA: g.commitSHA}}, nil
+}
+
+func (g *fakeGit) ListBlobs(context.Context, *git.ListBlobsParams) (*git.ListBlobsOutput, error) {
+	paths := make([]string, 0, len(g.files))
+	for path := range g.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	out := &git.ListBlobsOutput{}
+	for _, path := range paths {
+		content := g.files[path]
+		out.Blobs = append(out.Blobs, git.BlobNode{
+			Path: path,
+			Mode: git.TreeNodeModeFile,
+			SHA:  blobSHA(content),
+			Size: int64(len(content)),
+		})
+	}
+	return out, nil
+}
+
+func (g *fakeGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	g.reads[params.SHA]++
+	for _, content := range g.files {
+		if blobSHA(content).String() == params.SHA {
+			return &git.GetBlobOutput{
+				Size:        int64(len(content)),
+				ContentSize: int64(len(content)),
+				Content:     io.NopCloser(strings.NewReader(content)),
+			}, nil
+		}
+	}
+	return nil, errors.NotFound("blob %s not found", params.SHA)
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s fakeRepoStore) Find(context.Context, int64) (*types.Repository, error) {
+	return s.repo, nil
+}
+
+func TestLocalIndexSearcher_Search(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &types.Repository{ID: 1, GitUID: "repo-uid", DefaultBranch: "main"}
+	g := &fakeGit{
+		commitSHA: sha.Must(strings.Repeat("1", 40)),
+		files: map[string]string{
+			"main.go":   "package main\n\nfunc main() {}\n",
+			"README.md": "# readme\n",
+			"logo.png":  "\x89PNG\x00\x00",
+		},
+		reads: map[string]int{},
+	}
+
+	s := NewLocalIndexSearcher(
+		Config{IndexRoot: t.TempDir(), MaxFileSize: 1024},
+		fakeRepoStore{repo: repo},
+		g,
+	)
+
+	if err := s.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to index: %s", err)
+	}
+
+	idx, err := s.loadIndex(repo.ID)
+	if err != nil {
+		t.Fatalf("failed to load index: %s", err)
+	}
+
+	for _, file := range idx.Files {
+		if want := file.Path == "logo.png"; file.Binary != want {
+			t.Errorf("file %q: want binary=%t got=%t", file.Path, want, file.Binary)
+		}
+	}
+
+	// contents of the files aren't kept by the index, but read again when searched.
+	g.reads = map[string]int{}
+
+	query := &types.SearchQuery{Alternatives: [][]types.SearchTerm{{{Value: "func main"}}}}
+	result, err := s.Search(ctx, []int64{repo.ID}, query, SearchOptions{MaxResultCount: 10})
+	if err != nil {
+		t.Fatalf("failed to search: %s", err)
+	}
+
+	if len(result.FileMatches) != 1 || result.FileMatches[0].FileName != "main.go" {
+		t.Fatalf("expected a single match in main.go, got: %+v", result.FileMatches)
+	}
+
+	wantReads := map[string]int{blobSHA(g.files["main.go"]).String(): 1}
+	if len(g.reads) != len(wantReads) || g.reads[blobSHA(g.files["main.go"]).String()] != 1 {
+		t.Errorf("only the candidate file should be read: want=%v got=%v", wantReads, g.reads)
+	}
+
+	// an update of the index reads only new and modified files.
+	g.reads = map[string]int{}
+	g.commitSHA = sha.Must(strings.Repeat("2", 40))
+	g.files["main.go"] = "package main\n\nfunc main() { run() }\n"
+
+	if err = s.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to update index: %s", err)
+	}
+
+	if len(g.reads) != 1 || g.reads[blobSHA(g.files["main.go"]).String()] != 1 {
+		t.Errorf("only the modified file should be read: got=%v", g.reads)
+	}
+
+	query = &types.SearchQuery{Alternatives: [][]types.SearchTerm{{{Value: "run()"}}}}
+	result, err = s.Search(ctx, []int64{repo.ID}, query, SearchOptions{MaxResultCount: 10})
+	if err != nil {
+		t.Fatalf("failed to search: %s", err)
+	}
+
+	if len(result.FileMatches) != 1 || result.FileMatches[0].FileName != "main.go" {
+		t.Errorf("expected a single match in main.go, got: %+v", result.FileMatches)
+	}
+}
+
+func TestLocalIndexSearcher_SearchPagination(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &types.Repository{ID: 1, GitUID: "repo-uid", DefaultBranch: "main"}
+	g := &fakeGit{
+		commitSHA: sha.Must(strings.Repeat("1", 40)),
+		files: map[string]string{
+			"a.txt": "foo 1\nfoo 2\n",
+			"b.txt": "foo 3\n",
+			"c.txt": "foo 4\nfoo 5\nfoo 6\n",
+			"d.txt": "bar\n",
+		},
+		reads: map[string]int{},
+	}
+
+	s := NewLocalIndexSearcher(
+		Config{IndexRoot: t.TempDir(), MaxFileSize: 1024},
+		fakeRepoStore{repo: repo},
+		g,
+	)
+
+	if err := s.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to index: %s", err)
+	}
+
+	query := &types.SearchQuery{Alternatives: [][]types.SearchTerm{{{Value: "foo"}}}}
+
+	tests := []struct {
+		name       string
+		opts       SearchOptions
+		expFiles   []string
+		expMatches int
+		expTotal   types.SearchStats
+	}{
+		{
+			name:       "all",
+			opts:       SearchOptions{MaxResultCount: 100},
+			expFiles:   []string{"a.txt", "b.txt", "c.txt"},
+			expMatches: 6,
+			expTotal:   types.SearchStats{TotalFiles: 3, TotalMatches: 6},
+		},
+		{
+			name:       "first-page",
+			opts:       SearchOptions{MaxResultCount: 100, Limit: 2},
+			expFiles:   []string{"a.txt", "b.txt"},
+			expMatches: 3,
+			expTotal:   types.SearchStats{TotalFiles: 2, TotalMatches: 3, Truncated: true},
+		},
+		{
+			name:       "second-page",
+			opts:       SearchOptions{MaxResultCount: 100, Offset: 2, Limit: 2},
+			expFiles:   []string{"c.txt"},
+			expMatches: 3,
+			expTotal:   types.SearchStats{TotalFiles: 3, TotalMatches: 6},
+		},
+		{
+			name:       "skipped-files-not-limited",
+			opts:       SearchOptions{MaxResultCount: 2, Offset: 1},
+			expFiles:   []string{"b.txt", "c.txt"},
+			expMatches: 2,
+			expTotal:   types.SearchStats{TotalFiles: 3, TotalMatches: 4, Truncated: true},
+		},
+		{
+			name:     "page-after-last",
+			opts:     SearchOptions{MaxResultCount: 100, Offset: 4, Limit: 2},
+			expFiles: []string{},
+			expTotal: types.SearchStats{TotalFiles: 3, TotalMatches: 6},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := s.Search(ctx, []int64{repo.ID}, query, test.opts)
+			if err != nil {
+				t.Fatalf("failed to search: %s", err)
+			}
+
+			files := []string{}
+			matches := 0
+			for _, fileMatch := range result.FileMatches {
+				files = append(files, fileMatch.FileName)
+				matches += len(fileMatch.Matches)
+			}
+
+			if !reflect.DeepEqual(files, test.expFiles) {
+				t.Errorf("files: want=%v got=%v", test.expFiles, files)
+			}
+			if matches != test.expMatches {
+				t.Errorf("matches: want=%d got=%d", test.expMatches, matches)
+			}
+			if result.Stats != test.expTotal {
+				t.Errorf("stats: want=%+v got=%+v", test.expTotal, result.Stats)
+			}
+		})
+	}
+}
+
+func TestLocalIndexSearcher_SearchNotIndexed(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &types.Repository{ID: 1, GitUID: "repo-uid", DefaultBranch: "main"}
+	g := &fakeGit{
+		commitSHA: sha.Must(strings.Repeat("1", 40)),
+		files:     map[string]string{"main.go": "package main\n"},
+		reads:     map[string]int{},
+	}
+
+	s := NewLocalIndexSearcher(
+		Config{IndexRoot: t.TempDir(), MaxFileSize: 1024, MaxCachedIndexes: 1},
+		fakeRepoStore{repo: repo},
+		g,
+	)
+
+	query := &types.SearchQuery{Alternatives: [][]types.SearchTerm{{{Value: "package"}}}}
+
+	// the repository isn't searched before it's indexed, the index is built in the background.
+	result, err := s.Search(ctx, []int64{repo.ID}, query, SearchOptions{})
+	if err != nil {
+		t.Fatalf("failed to search: %s", err)
+	}
+
+	wantStats := []types.RepoSearchStats{{RepoID: repo.ID, NotIndexed: true}}
+	if len(result.FileMatches) != 0 || !reflect.DeepEqual(result.RepoStats, wantStats) {
+		t.Fatalf("expected the repository to be reported as not indexed, got: %+v", result)
+	}
+
+	s.background.Wait()
+
+	result, err = s.Search(ctx, []int64{repo.ID}, query, SearchOptions{})
+	if err != nil {
+		t.Fatalf("failed to search: %s", err)
+	}
+
+	wantStats = []types.RepoSearchStats{{RepoID: repo.ID, TotalFiles: 1, TotalMatches: 1}}
+	if len(result.FileMatches) != 1 || !reflect.DeepEqual(result.RepoStats, wantStats) {
+		t.Errorf("expected a single match once indexed, got: %+v", result)
+	}
+}
+
+func TestLocalIndexSearcher_ReloadUpdatedIndex(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &types.Repository{ID: 1, GitUID: "repo-uid", DefaultBranch: "main"}
+	g := &fakeGit{
+		commitSHA: sha.Must(strings.Repeat("1", 40)),
+		files:     map[string]string{"main.go": "package main\n"},
+		reads:     map[string]int{},
+	}
+
+	// two instances share the index directory, only one of them processes the update.
+	config := Config{IndexRoot: t.TempDir(), MaxFileSize: 1024, MaxCachedIndexes: 1}
+	s1 := NewLocalIndexSearcher(config, fakeRepoStore{repo: repo}, g)
+	s2 := NewLocalIndexSearcher(config, fakeRepoStore{repo: repo}, g)
+
+	if err := s1.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to index: %s", err)
+	}
+
+	query := &types.SearchQuery{Alternatives: [][]types.SearchTerm{{{Value: "func run"}}}}
+
+	result, err := s2.Search(ctx, []int64{repo.ID}, query, SearchOptions{})
+	if err != nil {
+		t.Fatalf("failed to search: %s", err)
+	}
+	if len(result.FileMatches) != 0 {
+		t.Fatalf("expected no matches, got: %+v", result.FileMatches)
+	}
+
+	g.commitSHA = sha.Must(strings.Repeat("2", 40))
+	g.files["main.go"] = "package main\n\nfunc run() {}\n"
+
+	if err = s1.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to update index: %s", err)
+	}
+
+	// make sure the update is detected regardless of the resolution of file times.
+	modTime := time.Now().Add(time.Minute)
+	if err = os.Chtimes(s1.store.filePath(repo.ID), modTime, modTime); err != nil {
+		t.Fatalf("failed to change index file time: %s", err)
+	}
+
+	result, err = s2.Search(ctx, []int64{repo.ID}, query, SearchOptions{})
+	if err != nil {
+		t.Fatalf("failed to search: %s", err)
+	}
+	if len(result.FileMatches) != 1 || result.FileMatches[0].FileName != "main.go" {
+		t.Errorf("expected the updated index to be searched, got: %+v", result.FileMatches)
+	}
+}
+
+func TestIndexCache(t *testing.T) {
+	c := newIndexCache(2)
+	modTime := time.Now()
+
+	idx := func(repoID int64, commitSHA string) *repoIndex {
+		return &repoIndex{RepoID: repoID, CommitSHA: commitSHA}
+	}
+
+	c.add(idx(1, "a"), modTime)
+	c.add(idx(2, "a"), modTime)
+
+	// use the first index to make the second one the least recently used.
+	if got := c.get(1, modTime); got == nil {
+		t.Fatalf("expected index of repo 1 to be cached")
+	}
+
+	c.add(idx(3, "a"), modTime)
+
+	if got := c.get(2, modTime); got != nil {
+		t.Errorf("expected the least recently used index to be evicted")
+	}
+	if got := c.get(1, modTime); got == nil {
+		t.Errorf("expected index of repo 1 to be cached")
+	}
+
+	// a new commit of a repository replaces its old index.
+	c.add(idx(1, "b"), modTime)
+
+	if got := c.get(1, modTime); got == nil || got.CommitSHA != "b" {
+		t.Errorf("expected the index of the new commit, got: %+v", got)
+	}
+	if c.order.Len() != 2 {
+		t.Errorf("expected 2 cached indexes, got %d", c.order.Len())
+	}
+
+	// an index loaded from an older index file isn't used.
+	if got := c.get(1, modTime.Add(time.Second)); got != nil {
+		t.Errorf("expected the outdated index not to be returned")
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
+)
+
+// symbolDefinitionKeywords are keywords that introduce a definition of a symbol in commonly used languages.
+// As there's no parser for the source code, symbol search is limited to lines starting with one of them.
+const symbolDefinitionKeywords = `func|function|type|class|struct|interface|enum|trait|def|fn|const|var|let|val|module`
+
+// matcher finds occurrences of a structured search query in files.
+type matcher struct {
+	alternatives [][]*termMatcher
+
+	pathIncludes []*regexp.Regexp
+	pathExcludes []*regexp.Regexp
+	languages    map[string]struct{}
+}
+
+// termMatcher finds occurrences of a single search term.
+type termMatcher struct {
+	re *regexp.Regexp
+
+	// literal is true if the term is matched literally, which allows a quick check on the whole content first.
+	literal bool
+
+	// symbol is true if the expression matches the whole symbol definition, with the name in the first group.
+	symbol bool
+
+	// trigrams are the trigrams any file has to contain to be able to match the term.
+	trigrams []trigram
+}
+
+// newMatcher creates a new matcher for the query.
+// Terms are matched case-insensitive, unless the query requires case-sensitive matching.
+func newMatcher(query *types.SearchQuery) (*matcher, error) {
+	if len(query.Alternatives) == 0 {
+		return nil, errors.New("query doesn't contain any search terms")
+	}
+
+	m := &matcher{
+		alternatives: make([][]*termMatcher, len(query.Alternatives)),
+		languages:    make(map[string]struct{}, len(query.Languages)),
+	}
+
+	for i, alternative := range query.Alternatives {
+		if len(alternative) == 0 {
+			return nil, errors.New("query contains an empty alternative")
+		}
+
+		for _, term := range alternative {
+			tm, err := newTermMatcher(term, query.EnableRegex, query.CaseSensitive)
+			if err != nil {
+				return nil, err
+			}
+
+			m.alternatives[i] = append(m.alternatives[i], tm)
+		}
+	}
+
+	var err error
+
+	m.pathIncludes, err = compilePathPatterns(query.PathIncludes)
+	if err != nil {
+		return nil, err
+	}
+
+	m.pathExcludes, err = compilePathPatterns(query.PathExcludes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, language := range query.Languages {
+		m.languages[strings.ToLower(language)] = struct{}{}
+	}
+
+	return m, nil
+}
+
+func newTermMatcher(term types.SearchTerm, enableRegex, caseSensitive bool) (*termMatcher, error) {
+	expr := term.Value
+	if !enableRegex {
+		expr = regexp.QuoteMeta(expr)
+	}
+
+	parsed, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", term.Value, err)
+	}
+
+	var trigrams []trigram
+	for _, literal := range requiredLiterals(parsed.Simplify()) {
+		trigrams = append(trigrams, queryTrigrams(literal)...)
+	}
+
+	if term.Symbol {
+		expr = `^\s*(?:(?:export|public|private|protected|static|async|pub)\s+)*` +
+			`(?:` + symbolDefinitionKeywords + `)\b.*?\b(` + expr + `)\b`
+	}
+
+	if !caseSensitive {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", term.Value, err)
+	}
+
+	return &termMatcher{
+		re:       re,
+		literal:  !enableRegex && !term.Symbol,
+		symbol:   term.Symbol,
+		trigrams: trigrams,
+	}, nil
+}
+
+func compilePathPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+		res[i] = re
+	}
+
+	return res, nil
+}
+
+// requiredLiterals returns literals that have to be part of any text matching the regular expression.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return nil
+		}
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpConcat:
+		var literals []string
+		for _, sub := range re.Sub {
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		return literals
+	default:
+		return nil
+	}
+}
+
+// candidates returns indexes of the files of the index that might match the query.
+func (m *matcher) candidates(idx *repoIndex) []uint32 {
+	var result []uint32
+	for i, alternative := range m.alternatives {
+		var trigrams []trigram
+		for _, term := range alternative {
+			trigrams = append(trigrams, term.trigrams...)
+		}
+
+		candidates := idx.candidates(trigrams)
+		if i == 0 {
+			result = candidates
+			continue
+		}
+
+		result = unionPostings(result, candidates)
+	}
+
+	return result
+}
+
+// unionPostings returns elements present in any of the two sorted lists.
+func unionPostings(a, b []uint32) []uint32 {
+	result := make([]uint32, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case a[i] > b[j]:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	result = append(result, a[i:]...)
+	result = append(result, b[j:]...)
+
+	return result
+}
+
+// acceptsFile returns true if the file passes the path and language filters of the query.
+func (m *matcher) acceptsFile(filePath string) bool {
+	for _, re := range m.pathIncludes {
+		if !re.MatchString(filePath) {
+			return false
+		}
+	}
+
+	for _, re := range m.pathExcludes {
+		if re.MatchString(filePath) {
+			return false
+		}
+	}
+
+	if len(m.languages) > 0 {
+		if _, ok := m.languages[strings.ToLower(languageFromPath(filePath))]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// match returns up to limit lines of the file that match the query.
+// A file matches if all terms of any alternative can be found in it. All lines matching
+// any of the terms of the satisfied alternatives are returned.
+//
+// The fragments of a line cover the whole line - every fragment contains the text between the end of
+// the previous match and its own match in Pre, and only the last fragment contains the rest of the line in Post.
+func (m *matcher) match(filePath string, content []byte, limit int) []types.Match {
+	if limit <= 0 || len(content) == 0 || !m.acceptsFile(filePath) {
+		return nil
+	}
+
+	lines := bytes.Split(content, []byte{'\n'})
+	for i := range lines {
+		lines[i] = bytes.TrimSuffix(lines[i], []byte{'\r'})
+	}
+
+	// terms are matched lazily, as a failed term allows skipping the rest of the alternative.
+	termRanges := make(map[*termMatcher]map[int][][]int)
+	matchTerm := func(term *termMatcher) map[int][][]int {
+		ranges, ok := termRanges[term]
+		if !ok {
+			ranges = term.matchLines(content, lines)
+			termRanges[term] = ranges
+		}
+		return ranges
+	}
+
+	lineRanges := make(map[int][][]int)
+	for _, alternative := range m.alternatives {
+		satisfied := true
+		for _, term := range alternative {
+			if len(matchTerm(term)) == 0 {
+				satisfied = false
+				break
+			}
+		}
+		if !satisfied {
+			continue
+		}
+
+		for _, term := range alternative {
+			for lineIdx, ranges := range matchTerm(term) {
+				lineRanges[lineIdx] = append(lineRanges[lineIdx], ranges...)
+			}
+		}
+	}
+
+	lineIdxs := make([]int, 0, len(lineRanges))
+	for lineIdx := range lineRanges {
+		lineIdxs = append(lineIdxs, lineIdx)
+	}
+	sort.Ints(lineIdxs)
+
+	if len(lineIdxs) > limit {
+		lineIdxs = lineIdxs[:limit]
+	}
+
+	matches := make([]types.Match, len(lineIdxs))
+	for i, lineIdx := range lineIdxs {
+		matches[i] = types.Match{
+			LineNum:   lineIdx + 1,
+			Fragments: lineFragments(lines[lineIdx], lineRanges[lineIdx]),
+		}
+		if lineIdx > 0 {
+			matches[i].Before = string(lines[lineIdx-1])
+		}
+		if lineIdx+1 < len(lines) {
+			matches[i].After = string(lines[lineIdx+1])
+		}
+	}
+
+	return matches
+}
+
+// matchLines returns the ranges of all non-empty matches of the term grouped by the line index.
+func (t *termMatcher) matchLines(content []byte, lines [][]byte) map[int][][]int {
+	result := make(map[int][][]int)
+
+	// regular expressions are matched line by line only, as anchors (^, $) refer to the line boundaries.
+	if t.literal && !t.re.Match(content) {
+		return result
+	}
+
+	for i, line := range lines {
+		for _, loc := range t.re.FindAllSubmatchIndex(line, -1) {
+			start, end := loc[0], loc[1]
+			if t.symbol {
+				// highlight only the name of the symbol, not the whole definition.
+				start, end = loc[2], loc[3]
+			}
+			if start == end {
+				// ignore empty matches, there's nothing to highlight
+				continue
+			}
+			result[i] = append(result[i], []int{start, end})
+		}
+	}
+
+	return result
+}
+
+// lineFragments splits the line into fragments using the provided (possibly overlapping) match ranges.
+func lineFragments(line []byte, ranges [][]int) []types.Fragment {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	merged := make([][]int, 0, len(ranges))
+	for _, r := range ranges {
+		last := len(merged) - 1
+		if last >= 0 && r[0] <= merged[last][1] {
+			if r[1] > merged[last][1] {
+				merged[last][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, []int{r[0], r[1]})
+	}
+
+	fragments := make([]types.Fragment, len(merged))
+
+	prevEnd := 0
+	for i, r := range merged {
+		fragments[i] = types.Fragment{
+			Pre:   string(line[prevEnd:r[0]]),
+			Match: string(line[r[0]:r[1]]),
+		}
+		prevEnd = r[1]
+	}
+
+	fragments[len(fragments)-1].Post = string(line[prevEnd:])
+
+	return fragments
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestMatcher_Search(t *testing.T) {
+	idx, contents := newTestIndex([]testFile{
+		{path: "main.go", content: []byte("package main\n\nfunc main() {\n\tfmt.Println(\"Hello\")\n}\n")},
+		{path: "README.md", content: []byte("# Hello World\r\nhello again\r\n")},
+		{path: "image.png", content: nil},
+	})
+
+	tests := []struct {
+		name          string
+		query         string
+		enableRegex   bool
+		caseSensitive bool
+		want          map[string][]int
+	}{
+		{
+			name:  "literal-case-insensitive",
+			query: "hello",
+			want:  map[string][]int{"main.go": {4}, "README.md": {1, 2}},
+		},
+		{
+			name:  "literal-special-characters",
+			query: "main()",
+			want:  map[string][]int{"main.go": {3}},
+		},
+		{
+			name:  "literal-short",
+			query: "fm",
+			want:  map[string][]int{"main.go": {4}},
+		},
+		{
+			name:  "literal-no-match",
+			query: "goodbye",
+			want:  map[string][]int{},
+		},
+		{
+			name:          "regex-case-sensitive",
+			query:         `Hello\s\w+`,
+			enableRegex:   true,
+			caseSensitive: true,
+			want:          map[string][]int{"README.md": {1}},
+		},
+		{
+			name:        "regex-flags",
+			query:       `(?i)^HELLO`,
+			enableRegex: true,
+			want:        map[string][]int{"README.md": {2}},
+		},
+		{
+			name:        "regex-alternation",
+			query:       `package|again`,
+			enableRegex: true,
+			want:        map[string][]int{"main.go": {1}, "README.md": {2}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := newMatcher(&types.SearchQuery{
+				Alternatives:  [][]types.SearchTerm{{{Value: test.query}}},
+				CaseSensitive: test.caseSensitive,
+				EnableRegex:   test.enableRegex,
+			})
+			if err != nil {
+				t.Fatalf("failed to create matcher: %s", err)
+			}
+
+			got := matchIndex(m, idx, contents)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMatcher_Query(t *testing.T) {
+	idx, contents := newTestIndex([]testFile{
+		{path: "cmd/main.go", content: []byte("package main\n\nfunc Run() {\n\trun()\n}\n")},
+		{path: "cmd/main_test.go", content: []byte("package main\n\nfunc TestRun() {\n\tRun()\n}\n")},
+		{path: "web/run.js", content: []byte("function run() {\n  return Run\n}\n")},
+	})
+
+	tests := []struct {
+		name  string
+		query types.SearchQuery
+		want  map[string][]int
+	}{
+		{
+			name: "case-sensitive",
+			query: types.SearchQuery{
+				Alternatives:  [][]types.SearchTerm{{{Value: "Run"}}},
+				CaseSensitive: true,
+			},
+			want: map[string][]int{"cmd/main.go": {3}, "cmd/main_test.go": {3, 4}, "web/run.js": {2}},
+		},
+		{
+			name: "all-terms-required",
+			query: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "package"}, {Value: "TestRun"}}},
+			},
+			want: map[string][]int{"cmd/main_test.go": {1, 3}},
+		},
+		{
+			name: "alternatives",
+			query: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "TestRun"}}, {{Value: "return"}}},
+			},
+			want: map[string][]int{"cmd/main_test.go": {3}, "web/run.js": {2}},
+		},
+		{
+			name: "path-filters",
+			query: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "package"}}},
+				PathIncludes: []string{`^cmd/`},
+				PathExcludes: []string{`_test\.go$`},
+			},
+			want: map[string][]int{"cmd/main.go": {1}},
+		},
+		{
+			name: "language-filter",
+			query: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "run"}}},
+				Languages:    []string{"javascript"},
+			},
+			want: map[string][]int{"web/run.js": {1, 2}},
+		},
+		{
+			name: "symbol",
+			query: types.SearchQuery{
+				Alternatives: [][]types.SearchTerm{{{Value: "run", Symbol: true}}},
+			},
+			want: map[string][]int{"cmd/main.go": {3}, "web/run.js": {1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := newMatcher(&test.query)
+			if err != nil {
+				t.Fatalf("failed to create matcher: %s", err)
+			}
+
+			got := matchIndex(m, idx, contents)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
+
+type testFile struct {
+	path    string
+	content []byte
+}
+
+// newTestIndex builds an index of the files and returns it together with the file contents mapped by blob SHA.
+func newTestIndex(files []testFile) (*repoIndex, map[string][]byte) {
+	contents := make(map[string][]byte)
+	builder := newIndexBuilder()
+	for i, f := range files {
+		blobSHA := strconv.Itoa(i + 1)
+		contents[blobSHA] = f.content
+		builder.add(indexedFile{Path: f.path, SHA: blobSHA, Binary: f.content == nil}, contentTrigrams(f.content))
+	}
+
+	return builder.build(1, "repo-uid", "main", "abc"), contents
+}
+
+// matchIndex returns line numbers of all matches of the matcher grouped by file path.
+func matchIndex(m *matcher, idx *repoIndex, contents map[string][]byte) map[string][]int {
+	got := map[string][]int{}
+	for _, fileIdx := range m.candidates(idx) {
+		file := idx.Files[fileIdx]
+		if file.Binary {
+			continue
+		}
+		for _, match := range m.match(file.Path, contents[file.SHA], 100) {
+			got[file.Path] = append(got[file.Path], match.LineNum)
+		}
+	}
+	return got
+}
+
+func TestMatcher_Fragments(t *testing.T) {
+	m, err := newMatcher(&types.SearchQuery{Alternatives: [][]types.SearchTerm{{{Value: "ab"}}}})
+	if err != nil {
+		t.Fatalf("failed to create matcher: %s", err)
+	}
+
+	got := m.match("a.txt", []byte("first\nxxABxxab!\nlast"), 10)
+	want := []types.Match{
+		{
+			LineNum: 2,
+			Fragments: []types.Fragment{
+				{Pre: "xx", Match: "AB"},
+				{Pre: "xx", Match: "ab", Post: "!"},
+			},
+			Before: "first",
+			After:  "last",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%+v got=%+v", want, got)
+	}
+}
+
+func TestMatcher_InvalidRegex(t *testing.T) {
+	_, err := newMatcher(&types.SearchQuery{
+		Alternatives: [][]types.SearchTerm{{{Value: "a(b"}}},
+		EnableRegex:  true,
+	})
+	if err == nil {
+		t.Errorf("expected an error for an invalid regular expression")
+	}
+}
+
+func TestIndexStore_SaveLoad(t *testing.T) {
+	s := &indexStore{root: t.TempDir()}
+
+	if _, err := s.load(1); err != errIndexNotFound {
+		t.Fatalf("expected errIndexNotFound, got: %v", err)
+	}
+
+	idx, _ := newTestIndex([]testFile{
+		{path: "a.txt", content: []byte("some content")},
+	})
+	if err := s.save(idx); err != nil {
+		t.Fatalf("failed to save index: %s", err)
+	}
+
+	loaded, err := s.load(1)
+	if err != nil {
+		t.Fatalf("failed to load index: %s", err)
+	}
+
+	if !reflect.DeepEqual(idx, loaded) {
+		t.Errorf("loaded index differs from the saved one: want=%+v got=%+v", idx, loaded)
+	}
+
+	if err = s.delete(1); err != nil {
+		t.Fatalf("failed to delete index: %s", err)
+	}
+	if _, err = s.load(1); err != errIndexNotFound {
+		t.Errorf("expected errIndexNotFound after delete, got: %v", err)
+	}
+}
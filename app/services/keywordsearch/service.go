@@ -33,6 +33,13 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// IndexRoot is the directory in which the search indexes are stored.
+	IndexRoot string
+	// MaxFileSize is the size (in bytes) above which files are not indexed.
+	MaxFileSize int64
+	// MaxCachedIndexes is the number of indexes kept in memory.
+	MaxCachedIndexes int
 }
 
 func (c *Config) Prepare() error {
@@ -48,6 +55,15 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.IndexRoot == "" {
+		return errors.New("config.IndexRoot is required")
+	}
+	if c.MaxFileSize < 1 {
+		return errors.New("config.MaxFileSize has to be a positive number")
+	}
+	if c.MaxCachedIndexes < 1 {
+		return errors.New("config.MaxCachedIndexes has to be a positive number")
+	}
 	return nil
 }
 
@@ -106,6 +122,7 @@ func NewService(
 				))
 
 			_ = r.RegisterDefaultBranchUpdated((service.handleUpdateDefaultBranch))
+			_ = r.RegisterRepoDeleted(service.handleRepoDeleted)
 			return nil
 		})
 	if err != nil {
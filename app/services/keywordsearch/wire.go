@@ -21,6 +21,7 @@ import (
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
@@ -48,8 +49,12 @@ func ProvideService(ctx context.Context,
 		indexer)
 }
 
-func ProvideLocalIndexSearcher() *LocalIndexSearcher {
-	return NewLocalIndexSearcher()
+func ProvideLocalIndexSearcher(
+	config Config,
+	repoStore store.RepoStore,
+	git git.Interface,
+) *LocalIndexSearcher {
+	return NewLocalIndexSearcher(config, repoStore, git)
 }
 
 func ProvideIndexer(l *LocalIndexSearcher) Indexer {
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type DefineInput struct {
+	Name        string          `json:"name"`
+	Color       enum.LabelColor `json:"color"`
+	Description string          `json:"description"`
+}
+
+// sanitize validates and sanitizes the define label input data.
+func (in *DefineInput) sanitize() error {
+	var err error
+
+	in.Name, err = sanitizeName(in.Name)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	in.Color, ok = in.Color.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Label color is invalid.")
+	}
+
+	if err = check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Define creates a new pull request label in a repository or a space.
+func (s *Service) Define(ctx context.Context,
+	principal *types.Principal,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	parentPath string,
+	in *DefineInput,
+) (*types.Label, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	spaceID, repoID, err := parentIDs(parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	label := &types.Label{
+		SpaceID:     spaceID,
+		RepoID:      repoID,
+		Name:        in.Name,
+		Color:       in.Color,
+		Description: in.Description,
+		CreatedBy:   principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = s.labelStore.Define(ctx, label)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("Label '%s' already exists in the %s.",
+			label.Name, parentName(parentType)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to define %s-level label: %w", parentName(parentType), err)
+	}
+
+	err = s.auditService.Log(ctx,
+		*principal,
+		audit.NewResource(audit.ResourceTypeLabel, label.Name),
+		audit.ActionCreated,
+		parentPath,
+		audit.WithNewObject(label),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for define label operation: %s", err)
+	}
+
+	return label, nil
+}
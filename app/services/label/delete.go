@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Delete deletes a pull request label of a repository or a space.
+// The label gets removed from all pull requests.
+func (s *Service) Delete(ctx context.Context,
+	principal *types.Principal,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	parentPath string,
+	labelID int64,
+) error {
+	label, err := s.find(ctx, parentType, parentID, labelID)
+	if err != nil {
+		return err
+	}
+
+	err = s.labelStore.Delete(ctx, label.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s-level label: %w", parentName(parentType), err)
+	}
+
+	err = s.auditService.Log(ctx,
+		*principal,
+		audit.NewResource(audit.ResourceTypeLabel, label.Name),
+		audit.ActionDeleted,
+		parentPath,
+		audit.WithOldObject(label),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete label operation: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// find returns the label if it's defined directly in the provided space or repository.
+func (s *Service) find(ctx context.Context,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	labelID int64,
+) (*types.Label, error) {
+	spaceID, repoID, err := parentIDs(parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	label, err := s.labelStore.Find(ctx, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s-level label: %w", parentName(parentType), err)
+	}
+
+	if !sameParent(label.SpaceID, spaceID) || !sameParent(label.RepoID, repoID) {
+		return nil, usererror.NotFound(fmt.Sprintf("Label not found in the %s.", parentName(parentType)))
+	}
+
+	return label, nil
+}
+
+// FindAvailable returns the label if it can be assigned to pull requests of the repository,
+// that is if it's defined in the repository or in any of the repository's ancestor spaces.
+func (s *Service) FindAvailable(ctx context.Context, repo *types.Repository, labelID int64) (*types.Label, error) {
+	label, err := s.labelStore.Find(ctx, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	if label.RepoID != nil {
+		if *label.RepoID == repo.ID {
+			return label, nil
+		}
+		return nil, usererror.NotFound("Label isn't available in the repository.")
+	}
+
+	spaceIDs, err := s.ancestorSpaceIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, spaceID := range spaceIDs {
+		if label.SpaceID != nil && *label.SpaceID == spaceID {
+			return label, nil
+		}
+	}
+
+	return nil, usererror.NotFound("Label isn't available in the repository.")
+}
+
+func sameParent(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
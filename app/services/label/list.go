@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List returns pull request labels defined directly in a repository or a space.
+func (s *Service) List(ctx context.Context,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	spaceID, repoID, err := parentIDs(parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := s.labelStore.List(ctx, spaceID, repoID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s-level labels: %w", parentName(parentType), err)
+	}
+
+	return labels, nil
+}
+
+// ListAvailable returns all pull request labels that can be assigned to pull requests of the repository:
+// labels of the repository and labels of all its ancestor spaces.
+func (s *Service) ListAvailable(ctx context.Context,
+	repo *types.Repository,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	spaceIDs, err := s.ancestorSpaceIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := s.labelStore.ListInScopes(ctx, repo.ID, spaceIDs, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels available in the repository: %w", err)
+	}
+
+	return labels, nil
+}
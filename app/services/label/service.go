@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxLabelNameLength = 50
+
+// Service manages pull request labels of repositories and spaces.
+//
+// Labels of a space are available to all repositories of the space and of its sub-spaces.
+// A label named "key:value" is a scoped label: a pull request can have at most one label with the same key,
+// so assigning a scoped label replaces any other label with the same key.
+type Service struct {
+	labelStore   store.LabelStore
+	spaceStore   store.SpaceStore
+	auditService audit.Service
+}
+
+func NewService(
+	labelStore store.LabelStore,
+	spaceStore store.SpaceStore,
+	auditService audit.Service,
+) *Service {
+	return &Service{
+		labelStore:   labelStore,
+		spaceStore:   spaceStore,
+		auditService: auditService,
+	}
+}
+
+// parentIDs returns the space ID and the repository ID of the label's parent.
+func parentIDs(parentType enum.ParentResourceType, parentID int64) (*int64, *int64, error) {
+	switch parentType {
+	case enum.ParentResourceTypeSpace:
+		return &parentID, nil, nil
+	case enum.ParentResourceTypeRepo:
+		return nil, &parentID, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported label parent type %q", parentType)
+	}
+}
+
+// parentName returns a human readable name of the label's parent used in error messages.
+func parentName(parentType enum.ParentResourceType) string {
+	if parentType == enum.ParentResourceTypeSpace {
+		return "space"
+	}
+	return "repository"
+}
+
+// sanitizeName validates and normalizes the label name.
+// Scoped label names must have a non-empty key and value, e.g. "priority:high".
+func sanitizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", usererror.BadRequest("Label name must be provided.")
+	}
+
+	if utf8.RuneCountInString(name) > maxLabelNameLength {
+		return "", usererror.BadRequestf("Label name can have at most %d characters.", maxLabelNameLength)
+	}
+
+	if err := check.ForControlCharacters(name); err != nil {
+		return "", err
+	}
+
+	if key, value, ok := strings.Cut(name, types.LabelScopeSeparator); ok {
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" || value == "" {
+			return "", usererror.BadRequest("Scoped label name must be in the form 'key:value'.")
+		}
+		name = key + types.LabelScopeSeparator + value
+	}
+
+	return name, nil
+}
+
+// ancestorSpaceIDs returns the ID of the space and IDs of all its ancestor spaces.
+func (s *Service) ancestorSpaceIDs(ctx context.Context, spaceID int64) ([]int64, error) {
+	var spaceIDs []int64
+	for spaceID > 0 {
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceIDs = append(spaceIDs, space.ID)
+		spaceID = space.ParentID
+	}
+
+	return spaceIDs, nil
+}
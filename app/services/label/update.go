@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UpdateInput struct {
+	Name        *string          `json:"name"`
+	Color       *enum.LabelColor `json:"color"`
+	Description *string          `json:"description"`
+}
+
+// sanitize validates and sanitizes the update label input data.
+func (in *UpdateInput) sanitize() error {
+	if in.Name != nil {
+		name, err := sanitizeName(*in.Name)
+		if err != nil {
+			return err
+		}
+		in.Name = &name
+	}
+
+	if in.Color != nil {
+		color, ok := in.Color.Sanitize()
+		if !ok {
+			return usererror.BadRequest("Label color is invalid.")
+		}
+		in.Color = &color
+	}
+
+	if in.Description != nil {
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update updates a pull request label of a repository or a space.
+// The label remains assigned to all pull requests it has been assigned to.
+func (s *Service) Update(ctx context.Context,
+	principal *types.Principal,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	parentPath string,
+	labelID int64,
+	in *UpdateInput,
+) (*types.Label, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	label, err := s.find(ctx, parentType, parentID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldLabel := *label
+
+	if in.Name != nil {
+		label.Name = *in.Name
+	}
+	if in.Color != nil {
+		label.Color = *in.Color
+	}
+	if in.Description != nil {
+		label.Description = *in.Description
+	}
+
+	if label.Name == oldLabel.Name && label.Color == oldLabel.Color && label.Description == oldLabel.Description {
+		return label, nil
+	}
+
+	label.Updated = time.Now().UnixMilli()
+
+	err = s.labelStore.Update(ctx, label)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("Label '%s' already exists in the %s.",
+			label.Name, parentName(parentType)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s-level label: %w", parentName(parentType), err)
+	}
+
+	err = s.auditService.Log(ctx,
+		*principal,
+		audit.NewResource(audit.ResourceTypeLabel, label.Name),
+		audit.ActionUpdated,
+		parentPath,
+		audit.WithOldObject(oldLabel),
+		audit.WithNewObject(label),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update label operation: %s", err)
+	}
+
+	return label, nil
+}
@@ -0,0 +1,574 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// actor is the user who added the pull request to the merge queue. The pull request is merged on their behalf.
+type actor struct {
+	session     *auth.Session
+	isRepoOwner bool
+}
+
+// checksStatus holds the first required status check that is still pending
+// and the first one that failed for the speculative merge commit.
+type checksStatus struct {
+	pending string
+	failed  string
+}
+
+// QueueRef returns the git reference that holds the speculative merge commit of the pull request.
+func QueueRef(prNum int64) string {
+	return "refs/pullreq/" + strconv.FormatInt(prNum, 10) + "/queue"
+}
+
+func (s *Service) getActor(ctx context.Context, repo *types.Repository, principalID int64) (*actor, error) {
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	return &actor{session: session, isRepoOwner: isRepoOwner}, nil
+}
+
+func (s *Service) branchSHA(ctx context.Context, repo *types.Repository, branch string) (string, error) {
+	ref, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Name:       branch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch %q: %w", branch, err)
+	}
+
+	return ref.SHA.String(), nil
+}
+
+func (s *Service) sourceRepo(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+) (*types.Repository, error) {
+	if pr.SourceRepoID == repo.ID {
+		return repo, nil
+	}
+
+	sourceRepo, err := s.repoStore.Find(ctx, pr.SourceRepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source repository: %w", err)
+	}
+
+	return sourceRepo, nil
+}
+
+// createSpeculativeMerge merges the pull request on top of the provided base commit
+// and stores the result in the queue reference of the pull request.
+// It returns a non-empty reason if the pull request can't be merged and has to be ejected from the queue.
+func (s *Service) createSpeculativeMerge(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	a *actor,
+	base string,
+) (string, error) {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, s.urlProvider, a.session, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	sourceRepo, err := s.sourceRepo(ctx, repo, pr)
+	if err != nil {
+		return "", err
+	}
+
+	var author *git.Identity
+
+	switch entry.Method {
+	case enum.MergeMethodMerge:
+		author = pullreq.IdentityFromPrincipalInfo(*a.session.Principal.ToPrincipalInfo())
+	case enum.MergeMethodSquash:
+		author = pullreq.IdentityFromPrincipalInfo(pr.Author)
+	case enum.MergeMethodRebase:
+		author = nil // Not important for the rebase merge: the author info in the commits will be preserved.
+	}
+
+	var committer *git.Identity
+
+	switch entry.Method {
+	case enum.MergeMethodMerge, enum.MergeMethodSquash:
+		committer = pullreq.IdentityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+	case enum.MergeMethodRebase:
+		committer = pullreq.IdentityFromPrincipalInfo(*a.session.Principal.ToPrincipalInfo())
+	}
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseBranch:      base,
+		HeadRepoUID:     sourceRepo.GitUID,
+		HeadBranch:      pr.SourceBranch,
+		Title:           entry.Title,
+		Message:         entry.Message,
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		RefType:         gitenum.RefTypeRaw,
+		RefName:         QueueRef(pr.Number),
+		HeadExpectedSHA: sha.Must(entry.SourceSHA),
+		Method:          gitenum.MergeMethod(entry.Method),
+	})
+	if errors.AsStatus(err) == errors.StatusPreconditionFailed {
+		return "New commits were pushed to the source branch.", nil
+	}
+	if errors.AsStatus(err) == errors.StatusInvalidArgument {
+		return errors.Message(err), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("merge execution failed: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		return fmt.Sprintf("Merge conflicts in files: %s.", strings.Join(mergeOutput.ConflictFiles, ", ")), nil
+	}
+
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = base
+	entry.MergeSHA = mergeOutput.MergeSHA.String()
+	entry.Updated = now.UnixMilli()
+
+	err = s.mergeQueueStore.Update(ctx, entry)
+	if errors.IsNotFound(err) {
+		// the pull request was removed from the queue while the speculative merge commit was created.
+		s.deleteQueueRef(ctx, repo, pr.Number)
+		return "", errEntryRemoved
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	s.eventReporter.MergeQueueCommitCreated(ctx, &pullreqevents.MergeQueueCommitCreatedPayload{
+		Base:         eventBase(pr, a.session.Principal.ID),
+		TargetBranch: entry.TargetBranch,
+		SourceSHA:    entry.SourceSHA,
+		BaseSHA:      entry.BaseSHA,
+		MergeSHA:     entry.MergeSHA,
+		Ref:          QueueRef(pr.Number),
+	})
+
+	return "", nil
+}
+
+// evaluateChecks returns the state of the required status checks reported for the speculative merge commit.
+func (s *Service) evaluateChecks(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	a *actor,
+) (checksStatus, error) {
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return checksStatus{}, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Actor:       &a.session.Principal,
+		IsRepoOwner: a.isRepoOwner,
+		Repo:        repo,
+		PullReq:     pr,
+	})
+	if err != nil {
+		return checksStatus{}, fmt.Errorf("failed to fetch required status checks: %w", err)
+	}
+
+	identifiers := make([]string, 0, len(reqChecks.RequiredIdentifiers)+len(reqChecks.BypassableIdentifiers))
+	for identifier := range reqChecks.RequiredIdentifiers {
+		identifiers = append(identifiers, identifier)
+	}
+	if !entry.BypassRules {
+		for identifier := range reqChecks.BypassableIdentifiers {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	sort.Strings(identifiers)
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+	if err != nil {
+		return checksStatus{}, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	statuses := make(map[string]enum.CheckStatus, len(checkResults))
+	for _, result := range checkResults {
+		statuses[result.Identifier] = result.Status
+	}
+
+	var out checksStatus
+	for _, identifier := range identifiers {
+		status, ok := statuses[identifier]
+		switch {
+		case !ok || !status.IsCompleted():
+			if out.pending == "" {
+				out.pending = identifier
+			}
+		case status != enum.CheckStatusSuccess:
+			if out.failed == "" {
+				out.failed = identifier
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// merge moves the target branch to the speculative merge commit of the pull request.
+// It returns false if the pull request was ejected from the queue because it violates the protection rules.
+// The repo level pull request lock is held only while the target branch and the pull request are updated.
+//
+//nolint:funlen // the flow mirrors the merge operation of the pull request API.
+func (s *Service) merge(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	a *actor,
+) (bool, error) {
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	sourceRepo, err := s.sourceRepo(ctx, repo, pr)
+	if err != nil {
+		return false, err
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+	if err != nil {
+		return false, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	// check for error and ignore if it is codeowners file not found else throw error
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return false, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:        &a.session.Principal,
+		AllowBypass:  entry.BypassRules,
+		IsRepoOwner:  a.isRepoOwner,
+		TargetRepo:   repo,
+		SourceRepo:   sourceRepo,
+		PullReq:      pr,
+		Reviewers:    reviewers,
+		Method:       entry.Method,
+		CheckResults: checkResults,
+		CodeOwners:   codeOwnerWithApproval,
+		MergeQueue:   true,
+		Commits:      protection.PullReqCommitsFunc(s.git, s.publicKey, repo, pr),
+
+		BypassJustification: entry.BypassJustification,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		s.eject(ctx, repo, pr, entry, violationsReason(violations))
+		return false, nil
+	}
+
+	deleteSourceBranch, err := s.canDeleteSourceBranch(ctx, repo, sourceRepo, a)
+	if err != nil {
+		return false, err
+	}
+
+	deleteSourceBranch = deleteSourceBranch && ruleOut.DeleteSourceBranch
+
+	// the repo level lock guarantees that the target branch isn't updated by a concurrent pull request merge.
+	unlock, err := s.locker.LockPR(ctx, repo.ID, 0, mergeLockTimeout)
+	if err != nil {
+		return false, err
+	}
+
+	merged, reason, err := s.updateTargetBranch(ctx, repo, pr, entry, a, deleteSourceBranch)
+
+	unlock()
+
+	if err != nil {
+		return false, err
+	}
+
+	if reason != "" {
+		s.eject(ctx, repo, pr, entry, reason)
+		return false, nil
+	}
+
+	pr = merged.pr
+
+	s.deleteQueueRef(ctx, repo, pr.Number)
+
+	pr.ActivitySeq = merged.activitySeqMerge
+	rulesBypassed := protection.IsBypassed(violations)
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod:   entry.Method,
+		MergeSHA:      entry.MergeSHA,
+		TargetSHA:     entry.BaseSHA,
+		SourceSHA:     entry.SourceSHA,
+		RulesBypassed: rulesBypassed,
+	}
+	if rulesBypassed {
+		activityPayload.BypassJustification = entry.BypassJustification
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, a.session.Principal.ID, activityPayload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	pullreq.LogMergeAudit(ctx, s.auditService, a.session.Principal, repo, pr, violations, entry.BypassJustification)
+
+	s.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base:        eventBase(pr, a.session.Principal.ID),
+		MergeMethod: entry.Method,
+		MergeSHA:    entry.MergeSHA,
+		TargetSHA:   entry.BaseSHA,
+		SourceSHA:   entry.SourceSHA,
+	})
+
+	if deleteSourceBranch {
+		sourceWriteParams, err := controller.CreateRPCInternalWriteParams(ctx, s.urlProvider, a.session, sourceRepo)
+		if err != nil {
+			// non-critical error
+			log.Ctx(ctx).Err(err).Msgf("failed to create RPC write params for deleting source branch")
+		} else {
+			pullreq.DeleteSourceBranch(ctx, s.git, s.activityStore, sourceWriteParams,
+				pr, a.session.Principal.ID, entry.SourceSHA, merged.activitySeqBranchDeleted)
+		}
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return true, nil
+}
+
+// mergedPullReq is the pull request merged from the queue,
+// with the activity sequence numbers reserved for the merge and the deletion of the source branch.
+type mergedPullReq struct {
+	pr                       *types.PullReq
+	activitySeqMerge         int64
+	activitySeqBranchDeleted int64
+}
+
+// updateTargetBranch moves the target branch to the speculative merge commit and marks the pull request as merged.
+// It must be called while holding the repo level pull request lock. Because the queue is processed without the lock,
+// the pull request, its merge queue entry and the target branch are reloaded first: It returns errEntryRemoved
+// if the entry was removed meanwhile, errTargetBranchMoved if the target branch was updated outside the queue,
+// or a non-empty reason if the pull request has to be ejected from the queue.
+func (s *Service) updateTargetBranch(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	a *actor,
+	deleteSourceBranch bool,
+) (*mergedPullReq, string, error) {
+	current, err := s.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, "", errEntryRemoved
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+	if current.ID != entry.ID || current.MergeSHA != entry.MergeSHA {
+		return nil, "", errEntryRemoved
+	}
+
+	pr, err = s.pullreqStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if reason := ejectReason(pr, entry); reason != "" {
+		return nil, reason, nil
+	}
+
+	tip, err := s.branchSHA(ctx, repo, entry.TargetBranch)
+	if err != nil {
+		return nil, "", err
+	}
+	if tip != entry.BaseSHA {
+		return nil, "", errTargetBranchMoved
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, s.urlProvider, a.session, repo)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        entry.TargetBranch,
+		NewValue:    sha.Must(entry.MergeSHA),
+		OldValue:    sha.Must(entry.BaseSHA),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to update target branch: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("successfully merged PR from the merge queue")
+
+	mergeBase, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Ref1:       entry.BaseSHA,
+		Ref2:       entry.SourceSHA,
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find merge base of the merged pull request")
+	}
+
+	now := time.Now()
+
+	merged := &mergedPullReq{}
+	merged.pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.State = enum.PullReqStateMerged
+
+		nowMilli := now.UnixMilli()
+		pr.Merged = &nowMilli
+		pr.MergedBy = &a.session.Principal.ID
+		pr.MergeMethod = &entry.Method
+
+		pr.MergeCheckStatus = enum.MergeCheckStatusMergeable
+		pr.SourceSHA = entry.SourceSHA
+		pr.MergeTargetSHA = ptr.String(entry.BaseSHA)
+		if !mergeBase.MergeBaseSHA.IsEmpty() {
+			pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
+		}
+		pr.MergeSHA = ptr.String(entry.MergeSHA)
+		pr.MergeConflicts = nil
+
+		// update sequence for PR activities
+		pr.ActivitySeq++
+		merged.activitySeqMerge = pr.ActivitySeq
+
+		if deleteSourceBranch {
+			pr.ActivitySeq++
+			merged.activitySeqBranchDeleted = pr.ActivitySeq
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	if err = s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return nil, "", fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	return merged, "", nil
+}
+
+// canDeleteSourceBranch returns true if the actor is allowed to delete the source branch of the pull request.
+// The source branch in a fork is deleted only if the actor is allowed to push to the fork.
+func (s *Service) canDeleteSourceBranch(
+	ctx context.Context,
+	repo *types.Repository,
+	sourceRepo *types.Repository,
+	a *actor,
+) (bool, error) {
+	if sourceRepo.ID == repo.ID {
+		return true, nil
+	}
+
+	err := apiauth.CheckRepo(ctx, s.authorizer, a.session, sourceRepo, enum.PermissionRepoPush)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check access to the source repository: %w", err)
+	}
+
+	return true, nil
+}
+
+func eventBase(pr *types.PullReq, principalID int64) pullreqevents.Base {
+	return pullreqevents.Base{
+		PullReqID:    pr.ID,
+		SourceRepoID: pr.SourceRepoID,
+		TargetRepoID: pr.TargetRepoID,
+		Number:       pr.Number,
+		PrincipalID:  principalID,
+	}
+}
+
+// violationsReason returns the messages of all critical rule violations.
+func violationsReason(violations []types.RuleViolations) string {
+	var messages []string
+	for i := range violations {
+		if !violations[i].IsCritical() {
+			continue
+		}
+		for _, violation := range violations[i].Violations {
+			messages = append(messages, violation.Message)
+		}
+	}
+
+	return strings.Join(messages, " ")
+}
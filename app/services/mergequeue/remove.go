@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Remove removes the pull request from the merge queue on behalf of the principal.
+// The pull requests behind it in the queue get new speculative merge commits the next time the queue is processed.
+func (s *Service) Remove(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	principalID int64,
+) error {
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	s.deleteQueueRef(ctx, repo, pr.Number)
+
+	s.WriteActivity(ctx, repo, pr, principalID, &types.PullRequestActivityPayloadMergeQueue{
+		Action:       enum.MergeQueueActionRemoved,
+		TargetBranch: entry.TargetBranch,
+	})
+
+	return nil
+}
+
+// eject removes the pull request from the merge queue because it can't be merged.
+func (s *Service) eject(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	reason string,
+) {
+	log.Ctx(ctx).Info().
+		Int64("repo_id", repo.ID).
+		Int64("pullreq_number", pr.Number).
+		Str("reason", reason).
+		Msg("ejecting pull request from the merge queue")
+
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue entry")
+		return
+	}
+
+	s.deleteQueueRef(ctx, repo, pr.Number)
+
+	s.WriteActivity(ctx, repo, pr, bootstrap.NewSystemServiceSession().Principal.ID,
+		&types.PullRequestActivityPayloadMergeQueue{
+			Action:       enum.MergeQueueActionEjected,
+			TargetBranch: entry.TargetBranch,
+			Reason:       reason,
+		})
+}
+
+// WriteActivity writes the merge queue activity to the pull request and notifies the clients about the change.
+func (s *Service) WriteActivity(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadMergeQueue,
+) {
+	pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to get pull request activity number for merge queue activity")
+		return
+	}
+
+	if _, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request merge queue activity")
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+}
+
+func (s *Service) deleteQueueRef(ctx context.Context, repo *types.Repository, prNum int64) {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, s.urlProvider,
+		bootstrap.NewSystemServiceSession(), repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params for deleting merge queue ref")
+		return
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeRaw,
+		Name:        QueueRef(prNum),
+		NewValue:    sha.None, // when NewValue is empty will delete the ref.
+		OldValue:    sha.None, // we don't care about the old value
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue ref")
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authz"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// jobType is the type of the recurring job that advances all merge queues.
+	// Status checks are reported asynchronously, so the queues are polled for completed checks.
+	jobType        = "gitness:merge-queue"
+	jobCron        = "* * * * *"
+	jobMaxDuration = 5 * time.Minute
+
+	// processTimeout is the max time given to process the merge queue of a single branch.
+	processTimeout = 3 * time.Minute
+
+	// mergeLockTimeout is the max time the repo level pull request lock is held to merge a pull request.
+	mergeLockTimeout = 30 * time.Second
+
+	// jobTypeProcess is the type of the background job that advances the merge queue of a single branch.
+	jobTypeProcess        = "gitness:merge-queue:process"
+	jobMaxDurationProcess = processTimeout + time.Minute
+)
+
+// errEntryRemoved is returned if the merge queue entry was removed while the queue was processed,
+// e.g. because the pull request was removed from the queue by a user.
+var errEntryRemoved = errors.New("merge queue entry was removed")
+
+// errTargetBranchMoved is returned if the target branch was updated outside the merge queue while the queue
+// was processed. The speculative merge commits are recreated on top of the new tip the next time around.
+var errTargetBranchMoved = errors.New("target branch was updated")
+
+type Config struct {
+	// ChecksTimeout is the maximum time the required status checks of a speculative merge commit can be pending.
+	// Pull requests whose checks don't complete in time are ejected from the merge queue.
+	// NOTE: A value of 0 turns off the timeout.
+	ChecksTimeout time.Duration
+}
+
+// Service maintains the merge queues of protected branches.
+//
+// Pull requests in the queue of a branch are merged one after the other. For every pull request
+// a speculative merge commit is created on top of the branch tip and the pull requests ahead in the queue.
+// The speculative merge commit of the first pull request becomes the new tip of the branch
+// once all required status checks reported for it succeeded.
+type Service struct {
+	config            Config
+	mergeQueueStore   store.MergeQueueStore
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	reviewerStore     store.PullReqReviewerStore
+	repoStore         store.RepoStore
+	principalStore    store.PrincipalStore
+	checkStore        store.CheckStore
+	git               git.Interface
+	urlProvider       url.Provider
+	authorizer        authz.Authorizer
+	protectionManager *protection.Manager
+	codeOwners        *codeowners.Service
+	eventReporter     *pullreqevents.Reporter
+	sseStreamer       sse.Streamer
+	locker            *locker.Locker
+	auditService      audit.Service
+	scheduler         *job.Scheduler
+	publicKey         publickey.Service
+}
+
+func NewService(
+	config Config,
+	mergeQueueStore store.MergeQueueStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	git git.Interface,
+	urlProvider url.Provider,
+	authorizer authz.Authorizer,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	eventReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+	locker *locker.Locker,
+	auditService audit.Service,
+	scheduler *job.Scheduler,
+	publicKey publickey.Service,
+) *Service {
+	return &Service{
+		config:            config,
+		mergeQueueStore:   mergeQueueStore,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		reviewerStore:     reviewerStore,
+		repoStore:         repoStore,
+		principalStore:    principalStore,
+		checkStore:        checkStore,
+		git:               git,
+		urlProvider:       urlProvider,
+		authorizer:        authorizer,
+		protectionManager: protectionManager,
+		codeOwners:        codeOwners,
+		eventReporter:     eventReporter,
+		sseStreamer:       sseStreamer,
+		locker:            locker,
+		auditService:      auditService,
+		scheduler:         scheduler,
+		publicKey:         publicKey,
+	}
+}
+
+// Register registers the recurring job that advances all merge queues.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, jobCron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for merge queues: %w", err)
+	}
+
+	return nil
+}
+
+// Handle advances all non-empty merge queues.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	branches, err := s.mergeQueueStore.ListBranches(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list merge queues: %w", err)
+	}
+
+	for _, branch := range branches {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		if err := s.Process(ctx, branch.RepoID, branch.TargetBranch); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", branch.RepoID).
+				Str("branch", branch.TargetBranch).
+				Msg("failed to process merge queue")
+		}
+	}
+
+	return fmt.Sprintf("processed %d merge queues", len(branches)), nil
+}
+
+type processJobInput struct {
+	RepoID       int64  `json:"repo_id"`
+	TargetBranch string `json:"target_branch"`
+}
+
+// processJob is the background job that advances the merge queue of a single branch.
+type processJob struct {
+	service *Service
+}
+
+// Handle advances the merge queue of the branch provided in the job data.
+func (j *processJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	input := processJobInput{}
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal merge queue job input: %w", err)
+	}
+
+	if err := j.service.Process(ctx, input.RepoID, input.TargetBranch); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("processed merge queue of branch %q", input.TargetBranch), nil
+}
+
+// ScheduleProcess schedules a background job that advances the merge queue of the target branch.
+// It's used to start processing a queue right away, without waiting for the recurring job.
+func (s *Service) ScheduleProcess(ctx context.Context, repoID int64, targetBranch string) error {
+	data, err := json.Marshal(processJobInput{
+		RepoID:       repoID,
+		TargetBranch: targetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal merge queue job input: %w", err)
+	}
+
+	return s.scheduler.RunJob(ctx, job.Definition{
+		UID:     fmt.Sprintf("merge-queue-%d-%d", repoID, time.Now().UnixNano()),
+		Type:    jobTypeProcess,
+		Timeout: jobMaxDurationProcess,
+		Data:    string(data),
+	})
+}
+
+// Process advances the merge queue of the target branch: It ejects pull requests that can't be merged anymore,
+// (re)creates outdated speculative merge commits and merges the pull requests at the front of the queue
+// for which all required status checks succeeded.
+func (s *Service) Process(ctx context.Context, repoID int64, targetBranch string) error {
+	// the queue of a branch is processed by one instance at a time. The repo level pull request lock
+	// is held only while the target branch is updated, so the speculative merges don't block merging of pull requests.
+	unlock, err := s.locker.LockMergeQueue(ctx, repoID, targetBranch, processTimeout+30*time.Second)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// the queue should be processed independent of request cancel - start with new, time restricted context.
+	ctx, cancel := context.WithTimeout(contextutil.WithNewValues(context.Background(), ctx), processTimeout)
+	defer cancel()
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	entries, err := s.mergeQueueStore.List(ctx, repoID, targetBranch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return s.process(ctx, repo, targetBranch, entries)
+}
+
+func (s *Service) process(
+	ctx context.Context,
+	repo *types.Repository,
+	targetBranch string,
+	entries []*types.MergeQueueEntry,
+) error {
+	base, err := s.branchSHA(ctx, repo, targetBranch)
+	if err != nil {
+		return err
+	}
+
+	// isFirst is true while all entries ahead of the current one were merged or ejected.
+	isFirst := true
+
+	for _, entry := range entries {
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if reason := ejectReason(pr, entry); reason != "" {
+			s.eject(ctx, repo, pr, entry, reason)
+			continue
+		}
+
+		a, err := s.getActor(ctx, repo, entry.CreatedBy)
+		if err != nil {
+			return err
+		}
+
+		// the speculative merge commit is outdated if the target branch or any entry ahead of it changed.
+		if entry.BaseSHA != base || entry.MergeSHA == "" {
+			reason, err := s.createSpeculativeMerge(ctx, repo, pr, entry, a, base)
+			if errors.Is(err, errEntryRemoved) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to create speculative merge commit for pull request %d: %w", pr.Number, err)
+			}
+
+			if reason != "" {
+				s.eject(ctx, repo, pr, entry, reason)
+				continue
+			}
+		}
+
+		checks, err := s.evaluateChecks(ctx, repo, pr, entry, a)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate status checks of pull request %d: %w", pr.Number, err)
+		}
+
+		if checks.failed != "" {
+			s.eject(ctx, repo, pr, entry, fmt.Sprintf("Required status check %q failed.", checks.failed))
+			continue
+		}
+
+		if checks.pending != "" && checksTimedOut(entry, s.config.ChecksTimeout, time.Now()) {
+			s.eject(ctx, repo, pr, entry, fmt.Sprintf("Required status check %q didn't complete within %s.",
+				checks.pending, s.config.ChecksTimeout))
+			continue
+		}
+
+		// only the first pull request of the queue can be merged, its speculative merge commit is based on the tip.
+		if isFirst && checks.pending == "" {
+			merged, err := s.merge(ctx, repo, pr, entry, a)
+			if errors.Is(err, errEntryRemoved) {
+				continue
+			}
+			if errors.Is(err, errTargetBranchMoved) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to merge pull request %d: %w", pr.Number, err)
+			}
+
+			if !merged {
+				continue
+			}
+		} else {
+			isFirst = false
+		}
+
+		base = entry.MergeSHA
+	}
+
+	return nil
+}
+
+// checksTimedOut returns true if the status checks of the speculative merge commit
+// of the entry didn't complete within the provided timeout. A zero timeout never expires.
+func checksTimedOut(entry *types.MergeQueueEntry, timeout time.Duration, now time.Time) bool {
+	if timeout <= 0 {
+		return false
+	}
+
+	// the entry is updated when its speculative merge commit gets (re)created.
+	return now.Sub(time.UnixMilli(entry.Updated)) > timeout
+}
+
+// ejectReason returns the reason why the pull request can't stay in the merge queue, or empty string if it can.
+func ejectReason(pr *types.PullReq, entry *types.MergeQueueEntry) string {
+	switch {
+	case pr.State != enum.PullReqStateOpen:
+		return "The pull request isn't open anymore."
+	case pr.IsDraft:
+		return "The pull request was converted to a draft."
+	case pr.TargetBranch != entry.TargetBranch:
+		return "The target branch of the pull request was changed."
+	case pr.SourceSHA != entry.SourceSHA:
+		return "New commits were pushed to the source branch."
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,745 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/lock"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestEjectReason(t *testing.T) {
+	entry := &types.MergeQueueEntry{TargetBranch: "main", SourceSHA: "abc"}
+
+	tests := []struct {
+		name  string
+		pr    types.PullReq
+		eject bool
+	}{
+		{
+			name: "unchanged",
+			pr:   types.PullReq{State: enum.PullReqStateOpen, TargetBranch: "main", SourceSHA: "abc"},
+		},
+		{
+			name:  "closed",
+			pr:    types.PullReq{State: enum.PullReqStateClosed, TargetBranch: "main", SourceSHA: "abc"},
+			eject: true,
+		},
+		{
+			name:  "draft",
+			pr:    types.PullReq{State: enum.PullReqStateOpen, IsDraft: true, TargetBranch: "main", SourceSHA: "abc"},
+			eject: true,
+		},
+		{
+			name:  "retargeted",
+			pr:    types.PullReq{State: enum.PullReqStateOpen, TargetBranch: "develop", SourceSHA: "abc"},
+			eject: true,
+		},
+		{
+			name:  "new-commits",
+			pr:    types.PullReq{State: enum.PullReqStateOpen, TargetBranch: "main", SourceSHA: "def"},
+			eject: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ejectReason(&test.pr, entry) != ""; got != test.eject {
+				t.Errorf("want eject=%t, got %t", test.eject, got)
+			}
+		})
+	}
+}
+
+func TestViolationsReason(t *testing.T) {
+	violations := []types.RuleViolations{
+		{
+			Rule:       types.RuleInfo{State: enum.RuleStateActive},
+			Violations: []types.Violation{{Message: "First."}, {Message: "Second."}},
+		},
+		{
+			Rule:       types.RuleInfo{State: enum.RuleStateActive},
+			Bypassed:   true,
+			Violations: []types.Violation{{Message: "Bypassed."}},
+		},
+		{
+			Rule:       types.RuleInfo{State: enum.RuleStateMonitor},
+			Violations: []types.Violation{{Message: "Monitored."}},
+		},
+	}
+
+	if want, got := "First. Second.", violationsReason(violations); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestChecksTimedOut(t *testing.T) {
+	now := time.Now()
+	entry := &types.MergeQueueEntry{Updated: now.Add(-time.Hour).UnixMilli()}
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    bool
+	}{
+		{name: "disabled", timeout: 0, want: false},
+		{name: "within-timeout", timeout: 2 * time.Hour, want: false},
+		{name: "expired", timeout: 30 * time.Minute, want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := checksTimedOut(entry, test.timeout, now); got != test.want {
+				t.Errorf("want timed out=%t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+// testSHA returns a valid commit SHA derived from the provided value.
+func testSHA(value string) string {
+	sum := sha1.Sum([]byte(value)) //nolint:gosec // not used for security
+	return hex.EncodeToString(sum[:])
+}
+
+// speculativeSHA returns the SHA of the speculative merge commit the fake git creates for the branch on the base.
+func speculativeSHA(base, branch string) string {
+	return testSHA("merge:" + base + ":" + branch)
+}
+
+// queueGit is a fake git that creates a deterministic speculative merge commit for every merge.
+type queueGit struct {
+	git.Interface
+	branches  map[string]string
+	conflicts map[string]bool
+	merges    []string // the head branch and the base commit of every created speculative merge commit.
+	heads     map[string]string
+	deleted   []string
+}
+
+func newQueueGit(tip string) *queueGit {
+	return &queueGit{
+		branches:  map[string]string{"main": tip},
+		conflicts: map[string]bool{},
+		heads:     map[string]string{},
+	}
+}
+
+func (g *queueGit) GetRef(_ context.Context, params git.GetRefParams) (git.GetRefResponse, error) {
+	return git.GetRefResponse{SHA: sha.Must(g.branches[params.Name])}, nil
+}
+
+func (g *queueGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	if params.Type != gitenum.RefTypeBranch {
+		if params.NewValue.IsEmpty() {
+			g.deleted = append(g.deleted, params.Name)
+		}
+		return nil
+	}
+
+	if g.branches[params.Name] != params.OldValue.String() {
+		return gitnesserrors.PreconditionFailed("branch %q was updated", params.Name)
+	}
+
+	g.branches[params.Name] = params.NewValue.String()
+
+	return nil
+}
+
+func (g *queueGit) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	g.merges = append(g.merges, params.HeadBranch+"@"+params.BaseBranch)
+
+	if g.conflicts[params.HeadBranch] {
+		return git.MergeOutput{ConflictFiles: []string{"README.md"}}, nil
+	}
+
+	mergeSHA := speculativeSHA(params.BaseBranch, params.HeadBranch)
+	g.heads[mergeSHA] = params.HeadBranch
+
+	return git.MergeOutput{MergeSHA: sha.Must(mergeSHA)}, nil
+}
+
+func (g *queueGit) MergeBase(context.Context, git.MergeBaseParams) (git.MergeBaseOutput, error) {
+	return git.MergeBaseOutput{}, nil
+}
+
+func (g *queueGit) GetTreeNode(context.Context, *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	return nil, gitnesserrors.NotFound("no CODEOWNERS file")
+}
+
+type queueStore struct {
+	store.MergeQueueStore
+	entries []types.MergeQueueEntry
+}
+
+func (s *queueStore) FindByPullReqID(_ context.Context, pullReqID int64) (*types.MergeQueueEntry, error) {
+	for _, entry := range s.entries {
+		if entry.PullReqID == pullReqID {
+			return &entry, nil
+		}
+	}
+	return nil, gitnessstore.ErrResourceNotFound
+}
+
+func (s *queueStore) Update(_ context.Context, entry *types.MergeQueueEntry) error {
+	for i := range s.entries {
+		if s.entries[i].ID == entry.ID {
+			s.entries[i] = *entry
+			return nil
+		}
+	}
+	return gitnesserrors.NotFound("Merge queue entry not found")
+}
+
+func (s *queueStore) Delete(_ context.Context, id int64) error {
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			return nil
+		}
+	}
+	return gitnesserrors.NotFound("Merge queue entry not found")
+}
+
+func (s *queueStore) List(context.Context, int64, string) ([]*types.MergeQueueEntry, error) {
+	entries := make([]*types.MergeQueueEntry, len(s.entries))
+	for i := range s.entries {
+		entry := s.entries[i]
+		entries[i] = &entry
+	}
+	return entries, nil
+}
+
+func (s *queueStore) pullReqIDs() []int64 {
+	ids := make([]int64, len(s.entries))
+	for i := range s.entries {
+		ids[i] = s.entries[i].PullReqID
+	}
+	return ids
+}
+
+type queuePullReqStore struct {
+	store.PullReqStore
+	pullReqs map[int64]types.PullReq
+}
+
+func (s *queuePullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.pullReqs[id]
+	if !ok {
+		return nil, gitnessstore.ErrResourceNotFound
+	}
+	return &pr, nil
+}
+
+func (s *queuePullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	current := s.pullReqs[pr.ID]
+	if err := mutateFn(&current); err != nil {
+		return nil, err
+	}
+	current.Version++
+	s.pullReqs[pr.ID] = current
+	return &current, nil
+}
+
+func (s *queuePullReqStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	return s.UpdateOptLock(context.Background(), pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+		return nil
+	})
+}
+
+type queueActivityStore struct {
+	store.PullReqActivityStore
+	payloads map[int64][]types.PullReqActivityPayload
+}
+
+func (s *queueActivityStore) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+) (*types.PullReqActivity, error) {
+	s.payloads[pr.ID] = append(s.payloads[pr.ID], payload)
+	return &types.PullReqActivity{}, nil
+}
+
+// ejectReasons returns the reasons for which the pull request was ejected from the merge queue.
+func (s *queueActivityStore) ejectReasons(pullReqID int64) []string {
+	var reasons []string
+	for _, payload := range s.payloads[pullReqID] {
+		p, ok := payload.(*types.PullRequestActivityPayloadMergeQueue)
+		if ok && p.Action == enum.MergeQueueActionEjected {
+			reasons = append(reasons, p.Reason)
+		}
+	}
+	return reasons
+}
+
+type queueReviewerStore struct {
+	store.PullReqReviewerStore
+}
+
+func (queueReviewerStore) List(context.Context, int64) ([]*types.PullReqReviewer, error) {
+	return nil, nil
+}
+
+type queuePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (queuePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id, UID: "user", Email: "user@example.com", Type: enum.PrincipalTypeUser}, nil
+}
+
+func (queuePrincipalStore) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: 100, UID: uid, Email: "system@example.com", Admin: true}, nil
+}
+
+// queueCheckStore reports the status of the "ci" check of the speculative merge commits by their source branch.
+type queueCheckStore struct {
+	store.CheckStore
+	git      *queueGit
+	statuses map[string]enum.CheckStatus
+}
+
+func (s *queueCheckStore) ListResults(_ context.Context, _ int64, commitSHA string) ([]types.CheckResult, error) {
+	status, ok := s.statuses[s.git.heads[commitSHA]]
+	if !ok {
+		return nil, nil
+	}
+	return []types.CheckResult{{Identifier: "ci", Status: status}}, nil
+}
+
+// requireCI is the rule store with a single rule that requires the "ci" status check.
+type requireCI struct {
+	store.RuleStore
+}
+
+func (requireCI) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	definition, _ := json.Marshal(protection.Branch{
+		PullReq: protection.DefPullReq{
+			StatusChecks: protection.DefStatusChecks{RequireIdentifiers: []string{"ci"}},
+		},
+	})
+	return []types.RuleInfoInternal{{
+		RuleInfo:   types.RuleInfo{ID: 1, Identifier: "ci", Type: protection.TypeBranch, State: enum.RuleStateActive},
+		Pattern:    json.RawMessage("{}"),
+		Definition: definition,
+	}}, nil
+}
+
+type allowAll struct {
+	authz.Authorizer
+}
+
+func (allowAll) Check(context.Context, *auth.Session, *types.Scope, *types.Resource, enum.Permission) (bool, error) {
+	return true, nil
+}
+
+type noopStreamer struct {
+	sse.Streamer
+}
+
+func (noopStreamer) Publish(context.Context, int64, enum.SSEType, any) error { return nil }
+
+type internalURL struct {
+	url.Provider
+}
+
+func (internalURL) GetInternalAPIURL() string { return "http://localhost/api" }
+
+// queueTest holds the merge queue service with fake dependencies and the merge queue of the "main" branch.
+type queueTest struct {
+	service    *Service
+	repo       *types.Repository
+	git        *queueGit
+	queue      *queueStore
+	pullReqs   *queuePullReqStore
+	activities *queueActivityStore
+	checks     *queueCheckStore
+}
+
+// newQueueTest creates a merge queue with the provided number of pull requests, the first one is at the front.
+// The pull request N has ID and number N and its source branch is "feature-N".
+func newQueueTest(t *testing.T, tip string, count int) *queueTest {
+	ctx := context.Background()
+
+	if err := bootstrap.SystemService(ctx, &types.Config{},
+		service.NewController(nil, nil, queuePrincipalStore{})); err != nil {
+		t.Fatalf("failed to set up the system service: %s", err.Error())
+	}
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %s", err.Error())
+	}
+
+	eventReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create events reporter: %s", err.Error())
+	}
+
+	protectionManager, err := protection.ProvideManager(requireCI{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %s", err.Error())
+	}
+
+	test := &queueTest{
+		repo:       &types.Repository{ID: 1, ParentID: 1, Path: "space/repo", GitUID: "repo", DefaultBranch: "main"},
+		git:        newQueueGit(tip),
+		queue:      &queueStore{},
+		pullReqs:   &queuePullReqStore{pullReqs: map[int64]types.PullReq{}},
+		activities: &queueActivityStore{payloads: map[int64][]types.PullReqActivityPayload{}},
+	}
+	test.checks = &queueCheckStore{git: test.git, statuses: map[string]enum.CheckStatus{}}
+
+	for i := int64(1); i <= int64(count); i++ {
+		sourceSHA := testSHA(fmt.Sprintf("source-%d", i))
+		test.pullReqs.pullReqs[i] = types.PullReq{
+			ID:           i,
+			Number:       i,
+			State:        enum.PullReqStateOpen,
+			SourceRepoID: test.repo.ID,
+			SourceBranch: fmt.Sprintf("feature-%d", i),
+			SourceSHA:    sourceSHA,
+			TargetRepoID: test.repo.ID,
+			TargetBranch: "main",
+		}
+		test.queue.entries = append(test.queue.entries, types.MergeQueueEntry{
+			ID:            i,
+			RepoID:        test.repo.ID,
+			PullReqID:     i,
+			PullReqNumber: i,
+			TargetBranch:  "main",
+			State:         enum.MergeQueueEntryStateQueued,
+			Method:        enum.MergeMethodMerge,
+			SourceSHA:     sourceSHA,
+			CreatedBy:     1,
+		})
+	}
+
+	test.service = NewService(
+		Config{},
+		test.queue,
+		test.pullReqs,
+		test.activities,
+		queueReviewerStore{},
+		nil,
+		queuePrincipalStore{},
+		test.checks,
+		test.git,
+		internalURL{},
+		allowAll{},
+		protectionManager,
+		codeowners.New(nil, test.git, codeowners.Config{}, nil, nil),
+		eventReporter,
+		noopStreamer{},
+		locker.NewLocker(lock.NewInMemory(lock.Config{Expiry: time.Minute, Tries: 1})),
+		audit.New(),
+		nil,
+		nil,
+	)
+
+	return test
+}
+
+func (test *queueTest) process(t *testing.T) {
+	entries, _ := test.queue.List(context.Background(), test.repo.ID, "main")
+	if err := test.service.process(context.Background(), test.repo, "main", entries); err != nil {
+		t.Fatalf("failed to process the merge queue: %s", err.Error())
+	}
+}
+
+func (test *queueTest) entry(t *testing.T, pullReqID int64) *types.MergeQueueEntry {
+	entry, err := test.queue.FindByPullReqID(context.Background(), pullReqID)
+	if err != nil {
+		t.Fatalf("pull request %d isn't in the merge queue", pullReqID)
+	}
+	return entry
+}
+
+func TestService_Process_Order(t *testing.T) {
+	tip := testSHA("tip")
+	test := newQueueTest(t, tip, 3)
+
+	test.checks.statuses["feature-1"] = enum.CheckStatusSuccess
+	test.checks.statuses["feature-2"] = enum.CheckStatusPending
+	test.checks.statuses["feature-3"] = enum.CheckStatusSuccess
+
+	test.process(t)
+
+	// every speculative merge commit is created on top of the one of the pull request ahead in the queue.
+	merge1 := speculativeSHA(tip, "feature-1")
+	merge2 := speculativeSHA(merge1, "feature-2")
+	wantMerges := []string{"feature-1@" + tip, "feature-2@" + merge1, "feature-3@" + merge2}
+	if !reflect.DeepEqual(test.git.merges, wantMerges) {
+		t.Errorf("speculative merges: want=%v got=%v", wantMerges, test.git.merges)
+	}
+
+	// only the front of the queue is merged, the third pull request waits for the second one.
+	if want, got := merge1, test.git.branches["main"]; want != got {
+		t.Errorf("target branch: want=%s got=%s", want, got)
+	}
+	if want, got := []int64{2, 3}, test.queue.pullReqIDs(); !reflect.DeepEqual(want, got) {
+		t.Errorf("queue: want=%v got=%v", want, got)
+	}
+	if want, got := merge2, test.entry(t, 3).BaseSHA; want != got {
+		t.Errorf("base of the third pull request: want=%s got=%s", want, got)
+	}
+
+	// once the checks of the second pull request succeed, both remaining pull requests are merged in order.
+	test.checks.statuses["feature-2"] = enum.CheckStatusSuccess
+	test.git.merges = nil
+
+	test.process(t)
+
+	if len(test.git.merges) != 0 {
+		t.Errorf("expected the up to date speculative merge commits to be reused, got %v", test.git.merges)
+	}
+	if want, got := speculativeSHA(merge2, "feature-3"), test.git.branches["main"]; want != got {
+		t.Errorf("target branch: want=%s got=%s", want, got)
+	}
+	if len(test.queue.entries) != 0 {
+		t.Errorf("expected the queue to be empty, got %v", test.queue.pullReqIDs())
+	}
+}
+
+func TestService_Process_EjectionRecomputesBase(t *testing.T) {
+	tip := testSHA("tip")
+	test := newQueueTest(t, tip, 3)
+
+	// all pull requests already have speculative merge commits with pending checks.
+	base := tip
+	for i := range test.queue.entries {
+		entry := &test.queue.entries[i]
+		entry.State = enum.MergeQueueEntryStateChecking
+		entry.BaseSHA = base
+		entry.MergeSHA = speculativeSHA(base, fmt.Sprintf("feature-%d", entry.PullReqID))
+		entry.Updated = time.Now().UnixMilli()
+		base = entry.MergeSHA
+	}
+
+	// new commits pushed to the second pull request eject it from the queue.
+	pr2 := test.pullReqs.pullReqs[2]
+	pr2.SourceSHA = testSHA("source-2-updated")
+	test.pullReqs.pullReqs[2] = pr2
+
+	test.process(t)
+
+	if want, got := []int64{1, 3}, test.queue.pullReqIDs(); !reflect.DeepEqual(want, got) {
+		t.Errorf("queue: want=%v got=%v", want, got)
+	}
+	if reasons := test.activities.ejectReasons(2); len(reasons) != 1 {
+		t.Errorf("expected the second pull request to be ejected once, got %v", reasons)
+	}
+	if want, got := []string{QueueRef(2)}, test.git.deleted; !reflect.DeepEqual(want, got) {
+		t.Errorf("deleted refs: want=%v got=%v", want, got)
+	}
+
+	// the third pull request skips the ejected one: its speculative merge commit is recreated on top of the first.
+	merge1 := speculativeSHA(tip, "feature-1")
+	if want, got := []string{"feature-3@" + merge1}, test.git.merges; !reflect.DeepEqual(want, got) {
+		t.Errorf("speculative merges: want=%v got=%v", want, got)
+	}
+	entry3 := test.entry(t, 3)
+	if want, got := merge1, entry3.BaseSHA; want != got {
+		t.Errorf("base of the third pull request: want=%s got=%s", want, got)
+	}
+	if want, got := speculativeSHA(merge1, "feature-3"), entry3.MergeSHA; want != got {
+		t.Errorf("speculative merge of the third pull request: want=%s got=%s", want, got)
+	}
+}
+
+func TestService_Process_Transitions(t *testing.T) {
+	tip := testSHA("tip")
+
+	tests := []struct {
+		name      string
+		setup     func(test *queueTest)
+		expMerged bool
+		expReason string
+	}{
+		{
+			name: "merged",
+			setup: func(test *queueTest) {
+				test.checks.statuses["feature-1"] = enum.CheckStatusSuccess
+			},
+			expMerged: true,
+		},
+		{
+			name: "check-failed",
+			setup: func(test *queueTest) {
+				test.checks.statuses["feature-1"] = enum.CheckStatusFailure
+			},
+			expReason: `Required status check "ci" failed.`,
+		},
+		{
+			name: "conflict",
+			setup: func(test *queueTest) {
+				test.git.conflicts["feature-1"] = true
+			},
+			expReason: "Merge conflicts in files: README.md.",
+		},
+		{
+			name: "closed",
+			setup: func(test *queueTest) {
+				pr := test.pullReqs.pullReqs[1]
+				pr.State = enum.PullReqStateClosed
+				test.pullReqs.pullReqs[1] = pr
+			},
+			expReason: "The pull request isn't open anymore.",
+		},
+		{
+			name: "checks-timeout",
+			setup: func(test *queueTest) {
+				test.service.config.ChecksTimeout = time.Minute
+				entry := &test.queue.entries[0]
+				entry.BaseSHA = tip
+				entry.MergeSHA = speculativeSHA(tip, "feature-1")
+				entry.Updated = time.Now().Add(-time.Hour).UnixMilli()
+			},
+			expReason: `Required status check "ci" didn't complete within 1m0s.`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			qt := newQueueTest(t, tip, 1)
+			test.setup(qt)
+
+			qt.process(t)
+
+			pr := qt.pullReqs.pullReqs[1]
+
+			if len(qt.queue.entries) != 0 {
+				t.Errorf("expected the pull request to leave the queue")
+			}
+
+			if merged := pr.State == enum.PullReqStateMerged; merged != test.expMerged {
+				t.Errorf("want merged=%t, got state %s", test.expMerged, pr.State)
+			}
+
+			wantTip := tip
+			if test.expMerged {
+				wantTip = speculativeSHA(tip, "feature-1")
+				if pr.MergeSHA == nil || *pr.MergeSHA != wantTip {
+					t.Errorf("expected the merge SHA of the pull request to be %s", wantTip)
+				}
+			}
+			if got := qt.git.branches["main"]; got != wantTip {
+				t.Errorf("target branch: want=%s got=%s", wantTip, got)
+			}
+
+			var wantReasons []string
+			if test.expReason != "" {
+				wantReasons = []string{test.expReason}
+			}
+			if got := qt.activities.ejectReasons(1); !reflect.DeepEqual(wantReasons, got) {
+				t.Errorf("eject reasons: want=%v got=%v", wantReasons, got)
+			}
+		})
+	}
+}
+
+func TestService_Merge_Stale(t *testing.T) {
+	tip := testSHA("tip")
+
+	tests := []struct {
+		name   string
+		setup  func(test *queueTest)
+		expErr error
+	}{
+		{
+			name: "target-branch-moved",
+			setup: func(test *queueTest) {
+				test.git.branches["main"] = testSHA("pushed")
+			},
+			expErr: errTargetBranchMoved,
+		},
+		{
+			name: "entry-removed",
+			setup: func(test *queueTest) {
+				test.queue.entries = nil
+			},
+			expErr: errEntryRemoved,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			qt := newQueueTest(t, tip, 1)
+			qt.checks.statuses["feature-1"] = enum.CheckStatusSuccess
+
+			entry := qt.entry(t, 1)
+			entry.BaseSHA = tip
+			entry.MergeSHA = speculativeSHA(tip, "feature-1")
+			_ = qt.queue.Update(context.Background(), entry)
+			qt.git.heads[entry.MergeSHA] = "feature-1"
+
+			test.setup(qt)
+
+			ctx := context.Background()
+			pr, _ := qt.pullReqs.Find(ctx, 1)
+			a, err := qt.service.getActor(ctx, qt.repo, entry.CreatedBy)
+			if err != nil {
+				t.Fatalf("failed to get actor: %s", err.Error())
+			}
+
+			merged, err := qt.service.merge(ctx, qt.repo, pr, entry, a)
+			if !errors.Is(err, test.expErr) {
+				t.Errorf("want error %v, got %v", test.expErr, err)
+			}
+			if merged || qt.pullReqs.pullReqs[1].State != enum.PullReqStateOpen {
+				t.Errorf("expected the pull request not to be merged")
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config Config,
+	mergeQueueStore store.MergeQueueStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	git git.Interface,
+	urlProvider url.Provider,
+	authorizer authz.Authorizer,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	eventReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+	locker *locker.Locker,
+	auditService audit.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	publicKey publickey.Service,
+) (*Service, error) {
+	service := NewService(config, mergeQueueStore, pullreqStore, activityStore, reviewerStore, repoStore,
+		principalStore, checkStore, git, urlProvider, authorizer, protectionManager, codeOwners, eventReporter, sseStreamer,
+		locker, auditService, scheduler, publicKey)
+
+	if err := executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	if err := executor.Register(jobTypeProcess, &processJob{service: service}); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/types"
 )
@@ -32,6 +33,7 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateWebhookDisabled      = "webhook_disabled.html"
 )
 
 type MailClient struct {
@@ -169,6 +171,29 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendWebhookDisabled(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *WebhookDisabledPayload,
+) error {
+	body, err := GetHTMLBody(TemplateWebhookDisabled, payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to generate mail requests after processing %s event: %w",
+			webhookevents.DisabledEvent,
+			err,
+		)
+	}
+
+	var email mailer.Payload
+	email.Body = string(body)
+	email.Subject = fmt.Sprintf(subjectWebhookDisabled, payload.ParentPath, payload.Webhook.Identifier)
+	email.RepoRef = payload.ParentPath
+	email.ToRecipients = RetrieveEmailsFromPrincipals(recipients)
+
+	return m.Mailer.Send(ctx, email)
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
@@ -23,6 +23,7 @@ import (
 	"path"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
@@ -34,6 +35,8 @@ const (
 	eventReaderGroupName = "gitness:notification"
 	templatesDir         = "templates"
 	subjectPullReqEvent  = "[%s] %s (PR #%d)"
+
+	subjectWebhookDisabled = "[%s] Webhook %s was disabled"
 )
 
 var (
@@ -88,6 +91,7 @@ type Service struct {
 	config                Config
 	notificationClient    Client
 	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
+	webhookReaderFactory  *events.ReaderFactory[*webhookevents.Reader]
 	pullReqStore          store.PullReqStore
 	repoStore             store.RepoStore
 	principalInfoView     store.PrincipalInfoView
@@ -95,6 +99,7 @@ type Service struct {
 	pullReqReviewersStore store.PullReqReviewerStore
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
+	webhookStore          store.WebhookStore
 	urlProvider           url.Provider
 }
 
@@ -103,6 +108,7 @@ func NewService(
 	config Config,
 	notificationClient Client,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	webhookReaderFactory *events.ReaderFactory[*webhookevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -110,12 +116,14 @@ func NewService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	webhookStore store.WebhookStore,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
 		notificationClient:    notificationClient,
 		prReaderFactory:       prReaderFactory,
+		webhookReaderFactory:  webhookReaderFactory,
 		pullReqStore:          pullReqStore,
 		repoStore:             repoStore,
 		principalInfoView:     principalInfoView,
@@ -123,6 +131,7 @@ func NewService(
 		pullReqReviewersStore: pullReqReviewersStore,
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
+		webhookStore:          webhookStore,
 		urlProvider:           urlProvider,
 	}
 
@@ -153,6 +162,25 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = service.webhookReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *webhookevents.Reader,
+		) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterDisabled(service.notifyWebhookDisabled)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch webhook event reader for %s: %w", eventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type WebhookDisabledPayload struct {
+	Webhook    *types.Webhook
+	ParentPath string
+	ParentURL  string
+	Reason     string
+}
+
+func (s *Service) notifyWebhookDisabled(
+	ctx context.Context,
+	event *events.Event[*webhookevents.DisabledPayload],
+) error {
+	payload, recipients, err := s.processWebhookDisabledEvent(ctx, event.Payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process %s event for webhookID %d: %w",
+			webhookevents.DisabledEvent,
+			event.Payload.WebhookID,
+			err,
+		)
+	}
+
+	err = s.notificationClient.SendWebhookDisabled(ctx, recipients, payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send email for event %s for webhookID %d: %w",
+			webhookevents.DisabledEvent,
+			event.Payload.WebhookID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) processWebhookDisabledEvent(
+	ctx context.Context,
+	disabled *webhookevents.DisabledPayload,
+) (*WebhookDisabledPayload, []*types.PrincipalInfo, error) {
+	webhook, err := s.webhookStore.Find(ctx, disabled.WebhookID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch webhook from webhookStore: %w", err)
+	}
+
+	owner, err := s.principalInfoCache.Get(ctx, webhook.CreatedBy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch webhook owner %d from principalInfoCache: %w",
+			webhook.CreatedBy, err)
+	}
+
+	payload := &WebhookDisabledPayload{
+		Webhook: webhook,
+		Reason:  disabled.Reason,
+	}
+
+	switch webhook.ParentType {
+	case enum.WebhookParentRepo:
+		repo, err := s.repoStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch repo from repoStore: %w", err)
+		}
+		payload.ParentPath = repo.Path
+		payload.ParentURL = s.urlProvider.GenerateUIRepoURL(repo.Path)
+	case enum.WebhookParentSpace:
+		spacePath, err := s.spacePathStore.FindPrimaryBySpaceID(ctx, webhook.ParentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch space path from spacePathStore: %w", err)
+		}
+		payload.ParentPath = spacePath.Value
+	}
+
+	return payload, []*types.PrincipalInfo{owner}, nil
+}
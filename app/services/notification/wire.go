@@ -18,6 +18,7 @@ import (
 	"context"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -36,6 +37,7 @@ func ProvideNotificationService(
 	notificationClient Client,
 	pullReqConfig Config,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	webhookReaderFactory *events.ReaderFactory[*webhookevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -43,6 +45,7 @@ func ProvideNotificationService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	webhookStore store.WebhookStore,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	return NewService(
@@ -50,6 +53,7 @@ func ProvideNotificationService(
 		pullReqConfig,
 		notificationClient,
 		prReaderFactory,
+		webhookReaderFactory,
 		pullReqStore,
 		repoStore,
 		principalInfoView,
@@ -57,6 +61,7 @@ func ProvideNotificationService(
 		pullReqReviewersStore,
 		pullReqActivityStore,
 		spacePathStore,
+		webhookStore,
 		urlProvider,
 	)
 }
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LogBypassAudit records in the audit log that protection rules were bypassed to change the provided refs.
+// Nothing is recorded if none of the violations were bypassed.
+func LogBypassAudit(
+	ctx context.Context,
+	auditService audit.Service,
+	principal types.Principal,
+	repo *types.Repository,
+	refNames []string,
+	violations []types.RuleViolations,
+	bypassJustification string,
+) {
+	if !IsBypassed(violations) {
+		return
+	}
+
+	err := auditService.Log(ctx,
+		principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionBypassed,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(violations),
+		audit.WithData(
+			audit.DataKeyRepoName, repo.Identifier,
+			audit.DataKeyRef, strings.Join(refNames, ","),
+			audit.DataKeyBypassJustification, bypassJustification,
+		),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for bypassed protection rules: %s", err)
+	}
+}
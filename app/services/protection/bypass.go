@@ -15,22 +15,120 @@
 package protection
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"golang.org/x/exp/slices"
 )
 
-type DefBypass struct {
-	UserIDs    []int64 `json:"user_ids,omitempty"`
-	RepoOwners bool    `json:"repo_owners,omitempty"`
+type (
+	DefBypass struct {
+		UserIDs    []int64 `json:"user_ids,omitempty"`
+		RepoOwners bool    `json:"repo_owners,omitempty"`
+		// UserGroupIdentifiers contains the scoped identifiers of the user groups whose members can bypass the rule.
+		UserGroupIdentifiers []string `json:"user_group_identifiers,omitempty"`
+		// SpaceRoles contains the membership roles that allow bypassing of the rule.
+		// The role can be assigned in the space of the repository or in any of its parent spaces.
+		SpaceRoles []enum.MembershipRole `json:"space_roles,omitempty"`
+		// RequireJustification requires a justification to be provided for the rule to be bypassed.
+		// It's opt-in per rule, rules without it can be bypassed without a justification.
+		// A justification consisting only of whitespace is treated as missing.
+		RequireJustification bool `json:"require_justification,omitempty"`
+	}
+
+	// BypassResolver resolves the user group memberships and the space roles of an actor.
+	BypassResolver interface {
+		// UserGroupExists returns true if the user group can be resolved.
+		UserGroupExists(ctx context.Context, userGroupIdentifier string) (bool, error)
+
+		// IsUserGroupMember returns true if the actor is a member of the user group.
+		IsUserGroupMember(ctx context.Context, actor *types.Principal, userGroupIdentifier string) (bool, error)
+
+		// HasSpaceRole returns true if the actor has any of the roles in the space of the repository
+		// or in any of its parent spaces.
+		HasSpaceRole(
+			ctx context.Context,
+			actor *types.Principal,
+			repo *types.Repository,
+			roles []enum.MembershipRole,
+		) (bool, error)
+	}
+
+	// bypassInput contains the information about the actor required to evaluate the bypass list.
+	bypassInput struct {
+		actor         *types.Principal
+		isRepoOwner   bool
+		repo          *types.Repository
+		allowBypass   bool
+		justification string
+		resolver      BypassResolver
+	}
+)
+
+func (v DefBypass) matches(ctx context.Context, in bypassInput) (bool, error) {
+	if in.actor == nil {
+		return false, nil
+	}
+
+	if v.RepoOwners && in.isRepoOwner || slices.Contains(v.UserIDs, in.actor.ID) {
+		return true, nil
+	}
+
+	if in.resolver == nil {
+		return false, nil
+	}
+
+	for _, identifier := range v.UserGroupIdentifiers {
+		isMember, err := in.resolver.IsUserGroupMember(ctx, in.actor, identifier)
+		if err != nil {
+			return false, fmt.Errorf("failed to check membership of user group %q: %w", identifier, err)
+		}
+
+		if isMember {
+			return true, nil
+		}
+	}
+
+	if len(v.SpaceRoles) > 0 && in.repo != nil {
+		hasRole, err := in.resolver.HasSpaceRole(ctx, in.actor, in.repo, v.SpaceRoles)
+		if err != nil {
+			return false, fmt.Errorf("failed to check space roles: %w", err)
+		}
+
+		if hasRole {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
-func (v DefBypass) matches(actor *types.Principal, isRepoOwner bool) bool {
-	return actor != nil &&
-		(v.RepoOwners && isRepoOwner ||
-			slices.Contains(v.UserIDs, actor.ID))
+// apply marks the violations as bypassable if the actor is in the bypass list,
+// and as bypassed if bypassing is requested and the justification, if required, is provided.
+func (v DefBypass) apply(ctx context.Context, in bypassInput, violations []types.RuleViolations) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	bypassable, err := v.matches(ctx, in)
+	if err != nil {
+		return err
+	}
+
+	hasJustification := strings.TrimSpace(in.justification) != ""
+	bypassed := in.allowBypass && bypassable && (!v.RequireJustification || hasJustification)
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+		violations[i].BypassJustificationRequired = bypassable && v.RequireJustification
+	}
+
+	return nil
 }
 
 func (v DefBypass) Sanitize() error {
@@ -38,5 +136,15 @@ func (v DefBypass) Sanitize() error {
 		return fmt.Errorf("user IDs error: %w", err)
 	}
 
+	if err := validateIdentifierSlice(v.UserGroupIdentifiers); err != nil {
+		return fmt.Errorf("user group identifiers error: %w", err)
+	}
+
+	for _, role := range v.SpaceRoles {
+		if _, ok := role.Sanitize(); !ok {
+			return errors.New("space roles error: invalid membership role")
+		}
+	}
+
 	return nil
 }
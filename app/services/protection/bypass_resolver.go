@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+var _ BypassResolver = (*bypassResolver)(nil)
+
+type bypassResolver struct {
+	membershipStore   store.MembershipStore
+	userGroupResolver usergroup.Resolver
+}
+
+func NewBypassResolver(
+	membershipStore store.MembershipStore,
+	userGroupResolver usergroup.Resolver,
+) BypassResolver {
+	return &bypassResolver{
+		membershipStore:   membershipStore,
+		userGroupResolver: userGroupResolver,
+	}
+}
+
+func (r *bypassResolver) UserGroupExists(ctx context.Context, userGroupIdentifier string) (bool, error) {
+	_, err := r.userGroupResolver.Resolve(ctx, userGroupIdentifier)
+	if errors.Is(err, usergroup.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve user group: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *bypassResolver) IsUserGroupMember(
+	ctx context.Context,
+	actor *types.Principal,
+	userGroupIdentifier string,
+) (bool, error) {
+	userGroup, err := r.userGroupResolver.Resolve(ctx, userGroupIdentifier)
+	if errors.Is(err, usergroup.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve user group: %w", err)
+	}
+
+	return slices.Contains(userGroup.Users, actor.UID), nil
+}
+
+func (r *bypassResolver) HasSpaceRole(
+	ctx context.Context,
+	actor *types.Principal,
+	repo *types.Repository,
+	roles []enum.MembershipRole,
+) (bool, error) {
+	hasRole, err := r.membershipStore.HasRoleInSpaceHierarchy(ctx, repo.ParentID, actor.ID, roles)
+	if err != nil {
+		return false, fmt.Errorf("failed to check membership roles in space hierarchy: %w", err)
+	}
+
+	return hasRole, nil
+}
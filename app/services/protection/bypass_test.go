@@ -15,14 +15,51 @@
 package protection
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
 )
 
+type fakeBypassResolver struct {
+	groups map[string][]int64
+	roles  map[int64]enum.MembershipRole
+}
+
+func (r fakeBypassResolver) UserGroupExists(_ context.Context, userGroupIdentifier string) (bool, error) {
+	_, ok := r.groups[userGroupIdentifier]
+	return ok, nil
+}
+
+func (r fakeBypassResolver) IsUserGroupMember(
+	_ context.Context,
+	actor *types.Principal,
+	userGroupIdentifier string,
+) (bool, error) {
+	return slices.Contains(r.groups[userGroupIdentifier], actor.ID), nil
+}
+
+func (r fakeBypassResolver) HasSpaceRole(
+	_ context.Context,
+	actor *types.Principal,
+	_ *types.Repository,
+	roles []enum.MembershipRole,
+) (bool, error) {
+	role, ok := r.roles[actor.ID]
+	return ok && slices.Contains(roles, role), nil
+}
+
 func TestBranch_matches(t *testing.T) {
 	user := &types.Principal{ID: 42}
 	admin := &types.Principal{ID: 66, Admin: true}
+	resolver := fakeBypassResolver{
+		groups: map[string][]int64{"devs": {1, 42}, "ops": {66}},
+		roles:  map[int64]enum.MembershipRole{42: enum.MembershipRoleContributor},
+	}
 
 	tests := []struct {
 		name   string
@@ -70,6 +107,30 @@ func TestBranch_matches(t *testing.T) {
 			actor:  user,
 			exp:    true,
 		},
+		{
+			name:   "user-group-false",
+			bypass: DefBypass{UserGroupIdentifiers: []string{"ops"}},
+			actor:  user,
+			exp:    false,
+		},
+		{
+			name:   "user-group-true",
+			bypass: DefBypass{UserGroupIdentifiers: []string{"ops", "devs"}},
+			actor:  user,
+			exp:    true,
+		},
+		{
+			name:   "space-role-false",
+			bypass: DefBypass{SpaceRoles: []enum.MembershipRole{enum.MembershipRoleSpaceOwner}},
+			actor:  user,
+			exp:    false,
+		},
+		{
+			name:   "space-role-true",
+			bypass: DefBypass{SpaceRoles: []enum.MembershipRole{enum.MembershipRoleContributor}},
+			actor:  user,
+			exp:    true,
+		},
 	}
 
 	for _, test := range tests {
@@ -78,9 +139,139 @@ func TestBranch_matches(t *testing.T) {
 				t.Errorf("invalid: %s", err.Error())
 			}
 
-			if want, got := test.exp, test.bypass.matches(test.actor, test.owner); want != got {
+			got, err := test.bypass.matches(context.Background(), bypassInput{
+				actor:       test.actor,
+				isRepoOwner: test.owner,
+				repo:        &types.Repository{ID: 1},
+				resolver:    resolver,
+			})
+			if err != nil {
+				t.Errorf("failed: %s", err.Error())
+				return
+			}
+
+			if want := test.exp; want != got {
 				t.Errorf("want=%t got=%t", want, got)
 			}
 		})
 	}
 }
+
+func TestBranch_apply(t *testing.T) {
+	user := &types.Principal{ID: 42}
+
+	tests := []struct {
+		name          string
+		bypass        DefBypass
+		allowBypass   bool
+		justification string
+		expBypassable bool
+		expBypassed   bool
+		expRequired   bool
+	}{
+		{
+			name:   "not-bypassable",
+			bypass: DefBypass{UserIDs: []int64{1}},
+		},
+		{
+			name:          "bypassable-not-requested",
+			bypass:        DefBypass{UserIDs: []int64{42}},
+			expBypassable: true,
+		},
+		{
+			name:          "bypassed",
+			bypass:        DefBypass{UserIDs: []int64{42}},
+			allowBypass:   true,
+			expBypassable: true,
+			expBypassed:   true,
+		},
+		{
+			name:          "justification-missing",
+			bypass:        DefBypass{UserIDs: []int64{42}, RequireJustification: true},
+			allowBypass:   true,
+			expBypassable: true,
+			expRequired:   true,
+		},
+		{
+			name:          "justification-blank",
+			bypass:        DefBypass{UserIDs: []int64{42}, RequireJustification: true},
+			allowBypass:   true,
+			justification: " \t\n",
+			expBypassable: true,
+			expRequired:   true,
+		},
+		{
+			name:          "justification-provided",
+			bypass:        DefBypass{UserIDs: []int64{42}, RequireJustification: true},
+			allowBypass:   true,
+			justification: "hotfix for incident",
+			expBypassable: true,
+			expBypassed:   true,
+			expRequired:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations := []types.RuleViolations{{}}
+
+			err := test.bypass.apply(context.Background(), bypassInput{
+				actor:         user,
+				allowBypass:   test.allowBypass,
+				justification: test.justification,
+			}, violations)
+			if err != nil {
+				t.Errorf("failed: %s", err.Error())
+				return
+			}
+
+			v := violations[0]
+			if v.Bypassable != test.expBypassable || v.Bypassed != test.expBypassed ||
+				v.BypassJustificationRequired != test.expRequired {
+				t.Errorf("want=%t/%t/%t got=%t/%t/%t",
+					test.expBypassable, test.expBypassed, test.expRequired,
+					v.Bypassable, v.Bypassed, v.BypassJustificationRequired)
+			}
+		})
+	}
+}
+
+func TestManager_VerifyUserGroups(t *testing.T) {
+	resolver := fakeBypassResolver{
+		groups: map[string][]int64{"devs": {1, 42}},
+	}
+
+	tests := []struct {
+		name       string
+		definition string
+		expErr     error
+	}{
+		{
+			name:       "no-user-groups",
+			definition: `{"bypass":{"user_ids":[1]}}`,
+		},
+		{
+			name:       "existing-user-group",
+			definition: `{"bypass":{"user_group_identifiers":["devs"]}}`,
+		},
+		{
+			name:       "unknown-user-group",
+			definition: `{"bypass":{"user_group_identifiers":["devs","ops"]}}`,
+			expErr:     ErrUnknownUserGroup,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewManager(nil, resolver)
+			if err := m.Register(TypeBranch, func() Definition { return &Branch{} }); err != nil {
+				t.Fatalf("failed to register rule type: %s", err.Error())
+			}
+
+			err := m.VerifyUserGroups(context.Background(), TypeBranch, []byte(test.definition))
+			if !errors.Is(err, test.expErr) {
+				t.Errorf("want=%v got=%v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
 )
 
 const TypeBranch types.RuleType = "branch"
@@ -28,6 +30,7 @@ type Branch struct {
 	Bypass    DefBypass    `json:"bypass"`
 	PullReq   DefPullReq   `json:"pullreq"`
 	Lifecycle DefLifecycle `json:"lifecycle"`
+	Commits   DefCommits   `json:"commits"`
 }
 
 var (
@@ -44,12 +47,24 @@ func (v *Branch) MergeVerify(
 		return
 	}
 
-	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
-	bypassed := in.AllowBypass && bypassable
-	for i := range violations {
-		violations[i].Bypassable = bypassable
-		violations[i].Bypassed = bypassed
+	commitsOut, commitsViolations, err := v.Commits.MergeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	if in.Method == "" {
+		out.AllowedMethods = intersectSorted(slices.Clone(out.AllowedMethods), commitsOut.AllowedMethods)
 	}
+	violations = append(violations, commitsViolations...)
+
+	err = v.Bypass.apply(ctx, bypassInput{
+		actor:         in.Actor,
+		isRepoOwner:   in.IsRepoOwner,
+		repo:          in.TargetRepo,
+		allowBypass:   in.AllowBypass,
+		justification: in.BypassJustification,
+		resolver:      in.bypassResolver,
+	}, violations)
 
 	return
 }
@@ -73,7 +88,17 @@ func (v *Branch) RequiredChecks(
 		bypassableIDs map[string]struct{}
 	)
 
-	if bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner); bypassable {
+	bypassable, err := v.Bypass.matches(ctx, bypassInput{
+		actor:       in.Actor,
+		isRepoOwner: in.IsRepoOwner,
+		repo:        in.Repo,
+		resolver:    in.bypassResolver,
+	})
+	if err != nil {
+		return RequiredChecksOutput{}, err
+	}
+
+	if bypassable {
 		bypassableIDs = ids
 	} else {
 		requiredIDs = ids
@@ -94,13 +119,24 @@ func (v *Branch) RefChangeVerify(
 	}
 
 	violations, err = v.Lifecycle.RefChangeVerify(ctx, in)
+	if err != nil {
+		return
+	}
 
-	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
-	bypassed := in.AllowBypass && bypassable
-	for i := range violations {
-		violations[i].Bypassable = bypassable
-		violations[i].Bypassed = bypassed
+	commitsViolations, err := v.Commits.RefChangeVerify(ctx, in)
+	if err != nil {
+		return
 	}
+	violations = append(violations, commitsViolations...)
+
+	err = v.Bypass.apply(ctx, bypassInput{
+		actor:         in.Actor,
+		isRepoOwner:   in.IsRepoOwner,
+		repo:          in.Repo,
+		allowBypass:   in.AllowBypass,
+		justification: in.BypassJustification,
+		resolver:      in.bypassResolver,
+	}, violations)
 
 	return
 }
@@ -109,6 +145,10 @@ func (v *Branch) UserIDs() ([]int64, error) {
 	return v.Bypass.UserIDs, nil
 }
 
+func (v *Branch) UserGroupIdentifiers() ([]string, error) {
+	return v.Bypass.UserGroupIdentifiers, nil
+}
+
 func (v *Branch) Sanitize() error {
 	if err := v.Bypass.Sanitize(); err != nil {
 		return fmt.Errorf("bypass: %w", err)
@@ -122,5 +162,9 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.Commits.Sanitize(); err != nil {
+		return fmt.Errorf("commits: %w", err)
+	}
+
 	return nil
 }
@@ -36,6 +36,7 @@ type (
 		RefChangeVerifier
 
 		UserIDs() ([]int64, error)
+		UserGroupIdentifiers() ([]string, error)
 	}
 
 	Definition interface {
@@ -48,8 +49,9 @@ type (
 
 	// Manager is used to enforce protection rules.
 	Manager struct {
-		defGenMap map[types.RuleType]DefinitionGenerator
-		ruleStore store.RuleStore
+		defGenMap      map[types.RuleType]DefinitionGenerator
+		ruleStore      store.RuleStore
+		bypassResolver BypassResolver
 	}
 )
 
@@ -58,6 +60,7 @@ var (
 	ErrAlreadyRegistered      = errors.New("protection type already registered")
 	ErrPatternEmpty           = errors.New("name pattern can't be empty")
 	ErrInvalidGlobstarPattern = errors.New("invalid globstar pattern")
+	ErrUnknownUserGroup       = errors.New("unknown user group")
 )
 
 func IsCritical(violations []types.RuleViolations) bool {
@@ -79,10 +82,11 @@ func IsBypassed(violations []types.RuleViolations) bool {
 }
 
 // NewManager creates new protection Manager.
-func NewManager(ruleStore store.RuleStore) *Manager {
+func NewManager(ruleStore store.RuleStore, bypassResolver BypassResolver) *Manager {
 	return &Manager{
-		defGenMap: make(map[types.RuleType]DefinitionGenerator),
-		ruleStore: ruleStore,
+		defGenMap:      make(map[types.RuleType]DefinitionGenerator),
+		ruleStore:      ruleStore,
+		bypassResolver: bypassResolver,
 	}
 }
 
@@ -132,6 +136,37 @@ func (m *Manager) SanitizeJSON(ruleType types.RuleType, message json.RawMessage)
 	return ToJSON(r)
 }
 
+// VerifyUserGroups makes sure that all user groups referenced in the rule definition exist.
+// It returns ErrUnknownUserGroup if any of them can't be resolved.
+func (m *Manager) VerifyUserGroups(ctx context.Context, ruleType types.RuleType, message json.RawMessage) error {
+	r, err := m.FromJSON(ruleType, message, false)
+	if err != nil {
+		return err
+	}
+
+	identifiers, err := r.UserGroupIdentifiers()
+	if err != nil {
+		return fmt.Errorf("failed to get user groups from rule: %w", err)
+	}
+
+	for _, identifier := range identifiers {
+		if m.bypassResolver == nil {
+			return fmt.Errorf("%w: %q", ErrUnknownUserGroup, identifier)
+		}
+
+		exists, err := m.bypassResolver.UserGroupExists(ctx, identifier)
+		if err != nil {
+			return fmt.Errorf("failed to check user group %q: %w", identifier, err)
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: %q", ErrUnknownUserGroup, identifier)
+		}
+	}
+
+	return nil
+}
+
 func (m *Manager) ForRepository(ctx context.Context, repoID int64) (Protection, error) {
 	ruleInfos, err := m.ruleStore.ListAllRepoRules(ctx, repoID)
 	if err != nil {
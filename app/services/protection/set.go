@@ -43,6 +43,8 @@ func (s ruleSet) MergeVerify(
 		out.AllowedMethods = slices.Clone(enum.MergeMethods)
 	}
 
+	in.bypassResolver = s.manager.bypassResolver
+
 	err := s.forEachRuleMatchBranch(in.TargetRepo.DefaultBranch, in.PullReq.TargetBranch,
 		func(r *types.RuleInfoInternal, p Protection) error {
 			rOut, rVs, err := p.MergeVerify(ctx, in)
@@ -60,6 +62,7 @@ func (s ruleSet) MergeVerify(
 			out.RequiresCodeOwnersApprovalLatest = out.RequiresCodeOwnersApprovalLatest || rOut.RequiresCodeOwnersApprovalLatest
 			out.RequiresCommentResolution = out.RequiresCommentResolution || rOut.RequiresCommentResolution
 			out.RequiresNoChangeRequests = out.RequiresNoChangeRequests || rOut.RequiresNoChangeRequests
+			out.RequiresMergeQueue = out.RequiresMergeQueue || rOut.RequiresMergeQueue
 
 			return nil
 		})
@@ -76,6 +79,8 @@ func (s ruleSet) RequiredChecks(
 ) (RequiredChecksOutput, error) {
 	requiredIDMap := map[string]struct{}{}
 	bypassableIDMap := map[string]struct{}{}
+
+	in.bypassResolver = s.manager.bypassResolver
 	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.PullReq.TargetBranch,
 		func(_ *types.RuleInfoInternal, p Protection) error {
 			out, err := p.RequiredChecks(ctx, in)
@@ -109,7 +114,15 @@ func (s ruleSet) RequiredChecks(
 func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations []types.RuleViolations
 
-	err := s.forEachRuleMatchRefs(in.Repo.DefaultBranch, in.RefNames,
+	in.bypassResolver = s.manager.bypassResolver
+
+	// The default branch pattern applies only to branches.
+	var defaultBranch string
+	if in.RefType == RefTypeBranch {
+		defaultBranch = in.Repo.DefaultBranch
+	}
+
+	err := s.forEachRuleMatchRefs(defaultBranch, in.RefNames,
 		func(r *types.RuleInfoInternal, p Protection, matched []string) error {
 			ruleIn := in
 			ruleIn.RefNames = matched
@@ -156,6 +169,32 @@ func (s ruleSet) UserIDs() ([]int64, error) {
 	return result, nil
 }
 
+func (s ruleSet) UserGroupIdentifiers() ([]string, error) {
+	mapIdentifiers := make(map[string]struct{})
+	err := s.forEachRule(func(_ *types.RuleInfoInternal, p Protection) error {
+		identifiers, err := p.UserGroupIdentifiers()
+		if err != nil {
+			return err
+		}
+
+		for _, identifier := range identifiers {
+			mapIdentifiers[identifier] = struct{}{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(mapIdentifiers))
+	for identifier := range mapIdentifiers {
+		result = append(result, identifier)
+	}
+
+	return result, nil
+}
+
 func (s ruleSet) forEachRule(
 	fn func(r *types.RuleInfoInternal, p Protection) error,
 ) error {
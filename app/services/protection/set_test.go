@@ -325,7 +325,7 @@ func TestRuleSet_MergeVerify(t *testing.T) {
 
 	ctx := context.Background()
 
-	m := NewManager(nil)
+	m := NewManager(nil, nil)
 	_ = m.Register(TypeBranch, func() Definition {
 		return &Branch{}
 	})
@@ -438,7 +438,7 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 
 	ctx := context.Background()
 
-	m := NewManager(nil)
+	m := NewManager(nil, nil)
 	_ = m.Register(TypeBranch, func() Definition {
 		return &Branch{}
 	})
@@ -462,6 +462,124 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 	}
 }
 
+func TestRuleSet_RefChangeVerify(t *testing.T) {
+	spaceRule := types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			SpacePath:  "space",
+			ID:         1,
+			Identifier: "space-rule",
+			Type:       TypeBranch,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    []byte(`{"default":true}`),
+		Definition: []byte(`{"lifecycle":{"delete_forbidden":true}}`),
+	}
+	repoRule := types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			RepoPath:   "space/repo",
+			ID:         2,
+			Identifier: "repo-rule",
+			Type:       TypeBranch,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    []byte(`{"default":true}`),
+		Definition: []byte(`{"bypass":{"repo_owners":true},"lifecycle":{"delete_forbidden":true}}`),
+	}
+	tagRule := types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			SpacePath:  "space",
+			ID:         3,
+			Identifier: "tag-rule",
+			Type:       TypeTag,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    []byte(`{"default":true,"include":["v*"]}`),
+		Definition: []byte(`{"lifecycle":{"delete_forbidden":true}}`),
+	}
+
+	tests := []struct {
+		name        string
+		rules       []types.RuleInfoInternal
+		refType     RefType
+		refName     string
+		expRuleIDs  []int64
+		expCritical bool
+	}{
+		{
+			name:        "inherited-rule-not-weakened-by-repo-rule",
+			rules:       []types.RuleInfoInternal{spaceRule, repoRule},
+			refType:     RefTypeBranch,
+			refName:     "main",
+			expRuleIDs:  []int64{1, 2},
+			expCritical: true,
+		},
+		{
+			name:       "repo-rule-bypassed",
+			rules:      []types.RuleInfoInternal{repoRule},
+			refType:    RefTypeBranch,
+			refName:    "main",
+			expRuleIDs: []int64{2},
+		},
+		{
+			name:    "tag-rule-ignores-default-branch",
+			rules:   []types.RuleInfoInternal{tagRule},
+			refType: RefTypeTag,
+			refName: "main",
+		},
+		{
+			name:        "tag-rule-pattern",
+			rules:       []types.RuleInfoInternal{spaceRule, tagRule},
+			refType:     RefTypeTag,
+			refName:     "v1.0",
+			expRuleIDs:  []int64{3},
+			expCritical: true,
+		},
+	}
+
+	ctx := context.Background()
+
+	m := NewManager(nil, nil)
+	_ = m.Register(TypeBranch, func() Definition { return &Branch{} })
+	_ = m.Register(TypeTag, func() Definition { return &Tag{} })
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := ruleSet{
+				rules:   test.rules,
+				manager: m,
+			}
+
+			violations, err := set.RefChangeVerify(ctx, RefChangeVerifyInput{
+				Actor:       &types.Principal{ID: 1},
+				AllowBypass: true,
+				IsRepoOwner: true,
+				Repo:        &types.Repository{ID: 1, DefaultBranch: "main"},
+				RefAction:   RefActionDelete,
+				RefType:     test.refType,
+				RefNames:    []string{test.refName},
+			})
+			if err != nil {
+				t.Fatalf("got error: %s", err.Error())
+			}
+
+			var ruleIDs []int64
+			for _, v := range violations {
+				if len(v.Violations) > 0 {
+					ruleIDs = append(ruleIDs, v.Rule.ID)
+				}
+			}
+
+			if want, got := test.expRuleIDs, ruleIDs; !reflect.DeepEqual(want, got) {
+				t.Errorf("rule IDs: want=%v got=%v", want, got)
+			}
+
+			if want, got := test.expCritical, IsCritical(violations); want != got {
+				t.Errorf("critical: want=%t got=%t", want, got)
+			}
+		})
+	}
+}
+
 func TestIntersectSorted(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,370 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type (
+	// Commit contains the details of a commit that are verified by the commit and push rules.
+	Commit struct {
+		SHA string
+		// Merge is true if the commit has more than one parent.
+		Merge bool
+		// Verified is true if the commit has a verified signature.
+		Verified bool
+
+		Message        string
+		AuthorEmail    string
+		CommitterEmail string
+	}
+
+	// CommitsFunc returns the commits to be verified by the commit rules.
+	// It is invoked only if a rule needs the commits.
+	CommitsFunc func(ctx context.Context) ([]Commit, error)
+
+	// CommitLister lists commits.
+	CommitLister interface {
+		ListCommits(ctx context.Context, params *git.ListCommitsParams) (*git.ListCommitsOutput, error)
+	}
+
+	// CommitVerifier verifies signatures of commits.
+	CommitVerifier interface {
+		VerifyCommits(ctx context.Context, commits []git.Commit) ([]*types.SignatureVerification, error)
+	}
+
+	DefCommits struct {
+		RequireSignatures    bool `json:"require_signatures,omitempty"`
+		RequireLinearHistory bool `json:"require_linear_history,omitempty"`
+	}
+)
+
+// ensures that the DefCommits type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefCommits)(nil)
+	_ RefChangeVerifier = (*DefCommits)(nil)
+)
+
+const (
+	codeCommitsRequireSignatures    = "commits.require_signatures"
+	codeCommitsRequireLinearHistory = "commits.require_linear_history"
+	codeCommitsTooMany              = "commits.too_many"
+	codeCommitsUnknown              = "commits.unknown"
+)
+
+// PendingCommitSHA is used in place of the SHA of a commit that is about to be created by the server.
+const PendingCommitSHA = "(new commit)"
+
+// maxVerifiedCommits is the maximum number of commits that are listed for verification by the rules.
+const maxVerifiedCommits = 5000
+
+// ErrTooManyCommits is returned when the number of commits to verify exceeds the limit.
+var ErrTooManyCommits = fmt.Errorf("more than %d commits to verify", maxVerifiedCommits)
+
+// ListCommitsFunc returns a CommitsFunc that lists the commits with the provided parameters
+// and verifies their signatures. The commits are listed only once, on the first invocation.
+func ListCommitsFunc(
+	lister CommitLister,
+	verifier CommitVerifier,
+	params *git.ListCommitsParams,
+) CommitsFunc {
+	var (
+		once    sync.Once
+		commits []Commit
+		err     error
+	)
+
+	return func(ctx context.Context) ([]Commit, error) {
+		once.Do(func() {
+			commits, err = listCommits(ctx, lister, verifier, params)
+		})
+		return commits, err
+	}
+}
+
+// StaticCommitsFunc returns a CommitsFunc that returns the provided commits.
+// It's used for commits that are about to be created by the server, e.g. by the web editor.
+func StaticCommitsFunc(commits ...Commit) CommitsFunc {
+	return func(context.Context) ([]Commit, error) {
+		return commits, nil
+	}
+}
+
+// JoinCommitsFunc returns a CommitsFunc that returns the commits returned by all the provided functions.
+func JoinCommitsFunc(fns ...CommitsFunc) CommitsFunc {
+	return func(ctx context.Context) ([]Commit, error) {
+		var commits []Commit
+		for _, fn := range fns {
+			fnCommits, err := fn(ctx)
+			if err != nil {
+				return nil, err
+			}
+			commits = append(commits, fnCommits...)
+		}
+
+		if len(commits) > maxVerifiedCommits {
+			return nil, ErrTooManyCommits
+		}
+
+		return commits, nil
+	}
+}
+
+// PullReqCommitsFunc returns a CommitsFunc that lists the commits of the pull request
+// and verifies their signatures.
+func PullReqCommitsFunc(
+	lister CommitLister,
+	verifier CommitVerifier,
+	repo *types.Repository,
+	pr *types.PullReq,
+) CommitsFunc {
+	return ListCommitsFunc(lister, verifier, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     pr.SourceSHA,
+		After:      pr.MergeBaseSHA,
+	})
+}
+
+func listCommits(
+	ctx context.Context,
+	lister CommitLister,
+	verifier CommitVerifier,
+	params *git.ListCommitsParams,
+) ([]Commit, error) {
+	output, err := lister.ListCommits(ctx, limitedCommitsParams(params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	if len(output.Commits) > maxVerifiedCommits {
+		return nil, ErrTooManyCommits
+	}
+
+	verifications, err := verifier.VerifyCommits(ctx, output.Commits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
+	commits := make([]Commit, len(output.Commits))
+	for i := range output.Commits {
+		commits[i] = Commit{
+			SHA:      output.Commits[i].SHA.String(),
+			Merge:    len(output.Commits[i].ParentSHAs) > 1,
+			Verified: verifications[i] != nil && verifications[i].Verified,
+
+			Message:        output.Commits[i].Message,
+			AuthorEmail:    output.Commits[i].Author.Identity.Email,
+			CommitterEmail: output.Commits[i].Committer.Identity.Email,
+		}
+	}
+
+	return commits, nil
+}
+
+func (v *DefCommits) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	if !v.RequireSignatures && !v.RequireLinearHistory {
+		return nil, nil
+	}
+
+	if in.RefAction == RefActionDelete {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	for _, refName := range in.RefNames {
+		commitsFn, ok := in.RefCommits[refName]
+		if !ok {
+			// the commits must be provided by every caller that creates or updates a branch.
+			addUnknownCommitsViolation(&violations, refName)
+			continue
+		}
+
+		commits, err := commitsFn(ctx)
+		if errors.Is(err, ErrTooManyCommits) {
+			addTooManyCommitsViolation(&violations, refName)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commits of branch %q: %w", refName, err)
+		}
+
+		if v.RequireLinearHistory {
+			if sha, count := firstCommit(commits, isMergeCommit); count > 0 {
+				violations.Addf(codeCommitsRequireLinearHistory,
+					"Branch %q requires linear history, but %d of the pushed commits are merge commits (e.g. %s).",
+					refName, count, sha)
+			}
+		}
+
+		if v.RequireSignatures {
+			if sha, count := firstCommit(commits, isUnverifiedCommit); count > 0 {
+				violations.Addf(codeCommitsRequireSignatures,
+					"Branch %q requires signed commits, "+
+						"but %d of the pushed commits don't have a verified signature (e.g. %s).",
+					refName, count, sha)
+			}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+// MergeVerify verifies that merging of the pull request doesn't introduce merge commits
+// or commits without a verified signature to the target branch.
+// Commits created by the merge itself, the merge commit or the squash commit, are created by the server
+// and are therefore trusted. If signatures are required, the merge and the squash method are allowed
+// only if all commits of the pull request have a verified signature. The rebase method is never allowed
+// in that case, because it re-creates the commits of the pull request without their signatures.
+func (v *DefCommits) MergeVerify(
+	ctx context.Context,
+	in MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	var out MergeVerifyOutput
+	var violations types.RuleViolations
+
+	if in.Method == "" {
+		out.AllowedMethods = enum.MergeMethods
+	}
+
+	if !v.RequireSignatures && !v.RequireLinearHistory {
+		return out, nil, nil
+	}
+
+	disallowed := make(map[enum.MergeMethod]struct{})
+
+	if v.RequireLinearHistory {
+		disallowed[enum.MergeMethodMerge] = struct{}{}
+		if in.Method == enum.MergeMethodMerge {
+			violations.Addf(codeCommitsRequireLinearHistory,
+				"The merge strategy %q is not allowed because the target branch requires linear history.",
+				in.Method)
+		}
+	}
+
+	if v.RequireSignatures {
+		disallowed[enum.MergeMethodRebase] = struct{}{}
+		if in.Method == enum.MergeMethodRebase {
+			violations.Addf(codeCommitsRequireSignatures,
+				"The merge strategy %q is not allowed because the target branch requires signed commits "+
+					"and rebasing re-creates the pull request commits without signatures.",
+				in.Method)
+		}
+	}
+
+	// the merge and the squash method require all commits of the pull request to have a verified signature.
+	verifyMethod := in.Method == "" || in.Method == enum.MergeMethodMerge || in.Method == enum.MergeMethodSquash
+	if v.RequireSignatures && in.Commits != nil && verifyMethod {
+		commits, err := in.Commits(ctx)
+		if errors.Is(err, ErrTooManyCommits) {
+			disallowed[enum.MergeMethodMerge] = struct{}{}
+			disallowed[enum.MergeMethodSquash] = struct{}{}
+			if in.Method != "" {
+				violations.Addf(codeCommitsTooMany,
+					"The pull request has more than %d commits, which is too many to verify their signatures.",
+					maxVerifiedCommits)
+			}
+		} else if err != nil {
+			return out, nil, fmt.Errorf("failed to get pull request commits: %w", err)
+		}
+
+		if sha, count := firstCommit(commits, isUnverifiedCommit); count > 0 {
+			disallowed[enum.MergeMethodMerge] = struct{}{}
+			disallowed[enum.MergeMethodSquash] = struct{}{}
+			if in.Method != "" {
+				violations.Addf(codeCommitsRequireSignatures,
+					"The target branch requires signed commits, "+
+						"but %d of the pull request commits don't have a verified signature (e.g. %s).",
+					count, sha)
+			}
+		}
+	}
+
+	if in.Method == "" && len(disallowed) > 0 {
+		out.AllowedMethods = make([]enum.MergeMethod, 0, len(enum.MergeMethods))
+		for _, method := range enum.MergeMethods {
+			if _, ok := disallowed[method]; !ok {
+				out.AllowedMethods = append(out.AllowedMethods, method)
+			}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return out, []types.RuleViolations{violations}, nil
+	}
+
+	return out, nil, nil
+}
+
+func (*DefCommits) Sanitize() error {
+	return nil
+}
+
+// limitedCommitsParams returns a copy of the parameters that lists at most one commit more
+// than the limit of verified commits, so that exceeding the limit can be detected,
+// and that includes the commit signatures required for the verification.
+func limitedCommitsParams(params *git.ListCommitsParams) *git.ListCommitsParams {
+	limited := *params
+	limited.Page = 1
+	limited.Limit = maxVerifiedCommits + 1
+	limited.IncludeSignatures = true
+	return &limited
+}
+
+func addTooManyCommitsViolation(violations *types.RuleViolations, refName string) {
+	violations.Addf(codeCommitsTooMany,
+		"Branch %q can't be verified because the push introduces more than %d commits.",
+		refName, maxVerifiedCommits)
+}
+
+func addUnknownCommitsViolation(violations *types.RuleViolations, refName string) {
+	violations.Addf(codeCommitsUnknown,
+		"Branch %q can't be verified because the commits introduced by the change are unknown.",
+		refName)
+}
+
+func isMergeCommit(c Commit) bool { return c.Merge }
+
+func isUnverifiedCommit(c Commit) bool { return !c.Verified }
+
+// firstCommit returns the SHA of the first commit that matches the provided function
+// and the number of all matching commits.
+func firstCommit(commits []Commit, fn func(Commit) bool) (string, int) {
+	var sha string
+	var count int
+	for _, c := range commits {
+		if !fn(c) {
+			continue
+		}
+		if count == 0 {
+			sha = c.SHA
+		}
+		count++
+	}
+
+	return sha, count
+}
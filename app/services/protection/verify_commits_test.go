@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func commitsFunc(commits ...Commit) CommitsFunc {
+	return func(context.Context) ([]Commit, error) {
+		return commits, nil
+	}
+}
+
+func TestDefCommits_RefChangeVerify(t *testing.T) {
+	const refName = "a"
+
+	signed := Commit{SHA: "1", Verified: true}
+	unsigned := Commit{SHA: "2"}
+	merge := Commit{SHA: "3", Merge: true, Verified: true}
+
+	tests := []struct {
+		name      string
+		def       DefCommits
+		action    RefAction
+		commits   CommitsFunc
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:    "empty",
+			action:  RefActionUpdate,
+			commits: commitsFunc(unsigned, merge),
+		},
+		{
+			name:    "commits.require_signatures-success",
+			def:     DefCommits{RequireSignatures: true},
+			action:  RefActionUpdate,
+			commits: commitsFunc(signed, merge),
+		},
+		{
+			name:      "commits.require_signatures-fail",
+			def:       DefCommits{RequireSignatures: true},
+			action:    RefActionCreate,
+			commits:   commitsFunc(signed, unsigned),
+			expCodes:  []string{"commits.require_signatures"},
+			expParams: [][]any{{refName, 1, unsigned.SHA}},
+		},
+		{
+			name:      "commits.require_linear_history-fail",
+			def:       DefCommits{RequireLinearHistory: true},
+			action:    RefActionUpdateForce,
+			commits:   commitsFunc(signed, merge, unsigned),
+			expCodes:  []string{"commits.require_linear_history"},
+			expParams: [][]any{{refName, 1, merge.SHA}},
+		},
+		{
+			name:      "commits.unknown",
+			def:       DefCommits{RequireSignatures: true},
+			action:    RefActionUpdate,
+			expCodes:  []string{"commits.unknown"},
+			expParams: [][]any{{refName}},
+		},
+		{
+			name:   "delete",
+			def:    DefCommits{RequireSignatures: true, RequireLinearHistory: true},
+			action: RefActionDelete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				RefNames:  []string{refName},
+				RefAction: test.action,
+				RefType:   RefTypeBranch,
+			}
+			if test.commits != nil {
+				in.RefCommits = map[string]CommitsFunc{refName: test.commits}
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+func TestDefCommits_MergeVerify(t *testing.T) {
+	withoutMerge := []enum.MergeMethod{enum.MergeMethodRebase, enum.MergeMethodSquash}
+	withoutRebase := []enum.MergeMethod{enum.MergeMethodMerge, enum.MergeMethodSquash}
+
+	tests := []struct {
+		name       string
+		def        DefCommits
+		method     enum.MergeMethod
+		commits    CommitsFunc
+		expMethods []enum.MergeMethod
+		expCodes   []string
+	}{
+		{
+			name:       "empty",
+			commits:    commitsFunc(Commit{SHA: "1"}),
+			expMethods: enum.MergeMethods,
+		},
+		{
+			name:       "commits.require_linear_history-methods",
+			def:        DefCommits{RequireLinearHistory: true},
+			expMethods: withoutMerge,
+		},
+		{
+			name:     "commits.require_linear_history-fail",
+			def:      DefCommits{RequireLinearHistory: true},
+			method:   enum.MergeMethodMerge,
+			expCodes: []string{"commits.require_linear_history"},
+		},
+		{
+			name:   "commits.require_linear_history-success",
+			def:    DefCommits{RequireLinearHistory: true},
+			method: enum.MergeMethodSquash,
+		},
+		{
+			name:       "commits.require_signatures-methods-signed",
+			def:        DefCommits{RequireSignatures: true},
+			commits:    commitsFunc(Commit{SHA: "1", Verified: true}),
+			expMethods: withoutRebase,
+		},
+		{
+			name:       "commits.require_signatures-methods-unsigned",
+			def:        DefCommits{RequireSignatures: true},
+			commits:    commitsFunc(Commit{SHA: "1"}),
+			expMethods: []enum.MergeMethod{},
+		},
+		{
+			name:       "commits.require_signatures-require_linear_history-methods",
+			def:        DefCommits{RequireSignatures: true, RequireLinearHistory: true},
+			commits:    commitsFunc(Commit{SHA: "1", Verified: true}),
+			expMethods: []enum.MergeMethod{enum.MergeMethodSquash},
+		},
+		{
+			name:     "commits.require_signatures-fail",
+			def:      DefCommits{RequireSignatures: true},
+			method:   enum.MergeMethodMerge,
+			commits:  commitsFunc(Commit{SHA: "1"}),
+			expCodes: []string{"commits.require_signatures"},
+		},
+		{
+			name:     "commits.require_signatures-fail-squash",
+			def:      DefCommits{RequireSignatures: true},
+			method:   enum.MergeMethodSquash,
+			commits:  commitsFunc(Commit{SHA: "1"}),
+			expCodes: []string{"commits.require_signatures"},
+		},
+		{
+			name:     "commits.require_signatures-fail-rebase",
+			def:      DefCommits{RequireSignatures: true},
+			method:   enum.MergeMethodRebase,
+			commits:  commitsFunc(Commit{SHA: "1", Verified: true}),
+			expCodes: []string{"commits.require_signatures"},
+		},
+		{
+			name:    "commits.require_signatures-success-squash",
+			def:     DefCommits{RequireSignatures: true},
+			method:  enum.MergeMethodSquash,
+			commits: commitsFunc(Commit{SHA: "1", Verified: true}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, violations, err := test.def.MergeVerify(context.Background(), MergeVerifyInput{
+				Method:  test.method,
+				Commits: test.commits,
+			})
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if want, got := test.expMethods, out.AllowedMethods; !reflect.DeepEqual(want, got) {
+				t.Errorf("allowed methods mismatch: want=%v got=%v", want, got)
+			}
+
+			var codes []string
+			for _, v := range violations {
+				for _, violation := range v.Violations {
+					codes = append(codes, violation.Code)
+				}
+			}
+
+			if want, got := test.expCodes, codes; !reflect.DeepEqual(want, got) {
+				t.Errorf("violation codes mismatch: want=%v got=%v", want, got)
+			}
+		})
+	}
+}
+
+// ensures that the commit rules are applied by the branch rule.
+func TestBranch_Commits(t *testing.T) {
+	branch := Branch{Commits: DefCommits{RequireLinearHistory: true}}
+
+	violations, err := branch.RefChangeVerify(context.Background(), RefChangeVerifyInput{
+		Actor:      &types.Principal{ID: 1},
+		RefNames:   []string{"main"},
+		RefAction:  RefActionUpdate,
+		RefType:    RefTypeBranch,
+		RefCommits: map[string]CommitsFunc{"main": commitsFunc(Commit{SHA: "1", Merge: true})},
+	})
+	if err != nil {
+		t.Fatalf("got an error: %s", err.Error())
+	}
+
+	if len(violations) != 1 || len(violations[0].Violations) != 1 ||
+		violations[0].Violations[0].Code != codeCommitsRequireLinearHistory {
+		t.Errorf("unexpected violations: %+v", violations)
+	}
+}
@@ -33,6 +33,16 @@ type (
 		RefAction   RefAction
 		RefType     RefType
 		RefNames    []string
+		// RefCommits contains, for every created or updated ref, the commits introduced by the change.
+		RefCommits map[string]CommitsFunc
+		// RefFiles contains, for every created or updated ref, the files changed by the introduced commits.
+		RefFiles map[string]FilesFunc
+		// PushFiles returns the files changed by all commits introduced by the push, optional.
+		PushFiles FilesFunc
+		// BypassJustification is the reason for bypassing the rules, required by some rules to allow bypassing.
+		BypassJustification string
+
+		bypassResolver BypassResolver
 	}
 
 	RefType int
@@ -43,6 +53,8 @@ type (
 		CreateForbidden bool `json:"create_forbidden,omitempty"`
 		DeleteForbidden bool `json:"delete_forbidden,omitempty"`
 		UpdateForbidden bool `json:"update_forbidden,omitempty"`
+		// UpdateForceForbidden forbids updates that are not fast-forward (force pushes).
+		UpdateForceForbidden bool `json:"update_force_forbidden,omitempty"`
 	}
 )
 
@@ -56,6 +68,8 @@ const (
 	RefActionCreate RefAction = iota
 	RefActionDelete
 	RefActionUpdate
+	// RefActionUpdateForce is an update of a ref that is not a fast-forward.
+	RefActionUpdateForce
 )
 
 // ensures that the DefLifecycle type implements Sanitizer and RefChangeVerifier interfaces.
@@ -68,6 +82,8 @@ const (
 	codeLifecycleCreate = "lifecycle.create"
 	codeLifecycleDelete = "lifecycle.delete"
 	codeLifecycleUpdate = "lifecycle.update"
+
+	codeLifecycleUpdateForce = "lifecycle.update_force"
 )
 
 func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
@@ -89,6 +105,14 @@ func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInpu
 			violations.Addf(codeLifecycleUpdate,
 				"Push to branch %q is not allowed. Please use pull requests.", in.RefNames[0])
 		}
+	case RefActionUpdateForce:
+		if v.UpdateForbidden {
+			violations.Addf(codeLifecycleUpdate,
+				"Push to branch %q is not allowed. Please use pull requests.", in.RefNames[0])
+		} else if v.UpdateForceForbidden {
+			violations.Addf(codeLifecycleUpdateForce,
+				"Force push to branch %q is not allowed.", in.RefNames[0])
+		}
 	}
 
 	if len(violations.Violations) > 0 {
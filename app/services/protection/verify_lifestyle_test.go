@@ -56,6 +56,25 @@ func TestDefLifecycle_RefChangeVerify(t *testing.T) {
 			expCodes:  []string{"lifecycle.update"},
 			expParams: [][]any{{refName}},
 		},
+		{
+			name:   "lifecycle.update_force-success-fast-forward",
+			def:    DefLifecycle{UpdateForceForbidden: true},
+			action: RefActionUpdate,
+		},
+		{
+			name:      "lifecycle.update_force-fail",
+			def:       DefLifecycle{UpdateForceForbidden: true},
+			action:    RefActionUpdateForce,
+			expCodes:  []string{"lifecycle.update_force"},
+			expParams: [][]any{{refName}},
+		},
+		{
+			name:      "lifecycle.update-fail-force",
+			def:       DefLifecycle{UpdateForbidden: true, UpdateForceForbidden: true},
+			action:    RefActionUpdateForce,
+			expCodes:  []string{"lifecycle.update"},
+			expParams: [][]any{{refName}},
+		},
 	}
 
 	for _, test := range tests {
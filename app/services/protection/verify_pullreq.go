@@ -45,6 +45,14 @@ type (
 		Method       enum.MergeMethod
 		CheckResults []types.CheckResult
 		CodeOwners   *codeowners.Evaluation
+		// MergeQueue is true if the pull request is merged through the merge queue.
+		MergeQueue bool
+		// Commits returns the commits of the pull request.
+		Commits CommitsFunc
+		// BypassJustification is the reason for bypassing the rules, required by some rules to allow bypassing.
+		BypassJustification string
+
+		bypassResolver BypassResolver
 	}
 
 	MergeVerifyOutput struct {
@@ -56,6 +64,7 @@ type (
 		RequiresCodeOwnersApprovalLatest    bool
 		RequiresCommentResolution           bool
 		RequiresNoChangeRequests            bool
+		RequiresMergeQueue                  bool
 	}
 
 	RequiredChecksInput struct {
@@ -63,6 +72,8 @@ type (
 		IsRepoOwner bool
 		Repo        *types.Repository
 		PullReq     *types.PullReq
+
+		bypassResolver BypassResolver
 	}
 
 	RequiredChecksOutput struct {
@@ -90,6 +101,7 @@ const (
 
 	codePullReqMergeStrategiesAllowed = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch      = "pullreq.merge.delete_branch"
+	codePullReqMergeRequireMergeQueue = "pullreq.merge.require_merge_queue"
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
@@ -107,6 +119,7 @@ func (v *DefPullReq) MergeVerify(
 	out.DeleteSourceBranch = v.Merge.DeleteBranch
 	out.RequiresCommentResolution = v.Comments.RequireResolveAll
 	out.RequiresNoChangeRequests = v.Approvals.RequireNoChangeRequest
+	out.RequiresMergeQueue = v.Merge.RequireMergeQueue
 
 	// output that depends on approval of latest commit
 	if v.Approvals.RequireLatestCommit {
@@ -244,6 +257,11 @@ func (v *DefPullReq) MergeVerify(
 		}
 	}
 
+	if v.Merge.RequireMergeQueue && !in.MergeQueue {
+		violations.Add(codePullReqMergeRequireMergeQueue,
+			"The pull request must be merged through the merge queue.")
+	}
+
 	if len(violations.Violations) > 0 {
 		return out, []types.RuleViolations{violations}, nil
 	}
@@ -341,6 +359,7 @@ func (c *DefStatusChecks) Sanitize() error {
 type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
+	RequireMergeQueue bool               `json:"require_merge_queue,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
@@ -362,14 +381,6 @@ func (v *DefMerge) Sanitize() error {
 	return nil
 }
 
-type DefPush struct {
-	Block bool `json:"block,omitempty"`
-}
-
-func (v *DefPush) Sanitize() error {
-	return nil
-}
-
 type DefPullReq struct {
 	Approvals    DefApprovals    `json:"approvals"`
 	Comments     DefComments     `json:"comments"`
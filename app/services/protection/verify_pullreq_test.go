@@ -328,6 +328,25 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods:     nil,
 			},
 		},
+		{
+			name: codePullReqMergeRequireMergeQueue + "-fail",
+			def:  DefPullReq{Merge: DefMerge{RequireMergeQueue: true}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqMergeRequireMergeQueue},
+			expParams: [][]any{nil},
+			expOut:    MergeVerifyOutput{RequiresMergeQueue: true},
+		},
+		{
+			name: codePullReqMergeRequireMergeQueue + "-success",
+			def:  DefPullReq{Merge: DefMerge{RequireMergeQueue: true}},
+			in: MergeVerifyInput{
+				Method:     enum.MergeMethodMerge,
+				MergeQueue: true,
+			},
+			expOut: MergeVerifyOutput{RequiresMergeQueue: true},
+		},
 		{
 			name: codePullReqApprovalReqChangeRequested + "-true",
 			def: DefPullReq{
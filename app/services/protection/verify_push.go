@@ -0,0 +1,407 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+type (
+	// FilesFunc returns the paths of the files changed by the commits to be verified by the push rules.
+	// It is invoked only if a rule needs the files.
+	FilesFunc func(ctx context.Context) ([]string, error)
+
+	DefPush struct {
+		// CommitMessageRegex is a regular expression every pushed commit message must match.
+		CommitMessageRegex string `json:"commit_message_regex,omitempty"`
+		// AllowedEmailDomains, if not empty, limits author and committer emails to the listed domains.
+		AllowedEmailDomains []string `json:"allowed_email_domains,omitempty"`
+		// CommitterMatchPusher requires the committer email of every pushed commit to match the pusher's email.
+		CommitterMatchPusher bool `json:"committer_match_pusher,omitempty"`
+		// ForbiddenPaths contains globstar patterns of file paths that must not be changed.
+		ForbiddenPaths []string `json:"forbidden_paths,omitempty"`
+		// MaxFiles is the maximum number of files a single push can change, counted across all pushed branches.
+		MaxFiles int `json:"max_files,omitempty"`
+	}
+)
+
+// ensures that the DefPush type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefPush)(nil)
+	_ RefChangeVerifier = (*DefPush)(nil)
+)
+
+const (
+	codePushCommitMessage        = "push.commit_message"
+	codePushEmailDomain          = "push.email_domain"
+	codePushCommitterMatchPusher = "push.committer_match_pusher"
+	codePushForbiddenPath        = "push.forbidden_path"
+	codePushMaxFiles             = "push.max_files"
+)
+
+// StaticFilesFunc returns a FilesFunc that returns the provided file paths, cleaned the same way git cleans them.
+// It's used for files changed by commits that are about to be created by the server, e.g. by the web editor.
+func StaticFilesFunc(files ...string) FilesFunc {
+	fileMap := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		file = strings.Trim(path.Clean("/"+file), "/")
+		if file != "" {
+			fileMap[file] = struct{}{}
+		}
+	}
+
+	cleaned := make([]string, 0, len(fileMap))
+	for file := range fileMap {
+		cleaned = append(cleaned, file)
+	}
+
+	sort.Strings(cleaned)
+
+	return func(context.Context) ([]string, error) {
+		return cleaned, nil
+	}
+}
+
+// UnionFilesFunc returns a FilesFunc that lists the paths of the files returned by any of the provided functions.
+// The files are listed only once, on the first invocation.
+func UnionFilesFunc(fns ...FilesFunc) FilesFunc {
+	var (
+		once  sync.Once
+		files []string
+		err   error
+	)
+
+	return func(ctx context.Context) ([]string, error) {
+		once.Do(func() {
+			files, err = unionFiles(ctx, fns)
+		})
+		return files, err
+	}
+}
+
+func unionFiles(ctx context.Context, fns []FilesFunc) ([]string, error) {
+	fileMap := make(map[string]struct{})
+	for _, fn := range fns {
+		fnFiles, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range fnFiles {
+			fileMap[file] = struct{}{}
+		}
+	}
+
+	files := make([]string, 0, len(fileMap))
+	for file := range fileMap {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// ListFilesFunc returns a FilesFunc that lists the paths of the files changed by the commits
+// listed with the provided parameters. The files are listed only once, on the first invocation.
+func ListFilesFunc(
+	lister CommitLister,
+	params *git.ListCommitsParams,
+) FilesFunc {
+	var (
+		once  sync.Once
+		files []string
+		err   error
+	)
+
+	return func(ctx context.Context) ([]string, error) {
+		once.Do(func() {
+			files, err = listFiles(ctx, lister, params)
+		})
+		return files, err
+	}
+}
+
+func listFiles(
+	ctx context.Context,
+	lister CommitLister,
+	params *git.ListCommitsParams,
+) ([]string, error) {
+	paramsWithStats := limitedCommitsParams(params)
+	paramsWithStats.IncludeStats = true
+
+	output, err := lister.ListCommits(ctx, paramsWithStats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits with changed files: %w", err)
+	}
+
+	if len(output.Commits) > maxVerifiedCommits {
+		return nil, ErrTooManyCommits
+	}
+
+	fileMap := make(map[string]struct{})
+	for i := range output.Commits {
+		for _, stat := range output.Commits[i].FileStats {
+			fileMap[stat.Path] = struct{}{}
+			if stat.OldPath != "" {
+				fileMap[stat.OldPath] = struct{}{}
+			}
+		}
+	}
+
+	files := make([]string, 0, len(fileMap))
+	for file := range fileMap {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+//nolint:gocognit // refactor if needed
+func (v *DefPush) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	if in.RefAction == RefActionDelete {
+		return nil, nil
+	}
+
+	needsCommits := v.CommitMessageRegex != "" || len(v.AllowedEmailDomains) > 0 || v.CommitterMatchPusher
+	needsFiles := len(v.ForbiddenPaths) > 0
+	needsPushFiles := v.MaxFiles > 0 && len(in.RefNames) > 0
+
+	if !needsCommits && !needsFiles && !needsPushFiles {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	for _, refName := range in.RefNames {
+		// the commits and the files must be provided by every caller that creates or updates a branch.
+		if needsCommits {
+			commitsFn, ok := in.RefCommits[refName]
+			if !ok {
+				addUnknownCommitsViolation(&violations, refName)
+				continue
+			}
+
+			commits, err := commitsFn(ctx)
+			if errors.Is(err, ErrTooManyCommits) {
+				addTooManyCommitsViolation(&violations, refName)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get commits of branch %q: %w", refName, err)
+			}
+
+			v.verifyCommits(&violations, in.Actor, refName, commits)
+		}
+
+		if needsFiles {
+			filesFn, ok := in.RefFiles[refName]
+			if !ok {
+				addUnknownCommitsViolation(&violations, refName)
+				continue
+			}
+
+			files, err := filesFn(ctx)
+			if errors.Is(err, ErrTooManyCommits) {
+				addTooManyCommitsViolation(&violations, refName)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get changed files of branch %q: %w", refName, err)
+			}
+
+			v.verifyFiles(&violations, refName, files)
+		}
+	}
+
+	if needsPushFiles && in.PushFiles == nil {
+		violations.Addf(codeCommitsUnknown,
+			"The push can't be verified because the commits introduced by the push are unknown.")
+	} else if needsPushFiles {
+		if err := v.verifyPushFiles(ctx, &violations, in.PushFiles); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *DefPush) verifyCommits(
+	violations *types.RuleViolations,
+	actor *types.Principal,
+	refName string,
+	commits []Commit,
+) {
+	if v.CommitMessageRegex != "" {
+		// the regular expression is validated by Sanitize
+		re := regexp.MustCompile(v.CommitMessageRegex)
+		if sha, count := firstCommit(commits, func(c Commit) bool {
+			return !re.MatchString(c.Message)
+		}); count > 0 {
+			violations.Addf(codePushCommitMessage,
+				"Branch %q requires commit messages to match %q, but %d of the pushed commits don't (e.g. %s).",
+				refName, v.CommitMessageRegex, count, sha)
+		}
+	}
+
+	if len(v.AllowedEmailDomains) > 0 {
+		if sha, count := firstCommit(commits, func(c Commit) bool {
+			return !v.emailDomainAllowed(c.AuthorEmail) || !v.emailDomainAllowed(c.CommitterEmail)
+		}); count > 0 {
+			violations.Addf(codePushEmailDomain,
+				"Branch %q allows only author and committer emails from domains %s, "+
+					"but %d of the pushed commits use a different domain (e.g. %s).",
+				refName, strings.Join(v.AllowedEmailDomains, ", "), count, sha)
+		}
+	}
+
+	if v.CommitterMatchPusher {
+		var pusherEmail string
+		if actor != nil {
+			pusherEmail = actor.Email
+		}
+
+		if sha, count := firstCommit(commits, func(c Commit) bool {
+			return pusherEmail == "" || !strings.EqualFold(c.CommitterEmail, pusherEmail)
+		}); count > 0 {
+			violations.Addf(codePushCommitterMatchPusher,
+				"Branch %q requires the committer email to match the email of the pusher, "+
+					"but %d of the pushed commits have a different committer (e.g. %s).",
+				refName, count, sha)
+		}
+	}
+}
+
+func (v *DefPush) verifyFiles(
+	violations *types.RuleViolations,
+	refName string,
+	files []string,
+) {
+	if len(v.ForbiddenPaths) > 0 {
+		var (
+			forbiddenFile string
+			count         int
+		)
+
+		for _, file := range files {
+			if !slices.ContainsFunc(v.ForbiddenPaths, func(pattern string) bool {
+				return patternMatches(pattern, file)
+			}) {
+				continue
+			}
+
+			if count == 0 {
+				forbiddenFile = file
+			}
+			count++
+		}
+
+		if count > 0 {
+			violations.Addf(codePushForbiddenPath,
+				"Branch %q forbids changes of files matching %s, but %d of the changed files match (e.g. %s).",
+				refName, strings.Join(v.ForbiddenPaths, ", "), count, forbiddenFile)
+		}
+	}
+}
+
+// verifyPushFiles verifies the number of files changed by the whole push, regardless of how many branches are pushed.
+func (v *DefPush) verifyPushFiles(
+	ctx context.Context,
+	violations *types.RuleViolations,
+	pushFiles FilesFunc,
+) error {
+	files, err := pushFiles(ctx)
+	if errors.Is(err, ErrTooManyCommits) {
+		violations.Addf(codeCommitsTooMany,
+			"The push can't be verified because it introduces more than %d commits.", maxVerifiedCommits)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get changed files of the push: %w", err)
+	}
+
+	if len(files) > v.MaxFiles {
+		violations.Addf(codePushMaxFiles,
+			"At most %d changed files are allowed per push, but the push changes %d files.",
+			v.MaxFiles, len(files))
+	}
+
+	return nil
+}
+
+func (v *DefPush) emailDomainAllowed(email string) bool {
+	idx := strings.LastIndexByte(email, '@')
+	if idx < 0 {
+		return false
+	}
+
+	return slices.Contains(v.AllowedEmailDomains, strings.ToLower(email[idx+1:]))
+}
+
+func (v *DefPush) Sanitize() error {
+	if v.CommitMessageRegex != "" {
+		if _, err := regexp.Compile(v.CommitMessageRegex); err != nil {
+			return fmt.Errorf("invalid commit message regular expression: %w", err)
+		}
+	}
+
+	if len(v.AllowedEmailDomains) > maxElements {
+		return errors.New("too many email domains provided")
+	}
+
+	for i, domain := range v.AllowedEmailDomains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain == "" {
+			return errors.New("email domain mustn't be an empty string")
+		}
+		v.AllowedEmailDomains[i] = domain
+	}
+
+	sort.Strings(v.AllowedEmailDomains)
+	v.AllowedEmailDomains = slices.Compact(v.AllowedEmailDomains)
+
+	if len(v.ForbiddenPaths) > maxElements {
+		return errors.New("too many forbidden paths provided")
+	}
+
+	for _, pattern := range v.ForbiddenPaths {
+		if err := patternValidate(pattern); err != nil {
+			return fmt.Errorf("forbidden path %q: %w", pattern, err)
+		}
+	}
+
+	if v.MaxFiles < 0 {
+		return errors.New("maximum number of files must be zero or a positive integer")
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func filesFunc(files ...string) FilesFunc {
+	return func(context.Context) ([]string, error) {
+		return files, nil
+	}
+}
+
+func TestDefPush_RefChangeVerify(t *testing.T) {
+	const refName = "a"
+
+	pusher := &types.Principal{ID: 1, Email: "pusher@example.com"}
+
+	good := Commit{
+		SHA:            "1",
+		Message:        "JIRA-1: good",
+		AuthorEmail:    "author@example.com",
+		CommitterEmail: "Pusher@Example.com",
+	}
+	bad := Commit{
+		SHA:            "2",
+		Message:        "bad",
+		AuthorEmail:    "author@other.com",
+		CommitterEmail: "committer@example.com",
+	}
+
+	tests := []struct {
+		name      string
+		def       DefPush
+		action    RefAction
+		commits   CommitsFunc
+		files     FilesFunc
+		pushFiles FilesFunc
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:    "empty",
+			action:  RefActionUpdate,
+			commits: commitsFunc(good, bad),
+			files:   filesFunc("a.txt", "b.txt"),
+		},
+		{
+			name:    "push.commit_message-success",
+			def:     DefPush{CommitMessageRegex: "^JIRA-[0-9]+: "},
+			action:  RefActionUpdate,
+			commits: commitsFunc(good),
+		},
+		{
+			name:      "push.commit_message-fail",
+			def:       DefPush{CommitMessageRegex: "^JIRA-[0-9]+: "},
+			action:    RefActionCreate,
+			commits:   commitsFunc(good, bad),
+			expCodes:  []string{"push.commit_message"},
+			expParams: [][]any{{refName, "^JIRA-[0-9]+: ", 1, bad.SHA}},
+		},
+		{
+			name:    "push.email_domain-success",
+			def:     DefPush{AllowedEmailDomains: []string{"@Example.com"}},
+			action:  RefActionUpdate,
+			commits: commitsFunc(good),
+		},
+		{
+			name:      "push.email_domain-fail",
+			def:       DefPush{AllowedEmailDomains: []string{"example.com"}},
+			action:    RefActionUpdate,
+			commits:   commitsFunc(good, bad),
+			expCodes:  []string{"push.email_domain"},
+			expParams: [][]any{{refName, "example.com", 1, bad.SHA}},
+		},
+		{
+			name:    "push.committer_match_pusher-success",
+			def:     DefPush{CommitterMatchPusher: true},
+			action:  RefActionUpdate,
+			commits: commitsFunc(good),
+		},
+		{
+			name:      "push.committer_match_pusher-fail",
+			def:       DefPush{CommitterMatchPusher: true},
+			action:    RefActionUpdateForce,
+			commits:   commitsFunc(bad, good, bad),
+			expCodes:  []string{"push.committer_match_pusher"},
+			expParams: [][]any{{refName, 2, bad.SHA}},
+		},
+		{
+			name:   "push.forbidden_path-success",
+			def:    DefPush{ForbiddenPaths: []string{"secrets/**"}},
+			action: RefActionUpdate,
+			files:  filesFunc("docs/secrets.md", "main.go"),
+		},
+		{
+			name:      "push.forbidden_path-fail",
+			def:       DefPush{ForbiddenPaths: []string{"**/*.pem", "secrets/**"}},
+			action:    RefActionUpdate,
+			files:     filesFunc("certs/key.pem", "main.go", "secrets/token"),
+			expCodes:  []string{"push.forbidden_path"},
+			expParams: [][]any{{refName, "**/*.pem, secrets/**", 2, "certs/key.pem"}},
+		},
+		{
+			name:      "push.max_files-success",
+			def:       DefPush{MaxFiles: 2},
+			action:    RefActionUpdate,
+			files:     filesFunc("a", "b", "c"),
+			pushFiles: filesFunc("a", "b"),
+		},
+		{
+			name:      "push.max_files-fail",
+			def:       DefPush{MaxFiles: 2},
+			action:    RefActionUpdate,
+			files:     filesFunc("a"),
+			pushFiles: filesFunc("a", "b", "c"),
+			expCodes:  []string{"push.max_files"},
+			expParams: [][]any{{2, 3}},
+		},
+		{
+			name:      "commits.too_many",
+			def:       DefPush{CommitMessageRegex: "^JIRA-[0-9]+: ", MaxFiles: 2},
+			action:    RefActionUpdate,
+			commits:   func(context.Context) ([]Commit, error) { return nil, ErrTooManyCommits },
+			pushFiles: func(context.Context) ([]string, error) { return nil, ErrTooManyCommits },
+			expCodes:  []string{"commits.too_many", "commits.too_many"},
+			expParams: [][]any{{refName, maxVerifiedCommits}, {maxVerifiedCommits}},
+		},
+		{
+			name: "delete",
+			def: DefPush{
+				CommitMessageRegex:   "^JIRA",
+				CommitterMatchPusher: true,
+				MaxFiles:             1,
+			},
+			action: RefActionDelete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				Actor:     pusher,
+				RefNames:  []string{refName},
+				RefAction: test.action,
+				RefType:   RefTypeBranch,
+			}
+			if test.commits != nil {
+				in.RefCommits = map[string]CommitsFunc{refName: test.commits}
+			}
+			if test.files != nil {
+				in.RefFiles = map[string]FilesFunc{refName: test.files}
+			}
+			in.PushFiles = test.pushFiles
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+func TestDefPush_Sanitize(t *testing.T) {
+	tests := []struct {
+		name       string
+		def        DefPush
+		expDomains []string
+		expErr     bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:       "domains",
+			def:        DefPush{AllowedEmailDomains: []string{"b.com", " @A.com", "a.com"}},
+			expDomains: []string{"a.com", "b.com"},
+		},
+		{
+			name:   "empty-domain",
+			def:    DefPush{AllowedEmailDomains: []string{"@"}},
+			expErr: true,
+		},
+		{
+			name:   "invalid-regex",
+			def:    DefPush{CommitMessageRegex: "("},
+			expErr: true,
+		},
+		{
+			name:   "invalid-path",
+			def:    DefPush{ForbiddenPaths: []string{"[a"}},
+			expErr: true,
+		},
+		{
+			name:   "negative-max-files",
+			def:    DefPush{MaxFiles: -1},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr != (err != nil) {
+				t.Errorf("error mismatch: expected=%t got=%v", test.expErr, err)
+				return
+			}
+
+			if test.expErr {
+				return
+			}
+
+			if want, got := test.expDomains, test.def.AllowedEmailDomains; !reflect.DeepEqual(want, got) {
+				t.Errorf("domains mismatch: want=%v got=%v", want, got)
+			}
+		})
+	}
+}
+
+// ensures that the push rule applies the bypass list.
+func TestPush_Bypass(t *testing.T) {
+	push := Push{
+		Bypass: DefBypass{UserIDs: []int64{1}},
+		Push:   DefPush{MaxFiles: 1},
+	}
+
+	violations, err := push.RefChangeVerify(context.Background(), RefChangeVerifyInput{
+		Actor:       &types.Principal{ID: 1},
+		AllowBypass: true,
+		RefNames:    []string{"main"},
+		RefAction:   RefActionUpdate,
+		RefType:     RefTypeBranch,
+		PushFiles:   filesFunc("a", "b"),
+	})
+	if err != nil {
+		t.Fatalf("got an error: %s", err.Error())
+	}
+
+	if len(violations) != 1 || !violations[0].Bypassed || len(violations[0].Violations) != 1 {
+		t.Errorf("expected one bypassed violation, got: %+v", violations)
+	}
+}
+
+func TestUnionFilesFunc(t *testing.T) {
+	fn := UnionFilesFunc(filesFunc("b", "a"), filesFunc("c", "a"), filesFunc())
+
+	files, err := fn(context.Background())
+	if err != nil {
+		t.Fatalf("got an error: %s", err.Error())
+	}
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(want, files) {
+		t.Errorf("files mismatch: want=%v got=%v", want, files)
+	}
+}
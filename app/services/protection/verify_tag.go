@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+
+	"github.com/harness/gitness/types"
+)
+
+type DefTagLifecycle struct {
+	CreateForbidden bool `json:"create_forbidden,omitempty"`
+	DeleteForbidden bool `json:"delete_forbidden,omitempty"`
+	// UpdateForbidden forbids moving of existing tags to a different object.
+	UpdateForbidden bool `json:"update_forbidden,omitempty"`
+}
+
+// ensures that the DefTagLifecycle type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefTagLifecycle)(nil)
+	_ RefChangeVerifier = (*DefTagLifecycle)(nil)
+)
+
+const (
+	codeTagLifecycleCreate = "tag.lifecycle.create"
+	codeTagLifecycleDelete = "tag.lifecycle.delete"
+	codeTagLifecycleUpdate = "tag.lifecycle.update"
+)
+
+func (v *DefTagLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	for _, tagName := range in.RefNames {
+		switch in.RefAction {
+		case RefActionCreate:
+			if v.CreateForbidden {
+				violations.Addf(codeTagLifecycleCreate,
+					"Creation of tag %q is not allowed.", tagName)
+			}
+		case RefActionDelete:
+			if v.DeleteForbidden {
+				violations.Addf(codeTagLifecycleDelete,
+					"Delete of tag %q is not allowed.", tagName)
+			}
+		case RefActionUpdate, RefActionUpdateForce:
+			if v.UpdateForbidden {
+				violations.Addf(codeTagLifecycleUpdate,
+					"Moving of tag %q is not allowed.", tagName)
+			}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (*DefTagLifecycle) Sanitize() error {
+	return nil
+}
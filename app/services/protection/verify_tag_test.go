@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestDefTagLifecycle_RefChangeVerify(t *testing.T) {
+	const tagName = "v1.0.0"
+	tests := []struct {
+		name      string
+		def       DefTagLifecycle
+		action    RefAction
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:   "empty",
+			action: RefActionDelete,
+		},
+		{
+			name:      "tag.lifecycle.create-fail",
+			def:       DefTagLifecycle{CreateForbidden: true},
+			action:    RefActionCreate,
+			expCodes:  []string{"tag.lifecycle.create"},
+			expParams: [][]any{{tagName}},
+		},
+		{
+			name:      "tag.lifecycle.delete-fail",
+			def:       DefTagLifecycle{DeleteForbidden: true},
+			action:    RefActionDelete,
+			expCodes:  []string{"tag.lifecycle.delete"},
+			expParams: [][]any{{tagName}},
+		},
+		{
+			name:      "tag.lifecycle.update-fail",
+			def:       DefTagLifecycle{UpdateForbidden: true},
+			action:    RefActionUpdate,
+			expCodes:  []string{"tag.lifecycle.update"},
+			expParams: [][]any{{tagName}},
+		},
+		{
+			name:   "tag.lifecycle.update-success-create",
+			def:    DefTagLifecycle{UpdateForbidden: true},
+			action: RefActionCreate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				RefNames:  []string{tagName},
+				RefAction: test.action,
+				RefType:   RefTypeTag,
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+func TestTag_RefChangeVerify(t *testing.T) {
+	tag := Tag{
+		Bypass:    DefBypass{UserIDs: []int64{1}},
+		Lifecycle: DefTagLifecycle{DeleteForbidden: true},
+	}
+
+	tests := []struct {
+		name          string
+		actor         *types.Principal
+		refType       RefType
+		expViolations bool
+		expBypassed   bool
+	}{
+		{
+			name:          "violation",
+			actor:         &types.Principal{ID: 2},
+			refType:       RefTypeTag,
+			expViolations: true,
+		},
+		{
+			name:          "bypassed",
+			actor:         &types.Principal{ID: 1},
+			refType:       RefTypeTag,
+			expViolations: true,
+			expBypassed:   true,
+		},
+		{
+			name:    "branch-ignored",
+			actor:   &types.Principal{ID: 2},
+			refType: RefTypeBranch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := tag.RefChangeVerify(context.Background(), RefChangeVerifyInput{
+				Actor:       test.actor,
+				AllowBypass: true,
+				RefAction:   RefActionDelete,
+				RefType:     test.refType,
+				RefNames:    []string{"v1"},
+			})
+			if err != nil {
+				t.Fatalf("got an error: %s", err.Error())
+			}
+
+			if want, got := test.expViolations, len(violations) > 0; want != got {
+				t.Fatalf("violations mismatch: want=%t got=%t", want, got)
+			}
+
+			if test.expViolations && test.expBypassed != violations[0].Bypassed {
+				t.Errorf("bypassed mismatch: want=%t got=%t", test.expBypassed, violations[0].Bypassed)
+			}
+		})
+	}
+}
@@ -15,21 +15,38 @@
 package protection
 
 import (
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
+	ProvideBypassResolver,
 	ProvideManager,
 )
 
-func ProvideManager(ruleStore store.RuleStore) (*Manager, error) {
-	m := NewManager(ruleStore)
+func ProvideBypassResolver(
+	membershipStore store.MembershipStore,
+	userGroupResolver usergroup.Resolver,
+) BypassResolver {
+	return NewBypassResolver(membershipStore, userGroupResolver)
+}
+
+func ProvideManager(ruleStore store.RuleStore, bypassResolver BypassResolver) (*Manager, error) {
+	m := NewManager(ruleStore, bypassResolver)
 
 	if err := m.Register(TypeBranch, func() Definition { return &Branch{} }); err != nil {
 		return nil, err
 	}
 
+	if err := m.Register(TypePush, func() Definition { return &Push{} }); err != nil {
+		return nil, err
+	}
+
+	if err := m.Register(TypeTag, func() Definition { return &Tag{} }); err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
@@ -19,6 +19,7 @@ import (
 	"encoding/base64"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/gliderlabs/ssh"
 	gossh "golang.org/x/crypto/ssh"
@@ -39,6 +40,23 @@ var DisallowedTypes = []string{
 	gossh.KeyAlgoDSA,
 }
 
+// Key is a parsed public key of any of the supported formats.
+type Key interface {
+	Matches(s string) bool
+	Fingerprint() string
+	Type() string
+}
+
+// ParseForUsage parses the key data of a key with the provided usage.
+// Signing keys can be either SSH keys or OpenPGP keys, other keys must be SSH keys.
+func ParseForUsage(keyData string, usage enum.PublicKeyUsage) (Key, string, error) {
+	if usage == enum.PublicKeyUsageSign && IsPGP(keyData) {
+		return ParsePGP(keyData)
+	}
+
+	return ParseString(keyData)
+}
+
 func From(key gossh.PublicKey) KeyInfo {
 	return KeyInfo{
 		Key: key,
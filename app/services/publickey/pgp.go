@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck // no maintained alternative among the dependencies
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
+)
+
+const (
+	// KeyTypePGP is the key type of OpenPGP public keys.
+	KeyTypePGP = "pgp"
+
+	pgpPublicKeyArmorStart = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	pgpSignatureArmorStart = "-----BEGIN PGP SIGNATURE-----"
+)
+
+// IsPGP returns true if the provided key data is an ASCII armored OpenPGP public key.
+func IsPGP(keyData string) bool {
+	return strings.HasPrefix(strings.TrimSpace(keyData), pgpPublicKeyArmorStart)
+}
+
+// ParsePGP parses an ASCII armored OpenPGP public key.
+// The returned comment is the name of the first user ID of the key.
+func ParsePGP(keyData string) (PGPKeyInfo, string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyData))
+	if err != nil {
+		return PGPKeyInfo{}, "", err
+	}
+
+	if len(entities) != 1 {
+		return PGPKeyInfo{}, "", errors.InvalidArgument("exactly one OpenPGP public key is expected")
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey != nil {
+		return PGPKeyInfo{}, "", errors.InvalidArgument("private keys are not allowed")
+	}
+
+	names := make([]string, 0, len(entity.Identities))
+	for name := range entity.Identities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var comment string
+	if len(names) > 0 {
+		comment = names[0]
+	}
+
+	return PGPKeyInfo{
+		Entity: entity,
+	}, comment, nil
+}
+
+type PGPKeyInfo struct {
+	Entity *openpgp.Entity
+}
+
+func (key PGPKeyInfo) Matches(s string) bool {
+	otherKey, _, err := ParsePGP(s)
+	if err != nil {
+		return false
+	}
+
+	return key.Fingerprint() == otherKey.Fingerprint()
+}
+
+func (key PGPKeyInfo) Fingerprint() string {
+	return fmt.Sprintf("%X", key.Entity.PrimaryKey.Fingerprint)
+}
+
+func (key PGPKeyInfo) Type() string {
+	return KeyTypePGP
+}
+
+// pgpSignatureKeyID returns the ID of the key that created the ASCII armored OpenPGP signature.
+func pgpSignatureKeyID(signature string) (uint64, error) {
+	block, err := armor.Decode(strings.NewReader(signature))
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode armored signature: %w", err)
+	}
+
+	if block.Type != openpgp.SignatureType {
+		return 0, fmt.Errorf("unexpected armor block type %q", block.Type)
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read signature packet: %w", err)
+	}
+
+	switch sig := p.(type) {
+	case *packet.Signature:
+		if sig.IssuerKeyId == nil {
+			return 0, fmt.Errorf("signature doesn't contain the issuer key ID")
+		}
+		return *sig.IssuerKeyId, nil
+	case *packet.SignatureV3:
+		return sig.IssuerKeyId, nil
+	default:
+		return 0, fmt.Errorf("unexpected packet type %T", p)
+	}
+}
+
+// formatPGPKeyID returns the long hexadecimal representation of an OpenPGP key ID.
+func formatPGPKeyID(keyID uint64) string {
+	return fmt.Sprintf("%016X", keyID)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -29,20 +30,24 @@ import (
 
 type Service interface {
 	ValidateKey(ctx context.Context, publicKey ssh.PublicKey, usage enum.PublicKeyUsage) (*types.PrincipalInfo, error)
+	VerifyCommits(ctx context.Context, commits []git.Commit) ([]*types.SignatureVerification, error)
 }
 
 func NewService(
 	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
 	pCache store.PrincipalInfoCache,
 ) LocalService {
 	return LocalService{
 		publicKeyStore: publicKeyStore,
+		principalStore: principalStore,
 		pCache:         pCache,
 	}
 }
 
 type LocalService struct {
 	publicKeyStore store.PublicKeyStore
+	principalStore store.PrincipalStore
 	pCache         store.PrincipalInfoCache
 }
 
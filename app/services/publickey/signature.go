@@ -0,0 +1,257 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/openpgp"                  //nolint:staticcheck
+	pgperrors "golang.org/x/crypto/openpgp/errors" //nolint:staticcheck
+)
+
+// maxSigningKeys is the maximum number of OpenPGP signing keys of a user considered during verification.
+const maxSigningKeys = 100
+
+// pgpKeyring holds the OpenPGP signing keys of a principal.
+type pgpKeyring struct {
+	principalID int64
+	entities    openpgp.EntityList
+}
+
+// VerifyCommits verifies the signatures of the commits against the registered signing keys.
+// The returned slice contains the verification result of each commit, in the same order as the commits.
+func (s LocalService) VerifyCommits(
+	ctx context.Context,
+	commits []git.Commit,
+) ([]*types.SignatureVerification, error) {
+	keyrings := make(map[string]*pgpKeyring)
+	verifications := make([]*types.SignatureVerification, len(commits))
+
+	for i := range commits {
+		verification, err := s.verifyCommit(ctx, &commits[i], keyrings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commit %s: %w", commits[i].SHA, err)
+		}
+
+		verifications[i] = verification
+	}
+
+	return verifications, nil
+}
+
+func (s LocalService) verifyCommit(
+	ctx context.Context,
+	commit *git.Commit,
+	keyrings map[string]*pgpKeyring,
+) (*types.SignatureVerification, error) {
+	if commit.Signature == nil {
+		return &types.SignatureVerification{
+			Reason: enum.SignatureVerificationReasonUnsigned,
+		}, nil
+	}
+
+	signature := strings.TrimSpace(commit.Signature.Signature)
+	payload := []byte(commit.Signature.Payload)
+	email := commit.Committer.Identity.Email
+
+	switch {
+	case strings.HasPrefix(signature, sshSignatureArmorStart):
+		return s.verifySSH(ctx, signature, payload, email)
+	case strings.HasPrefix(signature, pgpSignatureArmorStart):
+		return s.verifyPGP(ctx, signature, payload, email, keyrings)
+	default:
+		return &types.SignatureVerification{
+			Reason: enum.SignatureVerificationReasonUnsupported,
+		}, nil
+	}
+}
+
+func (s LocalService) verifySSH(
+	ctx context.Context,
+	signature string,
+	payload []byte,
+	email string,
+) (*types.SignatureVerification, error) {
+	verification := &types.SignatureVerification{
+		Method: enum.SignatureMethodSSH,
+	}
+
+	sig, err := parseSSHSignature(signature)
+	if err != nil {
+		verification.Reason = enum.SignatureVerificationReasonMalformed
+		return verification, nil
+	}
+
+	key := From(sig.PublicKey)
+	verification.KeyID = key.Fingerprint()
+
+	existingKeys, err := s.publicKeyStore.ListByFingerprint(ctx, verification.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys by fingerprint: %w", err)
+	}
+
+	var principalID int64
+	for _, existingKey := range existingKeys {
+		if existingKey.Usage == enum.PublicKeyUsageSign && key.Matches(existingKey.Content) {
+			principalID = existingKey.PrincipalID
+			break
+		}
+	}
+
+	if principalID == 0 {
+		verification.Reason = enum.SignatureVerificationReasonUnknownKey
+		return verification, nil
+	}
+
+	if err = sig.verify(payload); err != nil {
+		verification.Reason = enum.SignatureVerificationReasonInvalid
+		return verification, nil
+	}
+
+	return s.signedBy(ctx, verification, principalID, email)
+}
+
+func (s LocalService) verifyPGP(
+	ctx context.Context,
+	signature string,
+	payload []byte,
+	email string,
+	keyrings map[string]*pgpKeyring,
+) (*types.SignatureVerification, error) {
+	verification := &types.SignatureVerification{
+		Method: enum.SignatureMethodGPG,
+	}
+
+	keyID, err := pgpSignatureKeyID(signature)
+	if err != nil {
+		verification.Reason = enum.SignatureVerificationReasonMalformed
+		return verification, nil
+	}
+
+	verification.KeyID = formatPGPKeyID(keyID)
+
+	keyring, err := s.pgpKeyring(ctx, email, keyrings)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyring == nil {
+		verification.Reason = enum.SignatureVerificationReasonUnknownKey
+		return verification, nil
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(keyring.entities,
+		bytes.NewReader(payload), strings.NewReader(signature))
+	if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+		verification.Reason = enum.SignatureVerificationReasonUnknownKey
+		return verification, nil
+	}
+	if err != nil {
+		verification.Reason = enum.SignatureVerificationReasonInvalid
+		return verification, nil
+	}
+
+	return s.signedBy(ctx, verification, keyring.principalID, email)
+}
+
+// pgpKeyring returns the OpenPGP signing keys of the principal with the provided email.
+// It returns nil if there is no such principal or the principal doesn't have any OpenPGP signing keys.
+func (s LocalService) pgpKeyring(
+	ctx context.Context,
+	email string,
+	keyrings map[string]*pgpKeyring,
+) (*pgpKeyring, error) {
+	email = strings.ToLower(email)
+
+	if keyring, ok := keyrings[email]; ok {
+		return keyring, nil
+	}
+
+	principal, err := s.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		keyrings[email] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	keys, err := s.publicKeyStore.List(ctx, principal.ID, &types.PublicKeyFilter{
+		ListQueryFilter: types.ListQueryFilter{
+			Pagination: types.Pagination{Size: maxSigningKeys},
+		},
+		Usages: []enum.PublicKeyUsage{enum.PublicKeyUsageSign},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys of principal: %w", err)
+	}
+
+	var keyring *pgpKeyring
+	for _, key := range keys {
+		if key.Type != KeyTypePGP {
+			continue
+		}
+
+		pgpKey, _, err := ParsePGP(key.Content)
+		if err != nil {
+			continue
+		}
+
+		if keyring == nil {
+			keyring = &pgpKeyring{principalID: principal.ID}
+		}
+
+		keyring.entities = append(keyring.entities, pgpKey.Entity)
+	}
+
+	keyrings[email] = keyring
+
+	return keyring, nil
+}
+
+// signedBy completes the verification of a valid signature created by a key of the provided principal.
+// The signature is only considered verified if the email of the principal matches the committer email.
+func (s LocalService) signedBy(
+	ctx context.Context,
+	verification *types.SignatureVerification,
+	principalID int64,
+	email string,
+) (*types.SignatureVerification, error) {
+	signer, err := s.pCache.Get(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer principal info: %w", err)
+	}
+
+	verification.Signer = signer
+
+	if !strings.EqualFold(signer.Email, email) {
+		verification.Reason = enum.SignatureVerificationReasonEmailMismatch
+		return verification, nil
+	}
+
+	verification.Verified = true
+	verification.Reason = enum.SignatureVerificationReasonValid
+
+	return verification, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"       //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/armor" //nolint:staticcheck
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// signSSH creates an armored SSH signature of the message the same way "ssh-keygen -Y sign" does.
+func signSSH(t *testing.T, signer gossh.Signer, namespace string, message []byte) string {
+	t.Helper()
+
+	h := sha512.Sum512(message)
+	sig, err := signer.Sign(rand.Reader, sshSignedData(namespace, "sha512", h[:]))
+	if err != nil {
+		t.Fatalf("failed to sign: %s", err.Error())
+	}
+
+	blob := gossh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{
+		Version:       sshSignatureVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Signature:     gossh.Marshal(sig),
+	})
+
+	return sshSignatureArmorStart + "\n" +
+		base64.StdEncoding.EncodeToString(append([]byte(sshSignatureMagic), blob...)) + "\n" +
+		sshSignatureArmorEnd + "\n"
+}
+
+func TestSSHSignature(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err.Error())
+	}
+
+	signer, err := gossh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %s", err.Error())
+	}
+
+	message := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nTitle\n")
+
+	tests := []struct {
+		name      string
+		namespace string
+		message   []byte
+		valid     bool
+	}{
+		{name: "valid", namespace: sshSignatureNamespaceGit, message: message, valid: true},
+		{name: "other-namespace", namespace: "file", message: message},
+		{name: "modified-message", namespace: sshSignatureNamespaceGit, message: []byte("Title\n")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sig, err := parseSSHSignature(signSSH(t, signer, test.namespace, message))
+			if err != nil {
+				t.Fatalf("failed to parse signature: %s", err.Error())
+			}
+
+			if !From(sig.PublicKey).MatchesKey(signer.PublicKey()) {
+				t.Errorf("signature public key doesn't match the signer")
+			}
+
+			err = sig.verify(test.message)
+			if test.valid && err != nil {
+				t.Errorf("expected valid signature, got error: %s", err.Error())
+			}
+			if !test.valid && err == nil {
+				t.Errorf("expected invalid signature")
+			}
+		})
+	}
+}
+
+func TestParseSSHSignature_Malformed(t *testing.T) {
+	for _, sig := range []string{
+		"",
+		"-----BEGIN PGP SIGNATURE-----\n-----END PGP SIGNATURE-----",
+		sshSignatureArmorStart + "\nnot-base64\n" + sshSignatureArmorEnd,
+		sshSignatureArmorStart + "\n" +
+			base64.StdEncoding.EncodeToString([]byte(sshSignatureMagic)) + "\n" +
+			sshSignatureArmorEnd,
+	} {
+		if _, err := parseSSHSignature(sig); err == nil {
+			t.Errorf("expected error for signature %q", sig)
+		}
+	}
+}
+
+func TestPGP(t *testing.T) {
+	entity, err := openpgp.NewEntity("Jane", "", "jane@example.com", nil)
+	if err != nil {
+		t.Fatalf("failed to create entity: %s", err.Error())
+	}
+
+	publicKey := &bytes.Buffer{}
+	w, err := armor.Encode(publicKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("failed to create armor encoder: %s", err.Error())
+	}
+	if err = entity.Serialize(w); err != nil {
+		t.Fatalf("failed to serialize public key: %s", err.Error())
+	}
+	_ = w.Close()
+
+	if !IsPGP(publicKey.String()) {
+		t.Fatalf("expected key to be recognized as an OpenPGP key")
+	}
+
+	key, comment, err := ParsePGP(publicKey.String())
+	if err != nil {
+		t.Fatalf("failed to parse public key: %s", err.Error())
+	}
+
+	if want := "Jane <jane@example.com>"; comment != want {
+		t.Errorf("comment mismatch: want=%q got=%q", want, comment)
+	}
+
+	if !key.Matches(publicKey.String()) {
+		t.Errorf("expected key to match itself")
+	}
+
+	message := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nTitle\n"
+
+	signature := &bytes.Buffer{}
+	if err = openpgp.ArmoredDetachSign(signature, entity, strings.NewReader(message), nil); err != nil {
+		t.Fatalf("failed to sign: %s", err.Error())
+	}
+
+	keyID, err := pgpSignatureKeyID(signature.String())
+	if err != nil {
+		t.Fatalf("failed to read signature key ID: %s", err.Error())
+	}
+
+	if keyID != entity.PrimaryKey.KeyId {
+		t.Errorf("key ID mismatch: want=%s got=%s",
+			formatPGPKeyID(entity.PrimaryKey.KeyId), formatPGPKeyID(keyID))
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{key.Entity},
+		strings.NewReader(message), strings.NewReader(signature.String()))
+	if err != nil {
+		t.Errorf("expected valid signature, got error: %s", err.Error())
+	}
+}
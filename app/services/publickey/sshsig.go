@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strings"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// The SSH signature format is described in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+const (
+	sshSignatureArmorStart = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureArmorEnd   = "-----END SSH SIGNATURE-----"
+	sshSignatureMagic      = "SSHSIG"
+	sshSignatureVersion    = 1
+
+	// sshSignatureNamespaceGit is the namespace git uses when signing objects with SSH keys.
+	sshSignatureNamespaceGit = "git"
+)
+
+type sshSignature struct {
+	PublicKey     gossh.PublicKey
+	Namespace     string
+	HashAlgorithm string
+	Signature     *gossh.Signature
+}
+
+// parseSSHSignature parses an ASCII armored SSH signature.
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	armored = strings.TrimSpace(armored)
+	if !strings.HasPrefix(armored, sshSignatureArmorStart) || !strings.HasSuffix(armored, sshSignatureArmorEnd) {
+		return nil, fmt.Errorf("signature is not an armored SSH signature")
+	}
+
+	body := armored[len(sshSignatureArmorStart) : len(armored)-len(sshSignatureArmorEnd)]
+	raw, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if !bytes.HasPrefix(raw, []byte(sshSignatureMagic)) {
+		return nil, fmt.Errorf("signature doesn't start with the magic preamble")
+	}
+
+	var blob struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+	if err = gossh.Unmarshal(raw[len(sshSignatureMagic):], &blob); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signature: %w", err)
+	}
+
+	if blob.Version != sshSignatureVersion {
+		return nil, fmt.Errorf("unsupported signature version %d", blob.Version)
+	}
+
+	publicKey, err := gossh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature public key: %w", err)
+	}
+
+	signature := &gossh.Signature{}
+	if err = gossh.Unmarshal(blob.Signature, signature); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signature blob: %w", err)
+	}
+
+	return &sshSignature{
+		PublicKey:     publicKey,
+		Namespace:     blob.Namespace,
+		HashAlgorithm: blob.HashAlgorithm,
+		Signature:     signature,
+	}, nil
+}
+
+// verify checks that the signature has been created by signing the provided message in the git namespace.
+func (sig *sshSignature) verify(message []byte) error {
+	if sig.Namespace != sshSignatureNamespaceGit {
+		return fmt.Errorf("unexpected signature namespace %q", sig.Namespace)
+	}
+
+	h, err := sshSignatureHash(sig.HashAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	_, _ = h.Write(message)
+
+	return sig.PublicKey.Verify(sshSignedData(sig.Namespace, sig.HashAlgorithm, h.Sum(nil)), sig.Signature)
+}
+
+func sshSignatureHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported signature hash algorithm %q", algorithm)
+	}
+}
+
+// sshSignedData returns the data that is signed by the signature key.
+func sshSignedData(namespace, hashAlgorithm string, messageHash []byte) []byte {
+	data := gossh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		Namespace:     namespace,
+		HashAlgorithm: hashAlgorithm,
+		Hash:          messageHash,
+	})
+
+	return append([]byte(sshSignatureMagic), data...)
+}
@@ -26,7 +26,8 @@ var WireSet = wire.NewSet(
 
 func ProvidePublicKey(
 	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
 	pCache store.PrincipalInfoCache,
 ) Service {
-	return NewService(publicKeyStore, pCache)
+	return NewService(publicKeyStore, principalStore, pCache)
 }
@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Merger merges pull requests with enabled auto-merge.
+// It's implemented by the pull request API controller, so the pull requests are merged
+// through the same flow as when merged by a user.
+type Merger interface {
+	// AutoMerge merges the pull request on behalf of the user who enabled auto-merge.
+	// It returns false if the requirements for merging the pull request aren't satisfied yet.
+	AutoMerge(ctx context.Context, pr *types.PullReq, autoMerge *types.AutoMerge) (bool, error)
+}
+
+// AutoMergeService merges pull requests with enabled auto-merge as soon as all requirements
+// for merging are satisfied. Merging is reattempted whenever a review is submitted,
+// a status check is reported or the source branch is updated.
+type AutoMergeService struct {
+	merger         Merger
+	autoMergeStore store.AutoMergeStore
+	pullreqStore   store.PullReqStore
+	activityStore  store.PullReqActivityStore
+	repoStore      store.RepoStore
+	sseStreamer    sse.Streamer
+}
+
+func NewAutoMergeService(ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	merger Merger,
+	autoMergeStore store.AutoMergeStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	repoStore store.RepoStore,
+	sseStreamer sse.Streamer,
+) (*AutoMergeService, error) {
+	service := &AutoMergeService{
+		merger:         merger,
+		autoMergeStore: autoMergeStore,
+		pullreqStore:   pullreqStore,
+		activityStore:  activityStore,
+		repoStore:      repoStore,
+		sseStreamer:    sseStreamer,
+	}
+
+	const groupPullReqAutoMerge = "gitness:pullreq:automerge"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReqAutoMerge, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReviewSubmitted(service.autoMergeOnReviewSubmitted)
+			_ = r.RegisterBranchUpdated(service.autoMergeOnBranchUpdated)
+			_ = r.RegisterClosed(service.autoMergeOnClosed)
+			_ = r.RegisterMerged(service.autoMergeOnMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupCheckAutoMerge = "gitness:pullreq:automerge:checks"
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheckAutoMerge, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReported(service.autoMergeOnCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (s *AutoMergeService) autoMergeOnReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	if event.Payload.Decision != enum.PullReqReviewDecisionApproved {
+		return nil
+	}
+
+	return s.tryMerge(ctx, event.Payload.PullReqID)
+}
+
+// autoMergeOnBranchUpdated disables auto-merge if the new commits weren't pushed by the author
+// of the pull request. Otherwise, merging is reattempted with the new commits.
+func (s *AutoMergeService) autoMergeOnBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	autoMerge, err := s.autoMergeStore.Find(ctx, event.Payload.PullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find auto-merge: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if event.Payload.PrincipalID != pr.CreatedBy {
+		s.cancel(ctx, pr, event.Payload.PrincipalID,
+			"New commits were pushed to the source branch by someone other than the author.")
+		return nil
+	}
+
+	s.merge(ctx, pr, autoMerge)
+
+	return nil
+}
+
+// autoMergeOnCheckReported reattempts merging of all pull requests the status check was reported for.
+func (s *AutoMergeService) autoMergeOnCheckReported(ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	autoMerges, err := s.autoMergeStore.ListBySourceSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list auto-merges for the commit: %w", err)
+	}
+
+	for _, autoMerge := range autoMerges {
+		pr, err := s.pullreqStore.Find(ctx, autoMerge.PullReqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		s.merge(ctx, pr, autoMerge)
+	}
+
+	return nil
+}
+
+func (s *AutoMergeService) autoMergeOnClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.delete(ctx, event.Payload.PullReqID)
+}
+
+func (s *AutoMergeService) autoMergeOnMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.delete(ctx, event.Payload.PullReqID)
+}
+
+func (s *AutoMergeService) tryMerge(ctx context.Context, pullReqID int64) error {
+	autoMerge, err := s.autoMergeStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find auto-merge: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	s.merge(ctx, pr, autoMerge)
+
+	return nil
+}
+
+// merge attempts to merge the pull request. Failures are only logged,
+// because merging is reattempted on the next event that could satisfy the requirements.
+func (s *AutoMergeService) merge(ctx context.Context, pr *types.PullReq, autoMerge *types.AutoMerge) {
+	if pr.State != enum.PullReqStateOpen {
+		return
+	}
+
+	merged, err := s.merger.AutoMerge(ctx, pr, autoMerge)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("pullreq_id", pr.ID).
+			Msg("failed to auto-merge pull request")
+		return
+	}
+
+	if merged {
+		log.Ctx(ctx).Debug().Int64("pullreq_id", pr.ID).Msg("pull request auto-merged")
+	}
+}
+
+// cancel disables auto-merge for the pull request and records the reason in the pull request activity.
+func (s *AutoMergeService) cancel(ctx context.Context, pr *types.PullReq, principalID int64, reason string) {
+	err := s.autoMergeStore.Delete(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to disable auto-merge")
+		return
+	}
+
+	pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to get pull request activity number for auto-merge activity")
+		return
+	}
+
+	payload := &types.PullRequestActivityPayloadAutoMerge{
+		Action: enum.AutoMergeActionCanceled,
+		Reason: reason,
+	}
+	if _, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request auto-merge activity")
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find target repository")
+		return
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+}
+
+func (s *AutoMergeService) delete(ctx context.Context, pullReqID int64) error {
+	err := s.autoMergeStore.Delete(ctx, pullReqID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to delete auto-merge: %w", err)
+	}
+
+	return nil
+}
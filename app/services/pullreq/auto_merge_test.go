@@ -0,0 +1,266 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	autoMergeAuthorID = 1
+	autoMergeOtherID  = 2
+	autoMergeSHA      = "1111111111111111111111111111111111111111"
+)
+
+type autoMergeStore struct {
+	store.AutoMergeStore
+	autoMerges map[int64]*types.AutoMerge
+}
+
+func (s *autoMergeStore) Find(_ context.Context, pullReqID int64) (*types.AutoMerge, error) {
+	autoMerge, ok := s.autoMerges[pullReqID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return autoMerge, nil
+}
+
+func (s *autoMergeStore) Delete(_ context.Context, pullReqID int64) error {
+	if _, ok := s.autoMerges[pullReqID]; !ok {
+		return gitness_store.ErrResourceNotFound
+	}
+	delete(s.autoMerges, pullReqID)
+	return nil
+}
+
+func (s *autoMergeStore) ListBySourceSHA(
+	_ context.Context,
+	repoID int64,
+	sourceSHA string,
+) ([]*types.AutoMerge, error) {
+	if repoID != stackRepoID || sourceSHA != autoMergeSHA {
+		return nil, nil
+	}
+	var result []*types.AutoMerge
+	for _, autoMerge := range s.autoMerges {
+		result = append(result, autoMerge)
+	}
+	return result, nil
+}
+
+type autoMergePullReqStore struct {
+	store.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s *autoMergePullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	prCopy := *pr
+	return &prCopy, nil
+}
+
+func (s *autoMergePullReqStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	pr.ActivitySeq++
+	return pr, nil
+}
+
+type autoMergeRepoStore struct {
+	store.RepoStore
+}
+
+func (autoMergeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return &types.Repository{ID: id}, nil
+}
+
+type autoMerger struct {
+	merged []int64
+}
+
+func (m *autoMerger) AutoMerge(_ context.Context, pr *types.PullReq, _ *types.AutoMerge) (bool, error) {
+	m.merged = append(m.merged, pr.ID)
+	return true, nil
+}
+
+func newTestAutoMergeService(prs ...*types.PullReq) (
+	*AutoMergeService,
+	*autoMerger,
+	*autoMergeStore,
+	*stackActivityStore,
+) {
+	merger := &autoMerger{}
+	autoMerges := &autoMergeStore{autoMerges: map[int64]*types.AutoMerge{}}
+	pullreqStore := &autoMergePullReqStore{prs: map[int64]*types.PullReq{}}
+	activityStore := &stackActivityStore{}
+
+	for _, pr := range prs {
+		pullreqStore.prs[pr.ID] = pr
+		autoMerges.autoMerges[pr.ID] = &types.AutoMerge{
+			PullReqID: pr.ID,
+			RepoID:    stackRepoID,
+			Method:    enum.MergeMethodMerge,
+			CreatedBy: pr.CreatedBy,
+		}
+	}
+
+	service := &AutoMergeService{
+		merger:         merger,
+		autoMergeStore: autoMerges,
+		pullreqStore:   pullreqStore,
+		activityStore:  activityStore,
+		repoStore:      autoMergeRepoStore{},
+		sseStreamer:    stackStreamer{},
+	}
+
+	return service, merger, autoMerges, activityStore
+}
+
+func newAutoMergePullReq(id int64, state enum.PullReqState) *types.PullReq {
+	return &types.PullReq{
+		ID:           id,
+		Number:       id,
+		CreatedBy:    autoMergeAuthorID,
+		State:        state,
+		SourceRepoID: stackRepoID,
+		SourceSHA:    autoMergeSHA,
+		TargetRepoID: stackRepoID,
+	}
+}
+
+func TestAutoMergeService_BranchUpdated(t *testing.T) {
+	tests := []struct {
+		name        string
+		principalID int64
+		wantMerged  bool
+	}{
+		{
+			name:        "pushed by author",
+			principalID: autoMergeAuthorID,
+			wantMerged:  true,
+		},
+		{
+			name:        "pushed by non-author",
+			principalID: autoMergeOtherID,
+			wantMerged:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, merger, autoMerges, activityStore := newTestAutoMergeService(
+				newAutoMergePullReq(1, enum.PullReqStateOpen))
+
+			err := service.autoMergeOnBranchUpdated(context.Background(),
+				&events.Event[*pullreqevents.BranchUpdatedPayload]{
+					Payload: &pullreqevents.BranchUpdatedPayload{
+						Base:   pullreqevents.Base{PullReqID: 1, PrincipalID: test.principalID},
+						NewSHA: autoMergeSHA,
+					},
+				})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if merged := len(merger.merged) == 1; merged != test.wantMerged {
+				t.Errorf("want merged=%t, got merges %v", test.wantMerged, merger.merged)
+			}
+
+			_, enabled := autoMerges.autoMerges[1]
+			if enabled != test.wantMerged {
+				t.Errorf("want auto-merge enabled=%t, got %t", test.wantMerged, enabled)
+			}
+
+			if test.wantMerged {
+				if len(activityStore.payloads) != 0 {
+					t.Errorf("expected no activity, got %v", activityStore.payloads)
+				}
+				return
+			}
+
+			if len(activityStore.payloads) != 1 {
+				t.Fatalf("expected one activity, got %v", activityStore.payloads)
+			}
+
+			payload, ok := activityStore.payloads[0].(*types.PullRequestActivityPayloadAutoMerge)
+			if !ok || payload.Action != enum.AutoMergeActionCanceled {
+				t.Errorf("expected auto-merge canceled activity, got %+v", activityStore.payloads[0])
+			}
+		})
+	}
+}
+
+func TestAutoMergeService_CheckReported(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     enum.CheckStatus
+		commitSHA  string
+		wantMerged []int64
+	}{
+		{
+			name:       "completed check",
+			status:     enum.CheckStatusSuccess,
+			commitSHA:  autoMergeSHA,
+			wantMerged: []int64{1},
+		},
+		{
+			name:       "running check",
+			status:     enum.CheckStatusRunning,
+			commitSHA:  autoMergeSHA,
+			wantMerged: nil,
+		},
+		{
+			name:       "other commit",
+			status:     enum.CheckStatusSuccess,
+			commitSHA:  "2222222222222222222222222222222222222222",
+			wantMerged: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, merger, _, _ := newTestAutoMergeService(
+				newAutoMergePullReq(1, enum.PullReqStateOpen),
+				newAutoMergePullReq(2, enum.PullReqStateClosed))
+
+			err := service.autoMergeOnCheckReported(context.Background(),
+				&events.Event[*checkevents.ReportedPayload]{
+					Payload: &checkevents.ReportedPayload{
+						RepoID:    stackRepoID,
+						CommitSHA: test.commitSHA,
+						Status:    test.status,
+					},
+				})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(merger.merged) != len(test.wantMerged) ||
+				len(test.wantMerged) > 0 && merger.merged[0] != test.wantMerged[0] {
+				t.Errorf("want merged pull requests %v, got %v", test.wantMerged, merger.merged)
+			}
+		})
+	}
+}
@@ -57,9 +57,6 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		}
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
 		// First check if the merge base has changed
 
@@ -68,6 +65,19 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			return fmt.Errorf("failed to get repo git info: %w", err)
 		}
 
+		// The new commits of a fork must be present in the target repository before the merge base is computed.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+			if err != nil {
+				return fmt.Errorf("failed to generate rpc write params: %w", err)
+			}
+
+			err = s.fetchSourceCommits(ctx, writeParams, pr.SourceRepoID, pr.TargetRepoID, event.Payload.NewSHA)
+			if err != nil {
+				return err
+			}
+		}
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -150,6 +160,18 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 	return nil
 }
 
+// handleBranchDelete handles branch delete events.
+// Pull requests from the deleted branch are closed, and pull requests stacked on top of it are retargeted.
+func (s *Service) handleBranchDelete(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload],
+) error {
+	if err := s.closePullReqOnBranchDelete(ctx, event); err != nil {
+		return err
+	}
+
+	return s.retargetStackedPullReqsOnBranchDelete(ctx, event)
+}
+
 // closePullReqOnBranchDelete handles branch delete events.
 // It closes every open pull request for the branch and triggers the pull request BranchDeleted event.
 func (s *Service) closePullReqOnBranchDelete(ctx context.Context,
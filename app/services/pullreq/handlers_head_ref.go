@@ -41,8 +41,12 @@ func (s *Service) createHeadRefOnCreated(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo).
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceCommits(ctx, writeParams, event.Payload.SourceRepoID, event.Payload.TargetRepoID,
+		event.Payload.SourceSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -72,8 +76,12 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceCommits(ctx, writeParams, event.Payload.SourceRepoID, event.Payload.TargetRepoID,
+		event.Payload.NewSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -103,8 +111,12 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceCommits(ctx, writeParams, event.Payload.SourceRepoID, event.Payload.TargetRepoID,
+		event.Payload.SourceSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -118,3 +130,33 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 
 	return nil
 }
+
+// fetchSourceCommits copies the source commit of a pull request from the source repository (a fork)
+// into the target repository. It's a no-op for pull requests within the same repository.
+func (s *Service) fetchSourceCommits(
+	ctx context.Context,
+	targetWriteParams git.WriteParams,
+	sourceRepoID int64,
+	targetRepoID int64,
+	sourceSHA string,
+) error {
+	if sourceRepoID == targetRepoID {
+		return nil
+	}
+
+	sourceRepo, err := s.repoGitInfoCache.Get(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   targetWriteParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHA:     sha.Must(sourceSHA),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commits into the target repository: %w", err)
+	}
+
+	return nil
+}
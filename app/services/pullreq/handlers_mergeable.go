@@ -80,18 +80,32 @@ func (s *Service) mergeCheckOnReopen(ctx context.Context,
 	)
 }
 
+// mergeCheckOnTargetBranchChange handles pull request Target Branch Changed events.
+// It recomputes the mergeability against the new target branch.
+func (s *Service) mergeCheckOnTargetBranchChange(ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchChangedPayload],
+) error {
+	return s.updateMergeData(
+		ctx,
+		event.Payload.TargetRepoID,
+		event.Payload.Number,
+		sha.None.String(),
+		event.Payload.SourceSHA,
+	)
+}
+
 // mergeCheckOnClosed deletes the merge ref.
 func (s *Service) mergeCheckOnClosed(ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload],
 ) error {
-	return s.deleteMergeRef(ctx, event.Payload.SourceRepoID, event.Payload.Number)
+	return s.deleteMergeRef(ctx, event.Payload.TargetRepoID, event.Payload.Number)
 }
 
 // mergeCheckOnMerged deletes the merge ref.
 func (s *Service) mergeCheckOnMerged(ctx context.Context,
 	event *events.Event[*pullreqevents.MergedPayload],
 ) error {
-	return s.deleteMergeRef(ctx, event.Payload.SourceRepoID, event.Payload.Number)
+	return s.deleteMergeRef(ctx, event.Payload.TargetRepoID, event.Payload.Number)
 }
 
 func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64) error {
@@ -105,7 +119,6 @@ func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64)
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(prNum)),
@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	errPRTargetBranchChanged = errors.New("PR target branch has changed")
+)
+
+// retargetStackedPullReqsOnBranchDelete handles branch delete events.
+// If the deleted branch was the source branch of a merged pull request, every open pull request
+// that targets the deleted branch (i.e. is stacked on top of the merged pull request)
+// gets retargeted to the target branch of the merged pull request.
+// The branch must point to the merged commits when deleted, otherwise it was reused after the merge.
+func (s *Service) retargetStackedPullReqsOnBranchDelete(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload],
+) error {
+	const largeLimit = 1000000
+
+	branch, err := getBranchFromRef(event.Payload.Ref)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Send()
+		return nil
+	}
+
+	stacked, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Page:         0,
+		Size:         largeLimit,
+		TargetRepoID: event.Payload.RepoID,
+		TargetBranch: branch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests targeting the deleted branch: %w", err)
+	}
+
+	if len(stacked) == 0 {
+		return nil
+	}
+
+	merged, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Page:         0,
+		Size:         1,
+		SourceRepoID: event.Payload.RepoID,
+		SourceBranch: branch,
+		TargetRepoID: event.Payload.RepoID,
+		States:       []enum.PullReqState{enum.PullReqStateMerged},
+		Sort:         enum.PullReqSortMerged,
+		Order:        enum.OrderDesc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find merged pull request for the deleted branch: %w", err)
+	}
+
+	// The branch wasn't deleted as a result of a merge, the pull requests targeting it are left as they are.
+	if len(merged) == 0 {
+		return nil
+	}
+
+	mergedPR := merged[0]
+
+	ok, err := s.isBranchMerged(ctx, mergedPR, event.Payload.SHA)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Ctx(ctx).Info().Msgf("deleted branch %q doesn't match the merged pull request %d, "+
+			"stacked pull requests are not retargeted", branch, mergedPR.Number)
+		return nil
+	}
+
+	// Failures are returned to get the event redelivered.
+	// Pull requests that are already retargeted are skipped on redelivery.
+	var errs []error
+	for _, pr := range stacked {
+		if err = s.retargetPullReq(ctx, pr, branch, mergedPR, event.Payload.PrincipalID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to retarget stacked pull request %d: %w", pr.Number, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// isBranchMerged returns true if the deleted branch pointed to the commits merged by the pull request:
+// Either to the latest source commit of the pull request or to a commit that is part of the merge commit.
+func (s *Service) isBranchMerged(ctx context.Context, mergedPR *types.PullReq, branchSHA string) (bool, error) {
+	if mergedPR.SourceSHA == branchSHA {
+		return true, nil
+	}
+
+	if mergedPR.MergeSHA == nil || branchSHA == "" {
+		return false, nil
+	}
+
+	deletedSHA, err := sha.New(branchSHA)
+	if err != nil {
+		return false, fmt.Errorf("invalid commit SHA of the deleted branch: %w", err)
+	}
+
+	mergeSHA, err := sha.New(*mergedPR.MergeSHA)
+	if err != nil {
+		return false, fmt.Errorf("invalid merge commit SHA of the merged pull request: %w", err)
+	}
+
+	targetRepo, err := s.repoGitInfoCache.Get(ctx, mergedPR.TargetRepoID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get repo git info: %w", err)
+	}
+
+	result, err := s.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          git.ReadParams{RepoUID: targetRepo.GitUID},
+		AncestorCommitSHA:   deletedSHA,
+		DescendantCommitSHA: mergeSHA,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check if the deleted branch is merged: %w", err)
+	}
+
+	return result.Ancestor, nil
+}
+
+// retargetPullReq changes the target branch of the pull request to the target branch of the merged pull request.
+func (s *Service) retargetPullReq(ctx context.Context,
+	pr *types.PullReq,
+	oldTargetBranch string,
+	mergedPR *types.PullReq,
+	principalID int64,
+) error {
+	newTargetBranch := mergedPR.TargetBranch
+
+	if pr.SourceRepoID == pr.TargetRepoID && pr.SourceBranch == newTargetBranch {
+		log.Ctx(ctx).Warn().Msgf("can't retarget pull request %d to its own source branch %q",
+			pr.Number, newTargetBranch)
+		return nil
+	}
+
+	// Only one open pull request is allowed for the same source and target branches.
+	existing, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         1,
+		SourceRepoID: pr.SourceRepoID,
+		SourceBranch: pr.SourceBranch,
+		TargetRepoID: pr.TargetRepoID,
+		TargetBranch: newTargetBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check for existing pull requests: %w", err)
+	}
+	if len(existing) > 0 {
+		log.Ctx(ctx).Warn().Msgf("can't retarget pull request %d to %q, pull request %d already exists",
+			pr.Number, newTargetBranch, existing[0].Number)
+		return nil
+	}
+
+	targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo git info: %w", err)
+	}
+
+	mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       pr.SourceSHA,
+		Ref2:       newTargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get merge base with the new target branch %q: %w", newTargetBranch, err)
+	}
+
+	oldMergeBase := pr.MergeBaseSHA
+	newMergeBase := mergeBaseInfo.MergeBaseSHA
+
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		// to avoid racing conditions with merge
+		if pr.State != enum.PullReqStateOpen {
+			return errPRNotOpen
+		}
+
+		if pr.TargetBranch != oldTargetBranch {
+			return errPRTargetBranchChanged
+		}
+
+		pr.ActivitySeq++
+		pr.Edited = time.Now().UnixMilli()
+		pr.TargetBranch = newTargetBranch
+		pr.MergeBaseSHA = newMergeBase.String()
+
+		// reset merge-check fields for new run
+		pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
+		pr.MergeTargetSHA = nil
+		pr.MergeSHA = nil
+		pr.MergeConflicts = nil
+		pr.Stats.DiffStats.Commits = nil
+		pr.Stats.DiffStats.FilesChanged = nil
+
+		return nil
+	})
+	if errors.Is(err, errPRNotOpen) || errors.Is(err, errPRTargetBranchChanged) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update target branch of the pull request: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadTargetBranchChange{
+		Old:                 oldTargetBranch,
+		New:                 newTargetBranch,
+		MergedPullReqNumber: mergedPR.Number,
+	}
+
+	_, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after target branch change")
+	}
+
+	s.pullreqEvReporter.TargetBranchChanged(ctx, &pullreqevents.TargetBranchChangedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principalID,
+			Number:       pr.Number,
+		},
+		SourceSHA:       pr.SourceSHA,
+		OldTargetBranch: oldTargetBranch,
+		NewTargetBranch: newTargetBranch,
+		OldMergeBaseSHA: oldMergeBase,
+		NewMergeBaseSHA: newMergeBase.String(),
+	})
+
+	if err = s.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const stackRepoID = 1
+
+var (
+	stackSourceSHA = sha.Must("1111111111111111111111111111111111111111")
+	stackMergeSHA  = sha.Must("2222222222222222222222222222222222222222")
+	stackOtherSHA  = sha.Must("3333333333333333333333333333333333333333")
+)
+
+type stackPullReqStore struct {
+	store.PullReqStore
+	prs []*types.PullReq
+	// beforeUpdate is called with the stored pull request before it's updated.
+	beforeUpdate func(pr *types.PullReq)
+}
+
+func (s *stackPullReqStore) List(_ context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
+	var result []*types.PullReq
+	for _, pr := range s.prs {
+		if len(result) >= opts.Size ||
+			len(opts.States) > 0 && pr.State != opts.States[0] ||
+			opts.SourceRepoID != 0 && pr.SourceRepoID != opts.SourceRepoID ||
+			opts.SourceBranch != "" && pr.SourceBranch != opts.SourceBranch ||
+			opts.TargetRepoID != 0 && pr.TargetRepoID != opts.TargetRepoID ||
+			opts.TargetBranch != "" && pr.TargetBranch != opts.TargetBranch {
+			continue
+		}
+		prCopy := *pr
+		result = append(result, &prCopy)
+	}
+	return result, nil
+}
+
+func (s *stackPullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	for _, stored := range s.prs {
+		if stored.ID != pr.ID {
+			continue
+		}
+		if s.beforeUpdate != nil {
+			s.beforeUpdate(stored)
+		}
+		if err := mutateFn(stored); err != nil {
+			return nil, err
+		}
+		prCopy := *stored
+		return &prCopy, nil
+	}
+	return nil, errors.New("pull request not found")
+}
+
+func (s *stackPullReqStore) find(number int64) *types.PullReq {
+	for _, pr := range s.prs {
+		if pr.Number == number {
+			return pr
+		}
+	}
+	return nil
+}
+
+type stackActivityStore struct {
+	store.PullReqActivityStore
+	payloads []types.PullReqActivityPayload
+}
+
+func (s *stackActivityStore) CreateWithPayload(
+	_ context.Context,
+	_ *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+) (*types.PullReqActivity, error) {
+	s.payloads = append(s.payloads, payload)
+	return &types.PullReqActivity{}, nil
+}
+
+type stackRepoGitInfoCache struct{}
+
+func (stackRepoGitInfoCache) Stats() (int64, int64) { return 0, 0 }
+
+func (stackRepoGitInfoCache) Get(_ context.Context, id int64) (*types.RepositoryGitInfo, error) {
+	return &types.RepositoryGitInfo{ID: id, GitUID: "repo"}, nil
+}
+
+type stackGit struct {
+	git.Interface
+	ancestor     bool
+	mergeBaseErr error
+}
+
+func (g *stackGit) MergeBase(context.Context, git.MergeBaseParams) (git.MergeBaseOutput, error) {
+	if g.mergeBaseErr != nil {
+		return git.MergeBaseOutput{}, g.mergeBaseErr
+	}
+	return git.MergeBaseOutput{MergeBaseSHA: stackMergeSHA}, nil
+}
+
+func (g *stackGit) IsAncestor(_ context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error) {
+	return git.IsAncestorOutput{Ancestor: g.ancestor && params.DescendantCommitSHA == stackMergeSHA}, nil
+}
+
+type stackStreamer struct {
+	sse.Streamer
+}
+
+func (stackStreamer) Publish(context.Context, int64, enum.SSEType, any) error { return nil }
+
+func stackPullReq(number int64, state enum.PullReqState, sourceBranch, targetBranch string) *types.PullReq {
+	return &types.PullReq{
+		ID:           number,
+		Number:       number,
+		State:        state,
+		SourceRepoID: stackRepoID,
+		SourceBranch: sourceBranch,
+		SourceSHA:    stackOtherSHA.String(),
+		TargetRepoID: stackRepoID,
+		TargetBranch: targetBranch,
+	}
+}
+
+func TestService_RetargetStackedPullReqsOnBranchDelete(t *testing.T) {
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %s", err.Error())
+	}
+
+	reporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %s", err.Error())
+	}
+
+	mergedPR := func() *types.PullReq {
+		pr := stackPullReq(1, enum.PullReqStateMerged, "feature", "main")
+		pr.SourceSHA = stackSourceSHA.String()
+		mergeSHA := stackMergeSHA.String()
+		pr.MergeSHA = &mergeSHA
+		return pr
+	}
+
+	tests := []struct {
+		name         string
+		prs          []*types.PullReq
+		deletedSHA   sha.SHA
+		ancestor     bool
+		mergeBaseErr error
+		beforeUpdate func(pr *types.PullReq)
+		wantErr      bool
+		wantTargets  map[int64]string
+		// wantRetargeted is the number of retargeted pull requests.
+		wantRetargeted int
+	}{
+		{
+			name: "retarget",
+			prs: []*types.PullReq{
+				mergedPR(),
+				stackPullReq(2, enum.PullReqStateOpen, "next", "feature"),
+				stackPullReq(3, enum.PullReqStateOpen, "other", "feature"),
+			},
+			deletedSHA:     stackSourceSHA,
+			wantTargets:    map[int64]string{2: "main", 3: "main"},
+			wantRetargeted: 2,
+		},
+		{
+			name: "deleted-commit-merged",
+			prs: []*types.PullReq{
+				mergedPR(),
+				stackPullReq(2, enum.PullReqStateOpen, "next", "feature"),
+			},
+			deletedSHA:     stackOtherSHA,
+			ancestor:       true,
+			wantTargets:    map[int64]string{2: "main"},
+			wantRetargeted: 1,
+		},
+		{
+			name: "branch-reused",
+			prs: []*types.PullReq{
+				mergedPR(),
+				stackPullReq(2, enum.PullReqStateOpen, "next", "feature"),
+			},
+			deletedSHA:  stackOtherSHA,
+			wantTargets: map[int64]string{2: "feature"},
+		},
+		{
+			name: "not-merged",
+			prs: []*types.PullReq{
+				stackPullReq(1, enum.PullReqStateClosed, "feature", "main"),
+				stackPullReq(2, enum.PullReqStateOpen, "next", "feature"),
+			},
+			deletedSHA:  stackOtherSHA,
+			wantTargets: map[int64]string{2: "feature"},
+		},
+		{
+			name: "own-source-branch",
+			prs: []*types.PullReq{
+				mergedPR(),
+				stackPullReq(2, enum.PullReqStateOpen, "main", "feature"),
+			},
+			deletedSHA:  stackSourceSHA,
+			wantTargets: map[int64]string{2: "feature"},
+		},
+		{
+			name: "existing-pull-request",
+			prs: []*types.PullReq{
+				mergedPR(),
+				stackPullReq(2, enum.PullReqStateOpen, "next", "feature"),
+				stackPullReq(3, enum.PullReqStateOpen, "next", "main"),
+			},
+			deletedSHA:  stackSourceSHA,
+			wantTargets: map[int64]string{2: "feature", 3: "main"},
+		},
+		{
+			name: "target-already-changed",
+			prs: []*types.PullReq{
+				mergedPR(),
+				stackPullReq(2, enum.PullReqStateOpen, "next", "feature"),
+			},
+			deletedSHA: stackSourceSHA,
+			beforeUpdate: func(pr *types.PullReq) {
+				pr.TargetBranch = "develop"
+			},
+			wantTargets: map[int64]string{2: "develop"},
+		},
+		{
+			name: "failure",
+			prs: []*types.PullReq{
+				mergedPR(),
+				stackPullReq(2, enum.PullReqStateOpen, "next", "feature"),
+			},
+			deletedSHA:   stackSourceSHA,
+			mergeBaseErr: errors.New("git failed"),
+			wantErr:      true,
+			wantTargets:  map[int64]string{2: "feature"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullreqStore := &stackPullReqStore{prs: test.prs, beforeUpdate: test.beforeUpdate}
+			activityStore := &stackActivityStore{}
+			s := &Service{
+				pullreqEvReporter: reporter,
+				git:               &stackGit{ancestor: test.ancestor, mergeBaseErr: test.mergeBaseErr},
+				repoGitInfoCache:  stackRepoGitInfoCache{},
+				pullreqStore:      pullreqStore,
+				activityStore:     activityStore,
+				sseStreamer:       stackStreamer{},
+			}
+
+			err := s.retargetStackedPullReqsOnBranchDelete(context.Background(),
+				&events.Event[*gitevents.BranchDeletedPayload]{
+					Payload: &gitevents.BranchDeletedPayload{
+						RepoID: stackRepoID,
+						Ref:    "refs/heads/feature",
+						SHA:    test.deletedSHA.String(),
+					},
+				})
+			if test.wantErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for number, want := range test.wantTargets {
+				if pr := pullreqStore.find(number); pr.TargetBranch != want {
+					t.Errorf("pull request %d: want target %q, got %q", number, want, pr.TargetBranch)
+				}
+			}
+
+			if len(activityStore.payloads) != test.wantRetargeted {
+				t.Errorf("expected %d target branch change activities, got %d",
+					test.wantRetargeted, len(activityStore.payloads))
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// IdentityFromPrincipalInfo returns the git identity of the principal.
+func IdentityFromPrincipalInfo(p types.PrincipalInfo) *git.Identity {
+	return &git.Identity{
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
+
+// LogMergeAudit records the merge of the pull request in the audit log.
+// If any protection rules were bypassed to allow the merge, this is recorded as a separate event.
+func LogMergeAudit(
+	ctx context.Context,
+	auditService audit.Service,
+	principal types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	violations []types.RuleViolations,
+	bypassJustification string,
+) {
+	resource := audit.NewResource(audit.ResourceTypePullRequest, strconv.FormatInt(pr.Number, 10))
+	spacePath := paths.Parent(repo.Path)
+
+	err := auditService.Log(ctx,
+		principal,
+		resource,
+		audit.ActionMerged,
+		spacePath,
+		audit.WithNewObject(pr),
+		audit.WithData(audit.DataKeyRepoName, repo.Identifier, audit.DataKeyRef, pr.TargetBranch),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for merge pull request operation: %s", err)
+	}
+
+	if !protection.IsBypassed(violations) {
+		return
+	}
+
+	err = auditService.Log(ctx,
+		principal,
+		resource,
+		audit.ActionBypassed,
+		spacePath,
+		audit.WithNewObject(violations),
+		audit.WithData(
+			audit.DataKeyRepoName, repo.Identifier,
+			audit.DataKeyRef, pr.TargetBranch,
+			audit.DataKeyBypassJustification, bypassJustification,
+		),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for bypassed merge pull request operation: %s", err)
+	}
+}
+
+// DeleteSourceBranch deletes the source branch of the merged pull request
+// and writes the branch delete activity using the provided activity sequence number.
+// Failures aren't critical for the merge, so they are only logged. It returns true if the branch got deleted.
+func DeleteSourceBranch(
+	ctx context.Context,
+	gitInterface git.Interface,
+	activityStore store.PullReqActivityStore,
+	writeParams git.WriteParams,
+	pr *types.PullReq,
+	principalID int64,
+	mergedSHA string,
+	activitySeq int64,
+) bool {
+	err := gitInterface.DeleteBranch(ctx, &git.DeleteBranchParams{
+		WriteParams: writeParams,
+		BranchName:  pr.SourceBranch,
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to delete source branch after merging")
+		return false
+	}
+
+	// NOTE: there is a chance someone pushed on the branch between merge and delete.
+	// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
+	pr.ActivitySeq = activitySeq
+	if _, errAct := activityStore.CreateWithPayload(ctx, pr, principalID,
+		&types.PullRequestActivityPayloadBranchDelete{SHA: mergedSHA}); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).
+			Msgf("failed to write pull request activity for successful automatic branch delete")
+	}
+
+	return true
+}
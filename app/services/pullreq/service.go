@@ -104,7 +104,7 @@ func New(ctx context.Context,
 				))
 
 			_ = r.RegisterBranchUpdated(service.triggerPREventOnBranchUpdate)
-			_ = r.RegisterBranchDeleted(service.closePullReqOnBranchDelete)
+			_ = r.RegisterBranchDeleted(service.handleBranchDelete)
 
 			return nil
 		})
@@ -195,6 +195,7 @@ func New(ctx context.Context,
 			_ = r.RegisterReopened(service.mergeCheckOnReopen)
 			_ = r.RegisterClosed(service.mergeCheckOnClosed)
 			_ = r.RegisterMerged(service.mergeCheckOnMerged)
+			_ = r.RegisterTargetBranchChanged(service.mergeCheckOnTargetBranchChange)
 
 			return nil
 		})
@@ -17,6 +17,7 @@ package pullreq
 import (
 	"context"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
@@ -33,6 +34,7 @@ import (
 
 var WireSet = wire.NewSet(
 	ProvideService,
+	ProvideAutoMergeService,
 )
 
 func ProvideService(ctx context.Context,
@@ -56,3 +58,18 @@ func ProvideService(ctx context.Context,
 		repoGitInfoCache, repoStore, pullreqStore, activityStore,
 		codeCommentView, codeCommentMigrator, fileViewStore, pubsub, urlProvider, sseStreamer)
 }
+
+func ProvideAutoMergeService(ctx context.Context,
+	config *types.Config,
+	pullReqEvFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvFactory *events.ReaderFactory[*checkevents.Reader],
+	merger Merger,
+	autoMergeStore store.AutoMergeStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	repoStore store.RepoStore,
+	sseStreamer sse.Streamer,
+) (*AutoMergeService, error) {
+	return NewAutoMergeService(ctx, config, pullReqEvFactory, checkEvFactory, merger,
+		autoMergeStore, pullreqStore, activityStore, repoStore, sseStreamer)
+}
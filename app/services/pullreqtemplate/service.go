@@ -0,0 +1,309 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultName is the name of the default pull request template.
+	DefaultName = "default"
+
+	// maxTemplateSize is the maximum size of a template file. Larger files are ignored.
+	maxTemplateSize = 64 * 1024
+	// maxNamedTemplates is the maximum number of named templates read from a template directory.
+	maxNamedTemplates = 50
+)
+
+// templateExtensions are the file extensions of the files in a template directory that are considered templates.
+var templateExtensions = []string{".md", ".markdown", ".txt"}
+
+type Config struct {
+	// FilePaths are the possible locations of the default template, first found is used.
+	FilePaths []string
+	// DirPaths are the possible locations of the directory with named templates, first found is used.
+	DirPaths []string
+}
+
+// Service reads pull request templates from the target branch of a pull request.
+type Service struct {
+	git    git.Interface
+	config Config
+}
+
+func New(git git.Interface, config Config) *Service {
+	return &Service{
+		git:    git,
+		config: config,
+	}
+}
+
+// List returns all pull request templates available on the provided git reference.
+// The default template, if present, is always the first element of the list.
+// Named templates are sorted by name.
+func (s *Service) List(ctx context.Context, repo *types.Repository, ref string) ([]types.PullReqTemplate, error) {
+	params := git.CreateReadParams(repo)
+
+	templates := make([]types.PullReqTemplate, 0)
+
+	defaultTemplate, err := s.getDefault(ctx, params, ref)
+	if err != nil {
+		return nil, err
+	}
+	if defaultTemplate != nil {
+		templates = append(templates, *defaultTemplate)
+	}
+
+	named, err := s.listNamed(ctx, params, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(templates, named...), nil
+}
+
+// Find returns the pull request template with the provided name.
+// Only the content of the requested template is read.
+// It returns errors.NotFound if the template doesn't exist.
+func (s *Service) Find(
+	ctx context.Context,
+	repo *types.Repository,
+	ref string,
+	name string,
+) (*types.PullReqTemplate, error) {
+	params := git.CreateReadParams(repo)
+
+	var template *types.PullReqTemplate
+	var err error
+
+	if strings.EqualFold(name, DefaultName) {
+		template, err = s.getDefault(ctx, params, ref)
+	} else {
+		template, err = s.findNamed(ctx, params, ref, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if template == nil {
+		return nil, errors.NotFound("Pull request template '%s' not found", name)
+	}
+
+	return template, nil
+}
+
+// getDefault returns the default template from the first configured file path that exists.
+// It returns nil if none of the file paths exist.
+func (s *Service) getDefault(ctx context.Context, params git.ReadParams, ref string) (*types.PullReqTemplate, error) {
+	for _, filePath := range s.config.FilePaths {
+		node, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+			ReadParams: params,
+			GitREF:     ref,
+			Path:       filePath,
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request template file node: %w", err)
+		}
+
+		if node.Node.Mode != git.TreeNodeModeFile {
+			continue
+		}
+
+		content, ok, err := s.readContent(ctx, params, node.Node.SHA)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			log.Ctx(ctx).Warn().Msgf("pull request template %s exceeds the size limit", filePath)
+			return nil, nil
+		}
+
+		return &types.PullReqTemplate{
+			Name:    DefaultName,
+			Path:    filePath,
+			Content: content,
+			Default: true,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// listNamed returns the templates from the first configured template directory that exists.
+func (s *Service) listNamed(ctx context.Context, params git.ReadParams, ref string) ([]types.PullReqTemplate, error) {
+	nodes, err := s.listTemplateDir(ctx, params, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]types.PullReqTemplate, 0)
+	for _, node := range nodes {
+		if len(templates) >= maxNamedTemplates {
+			break
+		}
+
+		name, ok := templateName(node)
+		if !ok || strings.EqualFold(name, DefaultName) {
+			continue
+		}
+
+		template, err := s.readNamed(ctx, params, node, name)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			continue
+		}
+
+		templates = append(templates, *template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return strings.ToLower(templates[i].Name) < strings.ToLower(templates[j].Name)
+	})
+
+	return templates, nil
+}
+
+// findNamed returns the template with the provided name from the first configured template directory that exists.
+// It returns nil if the template doesn't exist or exceeds the size limit.
+func (s *Service) findNamed(
+	ctx context.Context,
+	params git.ReadParams,
+	ref string,
+	name string,
+) (*types.PullReqTemplate, error) {
+	nodes, err := s.listTemplateDir(ctx, params, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		nodeName, ok := templateName(node)
+		if !ok || !strings.EqualFold(nodeName, name) {
+			continue
+		}
+
+		return s.readNamed(ctx, params, node, nodeName)
+	}
+
+	return nil, nil
+}
+
+// listTemplateDir returns the tree nodes of the first configured template directory that exists.
+func (s *Service) listTemplateDir(ctx context.Context, params git.ReadParams, ref string) ([]git.TreeNode, error) {
+	for _, dirPath := range s.config.DirPaths {
+		output, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+			ReadParams: params,
+			GitREF:     ref,
+			Path:       dirPath,
+		})
+		if errors.IsNotFound(err) || errors.IsInvalidArgument(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request template directory: %w", err)
+		}
+
+		return output.Nodes, nil
+	}
+
+	return nil, nil
+}
+
+// readNamed reads the named template from the tree node. It returns nil if the template exceeds the size limit.
+func (s *Service) readNamed(
+	ctx context.Context,
+	params git.ReadParams,
+	node git.TreeNode,
+	name string,
+) (*types.PullReqTemplate, error) {
+	content, ok, err := s.readContent(ctx, params, node.SHA)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		log.Ctx(ctx).Warn().Msgf("pull request template %s exceeds the size limit", node.Path)
+		return nil, nil
+	}
+
+	return &types.PullReqTemplate{
+		Name:    name,
+		Path:    node.Path,
+		Content: content,
+	}, nil
+}
+
+// readContent returns the content of the blob. It returns false if the blob exceeds the size limit.
+func (s *Service) readContent(ctx context.Context, params git.ReadParams, sha string) (string, bool, error) {
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: params,
+		SHA:        sha,
+		SizeLimit:  maxTemplateSize,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get pull request template content: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if output.Size > maxTemplateSize {
+		return "", false, nil
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read pull request template content: %w", err)
+	}
+
+	return string(content), true, nil
+}
+
+// templateName returns the name of a named template, which is the file name without the extension.
+// It returns false if the tree node isn't a template file.
+func templateName(node git.TreeNode) (string, bool) {
+	if node.Mode != git.TreeNodeModeFile {
+		return "", false
+	}
+
+	ext := path.Ext(node.Name)
+	for _, templateExt := range templateExtensions {
+		if strings.EqualFold(ext, templateExt) {
+			name := strings.TrimSuffix(node.Name, ext)
+			return name, name != ""
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+func TestTemplateName(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     git.TreeNode
+		wantName string
+		wantOK   bool
+	}{
+		{
+			name:     "markdown file",
+			node:     git.TreeNode{Mode: git.TreeNodeModeFile, Name: "bugfix.md"},
+			wantName: "bugfix",
+			wantOK:   true,
+		},
+		{
+			name:     "extension is case insensitive",
+			node:     git.TreeNode{Mode: git.TreeNodeModeFile, Name: "Feature.MARKDOWN"},
+			wantName: "Feature",
+			wantOK:   true,
+		},
+		{
+			name:   "unsupported extension",
+			node:   git.TreeNode{Mode: git.TreeNodeModeFile, Name: "logo.png"},
+			wantOK: false,
+		},
+		{
+			name:   "extension only",
+			node:   git.TreeNode{Mode: git.TreeNodeModeFile, Name: ".md"},
+			wantOK: false,
+		},
+		{
+			name:   "directory",
+			node:   git.TreeNode{Mode: git.TreeNodeModeTree, Name: "nested.md"},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := templateName(tt.node)
+			if ok != tt.wantOK || name != tt.wantName {
+				t.Errorf("templateName() = (%q, %v), want (%q, %v)", name, ok, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
+type fakeGit struct {
+	git.Interface
+	// files maps file paths to blob SHAs.
+	files map[string]string
+	// dirs maps directory paths to their tree nodes.
+	dirs map[string][]git.TreeNode
+	// blobs maps blob SHAs to their content.
+	blobs     map[string]string
+	blobReads int
+}
+
+func (g *fakeGit) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	blobSHA, ok := g.files[params.Path]
+	if !ok {
+		return nil, errors.NotFound("path not found")
+	}
+	return &git.GetTreeNodeOutput{
+		Node: git.TreeNode{Mode: git.TreeNodeModeFile, SHA: blobSHA, Path: params.Path},
+	}, nil
+}
+
+func (g *fakeGit) ListTreeNodes(_ context.Context, params *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	nodes, ok := g.dirs[params.Path]
+	if !ok {
+		return nil, errors.NotFound("path not found")
+	}
+	return &git.ListTreeNodeOutput{Nodes: nodes}, nil
+}
+
+func (g *fakeGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	g.blobReads++
+	content := g.blobs[params.SHA]
+	if params.SizeLimit > 0 && int64(len(content)) > params.SizeLimit {
+		content = content[:params.SizeLimit]
+	}
+	return &git.GetBlobOutput{
+		Size:        int64(len(g.blobs[params.SHA])),
+		ContentSize: int64(len(content)),
+		Content:     io.NopCloser(strings.NewReader(content)),
+	}, nil
+}
+
+func templateNode(name string) git.TreeNode {
+	return git.TreeNode{
+		Mode: git.TreeNodeModeFile,
+		Name: name + ".md",
+		Path: "docs/pull_request_templates/" + name + ".md",
+		SHA:  name,
+	}
+}
+
+func newTestService() (*Service, *fakeGit) {
+	fake := &fakeGit{
+		files: map[string]string{
+			".harness/pull_request_template.md": "default-harness",
+			"docs/pull_request_template.md":     "default-docs",
+		},
+		dirs: map[string][]git.TreeNode{
+			"docs/pull_request_templates": {
+				templateNode("bugfix"),
+				templateNode("large"),
+				templateNode("feature"),
+			},
+		},
+		blobs: map[string]string{
+			"default-harness": "harness default",
+			"default-docs":    "docs default",
+			"bugfix":          "bugfix template",
+			"large":           strings.Repeat("x", maxTemplateSize+1),
+			"feature":         "feature template",
+		},
+	}
+
+	service := New(fake, Config{
+		FilePaths: []string{
+			".github/pull_request_template.md",
+			".harness/pull_request_template.md",
+			"docs/pull_request_template.md",
+		},
+		DirPaths: []string{
+			".harness/pull_request_templates",
+			"docs/pull_request_templates",
+		},
+	})
+
+	return service, fake
+}
+
+func TestService_Find(t *testing.T) {
+	tests := []struct {
+		name          string
+		templateName  string
+		wantPath      string
+		wantContent   string
+		wantNotFound  bool
+		wantBlobReads int
+	}{
+		{
+			name:          "first configured default template wins",
+			templateName:  "Default",
+			wantPath:      ".harness/pull_request_template.md",
+			wantContent:   "harness default",
+			wantBlobReads: 1,
+		},
+		{
+			name:          "named template from first existing directory",
+			templateName:  "Feature",
+			wantPath:      "docs/pull_request_templates/feature.md",
+			wantContent:   "feature template",
+			wantBlobReads: 1,
+		},
+		{
+			name:          "template exceeding the size limit is skipped",
+			templateName:  "large",
+			wantNotFound:  true,
+			wantBlobReads: 1,
+		},
+		{
+			name:          "unknown template",
+			templateName:  "unknown",
+			wantNotFound:  true,
+			wantBlobReads: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, fake := newTestService()
+
+			template, err := service.Find(context.Background(), &types.Repository{}, "main", test.templateName)
+			if test.wantNotFound {
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected not found error, got: %v", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("failed to find template: %v", err)
+				}
+				if template.Path != test.wantPath || template.Content != test.wantContent {
+					t.Errorf("want template %s with content %q, got %s with content %q",
+						test.wantPath, test.wantContent, template.Path, template.Content)
+				}
+			}
+
+			if fake.blobReads != test.wantBlobReads {
+				t.Errorf("want %d blob reads, got %d", test.wantBlobReads, fake.blobReads)
+			}
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	service, _ := newTestService()
+
+	templates, err := service.List(context.Background(), &types.Repository{}, "main")
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+
+	var names []string
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+
+	if want, got := "default,bugfix,feature", strings.Join(names, ","); want != got {
+		t.Errorf("want templates %s, got %s", want, got)
+	}
+}
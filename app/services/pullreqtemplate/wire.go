@@ -12,16 +12,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package pullreq
+package pullreqtemplate
 
 import (
 	"github.com/harness/gitness/git"
-	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvidePullReqTemplates,
 )
 
-func identityFromPrincipalInfo(p types.PrincipalInfo) *git.Identity {
-	return &git.Identity{
-		Name:  p.DisplayName,
-		Email: p.Email,
-	}
+func ProvidePullReqTemplates(git git.Interface, config Config) *Service {
+	return New(git, config)
 }
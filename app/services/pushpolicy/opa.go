@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+)
+
+type opaDataRequest struct {
+	Input any `json:"input"`
+}
+
+type opaDataResponse struct {
+	Result *Result `json:"result"`
+}
+
+type opaErrorResponse struct {
+	Message string `json:"message"`
+	Errors  []struct {
+		Message  string `json:"message"`
+		Location *struct {
+			Row int `json:"row"`
+			Col int `json:"col"`
+		} `json:"location"`
+	} `json:"errors"`
+}
+
+// query evaluates the decision using the data API of the OPA server.
+// It returns false if the decision is undefined.
+func (s *Service) query(ctx context.Context, decision string, input any) (Result, bool, error) {
+	data, err := json.Marshal(opaDataRequest{Input: input})
+	if err != nil {
+		return Result{}, false, fmt.Errorf("failed to marshal push policy input: %w", err)
+	}
+
+	body, err := s.do(ctx, http.MethodPost, "/v1/data/"+decision, "application/json", data)
+	if err != nil {
+		return Result{}, false, err
+	}
+
+	var response opaDataResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		return Result{}, false, fmt.Errorf("failed to parse push policy decision %q: %w", decision, err)
+	}
+
+	if response.Result == nil {
+		return Result{}, false, nil
+	}
+
+	return *response.Result, true, nil
+}
+
+// putModule loads the module of the space into the OPA server.
+// Compilation errors of the module are returned as invalid argument errors.
+func (s *Service) putModule(ctx context.Context, spaceID int64, module string) error {
+	module, err := spaceModule(spaceID, module)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.do(ctx, http.MethodPut, "/v1/policies/"+spacePolicyID(spaceID), "text/plain", []byte(module))
+	return err
+}
+
+// deleteModule removes the module of the space from the OPA server.
+func (s *Service) deleteModule(ctx context.Context, spaceID int64) error {
+	_, err := s.do(ctx, http.MethodDelete, "/v1/policies/"+spacePolicyID(spaceID), "", nil)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *Service) do(ctx context.Context, method, path, contentType string, data []byte) ([]byte, error) {
+	endpoint := strings.TrimSuffix(s.config.OPAURL, "/") + path
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OPA request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send OPA request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OPA response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, errors.NotFound("OPA resource %q not found", path)
+	case http.StatusBadRequest:
+		return nil, errors.InvalidArgument("Invalid push policy: %s", opaErrorMessage(body))
+	default:
+		return nil, fmt.Errorf("OPA server responded with status %d: %s", resp.StatusCode, body)
+	}
+}
+
+// opaErrorMessage returns the messages of the errors reported by the OPA server.
+func opaErrorMessage(body []byte) string {
+	var response opaErrorResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return string(body)
+	}
+
+	if len(response.Errors) == 0 {
+		return response.Message
+	}
+
+	messages := make([]string, len(response.Errors))
+	for i, e := range response.Errors {
+		messages[i] = e.Message
+		if e.Location != nil {
+			messages[i] = fmt.Sprintf("%d:%d: %s", e.Location.Row, e.Location.Col, e.Message)
+		}
+	}
+
+	return strings.Join(messages, "; ")
+}
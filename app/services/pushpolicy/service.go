@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushpolicy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+)
+
+const (
+	maxResponseSize = 1 << 20 // 1MiB
+
+	// MaxModuleSize is the maximum size of the Rego module of a space.
+	MaxModuleSize = 64 << 10 // 64KiB
+)
+
+// packageRegexp matches the package declaration of a Rego module.
+var packageRegexp = regexp.MustCompile(`(?m)^[ \t]*package[ \t]+[^\s#]+`)
+
+type Config struct {
+	// OPAURL is the base URL of the OPA server that evaluates the Rego policies.
+	OPAURL string
+	// OPADecision is the path of the global decision document (e.g. "gitness/push"), evaluated for all pushes.
+	OPADecision string
+	Timeout     time.Duration
+}
+
+// Result is the result of the push policy evaluation.
+// The push is rejected if it contains any deny messages.
+type Result struct {
+	Deny []string `json:"deny"`
+	Warn []string `json:"warn"`
+}
+
+// Service manages the push policies of spaces and evaluates them using an OPA server.
+//
+// A push policy is a Rego module that defines the "deny" and "warn" sets of messages.
+// The module of every space is loaded into the OPA server under a package unique to the space,
+// and it's evaluated for all pushes to the repositories of the space and its subspaces.
+type Service struct {
+	config     Config
+	settings   *settings.Service
+	spaceStore store.SpaceStore
+	httpClient *http.Client
+}
+
+func NewService(
+	config Config,
+	settings *settings.Service,
+	spaceStore store.SpaceStore,
+) *Service {
+	return &Service{
+		config:     config,
+		settings:   settings,
+		spaceStore: spaceStore,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Enabled returns true if an OPA server is configured.
+func (s *Service) Enabled() bool {
+	return s.config.OPAURL != ""
+}
+
+// Find returns the Rego module of the space, or an empty string if the space doesn't have a push policy.
+func (s *Service) Find(ctx context.Context, spaceID int64) (string, error) {
+	var module string
+	if _, err := s.settings.SpaceGet(ctx, spaceID, settings.KeyPushPolicy, &module); err != nil {
+		return "", fmt.Errorf("failed to find push policy of space %d: %w", spaceID, err)
+	}
+
+	return module, nil
+}
+
+// Update replaces the Rego module of the space. An empty module removes the push policy of the space.
+// The module is compiled by the OPA server, an invalid module is rejected.
+func (s *Service) Update(ctx context.Context, spaceID int64, module string) error {
+	if !s.Enabled() {
+		return errors.PreconditionFailed("Push policies require an OPA server to be configured.")
+	}
+
+	if len(module) > MaxModuleSize {
+		return errors.InvalidArgument("Push policy can't be larger than %d bytes.", MaxModuleSize)
+	}
+
+	if strings.TrimSpace(module) == "" {
+		module = ""
+		if err := s.deleteModule(ctx, spaceID); err != nil {
+			return err
+		}
+	} else if err := s.putModule(ctx, spaceID, module); err != nil {
+		return err
+	}
+
+	if err := s.settings.SpaceSet(ctx, spaceID, settings.KeyPushPolicy, module); err != nil {
+		return fmt.Errorf("failed to store push policy of space %d: %w", spaceID, err)
+	}
+
+	return nil
+}
+
+// Evaluate evaluates the global decision and the push policies of the space and all its ancestors,
+// starting with the root space. The results of all policies are combined.
+func (s *Service) Evaluate(ctx context.Context, spaceID int64, input any) (Result, error) {
+	var result Result
+
+	if decision := strings.Trim(s.config.OPADecision, "/"); decision != "" {
+		out, _, err := s.query(ctx, decision, input)
+		if err != nil {
+			return Result{}, err
+		}
+
+		result.add(out)
+	}
+
+	spaceIDs, err := s.spaceHierarchy(ctx, spaceID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	for _, id := range spaceIDs {
+		out, err := s.evaluateSpace(ctx, id, input)
+		if err != nil {
+			return Result{}, err
+		}
+
+		result.add(out)
+	}
+
+	return result, nil
+}
+
+func (s *Service) evaluateSpace(ctx context.Context, spaceID int64, input any) (Result, error) {
+	module, err := s.Find(ctx, spaceID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if module == "" {
+		return Result{}, nil
+	}
+
+	out, ok, err := s.query(ctx, spaceDecision(spaceID), input)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if ok {
+		return out, nil
+	}
+
+	// the OPA server doesn't know the module of the space (e.g. it got restarted), load it and try again.
+	if err = s.putModule(ctx, spaceID, module); err != nil {
+		return Result{}, fmt.Errorf("failed to load push policy of space %d: %w", spaceID, err)
+	}
+
+	out, ok, err = s.query(ctx, spaceDecision(spaceID), input)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if !ok {
+		return Result{}, fmt.Errorf("push policy of space %d is undefined", spaceID)
+	}
+
+	return out, nil
+}
+
+// spaceHierarchy returns the IDs of the space and all its ancestors, starting with the root space.
+func (s *Service) spaceHierarchy(ctx context.Context, spaceID int64) ([]int64, error) {
+	var spaceIDs []int64
+	for spaceID > 0 {
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceIDs = append([]int64{space.ID}, spaceIDs...)
+		spaceID = space.ParentID
+	}
+
+	return spaceIDs, nil
+}
+
+func (r *Result) add(other Result) {
+	r.Deny = append(r.Deny, other.Deny...)
+	r.Warn = append(r.Warn, other.Warn...)
+}
+
+// spacePackage returns the Rego package under which the module of the space is loaded.
+func spacePackage(spaceID int64) string {
+	return "gitness.spaces.space_" + strconv.FormatInt(spaceID, 10)
+}
+
+func spaceDecision(spaceID int64) string {
+	return strings.ReplaceAll(spacePackage(spaceID), ".", "/")
+}
+
+func spacePolicyID(spaceID int64) string {
+	return "gitness/spaces/" + strconv.FormatInt(spaceID, 10)
+}
+
+// spaceModule returns the module with its package declaration replaced by the package of the space.
+func spaceModule(spaceID int64, module string) (string, error) {
+	loc := packageRegexp.FindStringIndex(module)
+	if loc == nil {
+		return "", errors.InvalidArgument("Push policy must declare a package.")
+	}
+
+	return module[:loc[0]] + "package " + spacePackage(spaceID) + module[loc[1]:], nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type settingsStore struct {
+	values map[settingsKey]json.RawMessage
+}
+
+type settingsKey struct {
+	scopeID int64
+	key     string
+}
+
+func (s *settingsStore) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	key string,
+) (json.RawMessage, error) {
+	v, ok := s.values[settingsKey{scopeID: scopeID, key: key}]
+	if !ok {
+		return nil, gitnessstore.ErrResourceNotFound
+	}
+	return v, nil
+}
+
+func (s *settingsStore) FindMany(
+	context.Context,
+	enum.SettingsScope,
+	int64,
+	...string,
+) (map[string]json.RawMessage, error) {
+	return nil, nil
+}
+
+func (s *settingsStore) Upsert(
+	_ context.Context,
+	_ enum.SettingsScope,
+	scopeID int64,
+	key string,
+	value json.RawMessage,
+) error {
+	s.values[settingsKey{scopeID: scopeID, key: key}] = value
+	return nil
+}
+
+// spaceStore is a fake store with the space hierarchy root (1) <- child (2).
+type spaceStore struct {
+	store.SpaceStore
+}
+
+func (spaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	switch id {
+	case 1:
+		return &types.Space{ID: 1}, nil
+	case 2:
+		return &types.Space{ID: 2, ParentID: 1}, nil
+	default:
+		return nil, gitnessstore.ErrResourceNotFound
+	}
+}
+
+// opaServer is a fake OPA server. The result of a space package is the "deny" message
+// provided in the last comment of its module, the global decision always warns.
+type opaServer struct {
+	mx       sync.Mutex
+	policies map[string]string
+	puts     int
+}
+
+func (o *opaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/v1/policies/"):
+		if strings.Contains(string(body), "invalid") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"invalid_parameter",` +
+				`"message":"error(s) occurred while compiling module(s)",` +
+				`"errors":[{"message":"rego_parse_error","location":{"row":3,"col":1}}]}`))
+			return
+		}
+		o.policies[strings.TrimPrefix(r.URL.Path, "/v1/policies/")] = string(body)
+		o.puts++
+		_, _ = w.Write([]byte("{}"))
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/policies/"):
+		delete(o.policies, strings.TrimPrefix(r.URL.Path, "/v1/policies/"))
+		_, _ = w.Write([]byte("{}"))
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/data/gitness/push":
+		_, _ = w.Write([]byte(`{"result":{"warn":["global"]}}`))
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/v1/data/gitness/spaces/space_"):
+		id := strings.TrimPrefix(r.URL.Path, "/v1/data/gitness/spaces/space_")
+		module, ok := o.policies["gitness/spaces/"+id]
+		if !ok {
+			_, _ = w.Write([]byte("{}"))
+			return
+		}
+		if !strings.Contains(module, "package gitness.spaces.space_"+id+"\n") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		deny := module[strings.LastIndex(module, "# ")+2:]
+		_, _ = w.Write([]byte(`{"result":{"deny":["` + strings.TrimSpace(deny) + `"]}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func setupService(t *testing.T) (*Service, *opaServer) {
+	opa := &opaServer{policies: map[string]string{}}
+	server := httptest.NewServer(opa)
+	t.Cleanup(server.Close)
+
+	settingsSvc := settings.NewService(&settingsStore{values: map[settingsKey]json.RawMessage{}})
+
+	return NewService(Config{OPAURL: server.URL, OPADecision: "gitness/push"}, settingsSvc, spaceStore{}), opa
+}
+
+func TestService_Evaluate(t *testing.T) {
+	ctx := context.Background()
+	svc, opa := setupService(t)
+
+	if err := svc.Update(ctx, 1, "package push\n# root"); err != nil {
+		t.Fatalf("failed to update push policy of root space: %s", err.Error())
+	}
+	if err := svc.Update(ctx, 2, "# comment\npackage push.child\n# child"); err != nil {
+		t.Fatalf("failed to update push policy of child space: %s", err.Error())
+	}
+
+	result, err := svc.Evaluate(ctx, 2, map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to evaluate push policies: %s", err.Error())
+	}
+
+	want := Result{Deny: []string{"root", "child"}, Warn: []string{"global"}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("want=%+v got=%+v", want, result)
+	}
+
+	// the OPA server lost its policies, they are loaded again.
+	opa.policies = map[string]string{}
+
+	result, err = svc.Evaluate(ctx, 1, map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to evaluate push policies: %s", err.Error())
+	}
+
+	want = Result{Deny: []string{"root"}, Warn: []string{"global"}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("want=%+v got=%+v", want, result)
+	}
+
+	if opa.puts != 3 {
+		t.Errorf("expected the root policy to be loaded again, got %d loads", opa.puts)
+	}
+
+	// removed policies aren't evaluated anymore.
+	if err = svc.Update(ctx, 1, ""); err != nil {
+		t.Fatalf("failed to remove push policy of root space: %s", err.Error())
+	}
+
+	result, err = svc.Evaluate(ctx, 1, map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to evaluate push policies: %s", err.Error())
+	}
+
+	want = Result{Warn: []string{"global"}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("want=%+v got=%+v", want, result)
+	}
+}
+
+func TestService_Update_Invalid(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupService(t)
+
+	tests := []struct {
+		name   string
+		module string
+	}{
+		{name: "no-package", module: "deny[msg] { msg := \"no\" }"},
+		{name: "compile-error", module: "package push\n\ninvalid"},
+		{name: "too-large", module: "package push\n" + strings.Repeat("#", MaxModuleSize)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := svc.Update(ctx, 1, test.module)
+			if !errors.IsInvalidArgument(err) {
+				t.Errorf("expected invalid argument error, got: %v", err)
+			}
+		})
+	}
+
+	module, err := svc.Find(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find push policy: %s", err.Error())
+	}
+	if module != "" {
+		t.Errorf("invalid push policy got stored: %q", module)
+	}
+}
+
+func TestService_Update_Disabled(t *testing.T) {
+	svc := NewService(Config{}, nil, nil)
+
+	err := svc.Update(context.Background(), 1, "package push")
+	if !errors.IsPreconditionFailed(err) {
+		t.Errorf("expected precondition failed error, got: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CreateInput struct {
+	Type  types.RuleType `json:"type"`
+	State enum.RuleState `json:"state"`
+	// TODO [CODE-1363]: remove after identifier migration.
+	UID         string             `json:"uid" deprecated:"true"`
+	Identifier  string             `json:"identifier"`
+	Description string             `json:"description"`
+	Pattern     protection.Pattern `json:"pattern"`
+	Definition  json.RawMessage    `json:"definition"`
+}
+
+// sanitize validates and sanitizes the create rule input data.
+func (in *CreateInput) sanitize() error {
+	// TODO [CODE-1363]: remove after identifier migration.
+	if in.Identifier == "" {
+		in.Identifier = in.UID
+	}
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if err := in.Pattern.Validate(); err != nil {
+		return usererror.BadRequestf("invalid pattern: %s", err)
+	}
+
+	var ok bool
+	in.State, ok = in.State.Sanitize()
+	if !ok {
+		return usererror.BadRequest("rule state is invalid")
+	}
+
+	if in.Type == "" {
+		in.Type = protection.TypeBranch
+	}
+
+	if len(in.Definition) == 0 {
+		return usererror.BadRequest("rule definition missing")
+	}
+
+	return nil
+}
+
+// Create creates a new protection rule for a repository or a space.
+func (s *Service) Create(ctx context.Context,
+	principal *types.Principal,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	parentPath string,
+	in *CreateInput,
+) (*types.Rule, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	spaceID, repoID, err := parentIDs(parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	in.Definition, err = s.protectionManager.SanitizeJSON(in.Type, in.Definition)
+	if err != nil {
+		return nil, usererror.BadRequestf("invalid rule definition: %s", err.Error())
+	}
+
+	err = s.protectionManager.VerifyUserGroups(ctx, in.Type, in.Definition)
+	if errors.Is(err, protection.ErrUnknownUserGroup) {
+		return nil, usererror.BadRequestf("invalid rule definition: %s", err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify user groups of the rule: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	r := &types.Rule{
+		CreatedBy:     principal.ID,
+		Created:       now,
+		Updated:       now,
+		RepoID:        repoID,
+		SpaceID:       spaceID,
+		Type:          in.Type,
+		State:         in.State,
+		Identifier:    in.Identifier,
+		Description:   in.Description,
+		Pattern:       in.Pattern.JSON(),
+		Definition:    in.Definition,
+		CreatedByInfo: types.PrincipalInfo{},
+	}
+
+	err = s.ruleStore.Create(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s-level protection rule: %w", parentName(parentType), err)
+	}
+
+	err = s.auditService.Log(ctx,
+		*principal,
+		audit.NewResource(audit.ResourceTypeBranchRule, r.Identifier),
+		audit.ActionCreated,
+		parentPath,
+		audit.WithNewObject(r),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create branch rule operation: %s", err)
+	}
+
+	r.Users, err = s.getRuleUsers(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
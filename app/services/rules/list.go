@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List returns protection rules of a repository or a space.
+func (s *Service) List(ctx context.Context,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	filter *types.RuleFilter,
+) ([]types.Rule, int64, error) {
+	spaceID, repoID, err := parentIDs(parentType, parentID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var list []types.Rule
+	var count int64
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		list, err = s.ruleStore.List(ctx, spaceID, repoID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list %s-level protection rules: %w", parentName(parentType), err)
+		}
+
+		if filter.Page == 1 && len(list) < filter.Size {
+			count = int64(len(list))
+			return nil
+		}
+
+		count, err = s.ruleStore.Count(ctx, spaceID, repoID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count %s-level protection rules: %w", parentName(parentType), err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range list {
+		list[i].Users, err = s.getRuleUsers(ctx, &list[i])
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return list, count, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Service manages protection rules of repositories and spaces.
+//
+// Rules of a space are inherited by all repositories of the space and of its sub-spaces.
+// Inherited rules are always evaluated alongside the repository rules and
+// each rule is enforced independently, so the repository rules can only make the protection stricter.
+// Space rules can be managed only by users with the edit permission of the space.
+type Service struct {
+	tx                 dbtx.Transactor
+	ruleStore          store.RuleStore
+	principalInfoCache store.PrincipalInfoCache
+	protectionManager  *protection.Manager
+	auditService       audit.Service
+}
+
+func NewService(
+	tx dbtx.Transactor,
+	ruleStore store.RuleStore,
+	principalInfoCache store.PrincipalInfoCache,
+	protectionManager *protection.Manager,
+	auditService audit.Service,
+) *Service {
+	return &Service{
+		tx:                 tx,
+		ruleStore:          ruleStore,
+		principalInfoCache: principalInfoCache,
+		protectionManager:  protectionManager,
+		auditService:       auditService,
+	}
+}
+
+// parentIDs returns the space ID and the repository ID of the rule's parent.
+func parentIDs(parentType enum.ParentResourceType, parentID int64) (*int64, *int64, error) {
+	switch parentType {
+	case enum.ParentResourceTypeSpace:
+		return &parentID, nil, nil
+	case enum.ParentResourceTypeRepo:
+		return nil, &parentID, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported rule parent type %q", parentType)
+	}
+}
+
+// parentName returns a human readable name of the rule's parent used in error messages.
+func parentName(parentType enum.ParentResourceType) string {
+	if parentType == enum.ParentResourceTypeSpace {
+		return "space"
+	}
+	return "repository"
+}
+
+func (s *Service) getRuleUsers(ctx context.Context, r *types.Rule) (map[int64]*types.PrincipalInfo, error) {
+	rule, err := s.protectionManager.FromJSON(r.Type, r.Definition, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse json rule definition: %w", err)
+	}
+
+	userIDs, err := rule.UserIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ID from rule: %w", err)
+	}
+
+	userMap, err := s.principalInfoCache.Map(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal infos: %w", err)
+	}
+
+	return userMap, nil
+}
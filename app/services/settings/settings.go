@@ -22,4 +22,6 @@ var (
 	DefaultSecretScanningEnabled     = false
 	KeyFileSizeLimit             Key = "file_size_limit"
 	DefaultFileSizeLimit             = int64(1e+8) // 100 MB
+	// KeyPushPolicy [string] is the Rego module that is evaluated for pushes to the repositories of a space.
+	KeyPushPolicy Key = "push_policy"
 )
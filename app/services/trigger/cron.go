@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// cronJobType is the type of the recurring job that fires scheduled triggers.
+	// The job package guarantees that every tick of the job is executed by a single node only.
+	cronJobType        = "gitness:trigger:cron"
+	cronJobCron        = "* * * * *"
+	cronJobMaxDuration = time.Minute
+)
+
+// NextCronExecution returns the first time after the provided time at which the cron expression fires,
+// evaluated in the provided IANA time zone (UTC if empty).
+func NextCronExecution(cron string, timezone string, after time.Time) (time.Time, error) {
+	expr, err := cronexpr.Parse(cron)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression: %w", err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time zone: %w", err)
+		}
+	}
+
+	next := expr.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, errors.New("cron expression never fires")
+	}
+
+	return next, nil
+}
+
+// Register registers the recurring job that fires scheduled triggers.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, cronJobType, cronJobType, cronJobCron, cronJobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for scheduled triggers: %w", err)
+	}
+
+	return nil
+}
+
+// Handle fires all scheduled triggers that are due.
+// Triggers that missed multiple ticks (e.g. because the server was down) are fired only once.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+
+	triggers, err := s.triggerStore.ListAllEnabledCronDue(ctx, now.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list due scheduled triggers: %w", err)
+	}
+
+	var fired int
+	for _, t := range triggers {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		ok, err := s.fireCron(ctx, t, now)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("trigger_id", t.ID).
+				Int64("pipeline_id", t.PipelineID).
+				Msg("failed to fire scheduled trigger")
+			continue
+		}
+		if ok {
+			fired++
+		}
+	}
+
+	return fmt.Sprintf("fired %d scheduled triggers", fired), nil
+}
+
+// fireCron moves the trigger to its next fire time and starts an execution of its pipeline.
+// It returns false if the trigger was already handled by someone else.
+func (s *Service) fireCron(ctx context.Context, t *types.Trigger, now time.Time) (bool, error) {
+	next, err := NextCronExecution(t.Cron, t.CronTimezone, now)
+	if err != nil {
+		return false, err
+	}
+
+	// moving the next fire time first guarantees the trigger is fired at most once per tick.
+	ok, err := s.triggerStore.UpdateCronNext(ctx, t.ID, t.CronNext, next.UnixMilli())
+	if err != nil {
+		return false, fmt.Errorf("failed to update next fire time: %w", err)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, t.PipelineID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	// Don't fire triggers for disabled pipelines
+	if pipeline.Disabled {
+		return false, nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, t.RepoID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	branch := t.CronBranch
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	commit, err := s.commitSvc.FindRef(ctx, repo, branch)
+	if err != nil {
+		return false, fmt.Errorf("failed to find branch %q: %w", branch, err)
+	}
+
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerCron,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Cron:        t.Identifier,
+		Ref:         "refs/heads/" + branch,
+		Source:      branch,
+		Target:      branch,
+		Before:      commit.SHA,
+		After:       commit.SHA,
+		Title:       commit.Title,
+		Message:     commit.Message,
+		Timestamp:   now.UnixMilli(),
+		AuthorLogin: commit.Author.Identity.Name,
+		AuthorName:  commit.Author.Identity.Name,
+		AuthorEmail: commit.Author.Identity.Email,
+	}
+
+	if _, err = s.triggerSvc.Trigger(ctx, pipeline, hook); err != nil {
+		return false, fmt.Errorf("failed to trigger pipeline: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextCronExecution(t *testing.T) {
+	after := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		cron     string
+		timezone string
+		want     time.Time
+	}{
+		{
+			name: "every-hour",
+			cron: "0 * * * *",
+			want: time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily-in-timezone",
+			cron:     "0 9 * * *",
+			timezone: "America/New_York",
+			// 09:00 EST is 14:00 UTC
+			want: time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekly",
+			cron: "15 8 * * MON",
+			want: time.Date(2024, 3, 4, 8, 15, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NextCronExecution(test.cron, test.timezone, after)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.Equal(test.want) {
+				t.Errorf("want=%s got=%s", test.want, got.UTC())
+			}
+		})
+	}
+}
+
+func TestNextCronExecution_Invalid(t *testing.T) {
+	tests := []struct {
+		cron     string
+		timezone string
+	}{
+		{cron: "not a cron"},
+		{cron: "61 * * * *"},
+		{cron: "0 0 1 1 * 2000"},
+		{cron: "0 * * * *", timezone: "Mars/Olympus_Mons"},
+	}
+
+	for _, test := range tests {
+		if _, err := NextCronExecution(test.cron, test.timezone, time.Now()); err == nil {
+			t.Errorf("expected an error for cron=%q timezone=%q", test.cron, test.timezone)
+		}
+	}
+}
@@ -58,6 +58,42 @@ func (s *Service) triggerForEventWithRepo(ctx context.Context,
 	return s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, repo.ID, triggerType, body)
 }
 
+// triggerForEventWithRepoAndSpaces triggers all webhooks for the given repo and all of its parent spaces
+// using the eventID to generate a deterministic triggerID and using the output of bodyFn as payload.
+// It's used for repository lifecycle events, which are of interest also to the webhooks of the parent spaces.
+func (s *Service) triggerForEventWithRepoAndSpaces(ctx context.Context,
+	triggerType enum.WebhookTrigger, eventID string, principalID int64, repo *types.Repository,
+	createBodyFn func(*types.Principal) (any, error)) error {
+	principal, err := s.findPrincipalForEvent(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	// create body
+	body, err := createBodyFn(principal)
+	if err != nil {
+		return fmt.Errorf("body creation function failed: %w", err)
+	}
+
+	// trigger the webhooks of all parents and only then return the combined error,
+	// already successful executions are skipped in case the event gets reprocessed.
+	errs := s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, repo.ID, triggerType, body)
+
+	for spaceID := repo.ParentID; spaceID != 0; {
+		errs = multierr.Append(errs,
+			s.triggerForEvent(ctx, eventID, enum.WebhookParentSpace, spaceID, triggerType, body))
+
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return multierr.Append(errs, fmt.Errorf("failed to get space for id '%d': %w", spaceID, err))
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return errs
+}
+
 // triggerForEventWithPullReq triggers all webhooks for the given repo and triggerType
 // using the eventID to generate a deterministic triggerID and using the output of bodyFn as payload.
 // The method tries to find the pullreq, principal, target repo, and source repo
@@ -128,6 +164,11 @@ func (s *Service) findPullReqForEvent(ctx context.Context, prID int64) (*types.P
 		return nil, fmt.Errorf("failed to get PR for id '%d': %w", prID, err)
 	}
 
+	pr.Labels, err = s.pullReqLabelStore.ListAssigned(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels of PR with id '%d': %w", prID, err)
+	}
+
 	return pr, nil
 }
 
@@ -176,7 +217,9 @@ func (s *Service) triggerForEvent(ctx context.Context, eventID string,
 				result.Execution.ID, result.Webhook.ID, result.Execution.Result, result.Err))
 		}
 
-		if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		// retriable errors are redelivered via the redelivery queue - reprocess the event only if it's disabled.
+		if result.Execution.Result == enum.WebhookExecutionResultRetriableError &&
+			s.config.RedeliveryMaxAttempts == 0 {
 			retryRequired = true
 		}
 	}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckReportedPayload describes the body of the check reported trigger.
+type CheckReportedPayload struct {
+	BaseSegment
+	CheckSegment
+}
+
+// handleEventCheckReported handles check reported events
+// and triggers check reported webhooks for the repo.
+func (s *Service) handleEventCheckReported(ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerCheckReported,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			check, err := s.checkStore.FindByIdentifier(ctx, repo.ID, event.Payload.CommitSHA, event.Payload.Identifier)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return nil, events.NewDiscardEventErrorf("check %q for commit %s doesn't exist anymore",
+					event.Payload.Identifier, event.Payload.CommitSHA)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get check %q: %w", event.Payload.Identifier, err)
+			}
+
+			// report the status from the event as the check might have been updated since
+			check.Status = event.Payload.Status
+
+			return &CheckReportedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerCheckReported,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				CheckSegment: CheckSegment{
+					Check: checkInfoFrom(&check),
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PipelineExecutionPayload describes the body of the pipeline execution started and finished triggers.
+type PipelineExecutionPayload struct {
+	BaseSegment
+	PipelineExecutionSegment
+}
+
+// handleEventPipelineExecutionStarted handles pipeline execution started events
+// and triggers pipeline execution started webhooks for the repo.
+func (s *Service) handleEventPipelineExecutionStarted(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutionStartedPayload]) error {
+	return s.triggerForEventWithPipelineExecution(ctx, enum.WebhookTriggerPipelineExecutionStarted,
+		event.ID, event.Payload.ExecutionBase, "")
+}
+
+// handleEventPipelineExecutionFinished handles pipeline execution finished events
+// and triggers pipeline execution finished webhooks for the repo.
+func (s *Service) handleEventPipelineExecutionFinished(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutionFinishedPayload]) error {
+	return s.triggerForEventWithPipelineExecution(ctx, enum.WebhookTriggerPipelineExecutionFinished,
+		event.ID, event.Payload.ExecutionBase, event.Payload.Status)
+}
+
+// triggerForEventWithPipelineExecution triggers all webhooks for the repo of the pipeline execution.
+// If provided, the status overrides the current status of the execution.
+func (s *Service) triggerForEventWithPipelineExecution(ctx context.Context,
+	triggerType enum.WebhookTrigger, eventID string, base pipelineevents.ExecutionBase, status enum.CIStatus,
+) error {
+	return s.triggerForEventWithRepo(ctx, triggerType,
+		eventID, base.PrincipalID, base.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			pipeline, err := s.pipelineStore.Find(ctx, base.PipelineID)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return nil, events.NewDiscardEventErrorf("pipeline with id '%d' doesn't exist anymore", base.PipelineID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pipeline for id '%d': %w", base.PipelineID, err)
+			}
+
+			execution, err := s.executionStore.Find(ctx, base.ExecutionID)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return nil, events.NewDiscardEventErrorf("execution with id '%d' doesn't exist anymore",
+					base.ExecutionID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get execution for id '%d': %w", base.ExecutionID, err)
+			}
+
+			if status != "" {
+				execution.Status = status
+			}
+
+			return &PipelineExecutionPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PipelineExecutionSegment: PipelineExecutionSegment{
+					Pipeline:  pipelineInfoFrom(pipeline),
+					Execution: executionInfoFrom(execution, pipeline, repo, s.urlProvider),
+				},
+			}, nil
+		})
+}
@@ -360,3 +360,171 @@ func (s *Service) handleEventPullReqComment(
 			}, nil
 		})
 }
+
+// PullReqUpdatedPayload describes the body of the pullreq updated trigger.
+type PullReqUpdatedPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqUpdateSegment
+}
+
+// handleEventPullReqUpdated handles updated events for pull requests
+// and triggers pullreq updated webhooks for the target repo.
+func (s *Service) handleEventPullReqUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.UpdatedPayload]) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqUpdated,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+
+			return &PullReqUpdatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqUpdated,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				PullReqUpdateSegment: PullReqUpdateSegment{
+					TitleChanged:       event.Payload.OldTitle != event.Payload.NewTitle,
+					TitleOld:           event.Payload.OldTitle,
+					DescriptionChanged: event.Payload.OldDescription != event.Payload.NewDescription,
+					DescriptionOld:     event.Payload.OldDescription,
+				},
+			}, nil
+		})
+}
+
+// PullReqCommentUpdatedPayload describes the body of the pullreq comment updated trigger.
+// Note: same as payload for comment created.
+type PullReqCommentUpdatedPayload PullReqCommentPayload
+
+func (s *Service) handleEventPullReqCommentUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentUpdatedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqCommentUpdated,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+			activity, err := s.activityStore.Find(ctx, event.Payload.ActivityID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get activity by id for activity id %d: %w",
+					event.Payload.ActivityID, err)
+			}
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
+			if err != nil {
+				return nil, err
+			}
+			return &PullReqCommentUpdatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqCommentUpdated,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				ReferenceDetailsSegment: ReferenceDetailsSegment{
+					SHA:        event.Payload.SourceSHA,
+					Commit:     &commitInfo,
+					HeadCommit: &commitInfo,
+				},
+				PullReqCommentSegment: PullReqCommentSegment{
+					CommentInfo: CommentInfo{
+						Text:     activity.Text,
+						ID:       activity.ID,
+						ParentID: activity.ParentID,
+					},
+				},
+			}, nil
+		})
+}
+
+// PullReqCommentStatusUpdatedPayload describes the body of the pullreq comment status updated trigger.
+type PullReqCommentStatusUpdatedPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqCommentSegment
+	PullReqCommentStatusSegment
+}
+
+func (s *Service) handleEventPullReqCommentStatusUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentStatusUpdatedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqCommentStatusUpdated,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+			activity, err := s.activityStore.Find(ctx, event.Payload.ActivityID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get activity by id for activity id %d: %w",
+					event.Payload.ActivityID, err)
+			}
+			return &PullReqCommentStatusUpdatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqCommentStatusUpdated,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				PullReqCommentSegment: PullReqCommentSegment{
+					CommentInfo: CommentInfo{
+						Text:     activity.Text,
+						ID:       activity.ID,
+						ParentID: activity.ParentID,
+					},
+				},
+				PullReqCommentStatusSegment: PullReqCommentStatusSegment{
+					Status: event.Payload.Status,
+				},
+			}, nil
+		})
+}
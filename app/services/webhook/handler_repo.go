@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepositoryPayload describes the body of the repo created and repo deleted triggers.
+type RepositoryPayload struct {
+	BaseSegment
+}
+
+// handleEventRepoCreated handles repo created events
+// and triggers repo created webhooks for the repo and its parent spaces.
+func (s *Service) handleEventRepoCreated(ctx context.Context,
+	event *events.Event[*repoevents.CreatedPayload]) error {
+	repo, err := s.findRepositoryForEvent(ctx, event.Payload.RepoID)
+	if err != nil {
+		return err
+	}
+
+	return s.triggerForEventWithRepoAndSpaces(ctx, enum.WebhookTriggerRepoCreated,
+		event.ID, event.Payload.PrincipalID, repo,
+		func(principal *types.Principal) (any, error) {
+			return &RepositoryPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoCreated,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+			}, nil
+		})
+}
+
+// handleEventRepoSoftDeleted handles repo soft deleted events
+// and triggers repo deleted webhooks for the repo and its parent spaces.
+func (s *Service) handleEventRepoSoftDeleted(ctx context.Context,
+	event *events.Event[*repoevents.SoftDeletedPayload]) error {
+	repo, err := s.repoStore.FindByRefAndDeletedAt(ctx,
+		strconv.FormatInt(event.Payload.RepoID, 10), event.Payload.DeletedAt)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the repo got restored or purged in the meantime
+		return events.NewDiscardEventErrorf("deleted repo with id '%d' doesn't exist anymore", event.Payload.RepoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deleted repo for id '%d': %w", event.Payload.RepoID, err)
+	}
+
+	return s.triggerForEventWithRepoAndSpaces(ctx, enum.WebhookTriggerRepoDeleted,
+		event.ID, event.Payload.PrincipalID, repo,
+		func(principal *types.Principal) (any, error) {
+			return &RepositoryPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoDeleted,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+			}, nil
+		})
+}
+
+// RepositoryRenamedPayload describes the body of the repo renamed trigger.
+type RepositoryRenamedPayload struct {
+	BaseSegment
+	RepositoryRenameSegment
+}
+
+// handleEventRepoRenamed handles repo renamed events
+// and triggers repo renamed webhooks for the repo and its parent spaces.
+func (s *Service) handleEventRepoRenamed(ctx context.Context,
+	event *events.Event[*repoevents.RenamedPayload]) error {
+	repo, err := s.findRepositoryForEvent(ctx, event.Payload.RepoID)
+	if err != nil {
+		return err
+	}
+
+	return s.triggerForEventWithRepoAndSpaces(ctx, enum.WebhookTriggerRepoRenamed,
+		event.ID, event.Payload.PrincipalID, repo,
+		func(principal *types.Principal) (any, error) {
+			return &RepositoryRenamedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoRenamed,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepositoryRenameSegment: RepositoryRenameSegment{
+					OldIdentifier: event.Payload.OldIdentifier,
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+)
+
+// EncryptHeaders returns the custom headers of a webhook with encrypted values.
+// Values equal to types.WebhookHeaderValueRedacted keep the encrypted value of the same header in current,
+// which allows clients to update the headers without knowing the values of the existing headers.
+func EncryptHeaders(
+	encrypter encrypt.Encrypter,
+	headers map[string]string,
+	current map[string]string,
+) (map[string]string, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	encrypted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if currentValue, ok := current[name]; ok && value == types.WebhookHeaderValueRedacted {
+			encrypted[name] = currentValue
+			continue
+		}
+
+		ciphertext, err := encrypter.Encrypt(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt value of header %q: %w", name, err)
+		}
+
+		encrypted[name] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+
+	return encrypted, nil
+}
+
+// decryptHeaders returns the custom headers of a webhook with decrypted values.
+func decryptHeaders(encrypter encrypt.Encrypter, headers map[string]string) (map[string]string, error) {
+	decrypted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		ciphertext, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode value of header %q: %w", name, err)
+		}
+
+		plaintext, err := encrypter.Decrypt(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt value of header %q: %w", name, err)
+		}
+
+		decrypted[name] = plaintext
+	}
+
+	return decrypted, nil
+}
+
+// redactHeaders returns a copy of the request headers with redacted values of the custom headers.
+func redactHeaders(header http.Header, custom map[string]string) http.Header {
+	redacted := header.Clone()
+	for name := range custom {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, types.WebhookHeaderValueRedacted)
+		}
+	}
+
+	return redacted
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+)
+
+func TestEncryptHeaders(t *testing.T) {
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %s", err)
+	}
+
+	current, err := EncryptHeaders(encrypter, map[string]string{
+		"Authorization": "Bearer token",
+		"X-Team":        "a",
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to encrypt headers: %s", err)
+	}
+
+	if current["Authorization"] == "Bearer token" {
+		t.Fatal("expected the header value to be encrypted")
+	}
+
+	updated, err := EncryptHeaders(encrypter, map[string]string{
+		"Authorization": types.WebhookHeaderValueRedacted,
+		"X-Team":        "b",
+	}, current)
+	if err != nil {
+		t.Fatalf("failed to encrypt updated headers: %s", err)
+	}
+
+	if updated["Authorization"] != current["Authorization"] {
+		t.Error("expected the redacted header to keep its current value")
+	}
+
+	decrypted, err := decryptHeaders(encrypter, updated)
+	if err != nil {
+		t.Fatalf("failed to decrypt headers: %s", err)
+	}
+
+	if want := map[string]string{"Authorization": "Bearer token", "X-Team": "b"}; !reflect.DeepEqual(want, decrypted) {
+		t.Errorf("headers mismatch: want=%v got=%v", want, decrypted)
+	}
+
+	raw, err := json.Marshal(&types.Webhook{Headers: updated})
+	if err != nil {
+		t.Fatalf("failed to marshal webhook: %s", err)
+	}
+
+	got := struct {
+		Headers map[string]string `json:"headers"`
+	}{}
+	if err = json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("failed to unmarshal webhook: %s", err)
+	}
+
+	want := map[string]string{
+		"Authorization": types.WebhookHeaderValueRedacted,
+		"X-Team":        types.WebhookHeaderValueRedacted,
+	}
+	if !reflect.DeepEqual(want, got.Headers) {
+		t.Errorf("marshaled headers mismatch: want=%v got=%v", want, got.Headers)
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer token")
+	header.Set("Content-Type", "application/json")
+	redacted := redactHeaders(header, decrypted)
+	if redacted.Get("Authorization") != types.WebhookHeaderValueRedacted ||
+		redacted.Get("Content-Type") != "application/json" || header.Get("Authorization") != "Bearer token" {
+		t.Errorf("unexpected redacted request headers: %v", redacted)
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	contentTypeJSON        = "application/json"
+	contentTypeCloudEvents = "application/cloudevents+json"
+
+	cloudEventsSpecVersion = "1.0"
+
+	// templateMaxOutput is the maximum size of a payload rendered from a user provided template.
+	templateMaxOutput = 1 << 20
+	// templateMaxIterations is the maximum number of range iterations a single template execution can perform.
+	templateMaxIterations = 100000
+	// templateTimeout is the maximum duration of a single template execution.
+	templateTimeout = time.Second
+
+	// templateFuncIterable and templateFuncIteration are injected in every range action of a template.
+	// They aren't usable in the template text, because they aren't defined when the template is parsed.
+	templateFuncIterable  = "_iterable"
+	templateFuncIteration = "_iteration"
+)
+
+var (
+	errTemplateOutputTooLarge = fmt.Errorf("payload exceeds the maximum size of %d bytes", templateMaxOutput)
+	errTemplateTooExpensive   = errors.New("payload template execution exceeds the allowed resources")
+)
+
+// templateFuncs are the functions available to user provided payload templates.
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		raw, err := json.Marshal(v)
+		return string(raw), err
+	},
+	"upper":     strings.ToUpper,
+	"lower":     strings.ToLower,
+	"trimSpace": strings.TrimSpace,
+}
+
+// ParsePayloadTemplate parses a user provided payload template.
+// The template is executed on the native payload of the webhook trigger.
+// Because the templates are provided by users, the range actions of the template are instrumented
+// to reject ranging over integers and to limit the number of iterations and the duration of the execution.
+// Template definitions and invocations are not supported.
+func ParsePayloadTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("payload").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tmpl.Templates()) > 1 {
+		return nil, errors.New("template definitions are not supported")
+	}
+
+	if tmpl.Tree == nil {
+		return tmpl, nil
+	}
+
+	if err = instrumentTemplateNode(tmpl.Tree.Root); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// instrumentTemplateNode pipes the value of every range action to a function that rejects values that can't
+// be safely iterated over, and adds an action that counts the iterations at the beginning of every range body.
+func instrumentTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := instrumentTemplateNode(child); err != nil {
+				return err
+			}
+		}
+	case *parse.IfNode:
+		return instrumentTemplateBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return instrumentTemplateBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		if err := instrumentTemplateBranch(&n.BranchNode); err != nil {
+			return err
+		}
+
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args:     []parse.Node{parse.NewIdentifier(templateFuncIterable).SetPos(n.Pos)},
+		})
+
+		if n.List == nil {
+			n.List = &parse.ListNode{NodeType: parse.NodeList, Pos: n.Pos}
+		}
+		n.List.Nodes = append([]parse.Node{&parse.ActionNode{
+			NodeType: parse.NodeAction,
+			Pos:      n.Pos,
+			Line:     n.Line,
+			Pipe: &parse.PipeNode{
+				NodeType: parse.NodePipe,
+				Pos:      n.Pos,
+				Line:     n.Line,
+				Cmds: []*parse.CommandNode{{
+					NodeType: parse.NodeCommand,
+					Pos:      n.Pos,
+					Args:     []parse.Node{parse.NewIdentifier(templateFuncIteration).SetPos(n.Pos)},
+				}},
+			},
+		}}, n.List.Nodes...)
+	case *parse.TemplateNode:
+		return errors.New("template invocations are not supported")
+	}
+
+	return nil
+}
+
+func instrumentTemplateBranch(n *parse.BranchNode) error {
+	if err := instrumentTemplateNode(n.List); err != nil {
+		return err
+	}
+	return instrumentTemplateNode(n.ElseList)
+}
+
+// executePayloadTemplate executes the template with limits on the number of range iterations,
+// the execution time and the size of the output. The output must be a valid JSON document.
+func executePayloadTemplate(buf *bytes.Buffer, tmpl *template.Template, body any) error {
+	deadline := time.Now().Add(templateTimeout)
+	iterations := 0
+
+	tmpl = tmpl.Funcs(template.FuncMap{
+		templateFuncIterable: func(v any) (any, error) {
+			switch reflect.Indirect(reflect.ValueOf(v)).Kind() { //nolint:exhaustive // only few kinds are rejected
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+				reflect.Func, reflect.Chan:
+				return nil, fmt.Errorf("range over %T is not supported", v)
+			default:
+				return v, nil
+			}
+		},
+		templateFuncIteration: func() (string, error) {
+			iterations++
+			if iterations > templateMaxIterations || time.Now().After(deadline) {
+				return "", errTemplateTooExpensive
+			}
+			return "", nil
+		},
+	})
+
+	out := &bytes.Buffer{}
+	if err := tmpl.Execute(&limitedWriter{w: out, n: templateMaxOutput}, body); err != nil {
+		return err
+	}
+
+	if !json.Valid(out.Bytes()) {
+		return errors.New("payload template output is not a valid JSON document")
+	}
+
+	_, _ = buf.Write(out.Bytes())
+
+	return nil
+}
+
+// limitedWriter is a writer that fails if more than n bytes are written to it.
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > l.n {
+		return 0, errTemplateOutputTooLarge
+	}
+	l.n -= len(p)
+	return l.w.Write(p)
+}
+
+// cloudEvent is the CloudEvents 1.0 envelope (structured content mode) of a webhook payload.
+type cloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	Data            any    `json:"data"`
+}
+
+// chatMessage is a message compatible with Slack, Mattermost and Teams incoming webhooks.
+type chatMessage struct {
+	Text string `json:"text"`
+}
+
+// payloadContentType returns the content type of the payloads sent by the webhook.
+func payloadContentType(webhook *types.Webhook) string {
+	if format, _ := webhook.PayloadFormat.Sanitize(); format == enum.WebhookPayloadFormatCloudEvents {
+		return contentTypeCloudEvents
+	}
+
+	return contentTypeJSON
+}
+
+// renderPayload renders the native body in the payload format of the webhook and writes it to the buffer.
+func (s *Service) renderPayload(buf *bytes.Buffer, webhook *types.Webhook, triggerID string,
+	triggerType enum.WebhookTrigger, body any) error {
+	format, _ := webhook.PayloadFormat.Sanitize()
+
+	var payload any
+
+	switch format {
+	case enum.WebhookPayloadFormatNative:
+		payload = body
+
+	case enum.WebhookPayloadFormatChat:
+		text, err := renderChatText(triggerType, body)
+		if err != nil {
+			return fmt.Errorf("failed to render chat message: %w", err)
+		}
+		payload = chatMessage{Text: text}
+
+	case enum.WebhookPayloadFormatCloudEvents:
+		payload = cloudEvent{
+			SpecVersion:     cloudEventsSpecVersion,
+			ID:              triggerID,
+			Source:          fmt.Sprintf("/%ss/%d", webhook.ParentType, webhook.ParentID),
+			Type:            fmt.Sprintf("%s.%s", strings.ToLower(s.config.HeaderIdentity), triggerType),
+			Time:            time.Now().UTC().Format(time.RFC3339Nano),
+			DataContentType: contentTypeJSON,
+			Data:            body,
+		}
+
+	case enum.WebhookPayloadFormatTemplate:
+		tmpl, err := ParsePayloadTemplate(webhook.PayloadTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to parse payload template: %w", err)
+		}
+
+		if err = executePayloadTemplate(buf, tmpl, body); err != nil {
+			return fmt.Errorf("failed to execute payload template: %w", err)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("payload format '%s' is not supported", webhook.PayloadFormat)
+	}
+
+	if err := json.NewEncoder(buf).Encode(payload); err != nil {
+		return fmt.Errorf("failed to serialize payload to json: %w", err)
+	}
+
+	return nil
+}
+
+// chatFields contains the fields of all native payloads that are used to render chat messages.
+// The fields are extracted by serializing the native payload, which makes it independent of the payload type.
+type chatFields struct {
+	Repo           RepositoryInfo             `json:"repo"`
+	Principal      PrincipalInfo              `json:"principal"`
+	Ref            ReferenceInfo              `json:"ref"`
+	SHA            string                     `json:"sha"`
+	OldSHA         string                     `json:"old_sha"`
+	Forced         bool                       `json:"forced"`
+	PullReq        PullReqInfo                `json:"pull_req"`
+	Comment        CommentInfo                `json:"comment"`
+	Status         string                     `json:"status"`
+	Reviewer       PrincipalInfo              `json:"reviewer"`
+	ReviewDecision enum.PullReqReviewDecision `json:"review_decision"`
+	OldIdentifier  string                     `json:"old_identifier"`
+	Check          CheckInfo                  `json:"check"`
+	Pipeline       PipelineInfo               `json:"pipeline"`
+	Execution      ExecutionInfo              `json:"execution"`
+}
+
+// renderChatText renders a short, human readable message describing the webhook trigger.
+//
+//nolint:gocyclo,cyclop // one case per trigger keeps the messages easy to read.
+func renderChatText(triggerType enum.WebhookTrigger, body any) (string, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	f := chatFields{}
+	if err = json.Unmarshal(raw, &f); err != nil {
+		return "", err
+	}
+
+	actor := f.Principal.DisplayName
+	repo := f.Repo.Path
+	pr := fmt.Sprintf("pull request #%d %q", f.PullReq.Number, f.PullReq.Title)
+
+	switch triggerType {
+	case enum.WebhookTriggerBranchCreated:
+		return fmt.Sprintf("%s created branch %s in %s (%s)", actor, refName(f.Ref), repo, shortSHA(f.SHA)), nil
+	case enum.WebhookTriggerBranchUpdated:
+		verb := "pushed to"
+		if f.Forced {
+			verb = "force pushed to"
+		}
+		return fmt.Sprintf("%s %s branch %s in %s (%s..%s)",
+			actor, verb, refName(f.Ref), repo, shortSHA(f.OldSHA), shortSHA(f.SHA)), nil
+	case enum.WebhookTriggerBranchDeleted:
+		return fmt.Sprintf("%s deleted branch %s in %s", actor, refName(f.Ref), repo), nil
+	case enum.WebhookTriggerTagCreated:
+		return fmt.Sprintf("%s created tag %s in %s (%s)", actor, refName(f.Ref), repo, shortSHA(f.SHA)), nil
+	case enum.WebhookTriggerTagUpdated:
+		return fmt.Sprintf("%s moved tag %s in %s to %s", actor, refName(f.Ref), repo, shortSHA(f.SHA)), nil
+	case enum.WebhookTriggerTagDeleted:
+		return fmt.Sprintf("%s deleted tag %s in %s", actor, refName(f.Ref), repo), nil
+
+	case enum.WebhookTriggerPullReqCreated:
+		return fmt.Sprintf("%s opened %s in %s: %s", actor, pr, repo, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqReopened:
+		return fmt.Sprintf("%s reopened %s in %s: %s", actor, pr, repo, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		return fmt.Sprintf("%s pushed %s to %s in %s: %s", actor, shortSHA(f.SHA), pr, repo, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqClosed:
+		return fmt.Sprintf("%s closed %s in %s: %s", actor, pr, repo, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqMerged:
+		return fmt.Sprintf("%s merged %s in %s: %s", actor, pr, repo, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqUpdated:
+		return fmt.Sprintf("%s updated %s in %s: %s", actor, pr, repo, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqCommentCreated:
+		return fmt.Sprintf("%s commented on %s in %s: %s\n> %s",
+			actor, pr, repo, f.PullReq.PrURL, f.Comment.Text), nil
+	case enum.WebhookTriggerPullReqCommentUpdated:
+		return fmt.Sprintf("%s edited a comment on %s in %s: %s\n> %s",
+			actor, pr, repo, f.PullReq.PrURL, f.Comment.Text), nil
+	case enum.WebhookTriggerPullReqCommentStatusUpdated:
+		return fmt.Sprintf("%s marked a comment on %s in %s as %s: %s",
+			actor, pr, repo, f.Status, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqReviewSubmitted:
+		return fmt.Sprintf("%s reviewed %s in %s (%s): %s",
+			actor, pr, repo, f.ReviewDecision, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqReviewerAdded:
+		return fmt.Sprintf("%s requested a review from %s on %s in %s: %s",
+			actor, f.Reviewer.DisplayName, pr, repo, f.PullReq.PrURL), nil
+	case enum.WebhookTriggerPullReqReviewerRemoved:
+		return fmt.Sprintf("%s removed reviewer %s from %s in %s: %s",
+			actor, f.Reviewer.DisplayName, pr, repo, f.PullReq.PrURL), nil
+
+	case enum.WebhookTriggerCheckReported:
+		text := fmt.Sprintf("Check %s reported %s for commit %s in %s",
+			f.Check.Identifier, f.Check.Status, shortSHA(f.Check.CommitSHA), repo)
+		if f.Check.Link != "" {
+			text += ": " + f.Check.Link
+		}
+		return text, nil
+
+	case enum.WebhookTriggerPipelineExecutionStarted:
+		return fmt.Sprintf("Pipeline %s execution #%d started in %s: %s",
+			f.Pipeline.Identifier, f.Execution.Number, repo, f.Execution.URL), nil
+	case enum.WebhookTriggerPipelineExecutionFinished:
+		return fmt.Sprintf("Pipeline %s execution #%d finished with status %s in %s: %s",
+			f.Pipeline.Identifier, f.Execution.Number, f.Execution.Status, repo, f.Execution.URL), nil
+
+	case enum.WebhookTriggerRepoCreated:
+		return fmt.Sprintf("%s created repository %s", actor, repo), nil
+	case enum.WebhookTriggerRepoDeleted:
+		return fmt.Sprintf("%s deleted repository %s", actor, repo), nil
+	case enum.WebhookTriggerRepoRenamed:
+		return fmt.Sprintf("%s renamed repository %s to %s", actor, f.OldIdentifier, repo), nil
+
+	default:
+		return fmt.Sprintf("%s triggered %s in %s", actor, triggerType, repo), nil
+	}
+}
+
+func refName(ref ReferenceInfo) string {
+	name, _ := strings.CutPrefix(ref.Name, "refs/heads/")
+	name, _ = strings.CutPrefix(name, "refs/tags/")
+	return name
+}
+
+func shortSHA(sha string) string {
+	const length = 8
+	if len(sha) > length {
+		return sha[:length]
+	}
+	return sha
+}
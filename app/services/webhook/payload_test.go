@@ -0,0 +1,224 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestService_renderPayload(t *testing.T) {
+	body := &ReferencePayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerBranchUpdated,
+			Repo:      RepositoryInfo{ID: 1, Path: "space/repo"},
+			Principal: PrincipalInfo{DisplayName: "Jane"},
+		},
+		ReferenceSegment: ReferenceSegment{
+			Ref: ReferenceInfo{Name: "refs/heads/main"},
+		},
+		ReferenceDetailsSegment: ReferenceDetailsSegment{
+			SHA: "1234567890abcdef",
+		},
+		ReferenceUpdateSegment: ReferenceUpdateSegment{
+			OldSHA: "abcdef1234567890",
+			Forced: true,
+		},
+	}
+
+	s := &Service{config: Config{HeaderIdentity: "Gitness"}}
+
+	tests := []struct {
+		name     string
+		webhook  *types.Webhook
+		validate func(t *testing.T, payload []byte)
+	}{
+		{
+			name:    "native",
+			webhook: &types.Webhook{},
+			validate: func(t *testing.T, payload []byte) {
+				got := ReferencePayload{}
+				if err := json.Unmarshal(payload, &got); err != nil {
+					t.Fatalf("failed to unmarshal payload: %s", err)
+				}
+				if got.SHA != body.SHA || got.Ref.Name != body.Ref.Name {
+					t.Errorf("unexpected native payload: %s", payload)
+				}
+			},
+		},
+		{
+			name:    "chat",
+			webhook: &types.Webhook{PayloadFormat: enum.WebhookPayloadFormatChat},
+			validate: func(t *testing.T, payload []byte) {
+				got := chatMessage{}
+				if err := json.Unmarshal(payload, &got); err != nil {
+					t.Fatalf("failed to unmarshal payload: %s", err)
+				}
+				want := "Jane force pushed to branch main in space/repo (abcdef12..12345678)"
+				if got.Text != want {
+					t.Errorf("want text %q, got %q", want, got.Text)
+				}
+			},
+		},
+		{
+			name: "cloudevents",
+			webhook: &types.Webhook{
+				PayloadFormat: enum.WebhookPayloadFormatCloudEvents,
+				ParentType:    enum.WebhookParentRepo,
+				ParentID:      1,
+			},
+			validate: func(t *testing.T, payload []byte) {
+				got := struct {
+					cloudEvent
+					Data ReferencePayload `json:"data"`
+				}{}
+				if err := json.Unmarshal(payload, &got); err != nil {
+					t.Fatalf("failed to unmarshal payload: %s", err)
+				}
+				if got.SpecVersion != "1.0" || got.ID != "trigger" || got.Source != "/repos/1" ||
+					got.Type != "gitness.branch_updated" || got.Data.SHA != body.SHA {
+					t.Errorf("unexpected cloud event: %s", payload)
+				}
+			},
+		},
+		{
+			name: "template",
+			webhook: &types.Webhook{
+				PayloadFormat:   enum.WebhookPayloadFormatTemplate,
+				PayloadTemplate: `{"ref": {{json .Ref.Name}}, "repo": "{{upper .Repo.Path}}"}`,
+			},
+			validate: func(t *testing.T, payload []byte) {
+				want := `{"ref": "refs/heads/main", "repo": "SPACE/REPO"}`
+				if string(payload) != want {
+					t.Errorf("want payload %s, got %s", want, payload)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := s.renderPayload(buf, test.webhook, "trigger", enum.WebhookTriggerBranchUpdated, body)
+			if err != nil {
+				t.Fatalf("failed to render payload: %s", err)
+			}
+
+			test.validate(t, buf.Bytes())
+		})
+	}
+}
+
+func TestService_renderPayload_templateError(t *testing.T) {
+	s := &Service{}
+	webhook := &types.Webhook{
+		PayloadFormat:   enum.WebhookPayloadFormatTemplate,
+		PayloadTemplate: "{{.Unknown}}",
+	}
+
+	err := s.renderPayload(&bytes.Buffer{}, webhook, "trigger", enum.WebhookTriggerBranchDeleted, &ReferencePayload{})
+	if err == nil {
+		t.Error("expected an error for a template referencing an unknown field")
+	}
+}
+
+func TestExecutePayloadTemplate(t *testing.T) {
+	body := struct {
+		ID    int64
+		Items []string
+	}{
+		ID:    1700000000000,
+		Items: make([]string, 1000),
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+		expErr   bool
+	}{
+		{
+			name:     "range-slice",
+			template: `[{{range $i, $e := .Items}}{{if lt $i 2}}{{if $i}},{{end}}{{$i}}{{end}}{{end}}]`,
+			expected: `[0,1]`,
+		},
+		{
+			name:     "range-int-literal",
+			template: `[{{range 1000000000}}{{end}}]`,
+			expErr:   true,
+		},
+		{
+			name:     "range-int-variable",
+			template: `{{$n := 1000000000}}[{{range $n}}{{end}}]`,
+			expErr:   true,
+		},
+		{
+			name:     "range-int-field",
+			template: `[{{range .ID}}{{end}}]`,
+			expErr:   true,
+		},
+		{
+			name:     "range-nested",
+			template: `[{{range .Items}}{{range $.Items}}{{end}}{{end}}]`,
+			expErr:   true,
+		},
+		{
+			name:     "output-too-large",
+			template: `"{{printf "%02000000d" 0}}"`,
+			expErr:   true,
+		},
+		{
+			name:     "output-invalid-json",
+			template: `{"id": {{.ID}}`,
+			expErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := ParsePayloadTemplate(test.template)
+			if err != nil {
+				t.Fatalf("failed to parse template: %s", err)
+			}
+
+			buf := &bytes.Buffer{}
+			err = executePayloadTemplate(buf, tmpl, body)
+			if test.expErr != (err != nil) {
+				t.Fatalf("error mismatch: expected=%t got=%v", test.expErr, err)
+			}
+
+			if got := buf.String(); got != test.expected {
+				t.Errorf("want payload %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParsePayloadTemplate_Unsupported(t *testing.T) {
+	for _, text := range []string{
+		`{{define "x"}}{}{{end}}{{template "x"}}`,
+		`{{block "x" .}}{}{{end}}`,
+		`{{template "payload" .}}`,
+		`{{_iteration}}`,
+	} {
+		if _, err := ParsePayloadTemplate(text); err == nil {
+			t.Errorf("expected an error for template %q", text)
+		}
+	}
+}
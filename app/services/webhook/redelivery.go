@@ -0,0 +1,284 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeWebhookRedelivery        = "gitness:webhook:redelivery"
+	jobMaxDurationWebhookRedelivery = 1 * time.Minute
+	jobMaxRetriesWebhookRedelivery  = 3
+)
+
+type redeliveryJobInput struct {
+	WebhookExecutionID int64 `json:"webhook_execution_id"`
+	Attempt            int   `json:"attempt"`
+}
+
+// redeliveryJob is the background job redelivering a failed webhook execution.
+type redeliveryJob struct {
+	service *Service
+}
+
+// Handle redelivers the webhook execution provided in the job data.
+func (j *redeliveryJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	input := redeliveryJobInput{}
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal webhook redelivery job input: %w", err)
+	}
+
+	return j.service.redeliverWebhookExecution(ctx, input.WebhookExecutionID, input.Attempt)
+}
+
+// RedeliverFailedWebhookExecutions schedules a redelivery for all triggers of the webhook
+// that failed to be delivered since the provided time (unix milli).
+// For every trigger only the latest execution is redelivered, and only if the trigger wasn't delivered successfully.
+// The method returns the executions that got scheduled for redelivery.
+func (s *Service) RedeliverFailedWebhookExecutions(ctx context.Context, webhookID int64,
+	since int64) ([]*types.WebhookExecution, error) {
+	executions, err := s.webhookExecutionStore.ListForWebhookSince(ctx, webhookID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions of webhook %d: %w", webhookID, err)
+	}
+
+	// find the latest execution per trigger (executions are ordered from oldest to newest)
+	latest := make(map[string]*types.WebhookExecution)
+	delivered := make(map[string]bool)
+	triggerIDs := make([]string, 0)
+	for _, execution := range executions {
+		if _, ok := latest[execution.TriggerID]; !ok {
+			triggerIDs = append(triggerIDs, execution.TriggerID)
+		}
+		latest[execution.TriggerID] = execution
+		if execution.Result == enum.WebhookExecutionResultSuccess {
+			delivered[execution.TriggerID] = true
+		}
+	}
+
+	scheduled := make([]*types.WebhookExecution, 0)
+	for _, triggerID := range triggerIDs {
+		execution := latest[triggerID]
+		if delivered[triggerID] || !execution.Retriggerable {
+			continue
+		}
+
+		err = s.scheduleRedelivery(ctx, execution.ID, 1, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule redelivery of webhook execution %d: %w", execution.ID, err)
+		}
+
+		scheduled = append(scheduled, execution)
+	}
+
+	return scheduled, nil
+}
+
+// scheduleRedeliveryIfRetriable schedules a background redelivery of the execution in case it failed with
+// a retriable error and the maximum number of redelivery attempts isn't reached yet.
+func (s *Service) scheduleRedeliveryIfRetriable(ctx context.Context,
+	execution *types.WebhookExecution, attempt int) {
+	if execution == nil || execution.ID == 0 || !execution.Retriggerable ||
+		execution.Result != enum.WebhookExecutionResultRetriableError ||
+		attempt > s.config.RedeliveryMaxAttempts {
+		return
+	}
+
+	err := s.scheduleRedelivery(ctx, execution.ID, attempt, s.redeliveryBackoff(attempt))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf(
+			"failed to schedule redelivery attempt %d of webhook execution %d", attempt, execution.ID)
+	}
+}
+
+func (s *Service) scheduleRedelivery(ctx context.Context, executionID int64, attempt int,
+	delay time.Duration) error {
+	data, err := json.Marshal(redeliveryJobInput{
+		WebhookExecutionID: executionID,
+		Attempt:            attempt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook redelivery job input: %w", err)
+	}
+
+	return s.scheduler.RunJob(ctx, job.Definition{
+		// the same execution can be scheduled multiple times (e.g. bulk redelivery), stale jobs are skipped.
+		UID:        fmt.Sprintf("webhook-redelivery-%d-%d", executionID, time.Now().UnixNano()),
+		Type:       jobTypeWebhookRedelivery,
+		MaxRetries: jobMaxRetriesWebhookRedelivery,
+		Timeout:    jobMaxDurationWebhookRedelivery,
+		Data:       string(data),
+		Delay:      delay,
+	})
+}
+
+// redeliveryBackoff returns the delay before the provided redelivery attempt.
+// The delay doubles with every attempt, starting with RedeliveryBackoff and capped at RedeliveryBackoffMax.
+func (s *Service) redeliveryBackoff(attempt int) time.Duration {
+	delay := s.config.RedeliveryBackoff
+	for i := 1; i < attempt && delay < s.config.RedeliveryBackoffMax; i++ {
+		delay *= 2
+	}
+
+	if delay > s.config.RedeliveryBackoffMax {
+		delay = s.config.RedeliveryBackoffMax
+	}
+
+	return delay
+}
+
+// redeliverWebhookExecution redelivers the webhook execution with the provided id,
+// unless the trigger got delivered in the meantime or a newer execution exists for the same trigger.
+// In case the redelivery fails again with a retriable error, the next attempt is scheduled.
+func (s *Service) redeliverWebhookExecution(ctx context.Context, executionID int64, attempt int) (string, error) {
+	execution, err := s.webhookExecutionStore.Find(ctx, executionID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Sprintf("webhook execution %d doesn't exist anymore", executionID), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook execution %d: %w", executionID, err)
+	}
+
+	webhook, err := s.webhookStore.Find(ctx, execution.WebhookID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Sprintf("webhook %d doesn't exist anymore", execution.WebhookID), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook %d: %w", execution.WebhookID, err)
+	}
+
+	if !webhook.Enabled {
+		return fmt.Sprintf("webhook %d is disabled", webhook.ID), nil
+	}
+
+	executions, err := s.webhookExecutionStore.ListForTrigger(ctx, execution.TriggerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list executions for trigger '%s': %w", execution.TriggerID, err)
+	}
+
+	for _, other := range executions {
+		if other.WebhookID != webhook.ID {
+			continue
+		}
+		if other.Result == enum.WebhookExecutionResultSuccess {
+			return fmt.Sprintf("trigger '%s' got delivered by execution %d", other.TriggerID, other.ID), nil
+		}
+		if other.ID > execution.ID {
+			return fmt.Sprintf("webhook execution %d got superseded by execution %d", execution.ID, other.ID), nil
+		}
+	}
+
+	result := s.retriggerExecution(ctx, webhook, execution)
+	if result.Execution.Result == enum.WebhookExecutionResultSuccess {
+		return fmt.Sprintf("redelivered webhook execution %d as execution %d", execution.ID, result.Execution.ID), nil
+	}
+
+	s.scheduleRedeliveryIfRetriable(ctx, result.Execution, attempt+1)
+
+	return fmt.Sprintf("redelivery attempt %d of webhook execution %d resulted in %s: %s",
+		attempt, execution.ID, result.Execution.Result, result.Execution.Error), nil
+}
+
+// circuitOpen returns true in case the webhook failed too many times in a row.
+// New deliveries of such a webhook are deferred to the redelivery queue to protect the system and the receiver.
+func (s *Service) circuitOpen(webhook *types.Webhook) bool {
+	return s.config.RedeliveryMaxAttempts > 0 &&
+		s.config.CircuitBreakerThreshold > 0 &&
+		webhook.ConsecutiveFailures >= s.config.CircuitBreakerThreshold
+}
+
+// updateWebhookHealth updates the latest execution result and the failure statistics of the webhook.
+// In case the webhook keeps failing for longer than configured, it gets disabled and its owner gets notified.
+func (s *Service) updateWebhookHealth(ctx context.Context, webhook *types.Webhook,
+	execution *types.WebhookExecution, deferred bool) {
+	succeeded := execution.Result == enum.WebhookExecutionResultSuccess
+	resultChanged := webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result
+
+	// update the webhook IFF something changed (best effort)
+	if !resultChanged && (deferred || (succeeded && webhook.ConsecutiveFailures == 0)) {
+		return
+	}
+
+	var disabled bool
+	updated, err := s.webhookStore.UpdateOptLock(ctx, webhook, func(hook *types.Webhook) error {
+		disabled = false
+		hook.LatestExecutionResult = &execution.Result
+
+		switch {
+		case deferred:
+			// deferred deliveries weren't attempted and don't count as failures.
+		case succeeded:
+			hook.ConsecutiveFailures = 0
+			hook.FailingSince = 0
+		default:
+			hook.ConsecutiveFailures++
+			if hook.FailingSince == 0 {
+				hook.FailingSince = execution.Created
+			}
+
+			if hook.Enabled && s.shouldAutoDisable(hook, execution.Created) {
+				hook.Enabled = false
+				hook.DisabledReason = fmt.Sprintf(
+					"disabled automatically after %d consecutive failed deliveries since %s",
+					hook.ConsecutiveFailures, time.UnixMilli(hook.FailingSince).UTC().Format(time.RFC3339))
+				disabled = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf(
+			"failed to update health of webhook %d after execution with result %s",
+			webhook.ID, execution.Result)
+		return
+	}
+
+	if disabled {
+		log.Ctx(ctx).Warn().Msgf("webhook %d got disabled: %s", updated.ID, updated.DisabledReason)
+
+		s.webhookReporter.Disabled(ctx, &webhookevents.DisabledPayload{
+			WebhookID:           updated.ID,
+			ParentType:          updated.ParentType,
+			ParentID:            updated.ParentID,
+			ConsecutiveFailures: updated.ConsecutiveFailures,
+			Reason:              updated.DisabledReason,
+		})
+	}
+}
+
+// shouldAutoDisable returns true in case the webhook is failing for at least the configured duration.
+// NOTE: Internal webhooks are never disabled automatically.
+func (s *Service) shouldAutoDisable(webhook *types.Webhook, now int64) bool {
+	if s.config.AutoDisableAfter <= 0 || webhook.Internal || webhook.FailingSince == 0 {
+		return false
+	}
+
+	return webhook.ConsecutiveFailures >= s.config.CircuitBreakerThreshold &&
+		time.Duration(now-webhook.FailingSince)*time.Millisecond >= s.config.AutoDisableAfter
+}
@@ -21,13 +21,18 @@ import (
 	"net/http"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 )
 
@@ -47,6 +52,17 @@ type Config struct {
 	MaxRetries          int
 	AllowPrivateNetwork bool
 	AllowLoopback       bool
+
+	// RedeliveryMaxAttempts is the maximum number of background redeliveries of a failed delivery.
+	RedeliveryMaxAttempts int
+	// RedeliveryBackoff is the delay before the first redelivery, it doubles with every attempt.
+	RedeliveryBackoff time.Duration
+	// RedeliveryBackoffMax caps the delay between two redeliveries.
+	RedeliveryBackoffMax time.Duration
+	// CircuitBreakerThreshold is the number of consecutive failures after which deliveries are deferred.
+	CircuitBreakerThreshold int
+	// AutoDisableAfter is the duration of continuous failures after which a webhook gets disabled (0 = never).
+	AutoDisableAfter time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -65,11 +81,26 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.RedeliveryMaxAttempts < 0 {
+		return errors.New("config.RedeliveryMaxAttempts can't be negative")
+	}
+	if c.RedeliveryMaxAttempts > 0 && c.RedeliveryBackoff <= 0 {
+		return errors.New("config.RedeliveryBackoff has to be a positive duration")
+	}
+	if c.CircuitBreakerThreshold < 0 {
+		return errors.New("config.CircuitBreakerThreshold can't be negative")
+	}
+	if c.AutoDisableAfter < 0 {
+		return errors.New("config.AutoDisableAfter can't be negative")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
 		c.HeaderIdentity = c.UserAgentIdentity
 	}
+	if c.RedeliveryBackoffMax < c.RedeliveryBackoff {
+		c.RedeliveryBackoffMax = c.RedeliveryBackoff
+	}
 
 	return nil
 }
@@ -81,10 +112,17 @@ type Service struct {
 	urlProvider           url.Provider
 	repoStore             store.RepoStore
 	pullreqStore          store.PullReqStore
+	pullReqLabelStore     store.PullReqLabelStore
 	principalStore        store.PrincipalStore
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
 	encrypter             encrypt.Encrypter
+	spaceStore            store.SpaceStore
+	checkStore            store.CheckStore
+	pipelineStore         store.PipelineStore
+	executionStore        store.ExecutionStore
+	scheduler             *job.Scheduler
+	webhookReporter       *webhookevents.Reporter
 
 	secureHTTPClient   *http.Client
 	insecureHTTPClient *http.Client
@@ -100,15 +138,26 @@ func NewService(
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
+	pullReqLabelStore store.PullReqLabelStore,
 	activityStore store.PullReqActivityStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
+	spaceStore store.SpaceStore,
+	checkStore store.CheckStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	webhookReporter *webhookevents.Reporter,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
@@ -118,11 +167,18 @@ func NewService(
 		webhookExecutionStore: webhookExecutionStore,
 		repoStore:             repoStore,
 		pullreqStore:          pullreqStore,
+		pullReqLabelStore:     pullReqLabelStore,
 		activityStore:         activityStore,
 		urlProvider:           urlProvider,
 		principalStore:        principalStore,
 		git:                   git,
 		encrypter:             encrypter,
+		spaceStore:            spaceStore,
+		checkStore:            checkStore,
+		pipelineStore:         pipelineStore,
+		executionStore:        executionStore,
+		scheduler:             scheduler,
+		webhookReporter:       webhookReporter,
 
 		secureHTTPClient:   newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
 		insecureHTTPClient: newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
@@ -133,7 +189,12 @@ func NewService(
 		config: config,
 	}
 
-	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+	err := executor.Register(jobTypeWebhookRedelivery, &redeliveryJob{service: service})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register webhook redelivery job: %w", err)
+	}
+
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
 		func(r *gitevents.Reader) error {
 			const idleTimeout = 1 * time.Minute
 			r.Configure(
@@ -175,6 +236,12 @@ func NewService(
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterCommentCreated(service.handleEventPullReqComment)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterUpdated(service.handleEventPullReqUpdated)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterReviewerAdded(service.handleEventPullReqReviewerAdded)
+			_ = r.RegisterReviewerRemoved(service.handleEventPullReqReviewerRemoved)
+			_ = r.RegisterCommentUpdated(service.handleEventPullReqCommentUpdated)
+			_ = r.RegisterCommentStatusUpdated(service.handleEventPullReqCommentStatusUpdated)
 
 			return nil
 		})
@@ -182,5 +249,65 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventRepoCreated)
+			_ = r.RegisterSoftDeleted(service.handleEventRepoSoftDeleted)
+			_ = r.RegisterRenamed(service.handleEventRepoRenamed)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
+	_, err = checkReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for webhooks: %w", err)
+	}
+
+	_, err = pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterExecutionStarted(service.handleEventPipelineExecutionStarted)
+			_ = r.RegisterExecutionFinished(service.handleEventPipelineExecutionFinished)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
@@ -20,7 +20,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -96,9 +95,11 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 	// precalculate whether a webhook should be executed
 	skipExecution := make(map[int64]bool)
 	for _, execution := range executions {
-		// skip execution in case of success or unrecoverable error
+		// skip execution in case of success or unrecoverable error.
+		// Retriable errors are taken care of by the redelivery queue (if enabled).
 		if execution.Result == enum.WebhookExecutionResultSuccess ||
-			execution.Result == enum.WebhookExecutionResultFatalError {
+			execution.Result == enum.WebhookExecutionResultFatalError ||
+			s.config.RedeliveryMaxAttempts > 0 {
 			skipExecution[execution.WebhookID] = true
 		}
 	}
@@ -133,8 +134,11 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 			continue
 		}
 
-		// execute trigger and store output in result
-		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+		// execute trigger and store output in result (defer delivery to the redelivery queue if circuit is open)
+		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil,
+			s.circuitOpen(webhook))
+
+		s.scheduleRedeliveryIfRetriable(ctx, results[i].Execution, 1)
 	}
 
 	return results, nil
@@ -158,6 +162,17 @@ func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutio
 		return nil, fmt.Errorf("failed to find webhook with id %d: %w", webhookExecution.WebhookID, err)
 	}
 
+	result := s.retriggerExecution(ctx, webhook, webhookExecution)
+
+	// in case the retrigger failed again, keep redelivering it in the background
+	s.scheduleRedeliveryIfRetriable(ctx, result.Execution, 1)
+
+	return result, nil
+}
+
+// retriggerExecution executes the webhook again using the request body of the provided execution.
+func (s *Service) retriggerExecution(ctx context.Context, webhook *types.Webhook,
+	webhookExecution *types.WebhookExecution) *TriggerResult {
 	// reuse same trigger id as original execution
 	triggerID := webhookExecution.TriggerID
 	triggerType := webhookExecution.TriggerType
@@ -167,19 +182,23 @@ func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutio
 	// NOTE: bBuff.Write(v) will always return (len(v), nil) - no need to error handle
 	body.WriteString(webhookExecution.Request.Body)
 
-	newExecution, err := s.executeWebhook(ctx, webhook, triggerID, triggerType, body, &webhookExecution.ID)
+	newExecution, err := s.executeWebhook(ctx, webhook, triggerID, triggerType, body, &webhookExecution.ID, false)
 	return &TriggerResult{
 		TriggerID:   triggerID,
 		TriggerType: triggerType,
 		Webhook:     webhook,
 		Execution:   newExecution,
 		Err:         err,
-	}, nil
+	}
 }
 
+// executeWebhook executes the webhook and stores the execution.
+// In case deferDelivery is true, the request is prepared and stored, but not sent.
+//
 //nolint:gocognit // refactor into smaller chunks if necessary.
 func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, triggerID string,
-	triggerType enum.WebhookTrigger, body any, rerunOfID *int64) (*types.WebhookExecution, error) {
+	triggerType enum.WebhookTrigger, body any, rerunOfID *int64, deferDelivery bool,
+) (*types.WebhookExecution, error) {
 	// build execution entry on the fly (save no matter what)
 	execution := types.WebhookExecution{
 		RetriggerOf: rerunOfID,
@@ -203,18 +222,8 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 				execution.Result, execution.Response.Status, execution.Error)
 		}
 
-		// update latest execution result of webhook IFF it's different from before (best effort)
-		if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result {
-			_, err = s.webhookStore.UpdateOptLock(oCtx, webhook, func(hook *types.Webhook) error {
-				hook.LatestExecutionResult = &execution.Result
-				return nil
-			})
-			if err != nil {
-				log.Ctx(ctx).Warn().Err(err).Msgf(
-					"failed to update latest execution result to %s for webhook %d",
-					execution.Result, webhook.ID)
-			}
-		}
+		// update latest execution result and failure statistics of webhook (best effort)
+		s.updateWebhookHealth(oCtx, webhook, &execution, deferDelivery)
 	}(ctx, time.Now())
 
 	// derive context with time limit
@@ -227,6 +236,14 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		return &execution, err
 	}
 
+	// leave the delivery to the redelivery queue (request is stored with the execution)
+	if deferDelivery {
+		tErr := errors.New("delivery deferred as the webhook failed too many times in a row")
+		execution.Error = tErr.Error()
+		execution.Result = enum.WebhookExecutionResultRetriableError
+		return &execution, tErr
+	}
+
 	// Execute HTTP Request (insecure if requested)
 	var resp *http.Response
 	switch {
@@ -252,6 +269,7 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 
 	// handle certain errors explicitly to give more to-the-point error messages
 	var dnsError *net.DNSError
+	var opError *net.OpError
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
 		// we assume timeout without any response is not worth retrying - protect the system
@@ -266,6 +284,13 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		execution.Result = enum.WebhookExecutionResultFatalError
 		return &execution, fmt.Errorf("failed to resolve host name '%s': %w", dnsError.Name, err)
 
+	case errors.As(err, &opError):
+		// network errors (e.g. connection refused) are most likely temporary (e.g. the receiver is restarting)
+		tErr := fmt.Errorf("failed to connect to the remote server: %w", err)
+		execution.Error = tErr.Error()
+		execution.Result = enum.WebhookExecutionResultRetriableError
+		return &execution, tErr
+
 	case err != nil:
 		// for all other errors we don't retry - protect the system. User can retrigger manually (if body was set)
 		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
@@ -282,7 +307,8 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 
 // prepareHTTPRequest prepares a new http.Request object for the webhook using the provided body as request body.
 // All execution.Request.XXX values are set accordingly.
-// NOTE: if the body is an io.Reader, the value is used as response body as is, otherwise it'll be JSON serialized.
+// NOTE: if the body is an io.Reader, the value is used as response body as is,
+// otherwise it'll be rendered in the payload format of the webhook.
 func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.WebhookExecution,
 	triggerType enum.WebhookTrigger, webhook *types.Webhook, body any) (*http.Request, error) {
 	// set URL as is (already has been validated, any other error will be caught in request creation)
@@ -309,13 +335,14 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 		bBuff.Write(bBytes)
 
 	default:
-		// all other types we json serialize
-		err := json.NewEncoder(bBuff).Encode(body)
+		// all other types we render in the payload format of the webhook
+		err := s.renderPayload(bBuff, webhook, execution.TriggerID, triggerType, body)
 		if err != nil {
-			// this is an internal issue, nothing the user can do - don't expose error details
-			execution.Error = "an error occurred preparing the request body"
+			// expose the error details to help the user fix the payload template (body generation is internal)
+			tErr := fmt.Errorf("failed to render request body: %w", err)
+			execution.Error = tErr.Error()
 			execution.Result = enum.WebhookExecutionResultFatalError
-			return nil, fmt.Errorf("failed to serialize body to json: %w", err)
+			return nil, tErr
 		}
 	}
 	// set executioon body and mark it as retriggerable
@@ -332,15 +359,23 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 		return nil, tErr
 	}
 
-	// setup headers
-	req.Header.Add("User-Agent", fmt.Sprintf("%s/%s", s.config.UserAgentIdentity, version.Version))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add(s.toXHeader("Trigger"), string(triggerType))
-	req.Header.Add(s.toXHeader("Webhook-Parent-Type"), string(webhook.ParentType))
-	req.Header.Add(s.toXHeader("Webhook-Parent-Id"), fmt.Sprint(webhook.ParentID))
+	customHeaders, err := decryptHeaders(s.encrypter, webhook.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook headers: %w", err)
+	}
+
+	// setup headers (custom headers can't override the user agent or any of the identity headers)
+	req.Header.Set("Content-Type", payloadContentType(webhook))
+	for name, value := range customHeaders {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", s.config.UserAgentIdentity, version.Version))
+	req.Header.Set(s.toXHeader("Trigger"), string(triggerType))
+	req.Header.Set(s.toXHeader("Webhook-Parent-Type"), string(webhook.ParentType))
+	req.Header.Set(s.toXHeader("Webhook-Parent-Id"), fmt.Sprint(webhook.ParentID))
 	// TODO [CODE-1363]: remove after identifier migration.
-	req.Header.Add(s.toXHeader("Webhook-Uid"), fmt.Sprint(webhook.Identifier))
-	req.Header.Add(s.toXHeader("Webhook-Identifier"), fmt.Sprint(webhook.Identifier))
+	req.Header.Set(s.toXHeader("Webhook-Uid"), fmt.Sprint(webhook.Identifier))
+	req.Header.Set(s.toXHeader("Webhook-Identifier"), fmt.Sprint(webhook.Identifier))
 
 	// add HMAC only if a secret was provided
 	if webhook.Secret != "" {
@@ -353,11 +388,12 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate SHA256 based HMAC: %w", err)
 		}
-		req.Header.Add(s.toXHeader("Signature"), hmac)
+		req.Header.Set(s.toXHeader("Signature"), hmac)
 	}
 
+	// custom headers often contain credentials, their values aren't stored with the execution.
 	hBuffer := &bytes.Buffer{}
-	err = req.Header.Write(hBuffer)
+	err = redactHeaders(req.Header, customHeaders).Write(hBuffer)
 	if err != nil {
 		tErr := fmt.Errorf("failed to write request headers: %w", err)
 		execution.Error = tErr.Error()
@@ -79,6 +79,45 @@ type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
 }
 
+// PullReqCommentStatusSegment contains details for pull req comment status related payloads for webhooks.
+type PullReqCommentStatusSegment struct {
+	Status enum.PullReqCommentStatus `json:"status"`
+}
+
+// PullReqUpdateSegment contains details for pull req update related payloads for webhooks.
+type PullReqUpdateSegment struct {
+	TitleChanged       bool   `json:"title_changed"`
+	TitleOld           string `json:"title_old"`
+	DescriptionChanged bool   `json:"description_changed"`
+	DescriptionOld     string `json:"description_old"`
+}
+
+// PullReqReviewerSegment contains details for pull req reviewer related payloads for webhooks.
+type PullReqReviewerSegment struct {
+	Reviewer PrincipalInfo `json:"reviewer"`
+}
+
+// PullReqReviewSegment contains details for pull req review related payloads for webhooks.
+type PullReqReviewSegment struct {
+	ReviewDecision enum.PullReqReviewDecision `json:"review_decision"`
+}
+
+// RepositoryRenameSegment contains details for repository rename related payloads for webhooks.
+type RepositoryRenameSegment struct {
+	OldIdentifier string `json:"old_identifier"`
+}
+
+// CheckSegment contains details for status check related payloads for webhooks.
+type CheckSegment struct {
+	Check CheckInfo `json:"check"`
+}
+
+// PipelineExecutionSegment contains details for pipeline execution related payloads for webhooks.
+type PipelineExecutionSegment struct {
+	Pipeline  PipelineInfo  `json:"pipeline"`
+	Execution ExecutionInfo `json:"execution"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -130,6 +169,7 @@ type PullReqInfo struct {
 	MergeStrategy *enum.MergeMethod `json:"merge_strategy,omitempty"`
 	Author        PrincipalInfo     `json:"author"`
 	PrURL         string            `json:"pr_url"`
+	Labels        []LabelInfo       `json:"labels"`
 }
 
 // pullReqInfoFrom gets the PullReqInfo from a types.PullReq.
@@ -147,7 +187,28 @@ func pullReqInfoFrom(pr *types.PullReq, repo *types.Repository, urlProvider url.
 		MergeStrategy: pr.MergeMethod,
 		Author:        principalInfoFrom(&pr.Author),
 		PrURL:         urlProvider.GenerateUIPRURL(repo.Path, pr.Number),
+		Labels:        labelInfosFrom(pr.Labels),
+	}
+}
+
+// LabelInfo describes a label assigned to a pull request for a webhook payload.
+type LabelInfo struct {
+	ID    int64           `json:"id"`
+	Name  string          `json:"name"`
+	Color enum.LabelColor `json:"color"`
+}
+
+// labelInfosFrom gets the LabelInfo list from the labels of a types.PullReq.
+func labelInfosFrom(labels []types.LabelInfo) []LabelInfo {
+	infos := make([]LabelInfo, len(labels))
+	for i, l := range labels {
+		infos[i] = LabelInfo{
+			ID:    l.ID,
+			Name:  l.Name,
+			Color: l.Color,
+		}
 	}
+	return infos
 }
 
 // PrincipalInfo describes the principal related info for a webhook payload.
@@ -268,6 +329,86 @@ type ReferenceInfo struct {
 	Repo RepositoryInfo `json:"repo"`
 }
 
+// CheckInfo describes the status check related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CheckInfo struct {
+	Identifier string           `json:"identifier"`
+	CommitSHA  string           `json:"commit_sha"`
+	Status     enum.CheckStatus `json:"status"`
+	Summary    string           `json:"summary,omitempty"`
+	Link       string           `json:"link,omitempty"`
+	Started    int64            `json:"started,omitempty"`
+	Ended      int64            `json:"ended,omitempty"`
+}
+
+// checkInfoFrom gets the CheckInfo from a types.Check.
+func checkInfoFrom(check *types.Check) CheckInfo {
+	return CheckInfo{
+		Identifier: check.Identifier,
+		CommitSHA:  check.CommitSHA,
+		Status:     check.Status,
+		Summary:    check.Summary,
+		Link:       check.Link,
+		Started:    check.Started,
+		Ended:      check.Ended,
+	}
+}
+
+// PipelineInfo describes the pipeline related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type PipelineInfo struct {
+	ID            int64  `json:"id"`
+	Identifier    string `json:"identifier"`
+	DefaultBranch string `json:"default_branch"`
+	ConfigPath    string `json:"config_path"`
+}
+
+// pipelineInfoFrom gets the PipelineInfo from a types.Pipeline.
+func pipelineInfoFrom(pipeline *types.Pipeline) PipelineInfo {
+	return PipelineInfo{
+		ID:            pipeline.ID,
+		Identifier:    pipeline.Identifier,
+		DefaultBranch: pipeline.DefaultBranch,
+		ConfigPath:    pipeline.ConfigPath,
+	}
+}
+
+// ExecutionInfo describes the pipeline execution related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type ExecutionInfo struct {
+	Number   int64         `json:"number"`
+	Status   enum.CIStatus `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Trigger  string        `json:"trigger,omitempty"`
+	Event    string        `json:"event,omitempty"`
+	Ref      string        `json:"ref,omitempty"`
+	SHA      string        `json:"sha,omitempty"`
+	Started  int64         `json:"started,omitempty"`
+	Finished int64         `json:"finished,omitempty"`
+	URL      string        `json:"url"`
+}
+
+// executionInfoFrom gets the ExecutionInfo from a types.Execution.
+func executionInfoFrom(
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+	repo *types.Repository,
+	urlProvider url.Provider,
+) ExecutionInfo {
+	return ExecutionInfo{
+		Number:   execution.Number,
+		Status:   execution.Status,
+		Error:    execution.Error,
+		Trigger:  execution.Trigger,
+		Event:    execution.Event,
+		Ref:      execution.Ref,
+		SHA:      execution.After,
+		Started:  execution.Started,
+		Finished: execution.Finished,
+		URL:      urlProvider.GenerateUIBuildURL(repo.Path, pipeline.Identifier, execution.Number),
+	}
+}
+
 type CommentInfo struct {
 	ID       int64  `json:"id"`
 	ParentID *int64 `json:"parent_id,omitempty"`
@@ -17,13 +17,18 @@ package webhook
 import (
 	"context"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
@@ -37,17 +42,31 @@ func ProvideService(ctx context.Context,
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
+	pullReqLabelStore store.PullReqLabelStore,
 	activityStore store.PullReqActivityStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
+	spaceStore store.SpaceStore,
+	checkStore store.CheckStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	webhookReporter *webhookevents.Reporter,
 ) (*Service, error) {
 	return NewService(ctx, config, gitReaderFactory, prReaderFactory,
-		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore,
-		urlProvider, principalStore, git, encrypter)
+		repoReaderFactory, checkReaderFactory, pipelineReaderFactory,
+		webhookStore, webhookExecutionStore, repoStore, pullreqStore, pullReqLabelStore, activityStore,
+		urlProvider, principalStore, git, encrypter,
+		spaceStore, checkStore, pipelineStore, executionStore,
+		scheduler, executor, webhookReporter)
 }
@@ -17,6 +17,7 @@ package services
 import (
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
@@ -43,6 +44,8 @@ type Services struct {
 	Cleanup            *cleanup.Service
 	Notification       *notification.Service
 	Keywordsearch      *keywordsearch.Service
+	MergeQueue         *mergequeue.Service
+	AutoMerge          *pullreq.AutoMergeService
 }
 
 func ProvideServices(
@@ -56,6 +59,8 @@ func ProvideServices(
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	mergeQueueSvc *mergequeue.Service,
+	autoMergeSvc *pullreq.AutoMergeService,
 ) Services {
 	return Services{
 		Webhook:            webhooksSvc,
@@ -68,5 +73,7 @@ func ProvideServices(
 		Cleanup:            cleanupSvc,
 		Notification:       notificationSvc,
 		Keywordsearch:      keywordsearchSvc,
+		MergeQueue:         mergeQueueSvc,
+		AutoMerge:          autoMergeSvc,
 	}
 }
@@ -239,6 +239,9 @@ type (
 		// List returns a list of repos in a space. With "DeletedBeforeOrAt" filter, lists deleted repos.
 		List(ctx context.Context, parentID int64, opts *types.RepoFilter) ([]*types.Repository, error)
 
+		// ListForks returns a list of forks of a repo. With "DeletedBeforeOrAt" filter, lists deleted forks.
+		ListForks(ctx context.Context, repoID int64, opts *types.RepoFilter) ([]*types.Repository, error)
+
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
 	}
@@ -288,6 +291,15 @@ type (
 		ListUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) ([]types.MembershipUser, error)
 		CountSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) (int64, error)
 		ListSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) ([]types.MembershipSpace, error)
+
+		// HasRoleInSpaceHierarchy returns true if the principal has any of the roles
+		// in the space or in any of its parent spaces.
+		HasRoleInSpaceHierarchy(
+			ctx context.Context,
+			spaceID int64,
+			principalID int64,
+			roles []enum.MembershipRole,
+		) (bool, error)
 	}
 
 	// PublicAccessStore defines the publicly accessible resources data storage.
@@ -360,6 +372,10 @@ type (
 
 		// List returns a list of pull requests in a space.
 		List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error)
+
+		// ContainedTexts returns the subset of the texts that are contained in the description
+		// of any pull request of the repository or in any not deleted pull request comment.
+		ContainedTexts(ctx context.Context, repoID int64, texts []string) (map[string]bool, error)
 	}
 
 	PullReqActivityStore interface {
@@ -539,6 +555,10 @@ type (
 
 		// ListForTrigger lists the webhook executions for a given trigger id.
 		ListForTrigger(ctx context.Context, triggerID string) ([]*types.WebhookExecution, error)
+
+		// ListForWebhookSince lists the webhook executions for a given webhook id
+		// that were created at or after the provided time (unix milli), oldest first.
+		ListForWebhookSince(ctx context.Context, webhookID int64, since int64) ([]*types.WebhookExecution, error)
 	}
 
 	CheckStore interface {
@@ -779,6 +799,13 @@ type (
 		// ListAllEnabled lists all enabled triggers for a given repo without pagination.
 		// It's used only internally to trigger builds.
 		ListAllEnabled(ctx context.Context, repoID int64) ([]*types.Trigger, error)
+
+		// ListAllEnabledCronDue lists all enabled scheduled triggers that are due to fire at the provided time.
+		ListAllEnabledCronDue(ctx context.Context, now int64) ([]*types.Trigger, error)
+
+		// UpdateCronNext updates the time a scheduled trigger fires next.
+		// It returns false if the trigger was already moved to another time in the meantime.
+		UpdateCronNext(ctx context.Context, triggerID int64, cronNext, newCronNext int64) (bool, error)
 	}
 
 	PluginStore interface {
@@ -832,4 +859,81 @@ type (
 		// ListByFingerprint returns public keys given a fingerprint and key usage.
 		ListByFingerprint(ctx context.Context, fingerprint string) ([]types.PublicKey, error)
 	}
+
+	MergeQueueStore interface {
+		// FindByPullReqID returns the merge queue entry of the pull request.
+		FindByPullReqID(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error)
+
+		// Create adds a pull request to the end of the merge queue of its target branch.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the state and the speculative merge commit of the merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes the merge queue entry.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the merge queue of the target branch in the order in which the pull requests are merged.
+		List(ctx context.Context, repoID int64, targetBranch string) ([]*types.MergeQueueEntry, error)
+
+		// ListBranches returns all branches that have a non-empty merge queue.
+		ListBranches(ctx context.Context) ([]types.MergeQueueBranch, error)
+	}
+
+	AutoMergeStore interface {
+		// Find returns the auto-merge options of the pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.AutoMerge, error)
+
+		// Upsert enables auto-merge for the pull request or replaces its merge options.
+		Upsert(ctx context.Context, autoMerge *types.AutoMerge) error
+
+		// Delete disables auto-merge for the pull request.
+		Delete(ctx context.Context, pullReqID int64) error
+
+		// ListBySourceSHA returns the auto-merge options of all pull requests of the repository
+		// for which the provided commit is the latest commit of the source branch.
+		ListBySourceSHA(ctx context.Context, repoID int64, sourceSHA string) ([]*types.AutoMerge, error)
+	}
+
+	LabelStore interface {
+		// Find finds the label by id.
+		Find(ctx context.Context, id int64) (*types.Label, error)
+
+		// FindByName finds the label of a space or a repository by its name (case insensitive).
+		FindByName(ctx context.Context, spaceID, repoID *int64, name string) (*types.Label, error)
+
+		// Define creates a new label.
+		Define(ctx context.Context, label *types.Label) error
+
+		// Update updates the name, color and description of the label.
+		Update(ctx context.Context, label *types.Label) error
+
+		// Delete deletes the label. The label is removed from all pull requests.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the labels defined directly in the space or in the repository.
+		List(ctx context.Context, spaceID, repoID *int64, filter *types.LabelFilter) ([]*types.Label, error)
+
+		// ListInScopes returns the labels defined in the repository and in any of the provided spaces.
+		ListInScopes(
+			ctx context.Context,
+			repoID int64,
+			spaceIDs []int64,
+			filter *types.LabelFilter,
+		) ([]*types.Label, error)
+	}
+
+	PullReqLabelStore interface {
+		// Assign assigns the label to the pull request. Assigning an already assigned label is a no-op.
+		Assign(ctx context.Context, prLabel *types.PullReqLabel) error
+
+		// Unassign removes the label from the pull request.
+		Unassign(ctx context.Context, pullReqID, labelID int64) error
+
+		// ListAssigned returns the labels assigned to the pull request.
+		ListAssigned(ctx context.Context, pullReqID int64) ([]types.LabelInfo, error)
+
+		// ListAssignedByPullReqIDs returns the labels assigned to each of the provided pull requests.
+		ListAssignedByPullReqIDs(ctx context.Context, pullReqIDs []int64) (map[int64][]types.LabelInfo, error)
+	}
 )
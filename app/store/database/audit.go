@@ -0,0 +1,400 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ audit.Store = (*AuditStore)(nil)
+
+// NewAuditStore returns a new AuditStore.
+func NewAuditStore(db *sqlx.DB, spacePathCache store.SpacePathCache) *AuditStore {
+	return &AuditStore{
+		db:             db,
+		spacePathCache: spacePathCache,
+	}
+}
+
+// AuditStore implements an audit.Store backed by a relational database.
+type AuditStore struct {
+	db             *sqlx.DB
+	spacePathCache store.SpacePathCache
+}
+
+type auditEvent struct {
+	ID                 string      `db:"audit_event_id"`
+	Timestamp          int64       `db:"audit_event_timestamp"`
+	Action             string      `db:"audit_event_action"`
+	PrincipalID        int64       `db:"audit_event_principal_id"`
+	Principal          string      `db:"audit_event_principal"`
+	SpaceID            null.Int    `db:"audit_event_space_id"`
+	SpacePath          string      `db:"audit_event_space_path"`
+	ResourceType       string      `db:"audit_event_resource_type"`
+	ResourceIdentifier string      `db:"audit_event_resource_identifier"`
+	OldObject          null.String `db:"audit_event_old_object"`
+	NewObject          null.String `db:"audit_event_new_object"`
+	ClientIP           string      `db:"audit_event_client_ip"`
+	RequestMethod      string      `db:"audit_event_request_method"`
+	Data               string      `db:"audit_event_data"`
+}
+
+const (
+	auditEventColumns = `
+		 audit_event_id
+		,audit_event_timestamp
+		,audit_event_action
+		,audit_event_principal_id
+		,audit_event_principal
+		,audit_event_space_id
+		,audit_event_space_path
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_data`
+)
+
+// Create stores a new audit event.
+func (s *AuditStore) Create(ctx context.Context, event *audit.Event) error {
+	const sqlQuery = `
+		INSERT INTO audit_events (
+			 audit_event_id
+			,audit_event_timestamp
+			,audit_event_action
+			,audit_event_principal_id
+			,audit_event_principal
+			,audit_event_space_id
+			,audit_event_space_path
+			,audit_event_resource_type
+			,audit_event_resource_identifier
+			,audit_event_old_object
+			,audit_event_new_object
+			,audit_event_client_ip
+			,audit_event_request_method
+			,audit_event_data
+		) values (
+			 :audit_event_id
+			,:audit_event_timestamp
+			,:audit_event_action
+			,:audit_event_principal_id
+			,:audit_event_principal
+			,:audit_event_space_id
+			,:audit_event_space_path
+			,:audit_event_resource_type
+			,:audit_event_resource_identifier
+			,:audit_event_old_object
+			,:audit_event_new_object
+			,:audit_event_client_ip
+			,:audit_event_request_method
+			,:audit_event_data
+		)`
+
+	if event.SpaceID == 0 && event.SpacePath != "" {
+		spaceID, err := s.findSpaceID(ctx, event.SpacePath)
+		if err != nil {
+			return err
+		}
+		event.SpaceID = spaceID
+	}
+
+	dbEvent, err := mapToInternalAuditEvent(event)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbEvent)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert audit event query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of audit events matching the filter.
+func (s *AuditStore) Count(ctx context.Context, filter *audit.ListFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_events")
+
+	stmt = s.applyFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count audit events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count audit events query")
+	}
+
+	return count, nil
+}
+
+// List returns the audit events matching the filter, by default the newest first.
+func (s *AuditStore) List(ctx context.Context, filter *audit.ListFilter) ([]*audit.Event, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events")
+
+	stmt = s.applyFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	order := filter.Order
+	if order == enum.OrderDefault {
+		order = enum.OrderDesc
+	}
+
+	stmt = stmt.OrderBy("audit_event_timestamp "+order.String(), "audit_event_id "+order.String())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list audit events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*auditEvent, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list audit events query")
+	}
+
+	return mapToAuditEvents(dst)
+}
+
+// DeleteOld removes all audit events that were recorded before the provided time.
+func (s *AuditStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("audit_events").
+		Where("audit_event_timestamp < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete audit events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete audit events query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted audit events")
+	}
+
+	return n, nil
+}
+
+// findSpaceID returns the ID of the space with the provided path.
+// Zero is returned if the space doesn't exist (anymore), e.g. for events logged after a space was purged.
+func (s *AuditStore) findSpaceID(ctx context.Context, spacePath string) (int64, error) {
+	path, err := s.spacePathCache.Get(ctx, spacePath)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get space path: %w", err)
+	}
+
+	return path.SpaceID, nil
+}
+
+func (*AuditStore) applyFilter(
+	stmt squirrel.SelectBuilder,
+	filter *audit.ListFilter,
+) squirrel.SelectBuilder {
+	if filter.SpaceID > 0 {
+		if filter.Recursive {
+			stmt = stmt.Prefix(`WITH RECURSIVE SpaceHierarchy AS (
+				SELECT space_id
+				FROM spaces
+				WHERE space_id = ?
+
+				UNION
+
+				SELECT s.space_id
+				FROM spaces s
+				JOIN SpaceHierarchy h ON s.space_parent_id = h.space_id
+			)`, filter.SpaceID).
+				Where("audit_event_space_id IN (SELECT space_id FROM SpaceHierarchy)")
+		} else {
+			stmt = stmt.Where("audit_event_space_id = ?", filter.SpaceID)
+		}
+	}
+
+	if filter.AccountScoped {
+		stmt = stmt.Where("audit_event_space_path = ''")
+	}
+
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_action": filter.Actions})
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_resource_type": filter.ResourceTypes})
+	}
+
+	if len(filter.PrincipalIDs) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_principal_id": filter.PrincipalIDs})
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("audit_event_timestamp > ?", filter.CreatedGt)
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("audit_event_timestamp < ?", filter.CreatedLt)
+	}
+
+	return stmt
+}
+
+func mapToInternalAuditEvent(in *audit.Event) (*auditEvent, error) {
+	principal, err := json.Marshal(in.User)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event principal: %w", err)
+	}
+
+	oldObject, err := marshalAuditObject(in.DiffObject.OldObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event old object: %w", err)
+	}
+
+	newObject, err := marshalAuditObject(in.DiffObject.NewObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event new object: %w", err)
+	}
+
+	data := in.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event data: %w", err)
+	}
+
+	return &auditEvent{
+		ID:                 in.ID,
+		Timestamp:          in.Timestamp,
+		Action:             string(in.Action),
+		PrincipalID:        in.User.ID,
+		Principal:          string(principal),
+		SpaceID:            null.NewInt(in.SpaceID, in.SpaceID > 0),
+		SpacePath:          in.SpacePath,
+		ResourceType:       string(in.Resource.Type),
+		ResourceIdentifier: in.Resource.Identifier,
+		OldObject:          oldObject,
+		NewObject:          newObject,
+		ClientIP:           in.ClientIP,
+		RequestMethod:      in.RequestMethod,
+		Data:               string(dataJSON),
+	}, nil
+}
+
+func marshalAuditObject(obj any) (null.String, error) {
+	if obj == nil {
+		return null.String{}, nil
+	}
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return null.String{}, err
+	}
+
+	return null.StringFrom(string(raw)), nil
+}
+
+func mapToAuditEvent(in *auditEvent) (*audit.Event, error) {
+	event := &audit.Event{
+		ID:        in.ID,
+		Timestamp: in.Timestamp,
+		Action:    audit.Action(in.Action),
+		SpaceID:   in.SpaceID.Int64,
+		SpacePath: in.SpacePath,
+		Resource: audit.Resource{
+			Type:       audit.ResourceType(in.ResourceType),
+			Identifier: in.ResourceIdentifier,
+		},
+		ClientIP:      in.ClientIP,
+		RequestMethod: in.RequestMethod,
+	}
+
+	if err := json.Unmarshal([]byte(in.Principal), &event.User); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event principal: %w", err)
+	}
+
+	event.User.ID = in.PrincipalID
+
+	if in.OldObject.Valid {
+		event.DiffObject.OldObject = json.RawMessage(in.OldObject.String)
+	}
+
+	if in.NewObject.Valid {
+		event.DiffObject.NewObject = json.RawMessage(in.NewObject.String)
+	}
+
+	if err := json.Unmarshal([]byte(in.Data), &event.Data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event data: %w", err)
+	}
+
+	return event, nil
+}
+
+func mapToAuditEvents(events []*auditEvent) ([]*audit.Event, error) {
+	res := make([]*audit.Event, len(events))
+	for i := range events {
+		event, err := mapToAuditEvent(events[i])
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = event
+	}
+
+	return res, nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+func TestAuditStore_ListAndDeleteOld(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+
+	auditStore := database.NewAuditStore(db, spacePathCache)
+
+	ctx := context.Background()
+
+	// space_1 (with sub-space space_1/space_2) and space_3
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSubSpace(ctx, t, spaceStore, spacePathStore, 2, 1)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 3, 0)
+
+	now := time.Now()
+	events := []struct {
+		spacePath string
+		action    audit.Action
+		principal int64
+		timestamp time.Time
+	}{
+		{spacePath: "space_1", action: audit.ActionCreated, principal: 1, timestamp: now.Add(-3 * time.Hour)},
+		{spacePath: "space_1", action: audit.ActionUpdated, principal: 2, timestamp: now.Add(-2 * time.Hour)},
+		{spacePath: "Space_1/space_2", action: audit.ActionDeleted, principal: 1, timestamp: now.Add(-time.Hour)},
+		{spacePath: "space_3", action: audit.ActionCreated, principal: 1, timestamp: now},
+		{spacePath: "", action: audit.ActionCreated, principal: 2, timestamp: now.Add(-time.Minute)},
+	}
+
+	for i, e := range events {
+		err := auditStore.Create(ctx, &audit.Event{
+			ID:        "event-" + strconv.Itoa(i),
+			Timestamp: e.timestamp.UnixMilli(),
+			Action:    e.action,
+			User:      types.Principal{ID: e.principal, UID: "user_" + strconv.FormatInt(e.principal, 10)},
+			SpacePath: e.spacePath,
+			Resource:  audit.NewResource(audit.ResourceTypeRepository, "repo"),
+			DiffObject: audit.DiffObject{
+				NewObject: map[string]int{"index": i},
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to create audit event: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter audit.ListFilter
+		expect []string
+	}{
+		{
+			name:   "space only",
+			filter: audit.ListFilter{SpaceID: 1},
+			expect: []string{"event-1", "event-0"},
+		},
+		{
+			name:   "recursive",
+			filter: audit.ListFilter{SpaceID: 1, Recursive: true},
+			expect: []string{"event-2", "event-1", "event-0"},
+		},
+		{
+			name: "action and principal",
+			filter: audit.ListFilter{
+				SpaceID:      1,
+				Recursive:    true,
+				Actions:      []audit.Action{audit.ActionCreated, audit.ActionDeleted},
+				PrincipalIDs: []int64{1},
+			},
+			expect: []string{"event-2", "event-0"},
+		},
+		{
+			name: "time range",
+			filter: audit.ListFilter{
+				CreatedFilter: types.CreatedFilter{
+					CreatedGt: now.Add(-150 * time.Minute).UnixMilli(),
+					CreatedLt: now.Add(-30 * time.Minute).UnixMilli(),
+				},
+			},
+			expect: []string{"event-2", "event-1"},
+		},
+		{
+			name:   "account scoped",
+			filter: audit.ListFilter{AccountScoped: true},
+			expect: []string{"event-4"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := test.filter
+			list, err := auditStore.List(ctx, &filter)
+			if err != nil {
+				t.Fatalf("failed to list audit events: %v", err)
+			}
+
+			count, err := auditStore.Count(ctx, &filter)
+			if err != nil {
+				t.Fatalf("failed to count audit events: %v", err)
+			}
+
+			if int(count) != len(test.expect) {
+				t.Errorf("count = %d, want %d", count, len(test.expect))
+			}
+
+			ids := make([]string, len(list))
+			for i, e := range list {
+				ids[i] = e.ID
+			}
+
+			if len(ids) != len(test.expect) {
+				t.Fatalf("ids = %v, want %v", ids, test.expect)
+			}
+			for i := range ids {
+				if ids[i] != test.expect[i] {
+					t.Fatalf("ids = %v, want %v", ids, test.expect)
+				}
+			}
+		})
+	}
+
+	// events remain listed after their space is moved.
+	space, err := spaceStore.Find(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to find space: %v", err)
+	}
+	space.ParentID = 3
+	if err = spaceStore.Update(ctx, space); err != nil {
+		t.Fatalf("failed to move space: %v", err)
+	}
+
+	list, err := auditStore.List(ctx, &audit.ListFilter{SpaceID: 3, Recursive: true})
+	if err != nil {
+		t.Fatalf("failed to list audit events: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "event-3" || list[1].ID != "event-2" {
+		t.Fatalf("expected the events of the moved space, got %d events", len(list))
+	}
+
+	list, err = auditStore.List(ctx, &audit.ListFilter{SpaceID: 3})
+	if err != nil {
+		t.Fatalf("failed to list audit events: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected a single event, got %d", len(list))
+	}
+	if list[0].User.ID != 1 || list[0].User.UID != "user_1" {
+		t.Errorf("unexpected principal: %+v", list[0].User)
+	}
+	if raw, ok := list[0].DiffObject.NewObject.(json.RawMessage); !ok || string(raw) != `{"index":3}` {
+		t.Errorf("unexpected new object: %v", list[0].DiffObject.NewObject)
+	}
+	if list[0].DiffObject.OldObject != nil {
+		t.Errorf("expected no old object, got: %v", list[0].DiffObject.OldObject)
+	}
+
+	n, err := auditStore.DeleteOld(ctx, now.Add(-90*time.Minute))
+	if err != nil {
+		t.Fatalf("failed to delete old audit events: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("deleted = %d, want %d", n, 2)
+	}
+}
+
+// createSubSpace creates a space with a path segment below its parent space.
+func createSubSpace(
+	ctx context.Context,
+	t *testing.T,
+	spaceStore *database.SpaceStore,
+	spacePathStore store.SpacePathStore,
+	spaceID int64,
+	parentID int64,
+) {
+	t.Helper()
+
+	identifier := "space_" + strconv.FormatInt(spaceID, 10)
+
+	space := types.Space{ID: spaceID, Identifier: identifier, CreatedBy: userID, ParentID: parentID}
+	if err := spaceStore.Create(ctx, &space); err != nil {
+		t.Fatalf("failed to create space %v", err)
+	}
+
+	if err := spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: identifier, CreatedBy: userID, SpaceID: spaceID, ParentID: parentID, IsPrimary: true,
+	}); err != nil {
+		t.Fatalf("failed to insert segment %v", err)
+	}
+}
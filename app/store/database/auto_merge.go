@@ -0,0 +1,207 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AutoMergeStore = AutoMergeStore{}
+
+// NewAutoMergeStore returns a new AutoMergeStore.
+func NewAutoMergeStore(db *sqlx.DB) AutoMergeStore {
+	return AutoMergeStore{
+		db: db,
+	}
+}
+
+// AutoMergeStore implements a store.AutoMergeStore backed by a relational database.
+type AutoMergeStore struct {
+	db *sqlx.DB
+}
+
+type autoMerge struct {
+	PullReqID int64 `db:"auto_merge_pullreq_id"`
+	RepoID    int64 `db:"auto_merge_repo_id"`
+
+	Method             string `db:"auto_merge_method"`
+	Title              string `db:"auto_merge_title"`
+	Message            string `db:"auto_merge_message"`
+	DeleteSourceBranch bool   `db:"auto_merge_delete_source_branch"`
+
+	CreatedBy int64 `db:"auto_merge_created_by"`
+	Created   int64 `db:"auto_merge_created"`
+	Updated   int64 `db:"auto_merge_updated"`
+}
+
+const (
+	autoMergeColumns = `
+		 auto_merge_pullreq_id
+		,auto_merge_repo_id
+		,auto_merge_method
+		,auto_merge_title
+		,auto_merge_message
+		,auto_merge_delete_source_branch
+		,auto_merge_created_by
+		,auto_merge_created
+		,auto_merge_updated`
+
+	autoMergeSelectBase = `
+		SELECT` + autoMergeColumns + `
+		FROM pullreq_auto_merges`
+)
+
+// Find returns the auto-merge options of the pull request.
+func (s AutoMergeStore) Find(ctx context.Context, pullReqID int64) (*types.AutoMerge, error) {
+	const sqlQuery = autoMergeSelectBase + `
+		WHERE auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &autoMerge{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find auto-merge by pull request ID")
+	}
+
+	return mapToAutoMerge(dst), nil
+}
+
+// Upsert enables auto-merge for the pull request or replaces its merge options.
+func (s AutoMergeStore) Upsert(ctx context.Context, am *types.AutoMerge) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_auto_merges (` + autoMergeColumns + `
+		) values (
+			 :auto_merge_pullreq_id
+			,:auto_merge_repo_id
+			,:auto_merge_method
+			,:auto_merge_title
+			,:auto_merge_message
+			,:auto_merge_delete_source_branch
+			,:auto_merge_created_by
+			,:auto_merge_created
+			,:auto_merge_updated
+		)
+		ON CONFLICT (auto_merge_pullreq_id) DO
+		UPDATE SET
+			 auto_merge_method = :auto_merge_method
+			,auto_merge_title = :auto_merge_title
+			,auto_merge_message = :auto_merge_message
+			,auto_merge_delete_source_branch = :auto_merge_delete_source_branch
+			,auto_merge_created_by = :auto_merge_created_by
+			,auto_merge_updated = :auto_merge_updated
+		RETURNING auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAutoMerge(am))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind auto-merge object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&am.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert auto-merge query failed")
+	}
+
+	return nil
+}
+
+// Delete disables auto-merge for the pull request.
+func (s AutoMergeStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_auto_merges
+		WHERE auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullReqID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete auto-merge query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "RowsAffected after delete of auto-merge failed")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListBySourceSHA returns the auto-merge options of all pull requests of the repository
+// for which the provided commit is the latest commit of the source branch.
+func (s AutoMergeStore) ListBySourceSHA(
+	ctx context.Context,
+	repoID int64,
+	sourceSHA string,
+) ([]*types.AutoMerge, error) {
+	const sqlQuery = autoMergeSelectBase + `
+		INNER JOIN pullreqs ON pullreq_id = auto_merge_pullreq_id
+		WHERE auto_merge_repo_id = $1 AND pullreq_source_sha = $2
+		ORDER BY auto_merge_pullreq_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*autoMerge, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, sourceSHA); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list auto-merges by source SHA")
+	}
+
+	result := make([]*types.AutoMerge, len(dst))
+	for i := range dst {
+		result[i] = mapToAutoMerge(dst[i])
+	}
+
+	return result, nil
+}
+
+func mapToInternalAutoMerge(in *types.AutoMerge) *autoMerge {
+	return &autoMerge{
+		PullReqID:          in.PullReqID,
+		RepoID:             in.RepoID,
+		Method:             string(in.Method),
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		CreatedBy:          in.CreatedBy,
+		Created:            in.Created,
+		Updated:            in.Updated,
+	}
+}
+
+func mapToAutoMerge(in *autoMerge) *types.AutoMerge {
+	return &types.AutoMerge{
+		PullReqID:          in.PullReqID,
+		RepoID:             in.RepoID,
+		Method:             enum.MergeMethod(in.Method),
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		CreatedBy:          in.CreatedBy,
+		Created:            in.Created,
+		Updated:            in.Updated,
+	}
+}
@@ -73,6 +73,7 @@ type execution struct {
 	AuthorAvatar string             `db:"execution_author_avatar"`
 	Sender       string             `db:"execution_sender"`
 	Params       sqlxtypes.JSONText `db:"execution_params"`
+	Inputs       sqlxtypes.JSONText `db:"execution_inputs"`
 	Cron         string             `db:"execution_cron"`
 	Deploy       string             `db:"execution_deploy"`
 	DeployID     int64              `db:"execution_deploy_id"`
@@ -113,6 +114,7 @@ const (
 		,execution_author_avatar
 		,execution_sender
 		,execution_params
+		,execution_inputs
 		,execution_cron
 		,execution_deploy
 		,execution_deploy_id
@@ -190,6 +192,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,execution_author_avatar
 		,execution_sender
 		,execution_params
+		,execution_inputs
 		,execution_cron
 		,execution_deploy
 		,execution_deploy_id
@@ -226,6 +229,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_author_avatar
 		,:execution_sender
 		,:execution_params
+		,:execution_inputs
 		,:execution_cron
 		,:execution_deploy
 		,:execution_deploy_id
@@ -0,0 +1,328 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LabelStore = (*LabelStore)(nil)
+
+// NewLabelStore returns a new LabelStore.
+func NewLabelStore(db *sqlx.DB) *LabelStore {
+	return &LabelStore{
+		db: db,
+	}
+}
+
+// LabelStore implements a store.LabelStore backed by a relational database.
+type LabelStore struct {
+	db *sqlx.DB
+}
+
+type label struct {
+	ID      int64    `db:"label_id"`
+	SpaceID null.Int `db:"label_space_id"`
+	RepoID  null.Int `db:"label_repo_id"`
+
+	Name        string `db:"label_name"`
+	Color       string `db:"label_color"`
+	Description string `db:"label_description"`
+
+	CreatedBy int64 `db:"label_created_by"`
+	Created   int64 `db:"label_created"`
+	Updated   int64 `db:"label_updated"`
+}
+
+const (
+	labelColumns = `
+		 label_id
+		,label_space_id
+		,label_repo_id
+		,label_name
+		,label_color
+		,label_description
+		,label_created_by
+		,label_created
+		,label_updated`
+
+	labelSelectBase = `
+		SELECT` + labelColumns + `
+		FROM labels`
+)
+
+// Find finds the label by id.
+func (s *LabelStore) Find(ctx context.Context, id int64) (*types.Label, error) {
+	const sqlQuery = labelSelectBase + `
+		WHERE label_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &label{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find label")
+	}
+
+	return mapToLabel(dst), nil
+}
+
+// FindByName finds the label of a space or a repository by its name (case insensitive).
+func (s *LabelStore) FindByName(
+	ctx context.Context,
+	spaceID *int64,
+	repoID *int64,
+	name string,
+) (*types.Label, error) {
+	stmt := database.Builder.
+		Select(labelColumns).
+		From("labels").
+		Where("LOWER(label_name) = ?", strings.ToLower(name))
+	stmt = applyLabelParentID(stmt, spaceID, repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find label by name query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &label{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing find label by name query")
+	}
+
+	return mapToLabel(dst), nil
+}
+
+// Define creates a new label.
+func (s *LabelStore) Define(ctx context.Context, lbl *types.Label) error {
+	const sqlQuery = `
+		INSERT INTO labels (
+			 label_space_id
+			,label_repo_id
+			,label_name
+			,label_color
+			,label_description
+			,label_created_by
+			,label_created
+			,label_updated
+		) values (
+			 :label_space_id
+			,:label_repo_id
+			,:label_name
+			,:label_color
+			,:label_description
+			,:label_created_by
+			,:label_created
+			,:label_updated
+		) RETURNING label_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLabel(lbl))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind label object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&lbl.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert label query failed")
+	}
+
+	return nil
+}
+
+// Update updates the name, color and description of the label.
+func (s *LabelStore) Update(ctx context.Context, lbl *types.Label) error {
+	const sqlQuery = `
+		UPDATE labels
+		SET
+			 label_name = :label_name
+			,label_color = :label_color
+			,label_description = :label_description
+			,label_updated = :label_updated
+		WHERE label_id = :label_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLabel(lbl))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind label object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update label")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated labels")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the label. The label is removed from all pull requests.
+func (s *LabelStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM labels
+		WHERE label_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete label query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "RowsAffected after delete of label failed")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns the labels defined directly in the space or in the repository.
+func (s *LabelStore) List(
+	ctx context.Context,
+	spaceID *int64,
+	repoID *int64,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	stmt := database.Builder.
+		Select(labelColumns).
+		From("labels")
+	stmt = applyLabelParentID(stmt, spaceID, repoID)
+
+	return s.list(ctx, stmt, filter)
+}
+
+// ListInScopes returns the labels defined in the repository and in any of the provided spaces.
+func (s *LabelStore) ListInScopes(
+	ctx context.Context,
+	repoID int64,
+	spaceIDs []int64,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	scope := squirrel.Or{squirrel.Eq{"label_repo_id": repoID}}
+	if len(spaceIDs) > 0 {
+		scope = append(scope, squirrel.Eq{"label_space_id": spaceIDs})
+	}
+
+	stmt := database.Builder.
+		Select(labelColumns).
+		From("labels").
+		Where(scope)
+
+	return s.list(ctx, stmt, filter)
+}
+
+func (s *LabelStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(label_name) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("LOWER(label_name) ASC", "label_id ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*label, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list labels query")
+	}
+
+	result := make([]*types.Label, len(dst))
+	for i, l := range dst {
+		result[i] = mapToLabel(l)
+	}
+
+	return result, nil
+}
+
+func applyLabelParentID(
+	stmt squirrel.SelectBuilder,
+	spaceID, repoID *int64,
+) squirrel.SelectBuilder {
+	if spaceID != nil {
+		stmt = stmt.Where("label_space_id = ?", *spaceID)
+	}
+
+	if repoID != nil {
+		stmt = stmt.Where("label_repo_id = ?", *repoID)
+	}
+
+	return stmt
+}
+
+func mapToLabel(l *label) *types.Label {
+	return &types.Label{
+		ID:          l.ID,
+		SpaceID:     l.SpaceID.Ptr(),
+		RepoID:      l.RepoID.Ptr(),
+		Name:        l.Name,
+		Color:       enum.LabelColor(l.Color),
+		Description: l.Description,
+		CreatedBy:   l.CreatedBy,
+		Created:     l.Created,
+		Updated:     l.Updated,
+	}
+}
+
+func mapToInternalLabel(l *types.Label) *label {
+	return &label{
+		ID:          l.ID,
+		SpaceID:     null.IntFromPtr(l.SpaceID),
+		RepoID:      null.IntFromPtr(l.RepoID),
+		Name:        l.Name,
+		Color:       string(l.Color),
+		Description: l.Description,
+		CreatedBy:   l.CreatedBy,
+		Created:     l.Created,
+		Updated:     l.Updated,
+	}
+}
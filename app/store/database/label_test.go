@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_Labels(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	labelStore := database.NewLabelStore(db)
+	pullReqLabelStore := database.NewPullReqLabelStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	spaceID := int64(1)
+	repoID := int64(1)
+
+	labels := []*types.Label{
+		{SpaceID: &spaceID, Name: "bug", Color: enum.LabelColorRed},
+		{SpaceID: &spaceID, Name: "priority:high", Color: enum.LabelColorOrange},
+		{RepoID: &repoID, Name: "Bug", Color: enum.LabelColorPink},
+	}
+	for _, l := range labels {
+		l.CreatedBy = userID
+		if err := labelStore.Define(ctx, l); err != nil {
+			t.Fatalf("failed to define label %q: %v", l.Name, err)
+		}
+	}
+
+	// label names are unique (case insensitive) within a space or a repository
+	duplicate := &types.Label{SpaceID: &spaceID, Name: "BUG", Color: enum.LabelColorRed, CreatedBy: userID}
+	if err := labelStore.Define(ctx, duplicate); !errors.Is(err, store.ErrDuplicate) {
+		t.Errorf("expected duplicate error, got: %v", err)
+	}
+
+	found, err := labelStore.FindByName(ctx, nil, &repoID, "bug")
+	if err != nil {
+		t.Fatalf("failed to find label: %v", err)
+	}
+	if found.ID != labels[2].ID {
+		t.Errorf("expected label %d, got %d", labels[2].ID, found.ID)
+	}
+
+	list, err := labelStore.List(ctx, &spaceID, nil, &types.LabelFilter{})
+	if err != nil {
+		t.Fatalf("failed to list labels: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("expected 2 space labels, got %d", len(list))
+	}
+
+	list, err = labelStore.ListInScopes(ctx, repoID, []int64{spaceID}, &types.LabelFilter{})
+	if err != nil {
+		t.Fatalf("failed to list labels: %v", err)
+	}
+	if len(list) != 3 {
+		t.Errorf("expected 3 labels in scope, got %d", len(list))
+	}
+
+	prs := make([]*types.PullReq, 2)
+	for i := range prs {
+		prs[i] = &types.PullReq{
+			Number:       int64(i + 1),
+			CreatedBy:    userID,
+			State:        enum.PullReqStateOpen,
+			Title:        "test",
+			SourceRepoID: repoID,
+			SourceBranch: fmt.Sprintf("feature-%d", i),
+			SourceSHA:    "abc",
+			TargetRepoID: repoID,
+			TargetBranch: "main",
+		}
+		if err = pullreqStore.Create(ctx, prs[i]); err != nil {
+			t.Fatalf("failed to create pull request: %v", err)
+		}
+	}
+
+	assign := func(pr *types.PullReq, l *types.Label) {
+		prLabel := &types.PullReqLabel{PullReqID: pr.ID, LabelID: l.ID, CreatedBy: userID}
+		if err := pullReqLabelStore.Assign(ctx, prLabel); err != nil {
+			t.Fatalf("failed to assign label: %v", err)
+		}
+	}
+
+	assign(prs[0], labels[0])
+	assign(prs[0], labels[0]) // assigning twice is a no-op
+	assign(prs[0], labels[1])
+	assign(prs[1], labels[1])
+
+	assigned, err := pullReqLabelStore.ListAssignedByPullReqIDs(ctx, []int64{prs[0].ID, prs[1].ID})
+	if err != nil {
+		t.Fatalf("failed to list assigned labels: %v", err)
+	}
+	if len(assigned[prs[0].ID]) != 2 || len(assigned[prs[1].ID]) != 1 {
+		t.Errorf("unexpected assigned labels: %+v", assigned)
+	}
+
+	filter := &types.PullReqFilter{TargetRepoID: repoID, LabelIDs: []int64{labels[0].ID, labels[1].ID}}
+	filtered, err := pullreqStore.List(ctx, filter)
+	if err != nil {
+		t.Fatalf("failed to list pull requests: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].ID != prs[0].ID {
+		t.Errorf("expected only the first pull request to match the label filter, got %+v", filtered)
+	}
+
+	count, err := pullreqStore.Count(ctx, &types.PullReqFilter{TargetRepoID: repoID, LabelIDs: []int64{labels[1].ID}})
+	if err != nil {
+		t.Fatalf("failed to count pull requests: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 pull requests with the label, got %d", count)
+	}
+
+	if err = pullReqLabelStore.Unassign(ctx, prs[0].ID, labels[0].ID); err != nil {
+		t.Fatalf("failed to unassign label: %v", err)
+	}
+	if err = pullReqLabelStore.Unassign(ctx, prs[0].ID, labels[0].ID); !errors.Is(err, store.ErrResourceNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+
+	// deleting a label removes it from all pull requests
+	if err = labelStore.Delete(ctx, labels[1].ID); err != nil {
+		t.Fatalf("failed to delete label: %v", err)
+	}
+
+	prLabels, err := pullReqLabelStore.ListAssigned(ctx, prs[1].ID)
+	if err != nil {
+		t.Fatalf("failed to list assigned labels: %v", err)
+	}
+	if len(prLabels) != 0 {
+		t.Errorf("expected no labels after label delete, got %+v", prLabels)
+	}
+
+	if _, err = labelStore.Find(ctx, labels[1].ID); !errors.Is(err, store.ErrResourceNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
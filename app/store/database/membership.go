@@ -199,6 +199,54 @@ func (s *MembershipStore) Delete(ctx context.Context, key types.MembershipKey) e
 	return nil
 }
 
+// HasRoleInSpaceHierarchy returns true if the principal has any of the roles
+// in the space or in any of its parent spaces.
+func (s *MembershipStore) HasRoleInSpaceHierarchy(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+	roles []enum.MembershipRole,
+) (bool, error) {
+	if len(roles) == 0 {
+		return false, nil
+	}
+
+	const ctePrefix = `WITH RECURSIVE SpaceHierarchy AS (
+		SELECT space_id, space_parent_id
+		FROM spaces
+		WHERE space_id = ?
+
+		UNION
+
+		SELECT s.space_id, s.space_parent_id
+		FROM spaces s
+		JOIN SpaceHierarchy h ON s.space_id = h.space_parent_id
+	)`
+
+	stmt := database.Builder.
+		Select("count(*)").
+		Prefix(ctePrefix, spaceID).
+		From("memberships").
+		InnerJoin("SpaceHierarchy ON membership_space_id = space_id").
+		Where("membership_principal_id = ?", principalID).
+		Where(squirrel.Eq{"membership_role": roles})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to convert membership role query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed executing membership role query")
+	}
+
+	return count > 0, nil
+}
+
 // CountUsers returns a number of users memberships that matches the provided filter.
 func (s *MembershipStore) CountUsers(ctx context.Context,
 	spaceID int64,
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_HasRoleInSpaceHierarchy(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createNestedSpaces(ctx, t, spaceStore, spacePathStore)
+
+	membershipStore := database.NewMembershipStore(db, nil, spacePathStore, spaceStore)
+
+	// the user is a contributor in space 2, which is the parent of spaces 4, 5 and 6.
+	if err := membershipStore.Create(ctx, &types.Membership{
+		MembershipKey: types.MembershipKey{SpaceID: 2, PrincipalID: userID},
+		CreatedBy:     userID,
+		Role:          enum.MembershipRoleContributor,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		spaceID int64
+		roles   []enum.MembershipRole
+		exp     bool
+	}{
+		{
+			name:    "same-space",
+			spaceID: 2,
+			roles:   []enum.MembershipRole{enum.MembershipRoleContributor},
+			exp:     true,
+		},
+		{
+			name:    "child-space",
+			spaceID: 9,
+			roles:   []enum.MembershipRole{enum.MembershipRoleSpaceOwner, enum.MembershipRoleContributor},
+			exp:     true,
+		},
+		{
+			name:    "other-role",
+			spaceID: 9,
+			roles:   []enum.MembershipRole{enum.MembershipRoleSpaceOwner},
+			exp:     false,
+		},
+		{
+			name:    "parent-space",
+			spaceID: 1,
+			roles:   []enum.MembershipRole{enum.MembershipRoleContributor},
+			exp:     false,
+		},
+		{
+			name:    "sibling-space",
+			spaceID: 7,
+			roles:   []enum.MembershipRole{enum.MembershipRoleContributor},
+			exp:     false,
+		},
+		{
+			name:    "no-roles",
+			spaceID: 2,
+			exp:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := membershipStore.HasRoleInSpaceHierarchy(ctx, test.spaceID, userID, test.roles)
+			if err != nil {
+				t.Fatalf("failed to check role: %v", err)
+			}
+
+			if got != test.exp {
+				t.Errorf("want=%t got=%t", test.exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = MergeQueueStore{}
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) MergeQueueStore {
+	return MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements a store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID            int64 `db:"merge_queue_entry_id"`
+	RepoID        int64 `db:"merge_queue_entry_repo_id"`
+	PullReqID     int64 `db:"merge_queue_entry_pullreq_id"`
+	PullReqNumber int64 `db:"pullreq_number"`
+
+	TargetBranch string `db:"merge_queue_entry_target_branch"`
+	State        string `db:"merge_queue_entry_state"`
+
+	Method      string `db:"merge_queue_entry_method"`
+	Title       string `db:"merge_queue_entry_title"`
+	Message     string `db:"merge_queue_entry_message"`
+	BypassRules bool   `db:"merge_queue_entry_bypass_rules"`
+
+	BypassJustification string `db:"merge_queue_entry_bypass_justification"`
+
+	SourceSHA string `db:"merge_queue_entry_source_sha"`
+	BaseSHA   string `db:"merge_queue_entry_base_sha"`
+	MergeSHA  string `db:"merge_queue_entry_merge_sha"`
+
+	CreatedBy int64 `db:"merge_queue_entry_created_by"`
+	Created   int64 `db:"merge_queue_entry_created"`
+	Updated   int64 `db:"merge_queue_entry_updated"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_id
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_pullreq_id
+		,pullreq_number
+		,merge_queue_entry_target_branch
+		,merge_queue_entry_state
+		,merge_queue_entry_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_bypass_rules
+		,merge_queue_entry_bypass_justification
+		,merge_queue_entry_source_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_sha
+		,merge_queue_entry_created_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated`
+
+	mergeQueueEntrySelectBase = `
+		SELECT` + mergeQueueEntryColumns + `
+		FROM merge_queue_entries
+		INNER JOIN pullreqs ON pullreq_id = merge_queue_entry_pullreq_id`
+)
+
+// FindByPullReqID returns the merge queue entry of the pull request.
+func (s MergeQueueStore) FindByPullReqID(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+		WHERE merge_queue_entry_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry by pull request ID")
+	}
+
+	entry := mapToMergeQueueEntry(dst)
+
+	return &entry, nil
+}
+
+// Create adds a pull request to the end of the merge queue of its target branch.
+func (s MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_repo_id
+			,merge_queue_entry_pullreq_id
+			,merge_queue_entry_target_branch
+			,merge_queue_entry_state
+			,merge_queue_entry_method
+			,merge_queue_entry_title
+			,merge_queue_entry_message
+			,merge_queue_entry_bypass_rules
+			,merge_queue_entry_bypass_justification
+			,merge_queue_entry_source_sha
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_merge_sha
+			,merge_queue_entry_created_by
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+		) values (
+			 :merge_queue_entry_repo_id
+			,:merge_queue_entry_pullreq_id
+			,:merge_queue_entry_target_branch
+			,:merge_queue_entry_state
+			,:merge_queue_entry_method
+			,:merge_queue_entry_title
+			,:merge_queue_entry_message
+			,:merge_queue_entry_bypass_rules
+			,:merge_queue_entry_bypass_justification
+			,:merge_queue_entry_source_sha
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_merge_sha
+			,:merge_queue_entry_created_by
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+		) RETURNING merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEntry := mapToInternalMergeQueueEntry(entry)
+
+	query, arg, err := db.BindNamed(sqlQuery, &dbEntry)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Update updates the state and the speculative merge commit of the merge queue entry.
+func (s MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_state = :merge_queue_entry_state
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+			,merge_queue_entry_updated = :merge_queue_entry_updated
+		WHERE merge_queue_entry_id = :merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEntry := mapToInternalMergeQueueEntry(entry)
+
+	query, arg, err := db.BindNamed(sqlQuery, &dbEntry)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated merge queue entries")
+	}
+
+	if count == 0 {
+		return errors.NotFound("Merge queue entry not found")
+	}
+
+	return nil
+}
+
+// Delete removes the merge queue entry.
+func (s MergeQueueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete merge queue entry query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "RowsAffected after delete of merge queue entry failed")
+	}
+
+	if count == 0 {
+		return errors.NotFound("Merge queue entry not found")
+	}
+
+	return nil
+}
+
+// List returns the merge queue of the target branch in the order in which the pull requests are merged.
+func (s MergeQueueStore) List(
+	ctx context.Context,
+	repoID int64,
+	targetBranch string,
+) ([]*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+		WHERE merge_queue_entry_repo_id = $1 AND merge_queue_entry_target_branch = $2
+		ORDER BY merge_queue_entry_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*mergeQueueEntry, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, targetBranch); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue entries")
+	}
+
+	entries := make([]*types.MergeQueueEntry, len(dst))
+	for i := range dst {
+		entry := mapToMergeQueueEntry(dst[i])
+		entry.Position = i
+		entries[i] = &entry
+	}
+
+	return entries, nil
+}
+
+// ListBranches returns all branches that have a non-empty merge queue.
+func (s MergeQueueStore) ListBranches(ctx context.Context) ([]types.MergeQueueBranch, error) {
+	stmt := database.Builder.
+		Select("merge_queue_entry_repo_id, merge_queue_entry_target_branch").
+		Distinct().
+		From("merge_queue_entries").
+		OrderBy("merge_queue_entry_repo_id", "merge_queue_entry_target_branch")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue branches")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	branches := make([]types.MergeQueueBranch, 0)
+	for rows.Next() {
+		var branch types.MergeQueueBranch
+		if err = rows.Scan(&branch.RepoID, &branch.TargetBranch); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan merge queue branch")
+		}
+		branches = append(branches, branch)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue branches")
+	}
+
+	return branches, nil
+}
+
+func mapToInternalMergeQueueEntry(in *types.MergeQueueEntry) mergeQueueEntry {
+	return mergeQueueEntry{
+		ID:                  in.ID,
+		RepoID:              in.RepoID,
+		PullReqID:           in.PullReqID,
+		PullReqNumber:       in.PullReqNumber,
+		TargetBranch:        in.TargetBranch,
+		State:               string(in.State),
+		Method:              string(in.Method),
+		Title:               in.Title,
+		Message:             in.Message,
+		BypassRules:         in.BypassRules,
+		BypassJustification: in.BypassJustification,
+		SourceSHA:           in.SourceSHA,
+		BaseSHA:             in.BaseSHA,
+		MergeSHA:            in.MergeSHA,
+		CreatedBy:           in.CreatedBy,
+		Created:             in.Created,
+		Updated:             in.Updated,
+	}
+}
+
+func mapToMergeQueueEntry(in *mergeQueueEntry) types.MergeQueueEntry {
+	return types.MergeQueueEntry{
+		ID:                  in.ID,
+		RepoID:              in.RepoID,
+		PullReqID:           in.PullReqID,
+		PullReqNumber:       in.PullReqNumber,
+		TargetBranch:        in.TargetBranch,
+		State:               enum.MergeQueueEntryState(in.State),
+		Method:              enum.MergeMethod(in.Method),
+		Title:               in.Title,
+		Message:             in.Message,
+		BypassRules:         in.BypassRules,
+		BypassJustification: in.BypassJustification,
+		SourceSHA:           in.SourceSHA,
+		BaseSHA:             in.BaseSHA,
+		MergeSHA:            in.MergeSHA,
+		CreatedBy:           in.CreatedBy,
+		Created:             in.Created,
+		Updated:             in.Updated,
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_MergeQueue(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pullreqStore := database.NewPullReqStore(db, nil)
+	mergeQueueStore := database.NewMergeQueueStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	entries := make([]*types.MergeQueueEntry, 3)
+	for i := range entries {
+		pr := &types.PullReq{
+			Number:       int64(i + 1),
+			CreatedBy:    userID,
+			State:        enum.PullReqStateOpen,
+			Title:        "test",
+			SourceRepoID: 1,
+			SourceBranch: fmt.Sprintf("feature-%d", i),
+			SourceSHA:    "abc",
+			TargetRepoID: 1,
+			TargetBranch: "main",
+		}
+		if i == 2 {
+			pr.TargetBranch = "develop"
+		}
+		if err := pullreqStore.Create(ctx, pr); err != nil {
+			t.Fatalf("failed to create pull request: %v", err)
+		}
+
+		entries[i] = &types.MergeQueueEntry{
+			RepoID:        1,
+			PullReqID:     pr.ID,
+			PullReqNumber: pr.Number,
+			TargetBranch:  pr.TargetBranch,
+			State:         enum.MergeQueueEntryStateQueued,
+			Method:        enum.MergeMethodSquash,
+			SourceSHA:     pr.SourceSHA,
+			CreatedBy:     userID,
+		}
+		if err := mergeQueueStore.Create(ctx, entries[i]); err != nil {
+			t.Fatalf("failed to create merge queue entry: %v", err)
+		}
+	}
+
+	// a pull request can be in the queue only once
+	if err := mergeQueueStore.Create(ctx, &types.MergeQueueEntry{
+		RepoID:       1,
+		PullReqID:    entries[0].PullReqID,
+		TargetBranch: "main",
+		CreatedBy:    userID,
+	}); !errors.Is(err, store.ErrDuplicate) {
+		t.Errorf("expected duplicate error, got: %v", err)
+	}
+
+	entries[1].State = enum.MergeQueueEntryStateChecking
+	entries[1].BaseSHA = "def"
+	entries[1].MergeSHA = "123"
+	if err := mergeQueueStore.Update(ctx, entries[1]); err != nil {
+		t.Fatalf("failed to update merge queue entry: %v", err)
+	}
+
+	list, err := mergeQueueStore.List(ctx, 1, "main")
+	if err != nil {
+		t.Fatalf("failed to list merge queue: %v", err)
+	}
+
+	entries[1].Position = 1
+	if want := entries[:2]; !reflect.DeepEqual(list, want) {
+		t.Errorf("want merge queue %+v, got %+v", want, list)
+	}
+
+	branches, err := mergeQueueStore.ListBranches(ctx)
+	if err != nil {
+		t.Fatalf("failed to list merge queue branches: %v", err)
+	}
+
+	wantBranches := []types.MergeQueueBranch{{RepoID: 1, TargetBranch: "develop"}, {RepoID: 1, TargetBranch: "main"}}
+	if !reflect.DeepEqual(branches, wantBranches) {
+		t.Errorf("want merge queue branches %+v, got %+v", wantBranches, branches)
+	}
+
+	if err = mergeQueueStore.Delete(ctx, entries[0].ID); err != nil {
+		t.Fatalf("failed to delete merge queue entry: %v", err)
+	}
+
+	if _, err = mergeQueueStore.FindByPullReqID(ctx, entries[0].PullReqID); !errors.Is(err, store.ErrResourceNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+
+	found, err := mergeQueueStore.FindByPullReqID(ctx, entries[1].PullReqID)
+	if err != nil {
+		t.Fatalf("failed to find merge queue entry: %v", err)
+	}
+
+	entries[1].Position = 0
+	if !reflect.DeepEqual(found, entries[1]) {
+		t.Errorf("want merge queue entry %+v, got %+v", entries[1], found)
+	}
+}
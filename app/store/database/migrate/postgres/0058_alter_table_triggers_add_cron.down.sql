@@ -0,0 +1,7 @@
+DROP INDEX triggers_cron_next;
+
+ALTER TABLE triggers
+    DROP COLUMN trigger_cron,
+    DROP COLUMN trigger_cron_branch,
+    DROP COLUMN trigger_cron_timezone,
+    DROP COLUMN trigger_cron_next;
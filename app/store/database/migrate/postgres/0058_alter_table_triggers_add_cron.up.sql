@@ -0,0 +1,11 @@
+ALTER TABLE triggers
+    ADD COLUMN trigger_cron TEXT NOT NULL DEFAULT '',
+    ADD COLUMN trigger_cron_branch TEXT NOT NULL DEFAULT '',
+    ADD COLUMN trigger_cron_timezone TEXT NOT NULL DEFAULT '',
+    ADD COLUMN trigger_cron_next BIGINT NOT NULL DEFAULT 0;
+
+UPDATE triggers SET trigger_type = '@hook' WHERE trigger_type = '';
+
+CREATE INDEX triggers_cron_next
+    ON triggers(trigger_cron_next)
+    WHERE trigger_type = '@cron' AND trigger_disabled = FALSE;
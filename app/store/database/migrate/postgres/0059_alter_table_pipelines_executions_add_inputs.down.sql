@@ -0,0 +1,5 @@
+ALTER TABLE pipelines
+    DROP COLUMN pipeline_inputs;
+
+ALTER TABLE executions
+    DROP COLUMN execution_inputs;
@@ -0,0 +1,5 @@
+ALTER TABLE pipelines
+    ADD COLUMN pipeline_inputs TEXT NOT NULL DEFAULT '[]';
+
+ALTER TABLE executions
+    ADD COLUMN execution_inputs TEXT NOT NULL DEFAULT '{}';
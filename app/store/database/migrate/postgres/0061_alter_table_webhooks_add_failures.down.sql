@@ -0,0 +1,8 @@
+ALTER TABLE webhooks
+    DROP COLUMN webhook_consecutive_failures;
+
+ALTER TABLE webhooks
+    DROP COLUMN webhook_failing_since;
+
+ALTER TABLE webhooks
+    DROP COLUMN webhook_disabled_reason;
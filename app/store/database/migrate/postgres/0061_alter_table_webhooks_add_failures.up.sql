@@ -0,0 +1,8 @@
+ALTER TABLE webhooks
+    ADD COLUMN webhook_consecutive_failures INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE webhooks
+    ADD COLUMN webhook_failing_since BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE webhooks
+    ADD COLUMN webhook_disabled_reason TEXT NOT NULL DEFAULT '';
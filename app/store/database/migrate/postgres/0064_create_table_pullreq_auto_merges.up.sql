@@ -0,0 +1,27 @@
+CREATE TABLE pullreq_auto_merges (
+ auto_merge_pullreq_id INTEGER PRIMARY KEY
+,auto_merge_repo_id INTEGER NOT NULL
+,auto_merge_method TEXT NOT NULL
+,auto_merge_title TEXT NOT NULL
+,auto_merge_message TEXT NOT NULL
+,auto_merge_delete_source_branch BOOLEAN NOT NULL
+,auto_merge_created_by INTEGER NOT NULL
+,auto_merge_created BIGINT NOT NULL
+,auto_merge_updated BIGINT NOT NULL
+,CONSTRAINT fk_auto_merge_pullreq_id FOREIGN KEY (auto_merge_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_auto_merge_repo_id FOREIGN KEY (auto_merge_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_auto_merge_created_by FOREIGN KEY (auto_merge_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+-- this index is used to find the pull requests affected by a reported status check
+CREATE INDEX pullreq_auto_merges_repo_id
+    ON pullreq_auto_merges(auto_merge_repo_id);
@@ -0,0 +1,2 @@
+ALTER TABLE merge_queue_entries
+    ADD COLUMN merge_queue_entry_bypass_justification TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+ALTER TABLE webhooks
+    DROP COLUMN webhook_payload_format;
+
+ALTER TABLE webhooks
+    DROP COLUMN webhook_payload_template;
+
+ALTER TABLE webhooks
+    DROP COLUMN webhook_headers;
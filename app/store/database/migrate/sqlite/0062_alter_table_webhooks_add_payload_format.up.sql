@@ -0,0 +1,8 @@
+ALTER TABLE webhooks
+    ADD COLUMN webhook_payload_format TEXT NOT NULL DEFAULT 'native';
+
+ALTER TABLE webhooks
+    ADD COLUMN webhook_payload_template TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE webhooks
+    ADD COLUMN webhook_headers TEXT NOT NULL DEFAULT '{}';
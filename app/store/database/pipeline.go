@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/types"
 
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 	"github.com/pkg/errors"
 )
 
@@ -51,9 +52,50 @@ const (
 	,pipeline_created
 	,pipeline_updated
 	,pipeline_version
+	,pipeline_inputs
 	`
 )
 
+// pipeline is used to map the JSON encoded inputs of a pipeline, all other columns are mapped to types.Pipeline.
+type pipeline struct {
+	*types.Pipeline
+	Inputs sqlxtypes.JSONText `db:"pipeline_inputs"`
+}
+
+func mapInternalToPipeline(in *pipeline) (*types.Pipeline, error) {
+	ret := in.Pipeline
+	ret.Inputs = []types.PipelineInput{}
+	if len(in.Inputs) > 0 {
+		if err := in.Inputs.Unmarshal(&ret.Inputs); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal pipeline.inputs")
+		}
+	}
+	return ret, nil
+}
+
+func mapInternalToPipelineList(in []*pipeline) ([]*types.Pipeline, error) {
+	ret := make([]*types.Pipeline, len(in))
+	for i, p := range in {
+		pipeline, err := mapInternalToPipeline(p)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = pipeline
+	}
+	return ret, nil
+}
+
+func mapPipelineToInternal(p *types.Pipeline) *pipeline {
+	inputs := p.Inputs
+	if inputs == nil {
+		inputs = []types.PipelineInput{}
+	}
+	return &pipeline{
+		Pipeline: p,
+		Inputs:   EncodeToSQLXJSON(inputs),
+	}
+}
+
 // NewPipelineStore returns a new PipelineStore.
 func NewPipelineStore(db *sqlx.DB) store.PipelineStore {
 	return &pipelineStore{
@@ -71,11 +113,11 @@ func (s *pipelineStore) Find(ctx context.Context, id int64) (*types.Pipeline, er
 		WHERE pipeline_id = $1`
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Pipeline)
+	dst := &pipeline{Pipeline: new(types.Pipeline)}
 	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline")
 	}
-	return dst, nil
+	return mapInternalToPipeline(dst)
 }
 
 // FindByIdentifier returns a pipeline for a given repo with a given Identifier.
@@ -88,15 +130,15 @@ func (s *pipelineStore) FindByIdentifier(
 		WHERE pipeline_repo_id = $1 AND pipeline_uid = $2`
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Pipeline)
+	dst := &pipeline{Pipeline: new(types.Pipeline)}
 	if err := db.GetContext(ctx, dst, findQueryStmt, repoID, identifier); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline")
 	}
-	return dst, nil
+	return mapInternalToPipeline(dst)
 }
 
 // Create creates a pipeline.
-func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) error {
+func (s *pipelineStore) Create(ctx context.Context, p *types.Pipeline) error {
 	const pipelineInsertStmt = `
 	INSERT INTO pipelines (
 		pipeline_description
@@ -110,6 +152,7 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		,pipeline_created
 		,pipeline_updated
 		,pipeline_version
+		,pipeline_inputs
 	) VALUES (
 		:pipeline_description,
 		:pipeline_uid,
@@ -121,16 +164,17 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		:pipeline_config_path,
 		:pipeline_created,
 		:pipeline_updated,
-		:pipeline_version
+		:pipeline_version,
+		:pipeline_inputs
 	) RETURNING pipeline_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(pipelineInsertStmt, pipeline)
+	query, arg, err := db.BindNamed(pipelineInsertStmt, mapPipelineToInternal(p))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&pipeline.ID); err != nil {
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&p.ID); err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Pipeline query failed")
 	}
 
@@ -149,7 +193,8 @@ func (s *pipelineStore) Update(ctx context.Context, p *types.Pipeline) error {
 		pipeline_default_branch = :pipeline_default_branch,
 		pipeline_config_path = :pipeline_config_path,
 		pipeline_updated = :pipeline_updated,
-		pipeline_version = :pipeline_version
+		pipeline_version = :pipeline_version,
+		pipeline_inputs = :pipeline_inputs
 	WHERE pipeline_id = :pipeline_id AND pipeline_version = :pipeline_version - 1`
 	updatedAt := time.Now()
 	pipeline := *p
@@ -159,7 +204,7 @@ func (s *pipelineStore) Update(ctx context.Context, p *types.Pipeline) error {
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(pipelineUpdateStmt, pipeline)
+	query, arg, err := db.BindNamed(pipelineUpdateStmt, mapPipelineToInternal(&pipeline))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline object")
 	}
@@ -208,12 +253,12 @@ func (s *pipelineStore) List(
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Pipeline{}
+	dst := []*pipeline{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
 	}
 
-	return dst, nil
+	return mapInternalToPipelineList(dst)
 }
 
 // ListLatest lists all the pipelines under a repository with information
@@ -287,7 +332,7 @@ func (s *pipelineStore) ListLatest(
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
 	}
 
-	return convert(dst), nil
+	return convert(dst)
 }
 
 // UpdateOptLock updates the pipeline using the optimistic locking mechanism.
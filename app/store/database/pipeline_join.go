@@ -19,11 +19,15 @@ import (
 
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 )
 
 // pipelineExecutionjoin struct represents a joined row between pipelines and executions.
 type pipelineExecutionJoin struct {
 	*types.Pipeline
+	Inputs sqlxtypes.JSONText `db:"pipeline_inputs"`
+
 	ID           sql.NullInt64  `db:"execution_id"`
 	PipelineID   sql.NullInt64  `db:"execution_pipeline_id"`
 	Action       sql.NullString `db:"execution_action"`
@@ -50,19 +54,25 @@ type pipelineExecutionJoin struct {
 	Updated      sql.NullInt64  `db:"execution_updated"`
 }
 
-func convert(rows []*pipelineExecutionJoin) []*types.Pipeline {
+func convert(rows []*pipelineExecutionJoin) ([]*types.Pipeline, error) {
 	pipelines := []*types.Pipeline{}
 	for _, k := range rows {
-		pipeline := convertPipelineJoin(k)
+		pipeline, err := convertPipelineJoin(k)
+		if err != nil {
+			return nil, err
+		}
 		pipelines = append(pipelines, pipeline)
 	}
-	return pipelines
+	return pipelines, nil
 }
 
-func convertPipelineJoin(join *pipelineExecutionJoin) *types.Pipeline {
-	ret := join.Pipeline
+func convertPipelineJoin(join *pipelineExecutionJoin) (*types.Pipeline, error) {
+	ret, err := mapInternalToPipeline(&pipeline{Pipeline: join.Pipeline, Inputs: join.Inputs})
+	if err != nil {
+		return nil, err
+	}
 	if !join.ID.Valid {
-		return ret
+		return ret, nil
 	}
 	ret.Execution = &types.Execution{
 		ID:           join.ID.Int64,
@@ -90,5 +100,5 @@ func convertPipelineJoin(join *pipelineExecutionJoin) *types.Pipeline {
 		Created:      join.Created.Int64,
 		Updated:      join.Updated.Int64,
 	}
-	return ret
+	return ret, nil
 }
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_PipelineInputs(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pipelineStore := database.NewPipelineStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pipeline := &types.Pipeline{
+		Identifier: "release",
+		RepoID:     1,
+		CreatedBy:  userID,
+		ConfigPath: ".harness/release.yaml",
+		Inputs: []types.PipelineInput{
+			{Name: "version", Type: enum.PipelineInputTypeString, Required: true},
+			{Name: "environment", Type: enum.PipelineInputTypeChoice, Options: []string{"qa", "prod"}},
+		},
+	}
+	if err := pipelineStore.Create(ctx, pipeline); err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	found, err := pipelineStore.FindByIdentifier(ctx, 1, "release")
+	if err != nil {
+		t.Fatalf("failed to find pipeline: %v", err)
+	}
+	if !reflect.DeepEqual(found.Inputs, pipeline.Inputs) {
+		t.Errorf("want inputs %+v, got %+v", pipeline.Inputs, found.Inputs)
+	}
+
+	found.Inputs = nil
+	if err = pipelineStore.Update(ctx, found); err != nil {
+		t.Fatalf("failed to update pipeline: %v", err)
+	}
+
+	list, err := pipelineStore.ListLatest(ctx, 1, types.ListQueryFilter{})
+	if err != nil {
+		t.Fatalf("failed to list pipelines: %v", err)
+	}
+	if len(list) != 1 || list[0].Inputs == nil || len(list[0].Inputs) != 0 {
+		t.Errorf("expected a single pipeline without inputs, got %+v", list)
+	}
+}
@@ -290,6 +290,10 @@ func (PublicKeyStore) applyQueryFilter(
 			fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
 	}
 
+	if len(filter.Usages) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"public_key_usage": filter.Usages})
+	}
+
 	return stmt
 }
 
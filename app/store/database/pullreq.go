@@ -446,6 +446,8 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 		stmt = stmt.Where(squirrel.Eq{"pullreq_created_by": opts.CreatedBy})
 	}
 
+	stmt = applyPullReqLabelFilter(stmt, opts.LabelIDs)
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -462,6 +464,69 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 	return count, nil
 }
 
+// applyPullReqLabelFilter restricts the query to pull requests that have all the provided labels assigned.
+func applyPullReqLabelFilter(stmt squirrel.SelectBuilder, labelIDs []int64) squirrel.SelectBuilder {
+	for _, labelID := range labelIDs {
+		stmt = stmt.Where(`EXISTS (
+			SELECT 1 FROM pullreq_labels
+			WHERE pullreq_label_pullreq_id = pullreq_id AND pullreq_label_label_id = ?)`, labelID)
+	}
+
+	return stmt
+}
+
+// ContainedTexts returns the subset of the texts that are contained in the description
+// of any pull request of the repository or in any not deleted pull request comment.
+// All descriptions and comments of the repository are read only once, regardless of the number of texts.
+func (s *PullReqStore) ContainedTexts(ctx context.Context, repoID int64, texts []string) (map[string]bool, error) {
+	contained := make(map[string]bool)
+	if len(texts) == 0 {
+		return contained, nil
+	}
+
+	const sqlQuery = `
+		SELECT pullreq_description
+		FROM pullreqs
+		WHERE pullreq_target_repo_id = $1 AND pullreq_description <> ''
+		UNION ALL
+		SELECT pullreq_activity_text
+		FROM pullreq_activities
+		WHERE pullreq_activity_repo_id = $1 AND pullreq_activity_deleted IS NULL AND pullreq_activity_text <> ''`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sqlQuery, repoID)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing contained texts query")
+	}
+	defer rows.Close()
+
+	remaining := make(map[string]struct{}, len(texts))
+	for _, text := range texts {
+		remaining[text] = struct{}{}
+	}
+
+	for rows.Next() && len(remaining) > 0 {
+		var content string
+		if err = rows.Scan(&content); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed scanning contained texts query result")
+		}
+
+		for text := range remaining {
+			if strings.Contains(content, text) {
+				contained[text] = true
+				delete(remaining, text)
+			}
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed reading contained texts query result")
+	}
+
+	return contained, nil
+}
+
 // List returns a list of pull requests for a repo.
 func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
 	stmt := database.Builder.
@@ -498,6 +563,8 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 		stmt = stmt.Where(squirrel.Eq{"pullreq_created_by": opts.CreatedBy})
 	}
 
+	stmt = applyPullReqLabelFilter(stmt, opts.LabelIDs)
+
 	if opts.CreatedLt > 0 {
 		stmt = stmt.Where("pullreq_created < ?", opts.CreatedLt)
 	}
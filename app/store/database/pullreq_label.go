@@ -0,0 +1,185 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqLabelStore = (*PullReqLabelStore)(nil)
+
+// NewPullReqLabelStore returns a new PullReqLabelStore.
+func NewPullReqLabelStore(db *sqlx.DB) *PullReqLabelStore {
+	return &PullReqLabelStore{
+		db: db,
+	}
+}
+
+// PullReqLabelStore implements a store.PullReqLabelStore backed by a relational database.
+type PullReqLabelStore struct {
+	db *sqlx.DB
+}
+
+type pullReqLabel struct {
+	PullReqID int64 `db:"pullreq_label_pullreq_id"`
+	LabelID   int64 `db:"pullreq_label_label_id"`
+	CreatedBy int64 `db:"pullreq_label_created_by"`
+	Created   int64 `db:"pullreq_label_created"`
+}
+
+type pullReqLabelInfo struct {
+	PullReqID int64    `db:"pullreq_label_pullreq_id"`
+	ID        int64    `db:"label_id"`
+	SpaceID   null.Int `db:"label_space_id"`
+	RepoID    null.Int `db:"label_repo_id"`
+	Name      string   `db:"label_name"`
+	Color     string   `db:"label_color"`
+}
+
+const pullReqLabelInfoColumns = `
+		 pullreq_label_pullreq_id
+		,label_id
+		,label_space_id
+		,label_repo_id
+		,label_name
+		,label_color`
+
+// Assign assigns the label to the pull request. Assigning an already assigned label is a no-op.
+func (s *PullReqLabelStore) Assign(ctx context.Context, prLabel *types.PullReqLabel) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_labels (
+			 pullreq_label_pullreq_id
+			,pullreq_label_label_id
+			,pullreq_label_created_by
+			,pullreq_label_created
+		) values (
+			 :pullreq_label_pullreq_id
+			,:pullreq_label_label_id
+			,:pullreq_label_created_by
+			,:pullreq_label_created
+		)
+		ON CONFLICT (pullreq_label_pullreq_id, pullreq_label_label_id) DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &pullReqLabel{
+		PullReqID: prLabel.PullReqID,
+		LabelID:   prLabel.LabelID,
+		CreatedBy: prLabel.CreatedBy,
+		Created:   prLabel.Created,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request label object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Assign pull request label query failed")
+	}
+
+	return nil
+}
+
+// Unassign removes the label from the pull request.
+func (s *PullReqLabelStore) Unassign(ctx context.Context, pullReqID, labelID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_labels
+		WHERE pullreq_label_pullreq_id = $1 AND pullreq_label_label_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullReqID, labelID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Unassign pull request label query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "RowsAffected after unassign of pull request label failed")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListAssigned returns the labels assigned to the pull request.
+func (s *PullReqLabelStore) ListAssigned(ctx context.Context, pullReqID int64) ([]types.LabelInfo, error) {
+	m, err := s.ListAssignedByPullReqIDs(ctx, []int64{pullReqID})
+	if err != nil {
+		return nil, err
+	}
+
+	if m[pullReqID] == nil {
+		return []types.LabelInfo{}, nil
+	}
+
+	return m[pullReqID], nil
+}
+
+// ListAssignedByPullReqIDs returns the labels assigned to each of the provided pull requests.
+func (s *PullReqLabelStore) ListAssignedByPullReqIDs(
+	ctx context.Context,
+	pullReqIDs []int64,
+) (map[int64][]types.LabelInfo, error) {
+	result := make(map[int64][]types.LabelInfo, len(pullReqIDs))
+	if len(pullReqIDs) == 0 {
+		return result, nil
+	}
+
+	stmt := database.Builder.
+		Select(pullReqLabelInfoColumns).
+		From("pullreq_labels").
+		InnerJoin("labels ON label_id = pullreq_label_label_id").
+		Where(squirrel.Eq{"pullreq_label_pullreq_id": pullReqIDs}).
+		OrderBy("LOWER(label_name) ASC", "label_id ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list assigned labels query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]pullReqLabelInfo, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list assigned labels query")
+	}
+
+	for _, l := range dst {
+		result[l.PullReqID] = append(result[l.PullReqID], types.LabelInfo{
+			ID:      l.ID,
+			SpaceID: l.SpaceID.Ptr(),
+			RepoID:  l.RepoID.Ptr(),
+			Name:    l.Name,
+			Color:   enum.LabelColor(l.Color),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_PullReqContainedTexts(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pullreqStore := database.NewPullReqStore(db, nil)
+	activityStore := database.NewPullReqActivityStore(db, nil)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	pr := &types.PullReq{
+		Number:       1,
+		CreatedBy:    userID,
+		State:        enum.PullReqStateOpen,
+		Title:        "test",
+		Description:  "see ![image](/uploads/description_file.png)",
+		SourceRepoID: 1,
+		SourceBranch: "feature",
+		SourceSHA:    "abc",
+		TargetRepoID: 1,
+		TargetBranch: "main",
+	}
+	if err := pullreqStore.Create(ctx, pr); err != nil {
+		t.Fatalf("failed to create pull request: %v", err)
+	}
+
+	deleted := int64(2)
+	activities := []*types.PullReqActivity{
+		{Text: "comment with /uploads/comment_file.png"},
+		{Text: "deleted comment with /uploads/deleted_file.png", Deleted: &deleted},
+	}
+	for i, act := range activities {
+		act.CreatedBy = userID
+		act.Created = 1
+		act.Updated = 1
+		act.RepoID = 1
+		act.PullReqID = pr.ID
+		act.Order = int64(i + 1)
+		act.Type = enum.PullReqActivityTypeComment
+		act.Kind = enum.PullReqActivityKindComment
+		act.PayloadRaw = json.RawMessage("{}")
+		if err := activityStore.Create(ctx, act); err != nil {
+			t.Fatalf("failed to create pull request activity: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		repoID int64
+		texts  []string
+		want   map[string]bool
+	}{
+		{
+			name:   "no texts",
+			repoID: 1,
+			texts:  nil,
+			want:   map[string]bool{},
+		},
+		{
+			name:   "description and comment",
+			repoID: 1,
+			texts:  []string{"description_file.png", "comment_file.png", "unknown_file.png"},
+			want:   map[string]bool{"description_file.png": true, "comment_file.png": true},
+		},
+		{
+			name:   "deleted comment",
+			repoID: 1,
+			texts:  []string{"deleted_file.png"},
+			want:   map[string]bool{},
+		},
+		{
+			name:   "like wildcards are matched literally",
+			repoID: 1,
+			texts:  []string{"%_file.png", "comment_file_png", "comment%file.png"},
+			want:   map[string]bool{},
+		},
+		{
+			name:   "other repository",
+			repoID: 2,
+			texts:  []string{"description_file.png", "comment_file.png"},
+			want:   map[string]bool{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pullreqStore.ContainedTexts(ctx, test.repoID, test.texts)
+			if err != nil {
+				t.Fatalf("failed to check contained texts: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
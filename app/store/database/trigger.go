@@ -49,6 +49,11 @@ type trigger struct {
 	Created     int64              `db:"trigger_created"`
 	Updated     int64              `db:"trigger_updated"`
 	Version     int64              `db:"trigger_version"`
+
+	Cron         string `db:"trigger_cron"`
+	CronBranch   string `db:"trigger_cron_branch"`
+	CronTimezone string `db:"trigger_cron_timezone"`
+	CronNext     int64  `db:"trigger_cron_next"`
 }
 
 func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
@@ -72,6 +77,11 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		Created:     trigger.Created,
 		Updated:     trigger.Updated,
 		Version:     trigger.Version,
+
+		Cron:         trigger.Cron,
+		CronBranch:   trigger.CronBranch,
+		CronTimezone: trigger.CronTimezone,
+		CronNext:     trigger.CronNext,
 	}, nil
 }
 
@@ -102,6 +112,11 @@ func mapTriggerToInternal(t *types.Trigger) *trigger {
 		Created:     t.Created,
 		Updated:     t.Updated,
 		Version:     t.Version,
+
+		Cron:         t.Cron,
+		CronBranch:   t.CronBranch,
+		CronTimezone: t.CronTimezone,
+		CronNext:     t.CronNext,
 	}
 }
 
@@ -127,6 +142,12 @@ const (
 		,trigger_created
 		,trigger_updated
 		,trigger_version
+		,trigger_type
+		,trigger_repo_id
+		,trigger_cron
+		,trigger_cron_branch
+		,trigger_cron_timezone
+		,trigger_cron_next
 	`
 )
 
@@ -165,6 +186,10 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,trigger_created
 		,trigger_updated
 		,trigger_version
+		,trigger_cron
+		,trigger_cron_branch
+		,trigger_cron_timezone
+		,trigger_cron_next
 	) VALUES (
 		:trigger_uid
 		,:trigger_description
@@ -178,6 +203,10 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,:trigger_created
 		,:trigger_updated
 		,:trigger_version
+		,:trigger_cron
+		,:trigger_cron_branch
+		,:trigger_cron_timezone
+		,:trigger_cron_next
 	) RETURNING trigger_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -205,6 +234,10 @@ func (s *triggerStore) Update(ctx context.Context, t *types.Trigger) error {
 		,trigger_updated = :trigger_updated
 		,trigger_actions = :trigger_actions
 		,trigger_version = :trigger_version
+		,trigger_cron = :trigger_cron
+		,trigger_cron_branch = :trigger_cron_branch
+		,trigger_cron_timezone = :trigger_cron_timezone
+		,trigger_cron_next = :trigger_cron_next
 	WHERE trigger_id = :trigger_id AND trigger_version = :trigger_version - 1`
 	updatedAt := time.Now()
 	trigger := mapTriggerToInternal(t)
@@ -323,6 +356,61 @@ func (s *triggerStore) ListAllEnabled(
 	return mapInternalToTriggerList(dst)
 }
 
+// ListAllEnabledCronDue lists all enabled scheduled triggers that are due to fire at the provided time.
+func (s *triggerStore) ListAllEnabledCronDue(
+	ctx context.Context,
+	now int64,
+) ([]*types.Trigger, error) {
+	stmt := database.Builder.
+		Select(triggerColumns).
+		From("triggers").
+		Where("trigger_type = ? AND trigger_disabled = false", enum.TriggerCron).
+		Where("trigger_cron_next <= ?", now).
+		OrderBy("trigger_cron_next", "trigger_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*trigger{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list due cron triggers query")
+	}
+
+	return mapInternalToTriggerList(dst)
+}
+
+// UpdateCronNext moves the next fire time of a scheduled trigger from cronNext to newCronNext.
+// The trigger version isn't changed, as this isn't a modification of the trigger by a user.
+func (s *triggerStore) UpdateCronNext(
+	ctx context.Context,
+	triggerID int64,
+	cronNext int64,
+	newCronNext int64,
+) (bool, error) {
+	const triggerUpdateCronNextStmt = `
+	UPDATE triggers
+	SET trigger_cron_next = $1
+	WHERE trigger_id = $2 AND trigger_cron_next = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, triggerUpdateCronNextStmt, newCronNext, triggerID, cronNext)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to update trigger cron next")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	return count > 0, nil
+}
+
 // Count of triggers under a given pipeline.
 func (s *triggerStore) Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error) {
 	stmt := database.Builder.
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -67,6 +68,12 @@ type webhook struct {
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+	ConsecutiveFailures   int         `db:"webhook_consecutive_failures"`
+	FailingSince          int64       `db:"webhook_failing_since"`
+	DisabledReason        string      `db:"webhook_disabled_reason"`
+	PayloadFormat         string      `db:"webhook_payload_format"`
+	PayloadTemplate       string      `db:"webhook_payload_template"`
+	Headers               string      `db:"webhook_headers"`
 }
 
 const (
@@ -87,7 +94,13 @@ const (
 		,webhook_insecure
 		,webhook_triggers
 		,webhook_latest_execution_result
-		,webhook_internal`
+		,webhook_internal
+		,webhook_consecutive_failures
+		,webhook_failing_since
+		,webhook_disabled_reason
+		,webhook_payload_format
+		,webhook_payload_template
+		,webhook_headers`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -174,6 +187,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_triggers
 			,webhook_latest_execution_result
 			,webhook_internal
+			,webhook_consecutive_failures
+			,webhook_failing_since
+			,webhook_disabled_reason
+			,webhook_payload_format
+			,webhook_payload_template
+			,webhook_headers
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -190,6 +209,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_triggers
 			,:webhook_latest_execution_result
 			,:webhook_internal
+			,:webhook_consecutive_failures
+			,:webhook_failing_since
+			,:webhook_disabled_reason
+			,:webhook_payload_format
+			,:webhook_payload_template
+			,:webhook_headers
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -228,6 +253,12 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_internal = :webhook_internal
+			,webhook_consecutive_failures = :webhook_consecutive_failures
+			,webhook_failing_since = :webhook_failing_since
+			,webhook_disabled_reason = :webhook_disabled_reason
+			,webhook_payload_format = :webhook_payload_format
+			,webhook_payload_template = :webhook_payload_template
+			,webhook_headers = :webhook_headers
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -463,6 +494,15 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Internal:              hook.Internal,
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		FailingSince:          hook.FailingSince,
+		DisabledReason:        hook.DisabledReason,
+		PayloadFormat:         enum.WebhookPayloadFormat(hook.PayloadFormat),
+		PayloadTemplate:       hook.PayloadTemplate,
+	}
+
+	if err := json.Unmarshal([]byte(hook.Headers), &res.Headers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal headers of hook %d: %w", hook.ID, err)
 	}
 
 	switch {
@@ -499,7 +539,22 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Internal:              hook.Internal,
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		FailingSince:          hook.FailingSince,
+		DisabledReason:        hook.DisabledReason,
+		PayloadFormat:         string(hook.PayloadFormat),
+		PayloadTemplate:       hook.PayloadTemplate,
+	}
+
+	headers := hook.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	rawHeaders, err := json.Marshal(headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal headers of hook %d: %w", hook.ID, err)
 	}
+	res.Headers = string(rawHeaders)
 
 	switch hook.ParentType {
 	case enum.WebhookParentRepo:
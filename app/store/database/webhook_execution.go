@@ -213,6 +213,24 @@ func (s *WebhookExecutionStore) ListForWebhook(ctx context.Context, webhookID in
 	return mapToWebhookExecutions(dst), nil
 }
 
+// ListForWebhookSince lists the webhook executions for a given webhook id
+// that were created at or after the provided time (unix milli), oldest first.
+func (s *WebhookExecutionStore) ListForWebhookSince(ctx context.Context,
+	webhookID int64, since int64) ([]*types.WebhookExecution, error) {
+	const sqlQuery = webhookExecutionSelectBase + `
+	WHERE webhook_execution_webhook_id = $1 AND webhook_execution_created >= $2
+	ORDER BY webhook_execution_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*webhookExecution{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, webhookID, since); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToWebhookExecutions(dst), nil
+}
+
 // ListForTrigger lists the webhook executions for a given trigger id.
 func (s *WebhookExecutionStore) ListForTrigger(ctx context.Context,
 	triggerID string) ([]*types.WebhookExecution, error) {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database"
 
@@ -36,6 +37,7 @@ var WireSet = wire.NewSet(
 	ProvideRepoStore,
 	ProvideRuleStore,
 	ProvideJobStore,
+	ProvideAuditStore,
 	ProvideExecutionStore,
 	ProvidePipelineStore,
 	ProvideStageStore,
@@ -60,6 +62,10 @@ var WireSet = wire.NewSet(
 	ProvideTriggerStore,
 	ProvidePluginStore,
 	ProvidePublicKeyStore,
+	ProvideMergeQueueStore,
+	ProvideAutoMergeStore,
+	ProvideLabelStore,
+	ProvidePullReqLabelStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -128,6 +134,11 @@ func ProvideJobStore(db *sqlx.DB) job.Store {
 	return NewJobStore(db)
 }
 
+// ProvideAuditStore provides an audit store.
+func ProvideAuditStore(db *sqlx.DB, spacePathCache store.SpacePathCache) audit.Store {
+	return NewAuditStore(db, spacePathCache)
+}
+
 // ProvidePipelineStore provides a pipeline store.
 func ProvidePipelineStore(db *sqlx.DB) store.PipelineStore {
 	return NewPipelineStore(db)
@@ -259,3 +270,23 @@ func ProvidePublicAccessStore(db *sqlx.DB) store.PublicAccessStore {
 func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
 	return NewPublicKeyStore(db)
 }
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
+// ProvideAutoMergeStore provides a pull request auto-merge store.
+func ProvideAutoMergeStore(db *sqlx.DB) store.AutoMergeStore {
+	return NewAutoMergeStore(db)
+}
+
+// ProvideLabelStore provides a label store.
+func ProvideLabelStore(db *sqlx.DB) store.LabelStore {
+	return NewLabelStore(db)
+}
+
+// ProvidePullReqLabelStore provides a pull request label store.
+func ProvidePullReqLabelStore(db *sqlx.DB) store.PullReqLabelStore {
+	return NewPullReqLabelStore(db)
+}
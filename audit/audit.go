@@ -30,17 +30,57 @@ var (
 	ErrSpacePathIsRequired          = errors.New("space path is required")
 )
 
+// Keys of the event data used by multiple callers.
+const (
+	DataKeyRepoName = "repo_name"
+	DataKeyRef      = "ref"
+	DataKeyOwner    = "owner_uid"
+
+	DataKeyBypassJustification = "bypass_justification"
+)
+
 type Action string
 
 const (
-	ActionCreated Action = "created"
-	ActionUpdated Action = "updated" // update default branch, switching default branch, updating description
-	ActionDeleted Action = "deleted"
+	ActionCreated     Action = "created"
+	ActionUpdated     Action = "updated" // update default branch, switching default branch, updating description
+	ActionDeleted     Action = "deleted"
+	ActionBypassed    Action = "bypassed"     // protection rules were bypassed
+	ActionForcePushed Action = "force_pushed" // a ref was updated with a non fast-forward push
+	ActionMerged      Action = "merged"
+	ActionLoginFailed Action = "login_failed"
+	ActionTokenIssued Action = "token_issued"
 )
 
+var actions = []Action{
+	ActionCreated,
+	ActionUpdated,
+	ActionDeleted,
+	ActionBypassed,
+	ActionForcePushed,
+	ActionMerged,
+	ActionLoginFailed,
+	ActionTokenIssued,
+}
+
+func (Action) Enum() []interface{} {
+	res := make([]interface{}, len(actions))
+	for i, a := range actions {
+		res[i] = a
+	}
+	return res
+}
+
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted:
+	case ActionCreated,
+		ActionUpdated,
+		ActionDeleted,
+		ActionBypassed,
+		ActionForcePushed,
+		ActionMerged,
+		ActionLoginFailed,
+		ActionTokenIssued:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -53,22 +93,84 @@ const (
 	ResourceTypeRepository         ResourceType = "repository"
 	ResourceTypeBranchRule         ResourceType = "branch_rule"
 	ResourceTypeRepositorySettings ResourceType = "repository_settings"
+	ResourceTypeSpace              ResourceType = "space"
+	ResourceTypeMembership         ResourceType = "membership"
+	ResourceTypeSecret             ResourceType = "secret"
+	ResourceTypeConnector          ResourceType = "connector"
+	ResourceTypeServiceAccount     ResourceType = "service_account"
+	ResourceTypeToken              ResourceType = "token"
+	ResourceTypeWebhook            ResourceType = "webhook"
+	ResourceTypePipeline           ResourceType = "pipeline"
+	ResourceTypePullRequest        ResourceType = "pull_request"
+	ResourceTypePublicKey          ResourceType = "public_key"
+	ResourceTypeUser               ResourceType = "user"
+	ResourceTypeLabel              ResourceType = "label"
 )
 
+var resourceTypes = []ResourceType{
+	ResourceTypeRepository,
+	ResourceTypeBranchRule,
+	ResourceTypeRepositorySettings,
+	ResourceTypeSpace,
+	ResourceTypeMembership,
+	ResourceTypeSecret,
+	ResourceTypeConnector,
+	ResourceTypeServiceAccount,
+	ResourceTypeToken,
+	ResourceTypeWebhook,
+	ResourceTypePipeline,
+	ResourceTypePullRequest,
+	ResourceTypePublicKey,
+	ResourceTypeUser,
+	ResourceTypeLabel,
+}
+
+func (ResourceType) Enum() []interface{} {
+	res := make([]interface{}, len(resourceTypes))
+	for i, t := range resourceTypes {
+		res[i] = t
+	}
+	return res
+}
+
 func (a ResourceType) Validate() error {
 	switch a {
 	case ResourceTypeRepository,
 		ResourceTypeBranchRule,
-		ResourceTypeRepositorySettings:
+		ResourceTypeRepositorySettings,
+		ResourceTypeSpace,
+		ResourceTypeMembership,
+		ResourceTypeSecret,
+		ResourceTypeConnector,
+		ResourceTypeServiceAccount,
+		ResourceTypeToken,
+		ResourceTypeWebhook,
+		ResourceTypePipeline,
+		ResourceTypePullRequest,
+		ResourceTypePublicKey,
+		ResourceTypeUser:
 		return nil
 	default:
 		return ErrResourceTypeUndefined
 	}
 }
 
+// IsAccountScoped returns true for resource types that can belong to a user account rather than a space.
+// Events for such resources may be recorded without a space path.
+func (a ResourceType) IsAccountScoped() bool {
+	switch a {
+	case ResourceTypeUser,
+		ResourceTypeToken,
+		ResourceTypePublicKey:
+		return true
+	default:
+		return false
+	}
+}
+
 type Resource struct {
-	Type       ResourceType
-	Identifier string
+	Type       ResourceType `json:"type"`
+	Identifier string       `json:"identifier"`
 }
 
 func NewResource(rtype ResourceType, identifier string) Resource {
@@ -89,21 +191,22 @@ func (r Resource) Validate() error {
 }
 
 type DiffObject struct {
-	OldObject any
-	NewObject any
+	OldObject any `json:"old_object,omitempty"`
+	NewObject any `json:"new_object,omitempty"`
 }
 
 type Event struct {
-	ID            string
-	Timestamp     int64
-	Action        Action          // example: ActionCreated
-	User          types.Principal // example: Admin
-	SpacePath     string          // example: /root/projects
-	Resource      Resource
-	DiffObject    DiffObject
-	ClientIP      string
-	RequestMethod string
-	Data          map[string]string // internal data like correlationID/requestID
+	ID            string            `json:"id"`
+	Timestamp     int64             `json:"timestamp"`
+	Action        Action            `json:"action"`     // example: ActionCreated
+	User          types.Principal   `json:"principal"`  // example: Admin
+	SpaceID       int64             `json:"-"`          // resolved from SpacePath by the store if not set
+	SpacePath     string            `json:"space_path"` // example: /root/projects
+	Resource      Resource          `json:"resource"`
+	DiffObject    DiffObject        `json:"diff"`
+	ClientIP      string            `json:"client_ip"`
+	RequestMethod string            `json:"request_method"`
+	Data          map[string]string `json:"data,omitempty"` // internal data like correlationID/requestID
 }
 
 func (e *Event) Validate() error {
@@ -113,7 +216,7 @@ func (e *Event) Validate() error {
 	if e.User.UID == "" {
 		return ErrUserIsRequired
 	}
-	if e.SpacePath == "" {
+	if e.SpacePath == "" && !e.Resource.Type.IsAccountScoped() {
 		return ErrSpacePathIsRequired
 	}
 	if err := e.Resource.Validate(); err != nil {
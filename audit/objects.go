@@ -22,3 +22,8 @@ type RepositoryObject struct {
 	types.Repository
 	IsPublic bool `yaml:"is_public"`
 }
+
+type SpaceObject struct {
+	types.Space
+	IsPublic bool `yaml:"is_public"`
+}
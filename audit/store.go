@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Store interface {
+	// Create persists a new audit event.
+	Create(ctx context.Context, event *Event) error
+
+	// Count returns the number of audit events matching the filter.
+	Count(ctx context.Context, filter *ListFilter) (int64, error)
+
+	// List returns the audit events matching the filter.
+	// The old and new objects of the returned events are json.RawMessage values.
+	List(ctx context.Context, filter *ListFilter) ([]*Event, error)
+
+	// DeleteOld removes all audit events that were recorded before the provided time.
+	DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+// ListFilter stores audit event query parameters.
+type ListFilter struct {
+	types.Pagination
+	types.CreatedFilter
+
+	// SpaceID is the ID of the space the events were recorded in.
+	// Events are filtered by ID so they remain listed after the space is renamed or moved.
+	SpaceID int64
+	// Recursive includes the events recorded in all sub-spaces of the space.
+	Recursive bool
+	// AccountScoped limits the result to the events recorded without a space,
+	// i.e. the events of user accounts, their tokens and public keys.
+	AccountScoped bool
+
+	Actions       []Action
+	ResourceTypes []ResourceType
+	PrincipalIDs  []int64
+
+	Order enum.Order
+}
@@ -16,16 +16,25 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"mime"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
 const (
-	fileDiskPathFmt = "%s/%s"
+	fileDiskPathFmt     = "%s/%s"
+	multipartTargetFile = "target"
+	partFileExtension   = ".part"
 )
 
 type FileSystemStore struct {
@@ -92,3 +101,314 @@ func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadC
 	}
 	return io.ReadCloser(file), nil
 }
+
+func (c *FileSystemStore) Delete(_ context.Context, filePath string) error {
+	err := os.Remove(c.diskPath(filePath))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+func (c *FileSystemStore) Stat(_ context.Context, filePath string) (*FileInfo, error) {
+	fi, err := os.Stat(c.diskPath(filePath))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if fi.IsDir() {
+		return nil, ErrNotFound
+	}
+
+	return fileSystemFileInfo(filePath, fi), nil
+}
+
+func (c *FileSystemStore) List(_ context.Context, prefix string) ([]*FileInfo, error) {
+	var files []*FileInfo
+
+	err := filepath.WalkDir(c.basePath, func(diskPath string, d fs.DirEntry, err error) error {
+		if diskPath == c.basePath && errors.Is(err, fs.ErrNotExist) {
+			// nothing was uploaded yet
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(c.basePath, diskPath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			if relPath == "." {
+				return nil
+			}
+			// skip staged multipart uploads and directories that can't contain files with the prefix.
+			if relPath == multipartDir ||
+				!strings.HasPrefix(relPath+"/", prefix) && !strings.HasPrefix(prefix, relPath+"/") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasPrefix(relPath, prefix) {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		files = append(files, fileSystemFileInfo(relPath, fi))
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return files, nil
+}
+
+func (c *FileSystemStore) CreateMultipartUpload(_ context.Context, filePath string) (string, error) {
+	uploadID := uuid.NewString()
+	uploadDir := c.multipartUploadDir(uploadID)
+
+	if err := os.MkdirAll(uploadDir, os.ModeDir|os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create multipart upload directory: %w", err)
+	}
+
+	// the target file path is stored to ensure parts are uploaded only for the file the upload was created for.
+	err := os.WriteFile(filepath.Join(uploadDir, multipartTargetFile), []byte(filePath), 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to write multipart upload target: %w", err)
+	}
+
+	return uploadID, nil
+}
+
+func (c *FileSystemStore) UploadPart(
+	ctx context.Context,
+	filePath string,
+	uploadID string,
+	partNumber int,
+	part io.Reader,
+) error {
+	if err := checkPartNumber(partNumber); err != nil {
+		return err
+	}
+
+	uploadDir, err := c.findMultipartUpload(filePath, uploadID)
+	if err != nil {
+		return err
+	}
+
+	// parts are written to a temporary file first to not leave a partial part behind on failure.
+	tmpFile, err := os.CreateTemp(uploadDir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary part file: %w", err)
+	}
+
+	tmpPath := tmpFile.Name()
+	defer func() {
+		// no-op if the temporary file was successfully renamed
+		_ = os.Remove(tmpPath)
+	}()
+
+	_, err = io.Copy(tmpFile, part)
+	if errClose := tmpFile.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write part: %w", err)
+	}
+
+	if err = os.Rename(tmpPath, filepath.Join(uploadDir, partFileName(partNumber))); err != nil {
+		return fmt.Errorf("failed to store part: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("uploaded part %d of multipart upload %s", partNumber, uploadID)
+
+	return nil
+}
+
+func (c *FileSystemStore) CompleteMultipartUpload(_ context.Context, filePath string, uploadID string) error {
+	uploadDir, err := c.findMultipartUpload(filePath, uploadID)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(uploadDir)
+	if err != nil {
+		return fmt.Errorf("failed to list parts: %w", err)
+	}
+
+	// entries are sorted by name, and as such by part number.
+	var parts []string
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), partFileExtension) {
+			parts = append(parts, filepath.Join(uploadDir, entry.Name()))
+		}
+	}
+
+	if len(parts) == 0 {
+		return errors.New("multipart upload doesn't contain any parts")
+	}
+
+	fileDiskPath := c.diskPath(filePath)
+	if err = os.MkdirAll(filepath.Dir(fileDiskPath), os.ModeDir|os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create parent directory for the file: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(fileDiskPath), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	tmpPath := tmpFile.Name()
+	defer func() {
+		// no-op if the temporary file was successfully renamed
+		_ = os.Remove(tmpPath)
+	}()
+
+	for _, part := range parts {
+		if err = appendFile(tmpFile, part); err != nil {
+			break
+		}
+	}
+	if errClose := tmpFile.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return fmt.Errorf("failed to assemble parts: %w", err)
+	}
+
+	if err = os.Rename(tmpPath, fileDiskPath); err != nil {
+		return fmt.Errorf("failed to store file: %w", err)
+	}
+
+	if err = os.RemoveAll(uploadDir); err != nil {
+		return fmt.Errorf("failed to remove multipart upload directory: %w", err)
+	}
+
+	return nil
+}
+
+func (c *FileSystemStore) AbortMultipartUpload(_ context.Context, filePath string, uploadID string) error {
+	uploadDir, err := c.findMultipartUpload(filePath, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(uploadDir); err != nil {
+		return fmt.Errorf("failed to remove multipart upload directory: %w", err)
+	}
+
+	return nil
+}
+
+func (c *FileSystemStore) AbortStaleMultipartUploads(_ context.Context, olderThan time.Time) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(c.basePath, multipartDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list multipart uploads: %w", err)
+	}
+
+	var count int
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		// the directory is modified whenever a part is uploaded.
+		fi, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// completed or aborted in the meantime
+			continue
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to stat multipart upload directory: %w", err)
+		}
+
+		if !fi.ModTime().Before(olderThan) {
+			continue
+		}
+
+		if err = os.RemoveAll(c.multipartUploadDir(entry.Name())); err != nil {
+			return count, fmt.Errorf("failed to remove multipart upload directory: %w", err)
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func (c *FileSystemStore) diskPath(filePath string) string {
+	return fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+}
+
+func (c *FileSystemStore) multipartUploadDir(uploadID string) string {
+	return filepath.Join(c.basePath, multipartDir, uploadID)
+}
+
+// findMultipartUpload returns the directory of the multipart upload,
+// or ErrNotFound if the upload doesn't exist or was created for a different file.
+func (c *FileSystemStore) findMultipartUpload(filePath string, uploadID string) (string, error) {
+	// the upload ID is part of the disk path, so it must not be anything else than a UUID.
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return "", ErrNotFound
+	}
+
+	uploadDir := c.multipartUploadDir(uploadID)
+
+	target, err := os.ReadFile(filepath.Join(uploadDir, multipartTargetFile))
+	if os.IsNotExist(err) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read multipart upload target: %w", err)
+	}
+
+	if string(target) != filePath {
+		return "", ErrNotFound
+	}
+
+	return uploadDir, nil
+}
+
+func appendFile(w io.Writer, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func partFileName(partNumber int) string {
+	return fmt.Sprintf("%05d%s", partNumber, partFileExtension)
+}
+
+func fileSystemFileInfo(filePath string, fi fs.FileInfo) *FileInfo {
+	contentType := mime.TypeByExtension(path.Ext(filePath))
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	return &FileInfo{
+		Path:        filePath,
+		Size:        fi.Size(),
+		ContentType: contentType,
+		ModTime:     fi.ModTime(),
+	}
+}
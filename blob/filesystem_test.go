@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileSystemStore_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	store, _ := NewFileSystemStore(Config{Bucket: t.TempDir()})
+
+	for _, filePath := range []string{"uploads/1/a.png", "uploads/1/b.txt", "uploads/2/c.png", "other/d.png"} {
+		if err := store.Upload(ctx, strings.NewReader(filePath), filePath); err != nil {
+			t.Fatalf("failed to upload %s: %s", filePath, err)
+		}
+	}
+
+	info, err := store.Stat(ctx, "uploads/1/a.png")
+	if err != nil {
+		t.Fatalf("failed to stat: %s", err)
+	}
+	if info.Size != int64(len("uploads/1/a.png")) || info.ContentType != "image/png" || info.ModTime.IsZero() {
+		t.Errorf("unexpected file info: %+v", info)
+	}
+
+	if _, err = store.Stat(ctx, "uploads/1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a directory, got: %v", err)
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "", want: []string{"other/d.png", "uploads/1/a.png", "uploads/1/b.txt", "uploads/2/c.png"}},
+		{prefix: "uploads/", want: []string{"uploads/1/a.png", "uploads/1/b.txt", "uploads/2/c.png"}},
+		{prefix: "uploads/1/", want: []string{"uploads/1/a.png", "uploads/1/b.txt"}},
+		{prefix: "uploads/1/a", want: []string{"uploads/1/a.png"}},
+		{prefix: "missing/", want: nil},
+	}
+	for _, test := range tests {
+		files, err := store.List(ctx, test.prefix)
+		if err != nil {
+			t.Fatalf("failed to list %q: %s", test.prefix, err)
+		}
+
+		var got []string
+		for _, file := range files {
+			got = append(got, file.Path)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("prefix %q: want=%v got=%v", test.prefix, test.want, got)
+		}
+	}
+
+	if err = store.Delete(ctx, "uploads/1/a.png"); err != nil {
+		t.Fatalf("failed to delete: %s", err)
+	}
+	if _, err = store.Stat(ctx, "uploads/1/a.png"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got: %v", err)
+	}
+	if err = store.Delete(ctx, "uploads/1/a.png"); err != nil {
+		t.Errorf("deleting a missing file shouldn't fail: %s", err)
+	}
+}
+
+func TestFileSystemStore_MultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	store, _ := NewFileSystemStore(Config{Bucket: t.TempDir()})
+
+	uploadID, err := store.CreateMultipartUpload(ctx, "uploads/1/file.bin")
+	if err != nil {
+		t.Fatalf("failed to create multipart upload: %s", err)
+	}
+
+	// parts are uploaded out of order, and the second part is replaced to simulate a resumed upload.
+	parts := []struct {
+		number int
+		data   string
+	}{
+		{number: 2, data: "broken"},
+		{number: 1, data: "first-"},
+		{number: 3, data: "-third"},
+		{number: 2, data: "second"},
+	}
+	for _, part := range parts {
+		err = store.UploadPart(ctx, "uploads/1/file.bin", uploadID, part.number, strings.NewReader(part.data))
+		if err != nil {
+			t.Fatalf("failed to upload part %d: %s", part.number, err)
+		}
+	}
+
+	err = store.UploadPart(ctx, "uploads/1/other.bin", uploadID, 4, strings.NewReader("x"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a different file, got: %v", err)
+	}
+	err = store.UploadPart(ctx, "uploads/1/file.bin", "../../x", 4, strings.NewReader("x"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an invalid upload ID, got: %v", err)
+	}
+	if err = store.UploadPart(ctx, "uploads/1/file.bin", uploadID, 0, strings.NewReader("x")); err == nil {
+		t.Errorf("expected an error for an invalid part number")
+	}
+
+	if _, err = store.Stat(ctx, "uploads/1/file.bin"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("file shouldn't exist before the upload is completed, got: %v", err)
+	}
+
+	if err = store.CompleteMultipartUpload(ctx, "uploads/1/file.bin", uploadID); err != nil {
+		t.Fatalf("failed to complete multipart upload: %s", err)
+	}
+
+	rc, err := store.Download(ctx, "uploads/1/file.bin")
+	if err != nil {
+		t.Fatalf("failed to download: %s", err)
+	}
+	defer rc.Close()
+
+	data, _ := io.ReadAll(rc)
+	if string(data) != "first-second-third" {
+		t.Errorf("unexpected file content: %s", data)
+	}
+
+	files, err := store.List(ctx, "")
+	if err != nil {
+		t.Fatalf("failed to list: %s", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected only the uploaded file to be listed, got: %d files", len(files))
+	}
+
+	if err = store.AbortMultipartUpload(ctx, "uploads/1/file.bin", uploadID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a completed upload, got: %v", err)
+	}
+}
+
+func TestFileSystemStore_AbortMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	store, _ := NewFileSystemStore(Config{Bucket: t.TempDir()})
+
+	uploadID, err := store.CreateMultipartUpload(ctx, "file.bin")
+	if err != nil {
+		t.Fatalf("failed to create multipart upload: %s", err)
+	}
+
+	if err = store.UploadPart(ctx, "file.bin", uploadID, 1, strings.NewReader("data")); err != nil {
+		t.Fatalf("failed to upload part: %s", err)
+	}
+
+	if err = store.AbortMultipartUpload(ctx, "file.bin", uploadID); err != nil {
+		t.Fatalf("failed to abort multipart upload: %s", err)
+	}
+
+	if err = store.CompleteMultipartUpload(ctx, "file.bin", uploadID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an aborted upload, got: %v", err)
+	}
+}
+
+func TestFileSystemStore_AbortStaleMultipartUploads(t *testing.T) {
+	ctx := context.Background()
+	basePath := t.TempDir()
+	store, _ := NewFileSystemStore(Config{Bucket: basePath})
+
+	if n, err := store.AbortStaleMultipartUploads(ctx, time.Now()); err != nil || n != 0 {
+		t.Fatalf("expected no uploads to be aborted, got: %d, %v", n, err)
+	}
+
+	staleID, err := store.CreateMultipartUpload(ctx, "stale.bin")
+	if err != nil {
+		t.Fatalf("failed to create multipart upload: %s", err)
+	}
+
+	activeID, err := store.CreateMultipartUpload(ctx, "active.bin")
+	if err != nil {
+		t.Fatalf("failed to create multipart upload: %s", err)
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err = os.Chtimes(filepath.Join(basePath, multipartDir, staleID), old, old); err != nil {
+		t.Fatalf("failed to change upload time: %s", err)
+	}
+
+	n, err := store.AbortStaleMultipartUploads(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to abort stale multipart uploads: %s", err)
+	}
+	if n != 1 {
+		t.Errorf("expected a single aborted upload, got %d", n)
+	}
+
+	if err = store.UploadPart(ctx, "stale.bin", staleID, 1, strings.NewReader("x")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for the stale upload, got: %v", err)
+	}
+	if err = store.UploadPart(ctx, "active.bin", activeID, 1, strings.NewReader("x")); err != nil {
+		t.Errorf("failed to upload part of the active upload: %s", err)
+	}
+}
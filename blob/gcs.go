@@ -16,21 +16,29 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/impersonate"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
 // scopes best practice: https://cloud.google.com/compute/docs/access/service-accounts#scopes_best_practice
 const defaultScope = "https://www.googleapis.com/auth/cloud-platform"
 
+// gcsMaxComposeSources is the maximum number of source objects of a single compose request.
+const gcsMaxComposeSources = 32
+
 type GCSStore struct {
 	// Bucket is the name of the GCS bucket to use.
 	cachedClient        *storage.Client
@@ -157,3 +165,294 @@ func (c *GCSStore) checkAndRefreshToken(ctx context.Context) error {
 	c.tokenExpirationTime = now.Add(c.config.ImpersonationLifetime)
 	return nil
 }
+
+func (c *GCSStore) Delete(ctx context.Context, filePath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	err = gcsClient.Bucket(c.config.Bucket).Object(filePath).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+	return nil
+}
+
+func (c *GCSStore) Stat(ctx context.Context, filePath string) (*FileInfo, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	attrs, err := gcsClient.Bucket(c.config.Bucket).Object(filePath).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attributes of file: %s %w", filePath, err)
+	}
+
+	return gcsFileInfo(attrs), nil
+}
+
+func (c *GCSStore) List(ctx context.Context, prefix string) ([]*FileInfo, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	var files []*FileInfo
+	err = listGCSObjects(ctx, gcsClient.Bucket(c.config.Bucket), prefix, func(attrs *storage.ObjectAttrs) {
+		if !strings.HasPrefix(attrs.Name, multipartDir+"/") {
+			files = append(files, gcsFileInfo(attrs))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// CreateMultipartUpload starts a multipart upload. As GCS doesn't support multipart uploads natively,
+// parts are uploaded as separate objects which are composed into the file once the upload is completed.
+func (c *GCSStore) CreateMultipartUpload(ctx context.Context, filePath string) (string, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	uploadID := uuid.NewString()
+
+	// the target file path is stored to ensure parts are uploaded only for the file the upload was created for.
+	wc := gcsClient.Bucket(c.config.Bucket).Object(gcsMultipartTargetObject(uploadID)).NewWriter(ctx)
+	if _, err = io.WriteString(wc, filePath); err != nil {
+		_ = wc.Close()
+		return "", fmt.Errorf("failed to write multipart upload target: %w", err)
+	}
+	if err = wc.Close(); err != nil {
+		return "", fmt.Errorf("failed to write multipart upload target: %w", err)
+	}
+
+	return uploadID, nil
+}
+
+func (c *GCSStore) UploadPart(
+	ctx context.Context,
+	filePath string,
+	uploadID string,
+	partNumber int,
+	part io.Reader,
+) error {
+	if err := checkPartNumber(partNumber); err != nil {
+		return err
+	}
+
+	bkt, err := c.findMultipartUpload(ctx, filePath, uploadID)
+	if err != nil {
+		return err
+	}
+
+	wc := bkt.Object(gcsPartObject(uploadID, partNumber)).NewWriter(ctx)
+	if _, err = io.Copy(wc, part); err != nil {
+		_ = wc.Close()
+		return fmt.Errorf("failed to write part to GCS: %w", err)
+	}
+	if err = wc.Close(); err != nil {
+		return fmt.Errorf("failed to write part to GCS: %w", err)
+	}
+
+	return nil
+}
+
+func (c *GCSStore) CompleteMultipartUpload(ctx context.Context, filePath string, uploadID string) error {
+	bkt, err := c.findMultipartUpload(ctx, filePath, uploadID)
+	if err != nil {
+		return err
+	}
+
+	// objects are listed in lexicographical order, and as such sorted by part number.
+	var parts []*storage.ObjectHandle
+	err = listGCSObjects(ctx, bkt, gcsMultipartPrefix(uploadID), func(attrs *storage.ObjectAttrs) {
+		if strings.HasSuffix(attrs.Name, partFileExtension) {
+			parts = append(parts, bkt.Object(attrs.Name))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(parts) == 0 {
+		return errors.New("multipart upload doesn't contain any parts")
+	}
+
+	// a single compose request accepts a limited number of sources,
+	// so the file is composed incrementally, always including the already composed content.
+	dst := bkt.Object(filePath)
+	for i := 0; i < len(parts); {
+		var sources []*storage.ObjectHandle
+		if i > 0 {
+			sources = append(sources, dst)
+		}
+
+		n := gcsMaxComposeSources - len(sources)
+		if n > len(parts)-i {
+			n = len(parts) - i
+		}
+
+		sources = append(sources, parts[i:i+n]...)
+		i += n
+
+		if _, err = dst.ComposerFrom(sources...).Run(ctx); err != nil {
+			return fmt.Errorf("failed to compose parts: %w", err)
+		}
+	}
+
+	return c.deleteMultipartUpload(ctx, bkt, uploadID)
+}
+
+func (c *GCSStore) AbortMultipartUpload(ctx context.Context, filePath string, uploadID string) error {
+	bkt, err := c.findMultipartUpload(ctx, filePath, uploadID)
+	if err != nil {
+		return err
+	}
+
+	return c.deleteMultipartUpload(ctx, bkt, uploadID)
+}
+
+func (c *GCSStore) AbortStaleMultipartUploads(ctx context.Context, olderThan time.Time) (int, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	bkt := gcsClient.Bucket(c.config.Bucket)
+
+	// an upload is updated whenever a part is uploaded, so the latest object defines its last update.
+	lastUpdates := make(map[string]time.Time)
+	err = listGCSObjects(ctx, bkt, multipartDir+"/", func(attrs *storage.ObjectAttrs) {
+		uploadID, _, ok := strings.Cut(strings.TrimPrefix(attrs.Name, multipartDir+"/"), "/")
+		if ok && attrs.Updated.After(lastUpdates[uploadID]) {
+			lastUpdates[uploadID] = attrs.Updated
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for uploadID, updated := range lastUpdates {
+		if !updated.Before(olderThan) {
+			continue
+		}
+
+		if err = c.deleteMultipartUpload(ctx, bkt, uploadID); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// findMultipartUpload returns the bucket of the multipart upload,
+// or ErrNotFound if the upload doesn't exist or was created for a different file.
+func (c *GCSStore) findMultipartUpload(
+	ctx context.Context,
+	filePath string,
+	uploadID string,
+) (*storage.BucketHandle, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	bkt := gcsClient.Bucket(c.config.Bucket)
+
+	rc, err := bkt.Object(gcsMultipartTargetObject(uploadID)).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read multipart upload target: %w", err)
+	}
+	defer rc.Close()
+
+	target, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read multipart upload target: %w", err)
+	}
+
+	if string(target) != filePath {
+		return nil, ErrNotFound
+	}
+
+	return bkt, nil
+}
+
+func (c *GCSStore) deleteMultipartUpload(ctx context.Context, bkt *storage.BucketHandle, uploadID string) error {
+	var names []string
+	err := listGCSObjects(ctx, bkt, gcsMultipartPrefix(uploadID), func(attrs *storage.ObjectAttrs) {
+		names = append(names, attrs.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		err = bkt.Object(name).Delete(ctx)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("failed to delete multipart upload object %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func listGCSObjects(
+	ctx context.Context,
+	bkt *storage.BucketHandle,
+	prefix string,
+	fn func(attrs *storage.ObjectAttrs),
+) error {
+	it := bkt.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list objects with prefix %s: %w", prefix, err)
+		}
+
+		fn(attrs)
+	}
+}
+
+func gcsMultipartPrefix(uploadID string) string {
+	return path.Join(multipartDir, uploadID) + "/"
+}
+
+func gcsMultipartTargetObject(uploadID string) string {
+	return gcsMultipartPrefix(uploadID) + multipartTargetFile
+}
+
+func gcsPartObject(uploadID string, partNumber int) string {
+	return gcsMultipartPrefix(uploadID) + partFileName(partNumber)
+}
+
+func gcsFileInfo(attrs *storage.ObjectAttrs) *FileInfo {
+	contentType := attrs.ContentType
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	return &FileInfo{
+		Path:        attrs.Name,
+		Size:        attrs.Size,
+		ContentType: contentType,
+		ModTime:     attrs.Updated,
+	}
+}
@@ -17,14 +17,39 @@ package blob
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"time"
 )
 
 var (
 	ErrNotFound     = errors.New("resource not found")
 	ErrNotSupported = errors.New("not supported")
+	ErrPartTooLarge = fmt.Errorf("part is larger than %d bytes", MaxBufferedPartSize)
 )
 
+const (
+	// MinPartNumber and MaxPartNumber are the limits of part numbers of multipart uploads.
+	MinPartNumber = 1
+	MaxPartNumber = 10000
+
+	// MaxBufferedPartSize is the maximum size of parts that have to be buffered in memory
+	// (e.g. parts that aren't seekable uploaded to S3).
+	MaxBufferedPartSize = 64 << 20 // 64 MiB
+
+	// multipartDir is the directory (or prefix) used by providers to stage parts of multipart uploads.
+	multipartDir = ".multipart"
+
+	defaultContentType = "application/octet-stream"
+)
+
+func checkPartNumber(partNumber int) error {
+	if partNumber < MinPartNumber || partNumber > MaxPartNumber {
+		return fmt.Errorf("part number %d is out of range [%d, %d]", partNumber, MinPartNumber, MaxPartNumber)
+	}
+	return nil
+}
+
 type Store interface {
 	// Upload uploads a file to the blob store.
 	Upload(ctx context.Context, file io.Reader, filePath string) error
@@ -34,4 +59,41 @@ type Store interface {
 
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Delete removes a file from the blob store. Deleting a file that doesn't exist isn't an error.
+	Delete(ctx context.Context, filePath string) error
+
+	// Stat returns the metadata of a file in the blob store, or ErrNotFound if the file doesn't exist.
+	Stat(ctx context.Context, filePath string) (*FileInfo, error)
+
+	// List returns the metadata of all files in the blob store with a path starting with the prefix.
+	List(ctx context.Context, prefix string) ([]*FileInfo, error)
+
+	// CreateMultipartUpload starts a multipart upload of a file and returns the ID of the upload.
+	// The file becomes visible only once the upload is completed.
+	CreateMultipartUpload(ctx context.Context, filePath string) (string, error)
+
+	// UploadPart uploads a part of a multipart upload. Part numbers start at 1 and define the order of the parts.
+	// Uploading a part number again replaces the part, which allows resuming interrupted uploads.
+	// All parts except the last one have to be at least 5 MiB large, as required by S3.
+	// Parts that aren't seekable can be at most MaxBufferedPartSize large, otherwise ErrPartTooLarge is returned.
+	UploadPart(ctx context.Context, filePath string, uploadID string, partNumber int, part io.Reader) error
+
+	// CompleteMultipartUpload assembles all uploaded parts into the file.
+	CompleteMultipartUpload(ctx context.Context, filePath string, uploadID string) error
+
+	// AbortMultipartUpload discards a multipart upload and all of its uploaded parts.
+	AbortMultipartUpload(ctx context.Context, filePath string, uploadID string) error
+
+	// AbortStaleMultipartUploads discards all multipart uploads that weren't updated since the provided time
+	// (for S3, that were started before the provided time) and returns the number of discarded uploads.
+	AbortStaleMultipartUploads(ctx context.Context, olderThan time.Time) (int, error)
+}
+
+// FileInfo contains the metadata of a file in the blob store.
+type FileInfo struct {
+	Path        string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
 }
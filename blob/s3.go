@@ -0,0 +1,350 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const (
+	defaultS3Region       = "us-east-1"
+	s3SignedURLExpiration = 1 * time.Hour
+)
+
+// S3Store is a blob store backed by AWS S3 or any S3 compatible storage like MinIO.
+type S3Store struct {
+	config   Config
+	client   *s3.S3
+	uploader *s3manager.Uploader
+
+	maxBufferedPartSize int64
+}
+
+func NewS3Store(cfg Config) (Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("bucket is required for the s3 blob store")
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	awsConfig := &aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(cfg.PathStyle),
+	}
+
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+		awsConfig.DisableSSL = aws.Bool(!strings.HasPrefix(cfg.Endpoint, "https://"))
+	}
+
+	// Without static credentials the default credential chain (environment, shared config, instance role) is used.
+	if cfg.AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+
+	return &S3Store{
+		config:   cfg,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+
+		maxBufferedPartSize: MaxBufferedPartSize,
+	}, nil
+}
+
+func (c *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	_, err := c.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file to S3: %w", err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	req, _ := c.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+
+	signedURL, err := req.Presign(s3SignedURLExpiration)
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL for file: %s %w", filePath, err)
+	}
+	return signedURL, nil
+}
+
+func (c *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if isS3NotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file from S3: %w", err)
+	}
+	return out.Body, nil
+}
+
+func isS3NotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey
+}
+
+func (c *S3Store) Delete(ctx context.Context, filePath string) error {
+	_, err := c.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil && !isS3NotFound(err) {
+		return fmt.Errorf("failed to delete file from S3: %w", err)
+	}
+	return nil
+}
+
+func (c *S3Store) Stat(ctx context.Context, filePath string) (*FileInfo, error) {
+	out, err := c.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if isS3NotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata of file from S3: %w", err)
+	}
+
+	contentType := aws.StringValue(out.ContentType)
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	return &FileInfo{
+		Path:        filePath,
+		Size:        aws.Int64Value(out.ContentLength),
+		ContentType: contentType,
+		ModTime:     aws.TimeValue(out.LastModified),
+	}, nil
+}
+
+// List returns the files with the prefix. Listing objects in S3 doesn't return their content type,
+// so the content type of the returned files isn't set.
+func (c *S3Store) List(ctx context.Context, prefix string) ([]*FileInfo, error) {
+	var files []*FileInfo
+	err := c.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.config.Bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			files = append(files, &FileInfo{
+				Path:    aws.StringValue(obj.Key),
+				Size:    aws.Int64Value(obj.Size),
+				ModTime: aws.TimeValue(obj.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in S3: %w", err)
+	}
+
+	return files, nil
+}
+
+func (c *S3Store) CreateMultipartUpload(ctx context.Context, filePath string) (string, error) {
+	out, err := c.client.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload in S3: %w", err)
+	}
+
+	return aws.StringValue(out.UploadId), nil
+}
+
+func (c *S3Store) UploadPart(
+	ctx context.Context,
+	filePath string,
+	uploadID string,
+	partNumber int,
+	part io.Reader,
+) error {
+	if err := checkPartNumber(partNumber); err != nil {
+		return err
+	}
+
+	// the request has to be signed including the content, which requires a seekable body.
+	// Other parts are buffered in memory, so their size is limited.
+	body, ok := part.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(io.LimitReader(part, c.maxBufferedPartSize+1))
+		if err != nil {
+			return fmt.Errorf("failed to read part: %w", err)
+		}
+		if int64(len(data)) > c.maxBufferedPartSize {
+			return ErrPartTooLarge
+		}
+		body = bytes.NewReader(data)
+	}
+
+	_, err := c.client.UploadPartWithContext(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(c.config.Bucket),
+		Key:        aws.String(filePath),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int64(int64(partNumber)),
+		Body:       body,
+	})
+	if isS3NoSuchUpload(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload part to S3: %w", err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) CompleteMultipartUpload(ctx context.Context, filePath string, uploadID string) error {
+	// the parts are listed from S3 to not require clients to keep track of the ETags of the parts,
+	// which allows completing uploads that were resumed by a different client.
+	var parts []*s3.CompletedPart
+	err := c.client.ListPartsPagesWithContext(ctx, &s3.ListPartsInput{
+		Bucket:   aws.String(c.config.Bucket),
+		Key:      aws.String(filePath),
+		UploadId: aws.String(uploadID),
+	}, func(page *s3.ListPartsOutput, _ bool) bool {
+		for _, part := range page.Parts {
+			parts = append(parts, &s3.CompletedPart{
+				ETag:       part.ETag,
+				PartNumber: part.PartNumber,
+			})
+		}
+		return true
+	})
+	if isS3NoSuchUpload(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list parts of multipart upload in S3: %w", err)
+	}
+
+	if len(parts) == 0 {
+		return errors.New("multipart upload doesn't contain any parts")
+	}
+
+	_, err = c.client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(c.config.Bucket),
+		Key:             aws.String(filePath),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	if isS3NoSuchUpload(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload in S3: %w", err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) AbortMultipartUpload(ctx context.Context, filePath string, uploadID string) error {
+	_, err := c.client.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(c.config.Bucket),
+		Key:      aws.String(filePath),
+		UploadId: aws.String(uploadID),
+	})
+	if isS3NoSuchUpload(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to abort multipart upload in S3: %w", err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) AbortStaleMultipartUploads(ctx context.Context, olderThan time.Time) (int, error) {
+	var stale []*s3.MultipartUpload
+	err := c.client.ListMultipartUploadsPagesWithContext(ctx, &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(c.config.Bucket),
+	}, func(page *s3.ListMultipartUploadsOutput, _ bool) bool {
+		for _, upload := range page.Uploads {
+			if aws.TimeValue(upload.Initiated).Before(olderThan) {
+				stale = append(stale, upload)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list multipart uploads in S3: %w", err)
+	}
+
+	var count int
+	for _, upload := range stale {
+		err = c.AbortMultipartUpload(ctx, aws.StringValue(upload.Key), aws.StringValue(upload.UploadId))
+		if errors.Is(err, ErrNotFound) {
+			// completed or aborted in the meantime
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func isS3NoSuchUpload(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchUpload
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a minimal in-memory S3 server supporting path-style uploads, downloads and deletion of objects.
+type fakeS3 struct {
+	mx      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.objects[r.URL.Path] = data
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w,
+				`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code></Error>`)
+			return
+		}
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Store(Config{
+		Provider:        ProviderS3,
+		Bucket:          "gitness",
+		Endpoint:        server.URL,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		PathStyle:       true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %s", err)
+	}
+
+	ctx := context.Background()
+
+	if err = store.Upload(ctx, strings.NewReader("hello"), "dir/file.txt"); err != nil {
+		t.Fatalf("failed to upload: %s", err)
+	}
+
+	if _, ok := fake.objects["/gitness/dir/file.txt"]; !ok {
+		t.Fatalf("expected path-style object key, got objects: %v", fake.objects)
+	}
+
+	rc, err := store.Download(ctx, "dir/file.txt")
+	if err != nil {
+		t.Fatalf("failed to download: %s", err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("failed to read: %s", err)
+	}
+	if string(data) != "hello" {
+		t.Errorf("want=hello got=%s", data)
+	}
+
+	if _, err = store.Download(ctx, "missing.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	info, err := store.Stat(ctx, "dir/file.txt")
+	if err != nil {
+		t.Fatalf("failed to stat: %s", err)
+	}
+	if info.Size != 5 || info.ContentType != "text/plain" || info.ModTime.IsZero() {
+		t.Errorf("unexpected file info: %+v", info)
+	}
+
+	if _, err = store.Stat(ctx, "missing.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	signedURL, err := store.GetSignedURL(ctx, "dir/file.txt")
+	if err != nil {
+		t.Fatalf("failed to get signed URL: %s", err)
+	}
+
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("failed to parse signed URL: %s", err)
+	}
+	if u.Path != "/gitness/dir/file.txt" || u.Query().Get("X-Amz-Signature") == "" {
+		t.Errorf("unexpected signed URL: %s", signedURL)
+	}
+
+	if err = store.Delete(ctx, "dir/file.txt"); err != nil {
+		t.Fatalf("failed to delete: %s", err)
+	}
+	if _, ok := fake.objects["/gitness/dir/file.txt"]; ok {
+		t.Errorf("expected object to be deleted")
+	}
+}
+
+func TestS3Store_UploadPartTooLarge(t *testing.T) {
+	store, err := NewS3Store(Config{
+		Provider:        ProviderS3,
+		Bucket:          "gitness",
+		Endpoint:        "http://localhost",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		PathStyle:       true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %s", err)
+	}
+
+	s3Store, _ := store.(*S3Store)
+	s3Store.maxBufferedPartSize = 4
+
+	// parts that aren't seekable are buffered, which is rejected before anything is sent.
+	part := io.MultiReader(strings.NewReader("too large"))
+	err = store.UploadPart(context.Background(), "file.bin", "upload", 1, part)
+	if !errors.Is(err, ErrPartTooLarge) {
+		t.Errorf("expected ErrPartTooLarge, got: %v", err)
+	}
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
@@ -44,10 +46,11 @@ import (
 )
 
 const (
-	schemeHTTP     = "http"
-	schemeHTTPS    = "https"
-	gitnessHomeDir = ".gitness"
-	blobDir        = "blob"
+	schemeHTTP            = "http"
+	schemeHTTPS           = "https"
+	gitnessHomeDir        = ".gitness"
+	blobDir               = "blob"
+	keywordSearchIndexDir = "keywordsearch"
 )
 
 // LoadConfig returns the system configuration from the
@@ -257,6 +260,11 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 		KeyPath:               config.BlobStore.KeyPath,
 		TargetPrincipal:       config.BlobStore.TargetPrincipal,
 		ImpersonationLifetime: config.BlobStore.ImpersonationLifetime,
+		Endpoint:              config.BlobStore.Endpoint,
+		Region:                config.BlobStore.Region,
+		AccessKeyID:           config.BlobStore.AccessKeyID,
+		SecretAccessKey:       config.BlobStore.SecretAccessKey,
+		PathStyle:             config.BlobStore.PathStyle,
 	}, nil
 }
 
@@ -294,6 +302,12 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		MaxRetries:          config.Webhook.MaxRetries,
 		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 		AllowLoopback:       config.Webhook.AllowLoopback,
+
+		RedeliveryMaxAttempts:   config.Webhook.RedeliveryMaxAttempts,
+		RedeliveryBackoff:       config.Webhook.RedeliveryBackoff,
+		RedeliveryBackoffMax:    config.Webhook.RedeliveryBackoffMax,
+		CircuitBreakerThreshold: config.Webhook.CircuitBreakerThreshold,
+		AutoDisableAfter:        config.Webhook.AutoDisableAfter,
 	}
 }
 
@@ -345,6 +359,8 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		AuditEventsRetentionTime:         config.Audit.RetentionTime,
+		OrphanedUploadsRetentionTime:     config.BlobStore.OrphanRetentionTime,
 	}
 }
 
@@ -355,12 +371,36 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 	}
 }
 
+// ProvidePullReqTemplateConfig loads the pull request template config from the main config.
+func ProvidePullReqTemplateConfig(config *types.Config) pullreqtemplate.Config {
+	return pullreqtemplate.Config{
+		FilePaths: config.PullReqTemplates.FilePaths,
+		DirPaths:  config.PullReqTemplates.DirPaths,
+	}
+}
+
+// ProvideMergeQueueConfig loads the merge queue config from the main config.
+func ProvideMergeQueueConfig(config *types.Config) mergequeue.Config {
+	return mergequeue.Config{
+		ChecksTimeout: config.MergeQueue.ChecksTimeout,
+	}
+}
+
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
 func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
+	indexRoot := config.KeywordSearch.IndexRoot
+	if indexRoot == "" {
+		indexRoot = filepath.Join(config.Git.Root, keywordSearchIndexDir)
+	}
+
 	return keywordsearch.Config{
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.KeywordSearch.Concurrency,
 		MaxRetries:      config.KeywordSearch.MaxRetries,
+		IndexRoot:       indexRoot,
+		MaxFileSize:     config.KeywordSearch.MaxFileSize,
+
+		MaxCachedIndexes: config.KeywordSearch.MaxCachedIndexes,
 	}
 }
 
@@ -105,6 +105,16 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.Trigger.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register scheduled trigger job")
+			return err
+		}
+
+		if err := system.services.MergeQueue.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register merge queue job")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -37,9 +37,12 @@ import (
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -58,7 +61,9 @@ import (
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
@@ -66,7 +71,10 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
+	"github.com/harness/gitness/app/services/pushpolicy"
 	reposervice "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -131,8 +139,11 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		authn.WireSet,
 		authz.WireSet,
 		gitevents.WireSet,
+		checkevents.WireSet,
+		pipelineevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		webhookevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
 		cliserver.ProvideGitConfig,
@@ -158,6 +169,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		job.WireSet,
 		cliserver.ProvideCleanupConfig,
 		cleanup.WireSet,
+		mergequeue.WireSet,
 		codecomments.WireSet,
 		protection.WireSet,
 		checkcontroller.WireSet,
@@ -186,12 +198,18 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		metric.WireSet,
 		reposervice.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
+		cliserver.ProvidePullReqTemplateConfig,
+		cliserver.ProvideMergeQueueConfig,
 		codeowners.WireSet,
+		pullreqtemplate.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
 		controllerkeywordsearch.WireSet,
 		settings.WireSet,
+		pushpolicy.WireSet,
 		usergroup.WireSet,
+		rules.WireSet,
+		label.WireSet,
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
 		audit.WireSet,
@@ -108,12 +108,13 @@ func (g *Git) GetLatestCommit(
 	}
 	treePath = cleanTreePath(treePath)
 
-	return getCommit(ctx, repoPath, rev, treePath)
+	return getCommit(ctx, repoPath, nil, rev, treePath)
 }
 
 func getCommits(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	commitIDs []string,
 ) ([]*Commit, error) {
 	if len(commitIDs) == 0 {
@@ -121,7 +122,7 @@ func getCommits(
 	}
 	commits := make([]*Commit, 0, len(commitIDs))
 	for _, commitID := range commitIDs {
-		commit, err := getCommit(ctx, repoPath, commitID, "")
+		commit, err := getCommit(ctx, repoPath, alternateObjectDirs, commitID, "")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get commit '%s': %w", commitID, err)
 		}
@@ -209,6 +210,7 @@ func (g *Git) ListCommitSHAs(
 func (g *Git) ListCommits(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	ref string,
 	page int,
 	limit int,
@@ -219,19 +221,19 @@ func (g *Git) ListCommits(
 		return nil, nil, ErrRepositoryPathEmpty
 	}
 
-	commitSHAs, err := g.listCommitSHAs(ctx, repoPath, nil, ref, page, limit, filter)
+	commitSHAs, err := g.listCommitSHAs(ctx, repoPath, alternateObjectDirs, ref, page, limit, filter)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	commits, err := getCommits(ctx, repoPath, commitSHAs)
+	commits, err := getCommits(ctx, repoPath, alternateObjectDirs, commitSHAs)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	if includeStats {
 		for _, commit := range commits {
-			fileStats, err := getCommitFileStats(ctx, repoPath, commit.SHA)
+			fileStats, err := getCommitFileStats(ctx, repoPath, alternateObjectDirs, commit.SHA)
 			if err != nil {
 				return nil, nil, fmt.Errorf("encountered error getting commit file stats: %w", err)
 			}
@@ -254,6 +256,7 @@ func (g *Git) ListCommits(
 func getCommitFileStats(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	sha sha.SHA,
 ) ([]CommitFileStats, error) {
 	g, ctx := errgroup.WithContext(ctx)
@@ -262,13 +265,13 @@ func getCommitFileStats(
 
 	g.Go(func() error {
 		var err error
-		changeInfoChanges, err = getChangeInfoChanges(ctx, repoPath, sha)
+		changeInfoChanges, err = getChangeInfoChanges(ctx, repoPath, alternateObjectDirs, sha)
 		return err
 	})
 
 	g.Go(func() error {
 		var err error
-		changeInfoTypes, err = getChangeInfoTypes(ctx, repoPath, sha)
+		changeInfoTypes, err = getChangeInfoTypes(ctx, repoPath, alternateObjectDirs, sha)
 		return err
 	})
 
@@ -357,7 +360,7 @@ func gitGetRenameDetails(
 	sha sha.SHA,
 	path string,
 ) (*PathRenameDetails, error) {
-	changeInfos, err := getChangeInfoTypes(ctx, repoPath, sha)
+	changeInfos, err := getChangeInfoTypes(ctx, repoPath, nil, sha)
 	if err != nil {
 		return &PathRenameDetails{}, fmt.Errorf("failed to get change infos %w", err)
 	}
@@ -374,12 +377,18 @@ func gitGetRenameDetails(
 	return &PathRenameDetails{}, nil
 }
 
-func gitLogNameStatus(ctx context.Context, repoPath string, sha sha.SHA) ([]string, error) {
+func gitLogNameStatus(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	sha sha.SHA,
+) ([]string, error) {
 	cmd := command.New("log",
 		command.WithFlag("--name-status"),
 		command.WithFlag("--format="), //nolint:goconst
 		command.WithFlag("--max-count=1"),
 		command.WithArg(sha.String()),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
 	)
 	output := &bytes.Buffer{}
 	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
@@ -392,12 +401,14 @@ func gitLogNameStatus(ctx context.Context, repoPath string, sha sha.SHA) ([]stri
 func gitShowNumstat(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	sha sha.SHA,
 ) ([]string, error) {
 	cmd := command.New("show",
 		command.WithFlag("--numstat"),
 		command.WithFlag("--format="), //nolint:goconst
 		command.WithArg(sha.String()),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
 	)
 	output := &bytes.Buffer{}
 	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
@@ -414,9 +425,10 @@ var renameRegex = regexp.MustCompile(`\t(.+)\t(.+)`)
 func getChangeInfoTypes(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	sha sha.SHA,
 ) (map[string]changeInfoType, error) {
-	lines, err := gitLogNameStatus(ctx, repoPath, sha)
+	lines, err := gitLogNameStatus(ctx, repoPath, alternateObjectDirs, sha)
 	if err != nil {
 		return nil, err
 	}
@@ -455,9 +467,10 @@ var renameRegexWithArrow = regexp.MustCompile(`(?:\d+|-)\t(?:\d+|-)\t.+\s=>\s(.+
 func getChangeInfoChanges(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	sha sha.SHA,
 ) (map[string]changeInfoChange, error) {
-	lines, err := gitShowNumstat(ctx, repoPath, sha)
+	lines, err := gitShowNumstat(ctx, repoPath, alternateObjectDirs, sha)
 	if err != nil {
 		return nil, err
 	}
@@ -538,7 +551,46 @@ func (g *Git) GetCommit(
 		return nil, ErrRepositoryPathEmpty
 	}
 
-	return getCommit(ctx, repoPath, rev, "")
+	return getCommit(ctx, repoPath, nil, rev, "")
+}
+
+// LoadCommitSignatures reads the raw commit objects to populate the signatures of the provided commits.
+// It requires an additional cat-file batch, so it should be used only when the signatures are needed.
+func (g *Git) LoadCommitSignatures(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	commits []*Commit,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	if len(commits) == 0 {
+		return nil
+	}
+
+	writer, reader, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs)
+	defer func() {
+		cancel()
+		_ = writer.Close()
+	}()
+
+	for _, commit := range commits {
+		rev := commit.SHA.String()
+		if _, err := writer.Write([]byte(rev + "\n")); err != nil {
+			return fmt.Errorf("failed to request commit object %s: %w", rev, err)
+		}
+
+		rawCommit, err := getCommitFromBatchReader(ctx, repoPath, reader, rev)
+		if err != nil {
+			return fmt.Errorf("failed to read commit object %s: %w", rev, err)
+		}
+
+		commit.Signature = rawCommit.Signature
+	}
+
+	return nil
 }
 
 func (g *Git) GetFullCommitID(
@@ -574,7 +626,7 @@ func (g *Git) GetCommits(
 		return nil, ErrRepositoryPathEmpty
 	}
 
-	return getCommits(ctx, repoPath, refs)
+	return getCommits(ctx, repoPath, nil, refs)
 }
 
 // GetCommitDivergences returns the count of the diverging commits for all branch pairs.
@@ -683,6 +735,7 @@ func parseLinesToSlice(output []byte) []string {
 func getCommit(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	rev string,
 	path string,
 ) (*Commit, error) {
@@ -702,6 +755,7 @@ func getCommit(
 		command.WithFlag("--max-count", "1"),
 		command.WithFlag("--format="+format), //nolint:goconst
 		command.WithArg(rev),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
 	)
 	if path != "" {
 		cmd.Add(command.WithPostSepArg(path))
@@ -888,7 +942,7 @@ readLoop:
 		if !message {
 			// This is probably not correct but is copied from go-gits interpretation...
 			trimmed := bytes.TrimSpace(line)
-			if len(trimmed) == 0 {
+			if len(trimmed) == 0 && (len(line) == 0 || line[0] != ' ') {
 				message = true
 				_, _ = payloadSB.Write(line)
 				continue
@@ -918,10 +972,13 @@ readLoop:
 					return nil, fmt.Errorf("failed to parse committer signature: %w", err)
 				}
 				_, _ = payloadSB.Write(line)
-			case "gpgsig":
+			case "gpgsig", "gpgsig-sha256":
 				_, _ = signatureSB.Write(data)
 				_ = signatureSB.WriteByte('\n')
 				pgpsig = true
+			default:
+				// all other headers (e.g. encoding, mergetag) are part of the signed payload.
+				_, _ = payloadSB.Write(line)
 			}
 		} else {
 			_, _ = messageSB.Write(line)
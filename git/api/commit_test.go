@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+)
+
+func TestCommitFromReader_Signature(t *testing.T) {
+	const signedCommit = "" +
+		"tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"parent 16f267ad4f731af1b2e36f42e170ed8921377398\n" +
+		"author Marko <marko.gacesa@harness.io> 1669812989 +0100\n" +
+		"committer Committer <noreply@harness.io> 1669812989 +0100\n" +
+		"encoding ISO-8859-1\n" +
+		"gpgsig -----BEGIN SSH SIGNATURE-----\n" +
+		" U1NIU0lHAAAAAQ==\n" +
+		" \n" +
+		" -----END SSH SIGNATURE-----\n" +
+		"\n" +
+		"Title\n" +
+		"\n" +
+		"Body\n"
+
+	const wantPayload = "" +
+		"tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"parent 16f267ad4f731af1b2e36f42e170ed8921377398\n" +
+		"author Marko <marko.gacesa@harness.io> 1669812989 +0100\n" +
+		"committer Committer <noreply@harness.io> 1669812989 +0100\n" +
+		"encoding ISO-8859-1\n" +
+		"\n" +
+		"Title\n" +
+		"\n" +
+		"Body\n"
+
+	const wantSignature = "" +
+		"-----BEGIN SSH SIGNATURE-----\n" +
+		"U1NIU0lHAAAAAQ==\n" +
+		"\n" +
+		"-----END SSH SIGNATURE-----\n"
+
+	commit, err := CommitFromReader(sha.Must("dcb4b6b63e86f06ed4e4c52fbc825545dc0b6200"),
+		strings.NewReader(signedCommit))
+	if err != nil {
+		t.Fatalf("failed to read commit: %s", err.Error())
+	}
+
+	if commit.Signature == nil {
+		t.Fatal("expected commit signature")
+	}
+
+	if commit.Signature.Signature != wantSignature {
+		t.Errorf("signature mismatch: want=%q got=%q", wantSignature, commit.Signature.Signature)
+	}
+
+	if commit.Signature.Payload != wantPayload {
+		t.Errorf("payload mismatch: want=%q got=%q", wantPayload, commit.Signature.Payload)
+	}
+
+	if want, got := "Title\n\nBody\n", commit.Message; want != got {
+		t.Errorf("message mismatch: want=%q got=%q", want, got)
+	}
+}
+
+func TestCommitFromReader_Unsigned(t *testing.T) {
+	const unsignedCommit = "" +
+		"tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Marko <marko.gacesa@harness.io> 1669812989 +0100\n" +
+		"committer Committer <noreply@harness.io> 1669812989 +0100\n" +
+		"\n" +
+		"Title\n"
+
+	commit, err := CommitFromReader(sha.Must("dcb4b6b63e86f06ed4e4c52fbc825545dc0b6200"),
+		strings.NewReader(unsignedCommit))
+	if err != nil {
+		t.Fatalf("failed to read commit: %s", err.Error())
+	}
+
+	if commit.Signature != nil {
+		t.Errorf("expected no signature, got %+v", commit.Signature)
+	}
+}
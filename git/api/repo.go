@@ -19,12 +19,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
 )
@@ -263,6 +265,103 @@ func (g *Git) Sync(
 	return nil
 }
 
+// FetchObjects fetches the provided objects (and everything reachable from them) from the source repository.
+// No references are created or updated.
+// NOTE: Fetching objects by SHA relies on protocol version 2 which allows any object to be requested.
+func (g *Git) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []sha.SHA,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+
+	cmd := command.New("fetch",
+		command.WithConfig("protocol.version", "2"),
+		command.WithConfig("credential.helper", ""),
+		command.WithFlag(
+			"--quiet",
+			"--no-tags",
+			"--no-write-fetch-head",
+			"--no-show-forced-updates",
+		),
+		command.WithArg(source),
+	)
+	for _, objectSHA := range objectSHAs {
+		cmd.Add(command.WithArg(objectSHA.String()))
+	}
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
+// SetAlternates configures the repository to borrow objects from the object directories of the provided repositories.
+func (g *Git) SetAlternates(
+	repoPath string,
+	alternateRepoPaths ...string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	alternatesFilePath := filepath.Join(repoPath, "objects", "info", "alternates")
+
+	if len(alternateRepoPaths) == 0 {
+		if err := os.Remove(alternatesFilePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove alternates file: %w", err)
+		}
+		return nil
+	}
+
+	var data strings.Builder
+	for _, alternateRepoPath := range alternateRepoPaths {
+		data.WriteString(filepath.Join(alternateRepoPath, "objects"))
+		data.WriteString("\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(alternatesFilePath), 0o700); err != nil {
+		return fmt.Errorf("failed to create alternates directory: %w", err)
+	}
+
+	if err := os.WriteFile(alternatesFilePath, []byte(data.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write alternates file: %w", err)
+	}
+
+	return nil
+}
+
+// Dissociate copies all objects the repository borrows from its alternates into the repository
+// and removes the alternates afterwards. After that the repository doesn't depend on any other repository.
+func (g *Git) Dissociate(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	// without the --local flag repack includes objects borrowed from alternates.
+	cmd := command.New("repack",
+		command.WithFlag("-a", "-d", "-q"),
+	)
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to repack repository")
+	}
+
+	return g.SetAlternates(repoPath)
+}
+
 func (g *Git) AddFiles(
 	ctx context.Context,
 	repoPath string,
@@ -44,6 +44,9 @@ func (g *Git) InfoRefs(
 		command.WithFlag("--advertise-refs"),
 		command.WithArg("."),
 	)
+	if service == string(enum.GitServiceTypeReceivePack) {
+		cmd.Add(advertisePushOptions())
+	}
 	if err := cmd.Run(ctx,
 		command.WithDir(repoPath),
 		command.WithStdout(stdout),
@@ -90,6 +93,10 @@ func (g *Git) ServicePack(
 		cmd.Add(command.WithFlag("--stateless-rpc"))
 	}
 
+	if options.Service == enum.GitServiceTypeReceivePack {
+		cmd.Add(advertisePushOptions())
+	}
+
 	if options.Protocol != "" && safeGitProtocolHeader.MatchString(options.Protocol) {
 		cmd.Add(command.WithEnv("GIT_PROTOCOL", options.Protocol))
 	}
@@ -107,6 +114,12 @@ func (g *Git) ServicePack(
 	return err
 }
 
+// advertisePushOptions enables push options, which are used to provide additional information
+// with a push, like the justification for bypassing protection rules.
+func advertisePushOptions() command.CmdOptionFunc {
+	return command.WithConfig("receive.advertisePushOptions", "true")
+}
+
 func packetWrite(str string) []byte {
 	s := strconv.FormatInt(int64(len(str)+4), 16)
 	if len(s)%4 != 0 {
@@ -135,6 +135,7 @@ func lsTree(
 	rev string,
 	treePath string,
 	fetchSizes bool,
+	recursive bool,
 ) ([]TreeNode, error) {
 	if repoPath == "" {
 		return nil, ErrRepositoryPathEmpty
@@ -147,6 +148,10 @@ func lsTree(
 	if fetchSizes {
 		cmd.Add(command.WithFlag("-l"))
 	}
+	if recursive {
+		cmd.Add(command.WithFlag("-r"))
+		cmd.Add(command.WithFlag("--full-tree"))
+	}
 
 	output := &bytes.Buffer{}
 	err := cmd.Run(ctx,
@@ -236,7 +241,7 @@ func lsDirectory(
 		treePath += "/"
 	}
 
-	return lsTree(ctx, repoPath, rev, treePath, fetchSizes)
+	return lsTree(ctx, repoPath, rev, treePath, fetchSizes, false)
 }
 
 // lsFile returns one tree node entry.
@@ -249,7 +254,7 @@ func lsFile(
 ) (TreeNode, error) {
 	treePath = cleanTreePath(treePath)
 
-	list, err := lsTree(ctx, repoPath, rev, treePath, fetchSize)
+	list, err := lsTree(ctx, repoPath, rev, treePath, fetchSize, false)
 	if err != nil {
 		return TreeNode{}, fmt.Errorf("failed to ls file: %w", err)
 	}
@@ -323,6 +328,24 @@ func ListTreeNodes(ctx context.Context, repoPath, rev, treePath string, fetchSiz
 	return list, nil
 }
 
+// ListBlobs lists all blobs of the tree reachable from ref recursively, including their sizes.
+func (g *Git) ListBlobs(ctx context.Context, repoPath, rev string) ([]TreeNode, error) {
+	list, err := lsTree(ctx, repoPath, rev+"^{commit}", ".", true, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
+	}
+
+	blobs := make([]TreeNode, 0, len(list))
+	for _, node := range list {
+		if node.NodeType != TreeNodeTypeBlob {
+			continue
+		}
+		blobs = append(blobs, node)
+	}
+
+	return blobs, nil
+}
+
 func (g *Git) ReadTree(
 	ctx context.Context,
 	repoPath string,
@@ -32,6 +32,9 @@ import (
 type GetCommitParams struct {
 	ReadParams
 	Revision string
+
+	// IncludeSignature allows to include the raw signature of the commit, required for its verification.
+	IncludeSignature bool
 }
 
 type Commit struct {
@@ -42,6 +45,13 @@ type Commit struct {
 	Author     Signature         `json:"author"`
 	Committer  Signature         `json:"committer"`
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
+	Signature  *CommitSignature  `json:"signature,omitempty"`
+}
+
+// CommitSignature contains the raw signature of a commit and the payload it signs.
+type CommitSignature struct {
+	Signature string `json:"signature"`
+	Payload   string `json:"payload"`
 }
 
 type GetCommitOutput struct {
@@ -80,6 +90,13 @@ func (s *Service) GetCommit(ctx context.Context, params *GetCommitParams) (*GetC
 		return nil, err
 	}
 
+	if params.IncludeSignature {
+		err = s.git.LoadCommitSignatures(ctx, repoPath, params.AlternateObjectDirs, []*api.Commit{result})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load commit signature: %w", err)
+		}
+	}
+
 	commit, err := mapCommit(result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map rpc commit: %w", err)
@@ -112,6 +129,9 @@ type ListCommitsParams struct {
 
 	// IncludeStats allows to include information about inserted, deletions and status for changed files.
 	IncludeStats bool
+
+	// IncludeSignatures allows to include the raw signatures of the commits, required for their verification.
+	IncludeSignatures bool
 }
 
 type RenameDetails struct {
@@ -145,6 +165,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	gitCommits, renameDetails, err := s.git.ListCommits(
 		ctx,
 		repoPath,
+		params.AlternateObjectDirs,
 		params.GitREF,
 		int(params.Page),
 		int(params.Limit),
@@ -161,6 +182,13 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 		return nil, err
 	}
 
+	if params.IncludeSignatures {
+		err = s.git.LoadCommitSignatures(ctx, repoPath, params.AlternateObjectDirs, gitCommits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load commit signatures: %w", err)
+		}
+	}
+
 	// try to get total commits between gitref and After refs
 	totalCommits := 0
 	if params.Page == 1 && len(gitCommits) < int(params.Limit) {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -55,11 +56,17 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 		return fmt.Errorf("failed to read alternate object dirs from env: %w", err)
 	}
 
+	pushOptions, err := getPushOptionsFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to read push options from env: %w", err)
+	}
+
 	in := PreReceiveInput{
 		RefUpdates: refUpdates,
 		Environment: Environment{
 			AlternateObjectDirs: alternateObjDirs,
 		},
+		PushOptions: pushOptions,
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -181,6 +188,26 @@ func getUpdatedReferencesFromStdIn() ([]ReferenceUpdate, error) {
 	return updatedRefs, nil
 }
 
+// getPushOptionsFromEnv returns the push options provided by the client (git push -o <option>).
+func getPushOptionsFromEnv() ([]string, error) {
+	countRaw, ok := os.LookupEnv("GIT_PUSH_OPTION_COUNT")
+	if !ok || countRaw == "" {
+		return nil, nil
+	}
+
+	count, err := strconv.Atoi(countRaw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid push option count %q: %w", countRaw, err)
+	}
+
+	pushOptions := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		pushOptions = append(pushOptions, os.Getenv("GIT_PUSH_OPTION_"+strconv.Itoa(i)))
+	}
+
+	return pushOptions, nil
+}
+
 // getAlternateObjectDirsFromEnv returns the alternate object directories that have to be used
 // to be able to preemptively access the quarantined objects created by a write operation.
 // NOTE: The temp dir of a write operation is it's main object dir,
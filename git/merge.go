@@ -35,7 +35,7 @@ type MergeParams struct {
 	WriteParams
 	BaseBranch string
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// If it differs from the RepoUID, the head commits are fetched into the repository before merging.
 	HeadRepoUID string
 	HeadBranch  string
 	Title       string
@@ -185,7 +185,12 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
 	}
 
-	headCommitSHA, err := s.git.GetFullCommitID(ctx, repoPath, params.HeadBranch)
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" && params.HeadRepoUID != params.RepoUID {
+		headRepoPath = getFullPathForRepo(s.reposRoot, params.HeadRepoUID)
+	}
+
+	headCommitSHA, err := s.git.GetFullCommitID(ctx, headRepoPath, params.HeadBranch)
 	if err != nil {
 		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
 	}
@@ -198,6 +203,14 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			params.HeadExpectedSHA)
 	}
 
+	if headRepoPath != repoPath {
+		// the head commits must be available in the base repository.
+		err = s.git.FetchObjects(ctx, repoPath, headRepoPath, []sha.SHA{headCommitSHA})
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to fetch head commit from head repository: %w", err)
+		}
+	}
+
 	mergeBaseCommitSHA, _, err := s.git.GetMergeBase(ctx, repoPath, "origin",
 		baseCommitSHA.String(), headCommitSHA.String())
 	if err != nil {
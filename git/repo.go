@@ -27,6 +27,9 @@ import (
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/git/hash"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rs/zerolog/log"
@@ -43,6 +46,10 @@ const (
 	gitReferenceNamePrefixBranch = "refs/heads/"
 	gitReferenceNamePrefixTag    = "refs/tags/"
 
+	// fetchObjectsRef is the reference reported to the pre-receive hook for objects fetched from another repository.
+	// The reference itself is never created.
+	fetchObjectsRef = "refs/gitness/fetch-objects"
+
 	gitHooksDir = "hooks"
 
 	fileMode700 = 0o700
@@ -115,6 +122,60 @@ type SyncRepositoryOutput struct {
 	DefaultBranch string
 }
 
+type ForkRepositoryParams struct {
+	// Fork operation creates a new repository (similar to create), RepoUID is generated if not provided.
+	RepoUID string
+	Actor   Identity
+	EnvVars map[string]string
+
+	// UpstreamRepoUID is the UID of the repository that is forked.
+	UpstreamRepoUID string
+	DefaultBranch   string
+
+	// Branches [OPTIONAL] restricts the branches that are copied from the upstream repository.
+	// By default all branches and tags of the upstream repository are copied.
+	Branches []string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p.UpstreamRepoUID == "" {
+		return errors.InvalidArgument("upstream repository UID is mandatory")
+	}
+	if p.DefaultBranch == "" {
+		return errors.InvalidArgument("default branch is mandatory")
+	}
+	return p.Actor.Validate()
+}
+
+type ForkRepositoryOutput struct {
+	UID string
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+	// SourceRepoUID is the UID of the repository the objects are fetched from.
+	SourceRepoUID string
+	// ObjectSHA is the commit that is fetched, together with all objects reachable from it.
+	ObjectSHA sha.SHA
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository UID is mandatory")
+	}
+	if p.ObjectSHA.IsEmpty() {
+		return errors.InvalidArgument("object SHA is mandatory")
+	}
+	return nil
+}
+
+type DissociateRepositoryParams struct {
+	WriteParams
+}
+
 type HashRepositoryParams struct {
 	ReadParams
 	HashType        hash.Type
@@ -303,6 +364,162 @@ func (s *Service) SyncRepository(
 	}, nil
 }
 
+// ForkRepository creates a new repository that shares the objects of the upstream repository.
+// The fork borrows objects from the upstream repository using git alternates, only new objects are stored in the fork.
+// NOTE: The fork depends on the upstream repository until it's dissociated (see DissociateRepository).
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	upstreamRepoPath := getFullPathForRepo(s.reposRoot, params.UpstreamRepoUID)
+	if _, err := os.Stat(upstreamRepoPath); err != nil && os.IsNotExist(err) {
+		return nil, errors.NotFound("upstream repository not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check the status of the upstream repository %v: %w", upstreamRepoPath, err)
+	}
+
+	if params.RepoUID == "" {
+		uid, err := NewRepositoryUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new uid: %w", err)
+		}
+		params.RepoUID = uid
+	}
+
+	log.Ctx(ctx).Info().
+		Msgf("Fork git repository '%s' into new repository with uid '%s'", params.UpstreamRepoUID, params.RepoUID)
+
+	writeParams := WriteParams{
+		RepoUID: params.RepoUID,
+		Actor:   params.Actor,
+		EnvVars: params.EnvVars,
+	}
+
+	err := s.createRepositoryInternal(
+		ctx,
+		&writeParams,
+		params.DefaultBranch,
+		nil,
+		nil,
+		time.Time{},
+		nil,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			if cleanupErr := s.DeleteRepositoryBestEffort(ctx, params.RepoUID); cleanupErr != nil {
+				log.Ctx(ctx).Warn().Err(cleanupErr).Msg("failed to cleanup fork repo dir")
+			}
+		}
+	}()
+
+	if err = s.git.SetAlternates(repoPath, upstreamRepoPath); err != nil {
+		return nil, fmt.Errorf("failed to set alternates of the fork: %w", err)
+	}
+
+	// The fork keeps referencing objects of the upstream repository that become unreachable there,
+	// e.g. after a branch got deleted. Garbage collection of the upstream must never prune them.
+	if err = s.git.Config(ctx, upstreamRepoPath, "gc.pruneExpire", "never"); err != nil {
+		return nil, fmt.Errorf("failed to disable pruning of the upstream repository: %w", err)
+	}
+
+	refSpecs := []string{
+		"+" + gitReferenceNamePrefixBranch + "*:" + gitReferenceNamePrefixBranch + "*",
+		"+" + gitReferenceNamePrefixTag + "*:" + gitReferenceNamePrefixTag + "*",
+	}
+	if len(params.Branches) > 0 {
+		refSpecs = make([]string, len(params.Branches))
+		for i, branch := range params.Branches {
+			refSpecs[i] = "+" + gitReferenceNamePrefixBranch + branch + ":" + gitReferenceNamePrefixBranch + branch
+		}
+	}
+
+	// all objects are available through the alternates, so the sync only copies the references.
+	if err = s.git.Sync(ctx, repoPath, upstreamRepoPath, refSpecs); err != nil {
+		return nil, fmt.Errorf("failed to copy references from the upstream repository: %w", err)
+	}
+
+	return &ForkRepositoryOutput{
+		UID: params.RepoUID,
+	}, nil
+}
+
+// FetchObjects copies the provided commit (and all objects reachable from it) from the source repository.
+// It's used to make commits of forks available in the upstream repository. No references are updated.
+// The objects are fetched into a quarantine directory first and are moved into the repository
+// only if the pre-receive hook of the repository accepts them.
+func (s *Service) FetchObjects(ctx context.Context, params *FetchObjectsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourceRepoPath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, fetchObjectsRef)
+	if err != nil {
+		return fmt.Errorf("failed to create ref updater: %w", err)
+	}
+
+	if err = refUpdater.Init(ctx, sha.Nil, params.ObjectSHA); err != nil {
+		return fmt.Errorf("failed to init ref updater: %w", err)
+	}
+
+	sharedRepo, err := sharedrepo.NewSharedRepo(s.tmpDir, repoPath)
+	if err != nil {
+		return err
+	}
+
+	defer sharedRepo.Close(ctx)
+
+	// The shared repository borrows the objects of the repository, so only the missing objects are fetched.
+	if err = sharedRepo.Init(ctx); err != nil {
+		return err
+	}
+
+	err = s.git.FetchObjects(ctx, sharedRepo.Directory(), sourceRepoPath, []sha.SHA{params.ObjectSHA})
+	if err != nil {
+		return fmt.Errorf("failed to fetch objects from repository %q: %w", params.SourceRepoUID, err)
+	}
+
+	if err = refUpdater.Pre(ctx, sharedRepo.Directory()+"/objects"); err != nil {
+		return fmt.Errorf("pre-receive hook failed: %w", err)
+	}
+
+	if err = sharedRepo.MoveObjects(ctx); err != nil {
+		return fmt.Errorf("failed to move objects: %w", err)
+	}
+
+	return nil
+}
+
+// DissociateRepository copies all objects the repository borrows from other repositories into the repository.
+// It must be called for all forks of a repository before the repository can be deleted.
+func (s *Service) DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := s.git.Dissociate(ctx, repoPath); err != nil {
+		return fmt.Errorf("failed to dissociate repository: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryParams) (*HashRepositoryOutput, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
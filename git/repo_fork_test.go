@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/types"
+)
+
+const (
+	testUpstreamUID = "upstream0000"
+	testForkUID     = "fork00000000"
+)
+
+var testActor = Identity{Name: "Tester", Email: "tester@example.com"}
+
+// preReceiveClient is a git hook client that accepts or rejects every pre-receive call.
+type preReceiveClient struct {
+	hook.Client
+	reject bool
+	calls  []hook.PreReceiveInput
+}
+
+func (c *preReceiveClient) NewClient(map[string]string) (hook.Client, error) {
+	return c, nil
+}
+
+func (c *preReceiveClient) PreReceive(_ context.Context, in hook.PreReceiveInput) (hook.Output, error) {
+	c.calls = append(c.calls, in)
+	if c.reject {
+		msg := "rejected"
+		return hook.Output{Error: &msg}, nil
+	}
+	return hook.Output{}, nil
+}
+
+func runGit(t *testing.T, dir string, env []string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Tester", "GIT_AUTHOR_EMAIL=tester@example.com",
+		"GIT_COMMITTER_NAME=Tester", "GIT_COMMITTER_EMAIL=tester@example.com",
+		"GIT_CONFIG_NOSYSTEM=1", "HOME="+dir)
+	cmd.Env = append(cmd.Env, env...)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %s: %s", args, err.Error(), out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+func hasObject(repoPath string, objectSHA string) bool {
+	cmd := exec.Command("git", "cat-file", "-e", objectSHA+"^{commit}")
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
+
+// commitAndPush creates a commit on top of the current branch of the work dir and pushes it to the repository.
+func commitAndPush(t *testing.T, workDir, repoPath, branch, file string) string {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(workDir, file), []byte(file), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err.Error())
+	}
+
+	runGit(t, workDir, nil, "checkout", "-q", "-B", branch)
+	runGit(t, workDir, nil, "add", file)
+	runGit(t, workDir, nil, "commit", "-q", "-m", "add "+file)
+	runGit(t, workDir, nil, "push", "-q", repoPath, "HEAD:refs/heads/"+branch)
+
+	return runGit(t, workDir, nil, "rev-parse", "HEAD")
+}
+
+func setupFork(t *testing.T, hooks *preReceiveClient) (*Service, string, string) {
+	t.Helper()
+
+	adapter, err := api.New(types.Config{}, nil, hooks)
+	if err != nil {
+		t.Fatalf("failed to create git adapter: %s", err.Error())
+	}
+
+	s := &Service{
+		reposRoot:         t.TempDir(),
+		tmpDir:            t.TempDir(),
+		git:               adapter,
+		hookClientFactory: hooks,
+		gitHookPath:       "/bin/true",
+	}
+
+	upstreamPath := getFullPathForRepo(s.reposRoot, testUpstreamUID)
+	if err = os.MkdirAll(upstreamPath, 0o700); err != nil {
+		t.Fatalf("failed to create upstream dir: %s", err.Error())
+	}
+	runGit(t, upstreamPath, nil, "init", "-q", "--bare", "-b", "main")
+
+	workDir := t.TempDir()
+	runGit(t, workDir, nil, "init", "-q", "-b", "main")
+	commitAndPush(t, workDir, upstreamPath, "main", "main.txt")
+
+	_, err = s.ForkRepository(context.Background(), &ForkRepositoryParams{
+		RepoUID:         testForkUID,
+		Actor:           testActor,
+		UpstreamRepoUID: testUpstreamUID,
+		DefaultBranch:   "main",
+	})
+	if err != nil {
+		t.Fatalf("failed to fork repository: %s", err.Error())
+	}
+
+	return s, upstreamPath, workDir
+}
+
+// TestForkRepository_UpstreamGC ensures that garbage collection of the upstream repository
+// doesn't prune objects that are still referenced by a fork.
+func TestForkRepository_UpstreamGC(t *testing.T) {
+	s, upstreamPath, workDir := setupFork(t, &preReceiveClient{})
+	forkPath := getFullPathForRepo(s.reposRoot, testForkUID)
+
+	// the feature branch is copied to the fork before it's deleted in the upstream.
+	featureSHA := commitAndPush(t, workDir, upstreamPath, "feature", "feature.txt")
+	runGit(t, forkPath, nil, "fetch", "-q", upstreamPath, "refs/heads/feature:refs/heads/feature")
+
+	runGit(t, upstreamPath, nil, "update-ref", "-d", "refs/heads/feature")
+	runGit(t, upstreamPath, nil, "reflog", "expire", "--expire-unreachable=now", "--all")
+
+	// make the unreachable objects old enough to get pruned by default.
+	past := time.Now().AddDate(-1, 0, 0)
+	err := filepath.Walk(filepath.Join(upstreamPath, "objects"), func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, past, past)
+	})
+	if err != nil {
+		t.Fatalf("failed to change object times: %s", err.Error())
+	}
+
+	runGit(t, upstreamPath, nil, "gc", "-q")
+
+	if !hasObject(forkPath, featureSHA) {
+		t.Errorf("commit %s referenced by the fork got pruned in the upstream repository", featureSHA)
+	}
+}
+
+func TestFetchObjects(t *testing.T) {
+	tests := []struct {
+		name   string
+		reject bool
+	}{
+		{name: "accepted"},
+		{name: "rejected", reject: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hooks := &preReceiveClient{}
+			s, upstreamPath, workDir := setupFork(t, hooks)
+			forkPath := getFullPathForRepo(s.reposRoot, testForkUID)
+
+			forkSHA := commitAndPush(t, workDir, forkPath, "fork-change", "fork.txt")
+			if hasObject(upstreamPath, forkSHA) {
+				t.Fatalf("commit of the fork is expected to be missing in the upstream repository")
+			}
+
+			hooks.reject = test.reject
+
+			err := s.FetchObjects(context.Background(), &FetchObjectsParams{
+				WriteParams:   WriteParams{RepoUID: testUpstreamUID, Actor: testActor},
+				SourceRepoUID: testForkUID,
+				ObjectSHA:     sha.Must(forkSHA),
+			})
+
+			if len(hooks.calls) != 1 {
+				t.Fatalf("expected one pre-receive call, got %d", len(hooks.calls))
+			}
+
+			in := hooks.calls[0]
+			if len(in.RefUpdates) != 1 || in.RefUpdates[0].New.String() != forkSHA {
+				t.Errorf("unexpected ref updates: %+v", in.RefUpdates)
+			}
+			if len(in.Environment.AlternateObjectDirs) != 1 {
+				t.Fatalf("expected the quarantine directory, got: %v", in.Environment.AlternateObjectDirs)
+			}
+
+			if test.reject {
+				if !errors.IsPreconditionFailed(err) {
+					t.Errorf("expected the fetch to be rejected, got: %v", err)
+				}
+				if hasObject(upstreamPath, forkSHA) {
+					t.Errorf("rejected commit %s got moved into the upstream repository", forkSHA)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to fetch objects: %s", err.Error())
+			}
+			if !hasObject(upstreamPath, forkSHA) {
+				t.Errorf("commit %s is missing in the upstream repository", forkSHA)
+			}
+		})
+	}
+}
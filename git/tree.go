@@ -17,6 +17,8 @@ package git
 import (
 	"context"
 	"fmt"
+
+	"github.com/harness/gitness/git/sha"
 )
 
 // TreeNodeType specifies the different types of nodes in a git tree.
@@ -186,6 +188,57 @@ func (s *Service) ListPaths(ctx context.Context, params *ListPathsParams) (*List
 		nil
 }
 
+type ListBlobsParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+}
+
+type ListBlobsOutput struct {
+	Blobs []BlobNode
+}
+
+// BlobNode describes a single file of a git tree.
+type BlobNode struct {
+	Path string
+	Mode TreeNodeMode
+	SHA  sha.SHA
+	Size int64
+}
+
+// ListBlobs lists all files of the tree reachable from the git reference recursively.
+func (s *Service) ListBlobs(ctx context.Context, params *ListBlobsParams) (*ListBlobsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	nodes, err := s.git.ListBlobs(ctx, repoPath, params.GitREF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
+	}
+
+	blobs := make([]BlobNode, len(nodes))
+	for i := range nodes {
+		mode, err := mapTreeNodeMode(nodes[i].Mode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc node mode: %w", err)
+		}
+
+		blobs[i] = BlobNode{
+			Path: nodes[i].Path,
+			Mode: mode,
+			SHA:  nodes[i].SHA,
+			Size: nodes[i].Size,
+		}
+	}
+
+	return &ListBlobsOutput{
+		Blobs: blobs,
+	}, nil
+}
+
 type PathsDetailsParams struct {
 	ReadParams
 	GitREF string
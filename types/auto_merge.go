@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// AutoMerge holds the merge options of a pull request that gets merged automatically
+// as soon as all requirements for merging are satisfied.
+type AutoMerge struct {
+	PullReqID int64 `json:"-"`
+	RepoID    int64 `json:"-"`
+
+	Method             enum.MergeMethod `json:"method"`
+	Title              string           `json:"title,omitempty"`
+	Message            string           `json:"message,omitempty"`
+	DeleteSourceBranch bool             `json:"delete_source_branch"`
+
+	// CreatedBy is the user who enabled auto-merge. The pull request is merged on their behalf.
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
@@ -180,10 +180,10 @@ type Config struct {
 
 	// BlobStore defines the blob storage configuration parameters.
 	BlobStore struct {
-		// Provider is a name of blob storage service like filesystem or gcs
+		// Provider is a name of blob storage service like filesystem, gcs or s3
 		Provider blob.Provider `envconfig:"GITNESS_BLOBSTORE_PROVIDER" default:"filesystem"`
 		// Bucket is a path to the directory where the files will be stored when using filesystem blob storage,
-		// in case of gcs provider this will be the actual bucket where the images are stored.
+		// in case of gcs or s3 provider this will be the actual bucket where the images are stored.
 		Bucket string `envconfig:"GITNESS_BLOBSTORE_BUCKET"`
 
 		// In case of GCS provider, this is expected to be the path to the service account key file.
@@ -193,6 +193,20 @@ type Config struct {
 		TargetPrincipal string `envconfig:"GITNESS_BLOBSTORE_TARGET_PRINCIPAL" default:""`
 
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
+
+		// Endpoint overrides the S3 endpoint, required for S3 compatible storage like MinIO.
+		Endpoint string `envconfig:"GITNESS_BLOBSTORE_ENDPOINT"`
+		// Region is the region of the S3 bucket.
+		Region string `envconfig:"GITNESS_BLOBSTORE_REGION" default:"us-east-1"`
+		// AccessKeyID and SecretAccessKey are the S3 credentials. If not set, the default AWS credential chain is used.
+		AccessKeyID     string `envconfig:"GITNESS_BLOBSTORE_ACCESS_KEY_ID"`
+		SecretAccessKey string `envconfig:"GITNESS_BLOBSTORE_SECRET_ACCESS_KEY"`
+		// PathStyle enables path-style addressing of the S3 bucket, as commonly used by MinIO.
+		PathStyle bool `envconfig:"GITNESS_BLOBSTORE_PATH_STYLE"`
+
+		// OrphanRetentionTime is the time after which uploaded files that aren't referenced
+		// by any pull request description or comment are deleted.
+		OrphanRetentionTime time.Duration `envconfig:"GITNESS_BLOBSTORE_ORPHAN_RETENTION_TIME" default:"72h"`
 	}
 
 	// Token defines token configuration parameters.
@@ -328,6 +342,18 @@ type Config struct {
 		AllowLoopback       bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
+
+		// RedeliveryMaxAttempts is the maximum number of times a failed delivery is redelivered in the background.
+		// The delay between two attempts grows exponentially from RedeliveryBackoff up to RedeliveryBackoffMax.
+		RedeliveryMaxAttempts int           `envconfig:"GITNESS_WEBHOOK_REDELIVERY_MAX_ATTEMPTS" default:"12"`
+		RedeliveryBackoff     time.Duration `envconfig:"GITNESS_WEBHOOK_REDELIVERY_BACKOFF" default:"1m"`
+		RedeliveryBackoffMax  time.Duration `envconfig:"GITNESS_WEBHOOK_REDELIVERY_BACKOFF_MAX" default:"2h"`
+		// CircuitBreakerThreshold is the number of consecutive failed deliveries after which
+		// new deliveries of a webhook are no longer attempted immediately, but only via the redelivery queue.
+		CircuitBreakerThreshold int `envconfig:"GITNESS_WEBHOOK_CIRCUIT_BREAKER_THRESHOLD" default:"5"`
+		// AutoDisableAfter is the duration of continuous failures after which a webhook gets disabled.
+		// NOTE: A value of 0 turns off disabling webhooks automatically.
+		AutoDisableAfter time.Duration `envconfig:"GITNESS_WEBHOOK_AUTO_DISABLE_AFTER" default:"72h"`
 	}
 
 	Trigger struct {
@@ -348,10 +374,42 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	// PushPolicy configures an external policy that is evaluated for every push.
+	// At most one of Executable, URL and OPAURL can be configured.
+	PushPolicy struct {
+		// Executable is the path of a local executable that receives the push as JSON on stdin.
+		Executable string `envconfig:"GITNESS_PUSH_POLICY_EXECUTABLE"`
+		// URL is an HTTP endpoint that receives the push as JSON in the request body.
+		URL string `envconfig:"GITNESS_PUSH_POLICY_URL"`
+		// OPAURL is the base URL of an OPA server that evaluates the Rego policies.
+		// Besides the global decision, the OPA server evaluates the push policies configured for spaces.
+		OPAURL string `envconfig:"GITNESS_PUSH_POLICY_OPA_URL"`
+		// OPADecision is the path of the global OPA decision document (e.g. "gitness/push"), empty to disable it.
+		OPADecision string        `envconfig:"GITNESS_PUSH_POLICY_OPA_DECISION" default:"gitness/push"`
+		Timeout     time.Duration `envconfig:"GITNESS_PUSH_POLICY_TIMEOUT" default:"10s"`
+		// FailOpen allows pushes if the policy can't be evaluated.
+		FailOpen bool `envconfig:"GITNESS_PUSH_POLICY_FAIL_OPEN" default:"false"`
+		// MaxCommits is the maximum number of commits per reference that are sent to the policy.
+		MaxCommits int `envconfig:"GITNESS_PUSH_POLICY_MAX_COMMITS" default:"1000"`
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
 
+	PullReqTemplates struct {
+		// FilePaths are the possible locations of the default pull request template, first found is used.
+		FilePaths []string `envconfig:"GITNESS_PULLREQ_TEMPLATE_FILEPATH" default:".harness/PULL_REQUEST_TEMPLATE.md,PULL_REQUEST_TEMPLATE.md"` //nolint:lll // struct tags can't be multiline
+		// DirPaths are the possible locations of the directory with named pull request templates, first found is used.
+		DirPaths []string `envconfig:"GITNESS_PULLREQ_TEMPLATE_DIRPATH" default:".harness/PULL_REQUEST_TEMPLATE,PULL_REQUEST_TEMPLATE"` //nolint:lll // struct tags can't be multiline
+	}
+
+	MergeQueue struct {
+		// ChecksTimeout is the maximum time the required status checks of a speculative merge commit can be pending.
+		// Pull requests whose checks don't complete in time are removed from the merge queue.
+		ChecksTimeout time.Duration `envconfig:"GITNESS_MERGE_QUEUE_CHECKS_TIMEOUT" default:"2h"`
+	}
+
 	SMTP struct {
 		Host     string `envconfig:"GITNESS_SMTP_HOST"`
 		Port     int    `envconfig:"GITNESS_SMTP_PORT"`
@@ -369,6 +427,11 @@ type Config struct {
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+		// IndexRoot is the directory in which the search indexes are stored (defaults to a subdirectory of git root).
+		IndexRoot   string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_ROOT"`
+		MaxFileSize int64  `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"` // 1MiB
+		// MaxCachedIndexes is the number of search indexes kept in memory.
+		MaxCachedIndexes int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_CACHED_INDEXES" default:"32"`
 	}
 
 	Repos struct {
@@ -376,6 +439,11 @@ type Config struct {
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
 	}
 
+	Audit struct {
+		// RetentionTime is the duration after which audit events will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_AUDIT_RETENTION_TIME" default:"8760h"` // 365 days
+	}
+
 	Docker struct {
 		// Host sets the url to the docker server.
 		Host string `envconfig:"GITNESS_DOCKER_HOST"`
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AutoMergeAction defines what happened with the auto-merge of a pull request.
+type AutoMergeAction string
+
+// AutoMergeAction enumeration.
+const (
+	// AutoMergeActionEnabled means auto-merge was enabled for the pull request.
+	AutoMergeActionEnabled AutoMergeAction = "enabled"
+	// AutoMergeActionDisabled means auto-merge was disabled by a user.
+	AutoMergeActionDisabled AutoMergeAction = "disabled"
+	// AutoMergeActionCanceled means auto-merge was disabled automatically,
+	// for example because new commits were pushed by someone other than the author.
+	AutoMergeActionCanceled AutoMergeAction = "canceled"
+)
+
+func (AutoMergeAction) Enum() []interface{} { return toInterfaceSlice(autoMergeActions) }
+func (a AutoMergeAction) Sanitize() (AutoMergeAction, bool) {
+	return Sanitize(a, GetAllAutoMergeActions)
+}
+
+func GetAllAutoMergeActions() ([]AutoMergeAction, AutoMergeAction) {
+	return autoMergeActions, ""
+}
+
+var autoMergeActions = sortEnum([]AutoMergeAction{
+	AutoMergeActionEnabled,
+	AutoMergeActionDisabled,
+	AutoMergeActionCanceled,
+})
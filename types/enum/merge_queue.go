@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MergeQueueEntryState defines the state of a pull request in the merge queue.
+type MergeQueueEntryState string
+
+// MergeQueueEntryState enumeration.
+const (
+	// MergeQueueEntryStateQueued means the speculative merge commit of the pull request isn't created yet.
+	MergeQueueEntryStateQueued MergeQueueEntryState = "queued"
+	// MergeQueueEntryStateChecking means the speculative merge commit is created
+	// and the required status checks are awaited on it.
+	MergeQueueEntryStateChecking MergeQueueEntryState = "checking"
+)
+
+func (MergeQueueEntryState) Enum() []interface{} { return toInterfaceSlice(mergeQueueEntryStates) }
+func (s MergeQueueEntryState) Sanitize() (MergeQueueEntryState, bool) {
+	return Sanitize(s, GetAllMergeQueueEntryStates)
+}
+
+func GetAllMergeQueueEntryStates() ([]MergeQueueEntryState, MergeQueueEntryState) {
+	return mergeQueueEntryStates, MergeQueueEntryStateQueued
+}
+
+var mergeQueueEntryStates = sortEnum([]MergeQueueEntryState{
+	MergeQueueEntryStateQueued,
+	MergeQueueEntryStateChecking,
+})
+
+// MergeQueueAction defines what happened with a pull request in the merge queue.
+type MergeQueueAction string
+
+// MergeQueueAction enumeration.
+const (
+	// MergeQueueActionAdded means the pull request was added to the merge queue.
+	MergeQueueActionAdded MergeQueueAction = "added"
+	// MergeQueueActionRemoved means the pull request was removed from the merge queue by a user.
+	MergeQueueActionRemoved MergeQueueAction = "removed"
+	// MergeQueueActionEjected means the pull request was removed from the merge queue because it can't be merged.
+	MergeQueueActionEjected MergeQueueAction = "ejected"
+)
+
+func (MergeQueueAction) Enum() []interface{} { return toInterfaceSlice(mergeQueueActions) }
+func (a MergeQueueAction) Sanitize() (MergeQueueAction, bool) {
+	return Sanitize(a, GetAllMergeQueueActions)
+}
+
+func GetAllMergeQueueActions() ([]MergeQueueAction, MergeQueueAction) {
+	return mergeQueueActions, ""
+}
+
+var mergeQueueActions = sortEnum([]MergeQueueAction{
+	MergeQueueActionAdded,
+	MergeQueueActionRemoved,
+	MergeQueueActionEjected,
+})
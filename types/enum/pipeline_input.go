@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PipelineInputType defines the type of a pipeline input parameter.
+type PipelineInputType string
+
+// PipelineInputType enumeration.
+const (
+	// PipelineInputTypeString is a free text input.
+	PipelineInputTypeString PipelineInputType = "string"
+	// PipelineInputTypeBool is a boolean input, its value is either "true" or "false".
+	PipelineInputTypeBool PipelineInputType = "bool"
+	// PipelineInputTypeChoice is an input whose value has to be one of the options of the input.
+	PipelineInputTypeChoice PipelineInputType = "choice"
+	// PipelineInputTypeSecret is an input whose value is the identifier of a secret.
+	// Only the identifier is stored with the execution and exposed as input parameter. Pipelines read the value
+	// through the secrets of the runner (e.g. with secrets.get), which masks it in the logs.
+	PipelineInputTypeSecret PipelineInputType = "secret"
+)
+
+func (PipelineInputType) Enum() []interface{} { return toInterfaceSlice(pipelineInputTypes) }
+func (t PipelineInputType) Sanitize() (PipelineInputType, bool) {
+	return Sanitize(t, GetAllPipelineInputTypes)
+}
+
+func GetAllPipelineInputTypes() ([]PipelineInputType, PipelineInputType) {
+	return pipelineInputTypes, PipelineInputTypeString
+}
+
+var pipelineInputTypes = sortEnum([]PipelineInputType{
+	PipelineInputTypeString,
+	PipelineInputTypeBool,
+	PipelineInputTypeChoice,
+	PipelineInputTypeSecret,
+})
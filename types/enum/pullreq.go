@@ -86,6 +86,11 @@ const (
 	PullReqActivityTypeBranchUpdate PullReqActivityType = "branch-update"
 	PullReqActivityTypeBranchDelete PullReqActivityType = "branch-delete"
 	PullReqActivityTypeMerge        PullReqActivityType = "merge"
+	PullReqActivityTypeMergeQueue   PullReqActivityType = "merge-queue"
+	PullReqActivityTypeAutoMerge    PullReqActivityType = "auto-merge"
+	PullReqActivityTypeLabelModify  PullReqActivityType = "label-modify"
+
+	PullReqActivityTypeTargetBranchChange PullReqActivityType = "target-branch-change"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -97,6 +102,10 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
 	PullReqActivityTypeMerge,
+	PullReqActivityTypeMergeQueue,
+	PullReqActivityTypeAutoMerge,
+	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeTargetBranchChange,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -238,3 +247,27 @@ const (
 	// MergeCheckStatusMergeable branch can merged cleanly into the target branch.
 	MergeCheckStatusMergeable MergeCheckStatus = "mergeable"
 )
+
+// PullReqExportFormat defines the format of a pull request export.
+type PullReqExportFormat string
+
+func (PullReqExportFormat) Enum() []interface{} { return toInterfaceSlice(pullReqExportFormats) }
+
+func (f PullReqExportFormat) Sanitize() (PullReqExportFormat, bool) {
+	return Sanitize(f, GetAllPullReqExportFormats)
+}
+
+func GetAllPullReqExportFormats() ([]PullReqExportFormat, PullReqExportFormat) {
+	return pullReqExportFormats, PullReqExportFormatJSON
+}
+
+// PullReqExportFormat enumeration.
+const (
+	PullReqExportFormatJSON     PullReqExportFormat = "json"
+	PullReqExportFormatMarkdown PullReqExportFormat = "markdown"
+)
+
+var pullReqExportFormats = sortEnum([]PullReqExportFormat{
+	PullReqExportFormatJSON,
+	PullReqExportFormatMarkdown,
+})
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SignatureMethod defines the method used to sign a git object.
+type SignatureMethod string
+
+func (SignatureMethod) Enum() []interface{} { return toInterfaceSlice(signatureMethods) }
+func (s SignatureMethod) Sanitize() (SignatureMethod, bool) {
+	return Sanitize(s, GetAllSignatureMethods)
+}
+func GetAllSignatureMethods() ([]SignatureMethod, SignatureMethod) {
+	return signatureMethods, ""
+}
+
+// SignatureMethod enumeration.
+const (
+	SignatureMethodGPG SignatureMethod = "gpg"
+	SignatureMethodSSH SignatureMethod = "ssh"
+)
+
+var signatureMethods = sortEnum([]SignatureMethod{
+	SignatureMethodGPG,
+	SignatureMethodSSH,
+})
+
+// SignatureVerificationReason describes the outcome of a signature verification.
+type SignatureVerificationReason string
+
+func (SignatureVerificationReason) Enum() []interface{} {
+	return toInterfaceSlice(signatureVerificationReasons)
+}
+func (s SignatureVerificationReason) Sanitize() (SignatureVerificationReason, bool) {
+	return Sanitize(s, GetAllSignatureVerificationReasons)
+}
+func GetAllSignatureVerificationReasons() ([]SignatureVerificationReason, SignatureVerificationReason) {
+	return signatureVerificationReasons, ""
+}
+
+// SignatureVerificationReason enumeration.
+const (
+	// SignatureVerificationReasonValid means the signature is valid and made by a key of the committer.
+	SignatureVerificationReasonValid SignatureVerificationReason = "valid"
+	// SignatureVerificationReasonUnsigned means the object doesn't have a signature.
+	SignatureVerificationReasonUnsigned SignatureVerificationReason = "unsigned"
+	// SignatureVerificationReasonUnknownKey means the signing key isn't registered as a signing key of any user.
+	SignatureVerificationReasonUnknownKey SignatureVerificationReason = "unknown_key"
+	// SignatureVerificationReasonInvalid means the signature doesn't match the signed content.
+	SignatureVerificationReasonInvalid SignatureVerificationReason = "invalid"
+	// SignatureVerificationReasonMalformed means the signature couldn't be parsed.
+	SignatureVerificationReasonMalformed SignatureVerificationReason = "malformed"
+	// SignatureVerificationReasonUnsupported means the signature format isn't supported.
+	SignatureVerificationReasonUnsupported SignatureVerificationReason = "unsupported"
+	// SignatureVerificationReasonEmailMismatch means the signer's email doesn't match the committer email.
+	SignatureVerificationReasonEmailMismatch SignatureVerificationReason = "email_mismatch"
+)
+
+var signatureVerificationReasons = sortEnum([]SignatureVerificationReason{
+	SignatureVerificationReasonValid,
+	SignatureVerificationReasonUnsigned,
+	SignatureVerificationReasonUnknownKey,
+	SignatureVerificationReasonInvalid,
+	SignatureVerificationReasonMalformed,
+	SignatureVerificationReasonUnsupported,
+	SignatureVerificationReasonEmailMismatch,
+})
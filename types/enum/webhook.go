@@ -158,6 +158,34 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+	// WebhookTriggerPullReqUpdated gets triggered when a pull request title or description gets updated.
+	WebhookTriggerPullReqUpdated WebhookTrigger = "pullreq_updated"
+	// WebhookTriggerPullReqReviewSubmitted gets triggered when a pull request review gets submitted.
+	WebhookTriggerPullReqReviewSubmitted WebhookTrigger = "pullreq_review_submitted"
+	// WebhookTriggerPullReqReviewerAdded gets triggered when a reviewer gets added to a pull request.
+	WebhookTriggerPullReqReviewerAdded WebhookTrigger = "pullreq_reviewer_added"
+	// WebhookTriggerPullReqReviewerRemoved gets triggered when a reviewer gets removed from a pull request.
+	WebhookTriggerPullReqReviewerRemoved WebhookTrigger = "pullreq_reviewer_removed"
+	// WebhookTriggerPullReqCommentUpdated gets triggered when a pull request comment gets edited.
+	WebhookTriggerPullReqCommentUpdated WebhookTrigger = "pullreq_comment_updated"
+	// WebhookTriggerPullReqCommentStatusUpdated gets triggered when a pull request comment gets resolved
+	// or reactivated.
+	WebhookTriggerPullReqCommentStatusUpdated WebhookTrigger = "pullreq_comment_status_updated"
+
+	// WebhookTriggerCheckReported gets triggered when a commit status check gets reported.
+	WebhookTriggerCheckReported WebhookTrigger = "check_reported"
+
+	// WebhookTriggerPipelineExecutionStarted gets triggered when a pipeline execution starts running.
+	WebhookTriggerPipelineExecutionStarted WebhookTrigger = "pipeline_execution_started"
+	// WebhookTriggerPipelineExecutionFinished gets triggered when a pipeline execution finishes.
+	WebhookTriggerPipelineExecutionFinished WebhookTrigger = "pipeline_execution_finished"
+
+	// WebhookTriggerRepoCreated gets triggered when a repository gets created.
+	WebhookTriggerRepoCreated WebhookTrigger = "repo_created"
+	// WebhookTriggerRepoDeleted gets triggered when a repository gets deleted.
+	WebhookTriggerRepoDeleted WebhookTrigger = "repo_deleted"
+	// WebhookTriggerRepoRenamed gets triggered when a repository gets renamed.
+	WebhookTriggerRepoRenamed WebhookTrigger = "repo_renamed"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -173,4 +201,46 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerPullReqUpdated,
+	WebhookTriggerPullReqReviewSubmitted,
+	WebhookTriggerPullReqReviewerAdded,
+	WebhookTriggerPullReqReviewerRemoved,
+	WebhookTriggerPullReqCommentUpdated,
+	WebhookTriggerPullReqCommentStatusUpdated,
+	WebhookTriggerCheckReported,
+	WebhookTriggerPipelineExecutionStarted,
+	WebhookTriggerPipelineExecutionFinished,
+	WebhookTriggerRepoCreated,
+	WebhookTriggerRepoDeleted,
+	WebhookTriggerRepoRenamed,
+})
+
+// WebhookPayloadFormat defines the different formats of the payload sent by a webhook.
+type WebhookPayloadFormat string
+
+func (WebhookPayloadFormat) Enum() []interface{} { return toInterfaceSlice(webhookPayloadFormats) }
+func (f WebhookPayloadFormat) Sanitize() (WebhookPayloadFormat, bool) {
+	return Sanitize(f, GetAllWebhookPayloadFormats)
+}
+
+func GetAllWebhookPayloadFormats() ([]WebhookPayloadFormat, WebhookPayloadFormat) {
+	return webhookPayloadFormats, WebhookPayloadFormatNative
+}
+
+const (
+	// WebhookPayloadFormatNative sends the payload in the native JSON format.
+	WebhookPayloadFormatNative WebhookPayloadFormat = "native"
+	// WebhookPayloadFormatChat sends a chat message compatible with Slack, Mattermost and Teams incoming webhooks.
+	WebhookPayloadFormatChat WebhookPayloadFormat = "chat"
+	// WebhookPayloadFormatCloudEvents sends the native payload wrapped in a CloudEvents 1.0 envelope.
+	WebhookPayloadFormatCloudEvents WebhookPayloadFormat = "cloudevents"
+	// WebhookPayloadFormatTemplate sends the output of the user provided template executed on the native payload.
+	WebhookPayloadFormatTemplate WebhookPayloadFormat = "template"
+)
+
+var webhookPayloadFormats = sortEnum([]WebhookPayloadFormat{
+	WebhookPayloadFormatNative,
+	WebhookPayloadFormatChat,
+	WebhookPayloadFormatCloudEvents,
+	WebhookPayloadFormatTemplate,
 })
@@ -84,6 +84,17 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+
+	Verification *SignatureVerification `json:"verification,omitempty"`
+}
+
+// SignatureVerification contains the result of the verification of a commit signature.
+type SignatureVerification struct {
+	Verified bool                             `json:"verified"`
+	Reason   enum.SignatureVerificationReason `json:"reason"`
+	Method   enum.SignatureMethod             `json:"method,omitempty"`
+	KeyID    string                           `json:"key_id,omitempty"`
+	Signer   *PrincipalInfo                   `json:"signer,omitempty"`
 }
 
 type Signature struct {
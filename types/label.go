@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// LabelScopeSeparator separates the key from the value in a scoped label name, e.g. "priority:high".
+const LabelScopeSeparator = ":"
+
+// Label is a pull request label defined either in a space or in a repository.
+// Labels of a space are available to all repositories of the space and of its sub-spaces.
+type Label struct {
+	ID      int64  `json:"id"`
+	SpaceID *int64 `json:"space_id,omitempty"`
+	RepoID  *int64 `json:"repo_id,omitempty"`
+
+	Name        string          `json:"name"`
+	Color       enum.LabelColor `json:"color"`
+	Description string          `json:"description"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// Key returns the key of a scoped label ("key:value").
+// A pull request can have at most one label with the same key.
+// For labels that are not scoped it returns an empty string.
+func (l *Label) Key() string {
+	return LabelKey(l.Name)
+}
+
+// LabelKey returns the key part of a scoped label name or an empty string if the name isn't scoped.
+func LabelKey(name string) string {
+	key, _, ok := strings.Cut(name, LabelScopeSeparator)
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(key)
+}
+
+// LabelInfo is the short representation of a label assigned to a pull request.
+type LabelInfo struct {
+	ID      int64           `json:"id"`
+	SpaceID *int64          `json:"space_id,omitempty"`
+	RepoID  *int64          `json:"repo_id,omitempty"`
+	Name    string          `json:"name"`
+	Color   enum.LabelColor `json:"color"`
+}
+
+// PullReqLabel is the assignment of a label to a pull request.
+type PullReqLabel struct {
+	PullReqID int64 `json:"pullreq_id"`
+	LabelID   int64 `json:"label_id"`
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+}
+
+// LabelFilter stores label query parameters.
+type LabelFilter struct {
+	ListQueryFilter
+}
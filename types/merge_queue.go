@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its target branch.
+// Pull requests in the queue are merged in the order in which they were added.
+type MergeQueueEntry struct {
+	ID            int64 `json:"-"`
+	RepoID        int64 `json:"-"`
+	PullReqID     int64 `json:"-"`
+	PullReqNumber int64 `json:"pullreq_number"`
+
+	TargetBranch string                    `json:"target_branch"`
+	State        enum.MergeQueueEntryState `json:"state"`
+
+	Method      enum.MergeMethod `json:"method"`
+	Title       string           `json:"title"`
+	Message     string           `json:"message"`
+	BypassRules bool             `json:"bypass_rules"`
+
+	// BypassJustification is the reason for bypassing the protection rules when the pull request gets merged.
+	BypassJustification string `json:"bypass_justification,omitempty"`
+
+	// SourceSHA is the commit of the source branch that was approved for merging.
+	SourceSHA string `json:"source_sha"`
+	// BaseSHA is the commit the speculative merge commit was created on top of.
+	// It's either the tip of the target branch or the speculative merge commit of the preceding entry.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// MergeSHA is the speculative merge commit. The commit becomes the new tip of the target branch
+	// once all required status checks reported for it succeeded.
+	MergeSHA string `json:"merge_sha,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	// Position is the zero based position of the entry in the queue.
+	Position int `json:"position"`
+}
+
+// MergeQueueBranch identifies the merge queue of a branch.
+type MergeQueueBranch struct {
+	RepoID       int64
+	TargetBranch string
+}
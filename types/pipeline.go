@@ -32,6 +32,8 @@ type Pipeline struct {
 	Execution *Execution `db:"-"                        json:"execution,omitempty"`
 	Updated   int64      `db:"pipeline_updated"         json:"updated"`
 	Version   int64      `db:"pipeline_version"         json:"-"`
+	// Inputs are the input parameters of the pipeline, stored as JSON by the pipeline store.
+	Inputs []PipelineInput `db:"-"                        json:"inputs"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
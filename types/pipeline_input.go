@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// PipelineInput is a typed input parameter of a pipeline.
+// Values of the inputs are provided by the user when an execution is started manually.
+type PipelineInput struct {
+	Name        string                 `json:"name"`
+	Type        enum.PipelineInputType `json:"type"`
+	Description string                 `json:"description,omitempty"`
+	Required    bool                   `json:"required,omitempty"`
+	Default     string                 `json:"default,omitempty"`
+	// Options are the allowed values of a choice input.
+	Options []string `json:"options,omitempty"`
+}
+
+// EnvName returns the name of the environment variable that exposes the value of the input,
+// which is INPUT_<NAME>, with the name of the input in upper case.
+func (in *PipelineInput) EnvName() string {
+	return PipelineInputEnvName(in.Name)
+}
+
+// PipelineInputEnvName returns the name of the environment variable that exposes the value of the named input.
+func PipelineInputEnvName(name string) string {
+	return "INPUT_" + strings.ToUpper(name)
+}
+
+// SanitizeValue validates the provided value against the type of the input and returns it in its canonical form.
+func (in *PipelineInput) SanitizeValue(value string) (string, error) {
+	switch in.Type {
+	case enum.PipelineInputTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("input '%s' requires a boolean value", in.Name)
+		}
+		return strconv.FormatBool(b), nil
+	case enum.PipelineInputTypeChoice:
+		for _, option := range in.Options {
+			if option == value {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("input '%s' requires one of the values %v", in.Name, in.Options)
+	case enum.PipelineInputTypeString, enum.PipelineInputTypeSecret:
+		return value, nil
+	default:
+		return "", fmt.Errorf("input '%s' has an unknown type '%s'", in.Name, in.Type)
+	}
+}
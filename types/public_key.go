@@ -31,6 +31,7 @@ type PublicKey struct {
 
 type PublicKeyFilter struct {
 	ListQueryFilter
-	Sort  enum.PublicKeySort
-	Order enum.Order
+	Sort   enum.PublicKeySort
+	Order  enum.Order
+	Usages []enum.PublicKeyUsage
 }
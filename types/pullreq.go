@@ -60,6 +60,8 @@ type PullReq struct {
 	Author PrincipalInfo  `json:"author"`
 	Merger *PrincipalInfo `json:"merger"`
 	Stats  PullReqStats   `json:"stats"`
+
+	Labels []LabelInfo `json:"labels,omitempty"`
 }
 
 // DiffStats shows total number of commits and modified files.
@@ -96,6 +98,7 @@ type PullReqFilter struct {
 	TargetRepoID  int64               `json:"-"`
 	TargetBranch  string              `json:"target_branch"`
 	States        []enum.PullReqState `json:"state"`
+	LabelIDs      []int64             `json:"label_id"`
 	Sort          enum.PullReqSort    `json:"sort"`
 	Order         enum.Order          `json:"order"`
 	CreatedFilter
@@ -164,9 +167,22 @@ type MergeResponse struct {
 	RequiresCodeOwnersApprovalLatest    bool               `json:"requires_code_owners_approval_latest,omitempty"`
 	RequiresCommentResolution           bool               `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests            bool               `json:"requires_no_change_requests,omitempty"`
+	RequiresMergeQueue                  bool               `json:"requires_merge_queue,omitempty"`
 }
 
 type MergeViolations struct {
 	ConflictFiles  []string         `json:"conflict_files,omitempty"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 }
+
+// PullReqStackEntry is a pull request in a stack of pull requests.
+// A pull request is stacked on top of another one if it targets the source branch of the other one.
+type PullReqStackEntry struct {
+	// Depth is the position of the pull request in the stack, the bottom of the stack has depth zero.
+	Depth int `json:"depth"`
+
+	// ParentNumber is the number of the pull request this pull request is stacked on.
+	ParentNumber *int64 `json:"parent_number,omitempty"`
+
+	PullReq *PullReq `json:"pull_request"`
+}
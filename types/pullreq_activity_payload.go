@@ -60,6 +60,10 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueue{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMerge{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadLabel{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadTargetBranchChange{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -95,6 +99,8 @@ type PullRequestActivityPayloadMerge struct {
 	TargetSHA     string           `json:"target_sha"`
 	SourceSHA     string           `json:"source_sha"`
 	RulesBypassed bool             `json:"rules_bypassed,omitempty"`
+
+	BypassJustification string `json:"bypass_justification,omitempty"`
 }
 
 func (a *PullRequestActivityPayloadMerge) ActivityType() enum.PullReqActivityType {
@@ -146,3 +152,45 @@ type PullRequestActivityPayloadBranchDelete struct {
 func (a *PullRequestActivityPayloadBranchDelete) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeBranchDelete
 }
+
+type PullRequestActivityPayloadMergeQueue struct {
+	Action       enum.MergeQueueAction `json:"action"`
+	TargetBranch string                `json:"target_branch"`
+	Reason       string                `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueue) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueue
+}
+
+type PullRequestActivityPayloadAutoMerge struct {
+	Action enum.AutoMergeAction `json:"action"`
+	Method enum.MergeMethod     `json:"method,omitempty"`
+	Reason string               `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMerge) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMerge
+}
+
+type PullRequestActivityPayloadLabel struct {
+	Type     enum.PullReqLabelActivityType `json:"type"`
+	Label    string                        `json:"label"`
+	Color    enum.LabelColor               `json:"color"`
+	OldLabel string                        `json:"old_label,omitempty"`
+	OldColor enum.LabelColor               `json:"old_color,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadLabel) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeLabelModify
+}
+
+type PullRequestActivityPayloadTargetBranchChange struct {
+	Old                 string `json:"old"`
+	New                 string `json:"new"`
+	MergedPullReqNumber int64  `json:"merged_pullreq_number,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadTargetBranchChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeTargetBranchChange
+}
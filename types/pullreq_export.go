@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PullReqExport is a self-contained document with the complete history of a pull request.
+// It's meant for archiving the review evidence outside of the system.
+type PullReqExport struct {
+	Exported   int64         `json:"exported"`
+	ExportedBy PrincipalInfo `json:"exported_by"`
+
+	RepoPath string   `json:"repo_path"`
+	PullReq  *PullReq `json:"pullreq"`
+
+	Reviewers []*PullReqReviewer `json:"reviewers"`
+	Checks    PullReqChecks      `json:"checks"`
+
+	// Timeline contains all activities of the pull request in chronological order,
+	// with the replies following the comment they belong to.
+	Timeline []*PullReqActivity `json:"timeline"`
+}
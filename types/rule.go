@@ -145,6 +145,9 @@ type RuleViolations struct {
 	Bypassable bool        `json:"bypassable"`
 	Bypassed   bool        `json:"bypassed"`
 	Violations []Violation `json:"violations"`
+
+	// BypassJustificationRequired is true if the rule can be bypassed only with a justification.
+	BypassJustificationRequired bool `json:"bypass_justification_required,omitempty"`
 }
 
 func (violations *RuleViolations) Add(code, message string) {
@@ -16,6 +16,9 @@ package types
 
 type (
 	SearchInput struct {
+		// Query is the search query. Besides the search terms it can contain filters:
+		// repo:, branch:, path:, -path:, lang:, case:yes and symbol:.
+		// Phrases containing spaces can be quoted and alternatives separated with OR.
 		Query string `json:"query"`
 
 		// RepoPaths contains the paths of repositories to search in
@@ -33,16 +36,60 @@ type (
 		// Search all the repos in a space and its subspaces recursively.
 		// Valid only when spacePaths is set.
 		Recursive bool `json:"recursive"`
+
+		// Page and Size are used for pagination of the file matches. Pagination is disabled if Size isn't set.
+		Page int `json:"page"`
+		Size int `json:"size"`
+	}
+
+	// SearchQuery is the structured form of a search query as it's passed to the searcher.
+	SearchQuery struct {
+		// Alternatives are the OR separated parts of the query.
+		// A file matches the query if it contains all terms of any of the alternatives.
+		Alternatives [][]SearchTerm
+
+		// PathIncludes are regular expressions that all have to match the path of a file.
+		PathIncludes []string
+		// PathExcludes are regular expressions that none may match the path of a file.
+		PathExcludes []string
+		// Languages restricts the search to files written in any of the languages.
+		Languages []string
+
+		// CaseSensitive disables case-insensitive matching of the terms.
+		CaseSensitive bool
+		// EnableRegex makes the searcher treat the terms as regular expressions.
+		EnableRegex bool
+	}
+
+	SearchTerm struct {
+		Value string
+		// Symbol restricts matches of the term to definitions of symbols with the name.
+		Symbol bool
 	}
 
 	SearchResult struct {
-		FileMatches []FileMatch `json:"file_matches"`
-		Stats       SearchStats `json:"stats"`
+		FileMatches []FileMatch       `json:"file_matches"`
+		Stats       SearchStats       `json:"stats"`
+		RepoStats   []RepoSearchStats `json:"repo_stats"`
 	}
 
 	SearchStats struct {
 		TotalFiles   int `json:"total_files"`
 		TotalMatches int `json:"total_matches"`
+
+		// Truncated is true if the search stopped once the maximum number of results or the page size was reached.
+		// The stats then don't include matches in the remaining files.
+		Truncated bool `json:"truncated"`
+	}
+
+	// RepoSearchStats holds the number of matches found in a single repository.
+	RepoSearchStats struct {
+		RepoID       int64  `json:"-"`
+		RepoPath     string `json:"repo_path"`
+		TotalFiles   int    `json:"total_files"`
+		TotalMatches int    `json:"total_matches"`
+		// NotIndexed is true if the repository isn't indexed yet and wasn't searched.
+		NotIndexed bool `json:"not_indexed,omitempty"`
 	}
 
 	FileMatch struct {
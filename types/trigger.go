@@ -34,6 +34,15 @@ type Trigger struct {
 	Created     int64                `json:"created"`
 	Updated     int64                `json:"updated"`
 	Version     int64                `json:"-"`
+
+	// Cron is the cron expression of a scheduled trigger (trigger type @cron).
+	Cron string `json:"cron,omitempty"`
+	// CronBranch is the branch built by a scheduled trigger, the default branch of the repo if empty.
+	CronBranch string `json:"cron_branch,omitempty"`
+	// CronTimezone is the IANA time zone the cron expression is evaluated in, UTC if empty.
+	CronTimezone string `json:"cron_timezone,omitempty"`
+	// CronNext is the time (in milliseconds) the scheduled trigger fires next.
+	CronNext int64 `json:"cron_next,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -42,6 +42,21 @@ type Webhook struct {
 	Insecure              bool                         `json:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
+
+	// PayloadFormat is the format of the payload sent by the webhook.
+	PayloadFormat enum.WebhookPayloadFormat `json:"payload_format"`
+	// PayloadTemplate is the text/template used to render the payload in case of the template payload format.
+	PayloadTemplate string `json:"payload_template,omitempty"`
+	// Headers are custom static headers added to every request sent by the webhook.
+	// The values of the headers are encrypted and are redacted when the webhook is marshaled to json.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// ConsecutiveFailures is the number of deliveries that failed since the last successful delivery.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// FailingSince is the time (unix milli) of the first failed delivery since the last successful delivery.
+	FailingSince int64 `json:"failing_since,omitempty"`
+	// DisabledReason contains the reason why the webhook got disabled automatically.
+	DisabledReason string `json:"disabled_reason,omitempty"`
 }
 
 // MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
@@ -55,17 +70,36 @@ func (w *Webhook) MarshalJSON() ([]byte, error) {
 	type WebhookAlias Webhook
 	return json.Marshal(&struct {
 		*WebhookAlias
-		HasSecret bool `json:"has_secret"`
+		HasSecret bool              `json:"has_secret"`
+		Headers   map[string]string `json:"headers,omitempty"`
 		// TODO [CODE-1363]: remove after identifier migration.
 		UID string `json:"uid"`
 	}{
 		WebhookAlias: (*WebhookAlias)(w),
 		HasSecret:    w != nil && w.Secret != "",
+		Headers:      redactedWebhookHeaders(w),
 		// TODO [CODE-1363]: remove after identifier migration.
 		UID: w.Identifier,
 	})
 }
 
+// WebhookHeaderValueRedacted replaces the values of the custom headers of webhooks in API responses.
+// When provided as a header value in an update, the header keeps its current value.
+const WebhookHeaderValueRedacted = "********"
+
+func redactedWebhookHeaders(w *Webhook) map[string]string {
+	if w == nil || len(w.Headers) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string, len(w.Headers))
+	for name := range w.Headers {
+		headers[name] = WebhookHeaderValueRedacted
+	}
+
+	return headers
+}
+
 // WebhookExecution represents a single execution of a webhook.
 type WebhookExecution struct {
 	ID            int64                       `json:"id"`